@@ -0,0 +1,51 @@
+package config
+
+import (
+	bootflag "github.com/al8n/micro-boot/flag"
+	"time"
+)
+
+// Cache configures the cache of the notes read through the gateway, the
+// notes are kept in process unless a Redis address is set.
+type Cache struct {
+	// MaxBytes bounds the size of the notes kept in process.
+	MaxBytes int64 `json:"max-bytes" yaml:"max-bytes"`
+	TTL time.Duration `json:"ttl" yaml:"ttl"`
+
+	// Timeout bounds the read of a note shared by the concurrent misses, and
+	// the view recorded for a note served from the cache.
+	Timeout time.Duration `json:"timeout" yaml:"timeout"`
+
+	// ViewWorkers is the number of views of the cached notes recorded at
+	// once, ViewQueue the number of views waiting, the views past it are
+	// dropped.
+	ViewWorkers int `json:"view-workers" yaml:"view-workers"`
+	ViewQueue int `json:"view-queue" yaml:"view-queue"`
+
+	Redis Redis `json:"redis" yaml:"redis"`
+}
+
+// Redis configures a Redis compatible server shared by the gateways.
+type Redis struct {
+	Addr string `json:"addr" yaml:"addr"`
+	Password string `json:"password" yaml:"password"`
+	DB int `json:"db" yaml:"db"`
+
+	// PoolSize is the number of idle connections kept, Timeout the maximum
+	// time a command takes.
+	PoolSize int `json:"pool-size" yaml:"pool-size"`
+	Timeout time.Duration `json:"timeout" yaml:"timeout"`
+}
+
+func (c *Cache) BindFlags(fs *bootflag.FlagSet)  {
+	fs.Int64Var(&c.MaxBytes, "cache-max-bytes", 64 << 20, "maximum size of the notes cached in process")
+	fs.DurationVar(&c.TTL, "cache-ttl", 30 * time.Second, "time a note is cached for, no expiry when not positive")
+	fs.DurationVar(&c.Timeout, "cache-timeout", time.Second, "maximum time a read shared by the cache misses takes")
+	fs.IntVar(&c.ViewWorkers, "cache-view-workers", 0, "number of views of the cached notes recorded at once (default 4)")
+	fs.IntVar(&c.ViewQueue, "cache-view-queue", 0, "number of views of the cached notes waiting to be recorded (default 1024)")
+	fs.StringVar(&c.Redis.Addr, "cache-redis-addr", "", "address of the Redis server caching the notes, in process when empty")
+	fs.StringVar(&c.Redis.Password, "cache-redis-password", "", "password of the Redis server")
+	fs.IntVar(&c.Redis.DB, "cache-redis-db", 0, "Redis database caching the notes")
+	fs.IntVar(&c.Redis.PoolSize, "cache-redis-pool-size", 16, "idle connections kept to the Redis server")
+	fs.DurationVar(&c.Redis.Timeout, "cache-redis-timeout", 100 * time.Millisecond, "maximum time a Redis command takes")
+}
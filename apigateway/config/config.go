@@ -37,6 +37,17 @@ type Config struct {
 	ConsulAddr string `json:"consul-addr" yaml:"consul-addr"`
 	RetryMax int `json:"retry-max" yaml:"retry-max"`
 	RetryTimeout time.Duration `json:"retry-timeout" yaml:"retry-timeout"`
+
+	// HealthInterval is the time between two checks of the readiness,
+	// HealthTimeout the maximum time a check takes.
+	HealthInterval time.Duration `json:"health-interval" yaml:"health-interval"`
+	HealthTimeout time.Duration `json:"health-timeout" yaml:"health-timeout"`
+
+	// ShutdownTimeout is the maximum time the calls in flight are drained for
+	// on shutdown.
+	ShutdownTimeout time.Duration `json:"shutdown-timeout" yaml:"shutdown-timeout"`
+
+	Cache Cache `json:"cache" yaml:"cache"`
 }
 
 func (c *Config) Initialize(name string) (err error) {
@@ -88,10 +99,14 @@ func (c *Config) Initialize(name string) (err error) {
 func (c *Config) BindFlags(fs *bootflag.FlagSet)  {
 	c.HTTP.BindFlags(fs)
 	c.HTTPS.BindFlags(fs)
+	c.Cache.BindFlags(fs)
 
 	fs.StringVar(&c.ConsulAddr, "consul-addr", "", "Consul agent address")
 	fs.IntVar(&c.RetryMax, "retry-max", 3, "per-request retries to different instances")
 	fs.DurationVar(&c.RetryTimeout, "retry-timeout", 500 * time.Millisecond, "per-request timeout, including retries")
+	fs.DurationVar(&c.HealthInterval, "health-interval", 10 * time.Second, "time between two readiness checks")
+	fs.DurationVar(&c.HealthTimeout, "health-timeout", 2 * time.Second, "maximum time a readiness check takes")
+	fs.DurationVar(&c.ShutdownTimeout, "shutdown-timeout", 30 * time.Second, "maximum time the calls in flight are drained for on shutdown")
 }
 
 func (c *Config) Parse() (err error) {
@@ -0,0 +1,528 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/al8n/shareable-notes/apigateway/config"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"github.com/al8n/shareable-notes/share-svc/model/requests"
+	"github.com/al8n/shareable-notes/share-svc/model/responses"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"golang.org/x/sync/singleflight"
+	"sync"
+	"time"
+)
+
+// Backend stores the cached responses, it is an in-process LRU or shared by
+// the gateways, like Redis.
+type Backend interface {
+	// Get returns the value of key, ok is false when it is missing or expired.
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+
+	// Set stores value at key for ttl, without expiry when ttl is not positive.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes keys, the missing ones are ignored.
+	Delete(ctx context.Context, keys ...string) error
+
+	Close() error
+}
+
+const (
+	// watchCheck is the time between two checks that the notes are still
+	// cached, when the notes do not expire.
+	watchCheck = time.Minute
+
+	// watchRetry is the time between two failed attempts to watch the notes.
+	watchRetry = time.Second
+
+	// defaultViewWorkers is the number of views recorded at once, and
+	// defaultViewQueue the number of views queued, when they are not
+	// configured.
+	defaultViewWorkers = 4
+	defaultViewQueue = 1024
+)
+
+var (
+	errWatchStopped = errors.New("note watch is stopped")
+	errViewsFull = errors.New("views queue is full, the view is dropped")
+)
+
+// Cache caches the notes read through the gateway. The changes made to the
+// notes are watched through one WatchNotes stream of the share service, and
+// the notes are invalidated once a change to them is stored, wherever it is
+// made. No note is cached while the stream is down.
+type Cache struct {
+	backend Backend
+	ttl time.Duration
+	timeout time.Duration
+	watch endpoint.Endpoint
+	group singleflight.Group
+	logger log.Logger
+
+	// views queues the views of the notes served from the cache for the
+	// workers recording them.
+	views chan view
+
+	mu sync.Mutex
+	// watching is set while the stream is up, watches holds the notes cached
+	// or being read meanwhile.
+	watching bool
+	watches map[string]*noteWatch
+	closed bool
+	cancel context.CancelFunc
+	wg sync.WaitGroup
+}
+
+// noteWatch is the watch of a note. Its mutex orders the notes stored with
+// the end of the watch, so that no note is left cached unwatched.
+type noteWatch struct {
+	mu sync.Mutex
+	stopped bool
+	stored time.Time
+}
+
+// view is a view of a note served from the cache, recorded through next.
+type view struct {
+	ctx context.Context
+	next endpoint.Endpoint
+	id string
+	etag string
+}
+
+// New returns a Cache storing the notes in backend, watch is the WatchNotes
+// endpoint of the share service.
+func New(backend Backend, cfg config.Cache, watch endpoint.Endpoint, logger log.Logger) *Cache {
+	var (
+		workers = cfg.ViewWorkers
+		queue = cfg.ViewQueue
+		ctx context.Context
+	)
+
+	if workers <= 0 {
+		workers = defaultViewWorkers
+	}
+
+	if queue <= 0 {
+		queue = defaultViewQueue
+	}
+
+	c := &Cache{
+		backend: backend,
+		ttl: cfg.TTL,
+		timeout: cfg.Timeout,
+		watch: watch,
+		logger: log.With(logger, "component", "cache"),
+		views: make(chan view, queue),
+		watches: map[string]*noteWatch{},
+	}
+
+	for i := 0; i < workers; i++ {
+		c.wg.Add(1)
+		go c.recordViews()
+	}
+
+	ctx, c.cancel = context.WithCancel(context.Background())
+	c.wg.Add(1)
+	go c.watchNotes(ctx)
+	return c
+}
+
+// GetNote serves the notes from the cache, the concurrent misses of a note
+// share one call to next. Only the notes found are cached, and the
+// conditional reads are answered from their cached version. The views of
+// the notes served from the cache are recorded by the share service apart.
+func (c *Cache) GetNote(next endpoint.Endpoint) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var (
+			req = request.(requests.GetNoteRequest)
+			key = noteKey(req.NoteID)
+			own bool
+		)
+
+		value, ok, err := c.backend.Get(ctx, key)
+		if err != nil {
+			c.logger.Log("op", "Get", "key", key, "err", err)
+		}
+
+		if ok {
+			var resp responses.GetNoteResponse
+			if err = json.Unmarshal(value, &resp); err == nil {
+				c.view(ctx, next, req.NoteID, resp.ETag)
+				return conditional(req.Condition, resp), nil
+			}
+			c.logger.Log("op", "Decode", "key", key, "err", err)
+		}
+
+		// the call is shared, so it is not conditional, and it outlives the
+		// callers leaving before it is done
+		results := c.group.DoChan(key, func() (interface{}, error) {
+			own = true
+			return c.read(ctx, next, req.NoteID)
+		})
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case result := <-results:
+			if result.Err != nil {
+				return nil, result.Err
+			}
+
+			resp, ok := result.Val.(responses.GetNoteResponse)
+			if !ok {
+				return result.Val, nil
+			}
+
+			// the view of the caller of the shared call is recorded by it
+			if !own && resp.Error == "" {
+				c.view(ctx, next, req.NoteID, resp.ETag)
+			}
+			return conditional(req.Condition, resp), nil
+		}
+	}
+}
+
+// read reads the note through next, and caches it unless it has changed
+// meanwhile or the notes are not watched. ctx only lends its values to the
+// call.
+func (c *Cache) read(ctx context.Context, next endpoint.Endpoint, id string) (interface{}, error) {
+	var key = noteKey(id)
+
+	ctx, cancel := context.WithTimeout(detached{ctx}, c.timeout)
+	defer cancel()
+
+	w := c.watchNote(id)
+
+	response, err := next(ctx, requests.GetNoteRequest{NoteID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, ok := response.(responses.GetNoteResponse)
+	if !ok || resp.Error != "" || w == nil {
+		return response, nil
+	}
+
+	value, _ := json.Marshal(resp)
+	err = w.store(func() error {
+		return c.backend.Set(ctx, key, value, c.ttl)
+	})
+	if err != nil && err != errWatchStopped {
+		c.logger.Log("op", "Set", "key", key, "err", err)
+	}
+	return response, nil
+}
+
+// view queues the view of a note served from the cache, it is dropped when
+// the queue is full rather than holding the response.
+func (c *Cache) view(ctx context.Context, next endpoint.Endpoint, id, etag string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+
+	select {
+	case c.views <- view{ctx: detached{ctx}, next: next, id: id, etag: etag}:
+	default:
+		c.logger.Log("op", "View", "note", id, "err", errViewsFull)
+	}
+}
+
+// recordViews records the queued views, by a read of the note conditional
+// on its cached version, until the queue is closed.
+func (c *Cache) recordViews() {
+	defer c.wg.Done()
+
+	for v := range c.views {
+		ctx, cancel := context.WithTimeout(v.ctx, c.timeout)
+		_, err := v.next(ctx, requests.GetNoteRequest{
+			NoteID: v.id,
+			Condition: model.NoteCondition{IfNoneMatch: v.etag},
+		})
+		cancel()
+
+		if err != nil {
+			c.logger.Log("op", "View", "note", v.id, "err", err)
+		}
+	}
+}
+
+// PrivateNote invalidates the notes made private.
+func (c *Cache) PrivateNote(next endpoint.Endpoint) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		defer c.invalidate(ctx, request.(requests.PrivateNoteRequest).NoteID)
+		return next(ctx, request)
+	}
+}
+
+// SyncNotes invalidates the notes changed by the clients.
+func (c *Cache) SyncNotes(next endpoint.Endpoint) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var ids []string
+		for _, change := range request.(requests.SyncNotesRequest).Changes {
+			ids = append(ids, change.NoteID)
+		}
+
+		defer c.invalidate(ctx, ids...)
+		return next(ctx, request)
+	}
+}
+
+// Close stops watching the notes, waits for the views queued, then closes
+// the backend.
+func (c *Cache) Close() error {
+	c.mu.Lock()
+	if !c.closed {
+		c.closed = true
+		close(c.views)
+	}
+	c.mu.Unlock()
+
+	c.cancel()
+	c.wg.Wait()
+	return c.backend.Close()
+}
+
+// watchNote returns the watch of the note, nil when the notes are not
+// watched. The watch lasts until the note changes or is no longer cached.
+func (c *Cache) watchNote(id string) *noteWatch {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.watching || c.closed {
+		return nil
+	}
+
+	w, ok := c.watches[id]
+	if !ok {
+		// the note is not idle while it is read
+		w = &noteWatch{stored: time.Now()}
+		c.watches[id] = w
+	}
+	return w
+}
+
+// watchNotes keeps the notes watched until ctx is done. The watched notes
+// are invalidated on their events, and all of them when the stream ends, as
+// their events may be lost meanwhile.
+func (c *Cache) watchNotes(ctx context.Context) {
+	defer c.wg.Done()
+
+	for {
+		response, err := c.watch(ctx, requests.WatchNotesRequest{})
+		if err == nil && response.(responses.WatchNotesResponse).Error != "" {
+			err = errors.New(response.(responses.WatchNotesResponse).Error)
+		}
+
+		if err == nil {
+			c.mu.Lock()
+			c.watching = true
+			c.mu.Unlock()
+
+			c.watchEvents(ctx, response.(responses.WatchNotesResponse).Events)
+
+			// the cached notes outlive the gateway when it stops, an ended
+			// stream is opened again at once, likely on another instance
+			c.unwatchAll(ctx.Err() == nil)
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		if ctx.Err() == nil {
+			c.logger.Log("op", "Watch", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchRetry):
+		}
+	}
+}
+
+// watchEvents invalidates the notes changed, and stops watching the notes
+// no longer cached, until the events end or ctx is done.
+func (c *Cache) watchEvents(ctx context.Context, events <-chan model.NoteEvent) {
+	var check = c.ttl
+	if check <= 0 {
+		check = watchCheck
+	}
+
+	ticker := time.NewTicker(check)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			// the note is read and watched again by the next miss
+			c.mu.Lock()
+			w := c.watches[event.NoteID]
+			c.mu.Unlock()
+
+			if w != nil {
+				c.unwatch(event.NoteID, w, true)
+			}
+		case now := <-ticker.C:
+			c.unwatchIdle(ctx, now)
+		}
+	}
+}
+
+// unwatch stops the watch of the note, and invalidates it when its changes
+// may have been missed.
+func (c *Cache) unwatch(id string, w *noteWatch, invalidate bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	c.stop(id, w)
+	if invalidate {
+		c.invalidate(context.Background(), id)
+	}
+}
+
+// unwatchAll stops watching every note, and invalidates them when their
+// changes may have been missed.
+func (c *Cache) unwatchAll(invalidate bool) {
+	c.mu.Lock()
+	watches := c.watches
+	c.watches = map[string]*noteWatch{}
+	c.watching = false
+	c.mu.Unlock()
+
+	var ids = make([]string, 0, len(watches))
+	for id, w := range watches {
+		w.mu.Lock()
+		w.stopped = true
+		w.mu.Unlock()
+		ids = append(ids, id)
+	}
+
+	if invalidate {
+		c.invalidate(context.Background(), ids...)
+	}
+}
+
+// unwatchIdle stops watching the notes which are no longer cached: the notes
+// stored last before the TTL and the read timeout have expired, and the ones
+// missing from the backend when the notes do not expire.
+func (c *Cache) unwatchIdle(ctx context.Context, now time.Time) {
+	c.mu.Lock()
+	var watches = make(map[string]*noteWatch, len(c.watches))
+	for id, w := range c.watches {
+		watches[id] = w
+	}
+	c.mu.Unlock()
+
+	for id, w := range watches {
+		if c.ttl > 0 {
+			c.unwatchStored(id, w, now.Add(-c.ttl - c.timeout))
+			continue
+		}
+
+		_, ok, err := c.backend.Get(ctx, noteKey(id))
+		if err == nil && !ok {
+			c.unwatchStored(id, w, now.Add(-c.timeout))
+		}
+	}
+}
+
+// unwatchStored stops the watch of the note unless it has been stored since.
+func (c *Cache) unwatchStored(id string, w *noteWatch, since time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.stored.After(since) {
+		c.stop(id, w)
+	}
+}
+
+func (c *Cache) stop(id string, w *noteWatch) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.watches[id] == w {
+		delete(c.watches, id)
+	}
+	w.stopped = true
+}
+
+// store runs set unless the watch is stopped, the watch does not stop
+// meanwhile.
+func (w *noteWatch) store(set func() error) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.stopped {
+		return errWatchStopped
+	}
+
+	w.stored = time.Now()
+	return set()
+}
+
+// invalidate removes the notes from the cache, the misses in flight are not
+// shared with the next ones.
+func (c *Cache) invalidate(ctx context.Context, ids ...string) {
+	if len(ids) == 0 {
+		return
+	}
+
+	var keys = make([]string, 0, len(ids))
+	for _, id := range ids {
+		key := noteKey(id)
+		c.group.Forget(key)
+		keys = append(keys, key)
+	}
+
+	if err := c.backend.Delete(ctx, keys...); err != nil {
+		c.logger.Log("op", "Delete", "keys", len(keys), "err", err)
+	}
+}
+
+// conditional returns resp, or its version alone when cond holds.
+func conditional(cond model.NoteCondition, resp responses.GetNoteResponse) responses.GetNoteResponse {
+	if resp.Error != "" || !cond.Unchanged(model.NoteVersion{ETag: resp.ETag, LastModified: resp.LastModified}) {
+		return resp
+	}
+
+	return responses.GetNoteResponse{
+		ETag: resp.ETag,
+		LastModified: resp.LastModified,
+		NotModified: true,
+	}
+}
+
+func noteKey(id string) string {
+	return "share:note:" + id
+}
+
+// detached carries the values of a context, like the viewer and the span,
+// without its deadline and cancellation.
+type detached struct {
+	context.Context
+}
+
+func (detached) Deadline() (deadline time.Time, ok bool) {
+	return time.Time{}, false
+}
+
+func (detached) Done() <-chan struct{} {
+	return nil
+}
+
+func (detached) Err() error {
+	return nil
+}
@@ -0,0 +1,338 @@
+package cache
+
+import (
+	"context"
+	"github.com/al8n/shareable-notes/apigateway/config"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"github.com/al8n/shareable-notes/share-svc/model/requests"
+	"github.com/al8n/shareable-notes/share-svc/model/responses"
+	"github.com/go-kit/kit/log"
+	"sync"
+	"testing"
+	"time"
+)
+
+// share is a share service serving the note "found", its reads wait for
+// release when it is set.
+type share struct {
+	mu       sync.Mutex
+	reads    []requests.GetNoteRequest
+	release  chan struct{}
+	events   chan model.NoteEvent
+	watchErr string
+}
+
+func newShare() *share {
+	return &share{}
+}
+
+func (s *share) getNote(ctx context.Context, request interface{}) (interface{}, error) {
+	req := request.(requests.GetNoteRequest)
+
+	s.mu.Lock()
+	s.reads = append(s.reads, req)
+	release := s.release
+	s.mu.Unlock()
+
+	if release != nil && req.Condition == (model.NoteCondition{}) {
+		select {
+		case <-release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if req.NoteID != "found" {
+		return responses.GetNoteResponse{Error: common.ErrorNoteNotFound.Error()}, nil
+	}
+
+	if req.Condition.Unchanged(model.NoteVersion{ETag: `"1"`}) {
+		return responses.GetNoteResponse{ETag: `"1"`, NotModified: true}, nil
+	}
+	return responses.GetNoteResponse{Name: "name", Content: "content", ETag: `"1"`}, nil
+}
+
+func (s *share) watchNotes(_ context.Context, _ interface{}) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.watchErr != "" {
+		return responses.WatchNotesResponse{Error: s.watchErr}, nil
+	}
+
+	s.events = make(chan model.NoteEvent, 1)
+	return responses.WatchNotesResponse{Events: s.events}, nil
+}
+
+// counts returns the number of reads, and of conditional ones.
+func (s *share) counts() (reads, views int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, req := range s.reads {
+		if req.Condition != (model.NoteCondition{}) {
+			views++
+		} else {
+			reads++
+		}
+	}
+	return reads, views
+}
+
+func (s *share) publish(event model.NoteEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events <- event
+}
+
+// end ends the stream of the events.
+func (s *share) end() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	close(s.events)
+}
+
+// newCache returns a cache once it watches the notes, unless the share
+// service fails to watch them.
+func newCache(t *testing.T, s *share) (*Cache, *LRU) {
+	t.Helper()
+
+	lru := NewLRU(1 << 20)
+	c := New(lru, config.Cache{TTL: time.Minute, Timeout: time.Second}, s.watchNotes, log.NewNopLogger())
+	t.Cleanup(func() { c.Close() })
+
+	if s.watchErr == "" {
+		eventually(t, c.isWatching)
+	}
+	return c, lru
+}
+
+func (c *Cache) isWatching() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.watching
+}
+
+// eventually fails the test unless cond holds within a second.
+func eventually(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	for deadline := time.Now().Add(time.Second); !cond(); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("condition does not hold")
+		}
+	}
+}
+
+func TestCacheGetNote(t *testing.T) {
+	var ctx = context.Background()
+
+	t.Run("hit records a view", func(t *testing.T) {
+		s := newShare()
+		c, _ := newCache(t, s)
+		get := c.GetNote(s.getNote)
+
+		for i := 0; i < 3; i++ {
+			response, err := get(ctx, requests.GetNoteRequest{NoteID: "found"})
+			if resp := response.(responses.GetNoteResponse); err != nil || resp.Content != "content" {
+				t.Fatalf("got %+v %v", resp, err)
+			}
+		}
+
+		eventually(t, func() bool {
+			reads, views := s.counts()
+			return reads == 1 && views == 2
+		})
+	})
+
+	t.Run("conditional hit", func(t *testing.T) {
+		s := newShare()
+		c, _ := newCache(t, s)
+		get := c.GetNote(s.getNote)
+
+		get(ctx, requests.GetNoteRequest{NoteID: "found"})
+		response, err := get(ctx, requests.GetNoteRequest{
+			NoteID:    "found",
+			Condition: model.NoteCondition{IfNoneMatch: `W/"1"`},
+		})
+		if resp := response.(responses.GetNoteResponse); err != nil || !resp.NotModified || resp.ETag != `"1"` {
+			t.Fatalf("got %+v %v", resp, err)
+		}
+	})
+
+	t.Run("not found is not cached", func(t *testing.T) {
+		s := newShare()
+		c, _ := newCache(t, s)
+		get := c.GetNote(s.getNote)
+
+		for i := 0; i < 2; i++ {
+			response, err := get(ctx, requests.GetNoteRequest{NoteID: "missing"})
+			if resp := response.(responses.GetNoteResponse); err != nil || resp.Error != common.ErrorNoteNotFound.Error() {
+				t.Fatalf("got %+v %v", resp, err)
+			}
+		}
+
+		if reads, views := s.counts(); reads != 2 || views != 0 {
+			t.Fatalf("%d reads, %d views", reads, views)
+		}
+	})
+
+	t.Run("misses share a read", func(t *testing.T) {
+		s := newShare()
+		s.release = make(chan struct{})
+		c, _ := newCache(t, s)
+		get := c.GetNote(s.getNote)
+
+		var (
+			wg   sync.WaitGroup
+			errs = make(chan error, 4)
+		)
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := get(ctx, requests.GetNoteRequest{NoteID: "found"})
+				errs <- err
+			}()
+		}
+
+		eventually(t, func() bool {
+			reads, _ := s.counts()
+			return reads == 1
+		})
+		time.Sleep(10 * time.Millisecond)
+		close(s.release)
+		wg.Wait()
+
+		close(errs)
+		for err := range errs {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// every caller is a view, the shared read is the one of its caller
+		eventually(t, func() bool {
+			reads, views := s.counts()
+			return reads == 1 && views == 3
+		})
+	})
+
+	t.Run("waiter leaves the shared read", func(t *testing.T) {
+		s := newShare()
+		s.release = make(chan struct{})
+		c, lru := newCache(t, s)
+		get := c.GetNote(s.getNote)
+
+		leaving, cancel := context.WithCancel(ctx)
+		done := make(chan error)
+		go func() {
+			_, err := get(leaving, requests.GetNoteRequest{NoteID: "found"})
+			done <- err
+		}()
+
+		eventually(t, func() bool {
+			reads, _ := s.counts()
+			return reads == 1
+		})
+		cancel()
+		if err := <-done; err != context.Canceled {
+			t.Fatalf("got %v", err)
+		}
+
+		// the read goes on and caches the note
+		close(s.release)
+		eventually(t, func() bool {
+			_, ok, _ := lru.Get(ctx, noteKey("found"))
+			return ok
+		})
+	})
+
+	t.Run("change invalidates", func(t *testing.T) {
+		s := newShare()
+		c, lru := newCache(t, s)
+		get := c.GetNote(s.getNote)
+
+		get(ctx, requests.GetNoteRequest{NoteID: "found"})
+		if _, ok, _ := lru.Get(ctx, noteKey("found")); !ok {
+			t.Fatal("note is not cached")
+		}
+
+		s.publish(model.NoteEvent{NoteID: "found", Type: model.NoteEventUpdate})
+		eventually(t, func() bool {
+			_, ok, _ := lru.Get(ctx, noteKey("found"))
+			return !ok
+		})
+
+		// the next miss reads the note and watches it again
+		eventually(t, func() bool {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			return len(c.watches) == 0
+		})
+		get(ctx, requests.GetNoteRequest{NoteID: "found"})
+		if reads, _ := s.counts(); reads != 2 {
+			t.Fatalf("%d reads", reads)
+		}
+
+		s.publish(model.NoteEvent{NoteID: "found", Type: model.NoteEventPrivate})
+		eventually(t, func() bool {
+			_, ok, _ := lru.Get(ctx, noteKey("found"))
+			return !ok
+		})
+	})
+
+	t.Run("change to another note", func(t *testing.T) {
+		s := newShare()
+		c, lru := newCache(t, s)
+		get := c.GetNote(s.getNote)
+
+		get(ctx, requests.GetNoteRequest{NoteID: "found"})
+		s.publish(model.NoteEvent{NoteID: "other", Type: model.NoteEventUpdate})
+		s.publish(model.NoteEvent{NoteID: "other", Type: model.NoteEventDelete})
+
+		if _, ok, _ := lru.Get(ctx, noteKey("found")); !ok {
+			t.Fatal("note is not cached")
+		}
+	})
+
+	t.Run("stream end invalidates", func(t *testing.T) {
+		s := newShare()
+		c, lru := newCache(t, s)
+		get := c.GetNote(s.getNote)
+
+		get(ctx, requests.GetNoteRequest{NoteID: "found"})
+		s.end()
+		eventually(t, func() bool {
+			_, ok, _ := lru.Get(ctx, noteKey("found"))
+			return !ok
+		})
+
+		// the notes are cached again once the stream is back
+		eventually(t, c.isWatching)
+		get(ctx, requests.GetNoteRequest{NoteID: "found"})
+		if _, ok, _ := lru.Get(ctx, noteKey("found")); !ok {
+			t.Fatal("note is not cached")
+		}
+	})
+
+	t.Run("unwatched note is not cached", func(t *testing.T) {
+		s := newShare()
+		s.watchErr = common.ErrorNoteNotFound.Error()
+		c, lru := newCache(t, s)
+		get := c.GetNote(s.getNote)
+
+		response, err := get(ctx, requests.GetNoteRequest{NoteID: "found"})
+		if resp := response.(responses.GetNoteResponse); err != nil || resp.Content != "content" {
+			t.Fatalf("got %+v %v", resp, err)
+		}
+
+		if _, ok, _ := lru.Get(ctx, noteKey("found")); ok {
+			t.Fatal("note is cached")
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLRU(t *testing.T) {
+	type op struct {
+		set    bool
+		delete bool
+		key    string
+		value  string
+		ttl    time.Duration
+	}
+
+	// the entries take the size of their key and value, 2 bytes each here
+	for _, tc := range []struct {
+		name     string
+		maxBytes int64
+		ops      []op
+		cached   map[string]string
+		missing  []string
+	}{
+		{
+			name:     "set and get",
+			maxBytes: 64,
+			ops:      []op{{set: true, key: "a", value: "1", ttl: time.Minute}},
+			cached:   map[string]string{"a": "1"},
+		},
+		{
+			name:     "set replaces",
+			maxBytes: 64,
+			ops: []op{
+				{set: true, key: "a", value: "1", ttl: time.Minute},
+				{set: true, key: "a", value: "2", ttl: time.Minute},
+			},
+			cached: map[string]string{"a": "2"},
+		},
+		{
+			name:     "least recently used evicted",
+			maxBytes: 4,
+			ops: []op{
+				{set: true, key: "a", value: "1", ttl: time.Minute},
+				{set: true, key: "b", value: "2", ttl: time.Minute},
+				{key: "a"},
+				{set: true, key: "c", value: "3", ttl: time.Minute},
+				{set: true, key: "d", value: "4", ttl: time.Minute},
+			},
+			cached:  map[string]string{"c": "3", "d": "4"},
+			missing: []string{"a", "b"},
+		},
+		{
+			name:     "get refreshes",
+			maxBytes: 4,
+			ops: []op{
+				{set: true, key: "a", value: "1", ttl: time.Minute},
+				{set: true, key: "b", value: "2", ttl: time.Minute},
+				{set: true, key: "c", value: "3", ttl: time.Minute},
+				{key: "b"},
+				{set: true, key: "d", value: "4", ttl: time.Minute},
+			},
+			cached:  map[string]string{"b": "2", "d": "4"},
+			missing: []string{"a", "c"},
+		},
+		{
+			name:     "larger than the cache",
+			maxBytes: 4,
+			ops:      []op{{set: true, key: "a", value: "12345", ttl: time.Minute}},
+			missing:  []string{"a"},
+		},
+		{
+			name:     "expired",
+			maxBytes: 64,
+			ops:      []op{{set: true, key: "a", value: "1", ttl: time.Nanosecond}},
+			missing:  []string{"a"},
+		},
+		{
+			name:     "no expiry",
+			maxBytes: 64,
+			ops: []op{
+				{set: true, key: "a", value: "1"},
+				{set: true, key: "b", value: "2", ttl: -time.Second},
+			},
+			cached: map[string]string{"a": "1", "b": "2"},
+		},
+		{
+			name:     "delete",
+			maxBytes: 64,
+			ops: []op{
+				{set: true, key: "a", value: "1", ttl: time.Minute},
+				{set: true, key: "b", value: "2", ttl: time.Minute},
+				{delete: true, key: "a"},
+				{delete: true, key: "missing"},
+			},
+			cached:  map[string]string{"b": "2"},
+			missing: []string{"a"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				ctx = context.Background()
+				lru = NewLRU(tc.maxBytes)
+			)
+
+			for _, op := range tc.ops {
+				switch {
+				case op.set:
+					lru.Set(ctx, op.key, []byte(op.value), op.ttl)
+				case op.delete:
+					lru.Delete(ctx, op.key)
+				default:
+					lru.Get(ctx, op.key)
+				}
+			}
+			time.Sleep(time.Millisecond)
+
+			for key, want := range tc.cached {
+				if value, ok, err := lru.Get(ctx, key); !ok || err != nil || string(value) != want {
+					t.Fatalf("%s: got %q %v %v, want %q", key, value, ok, err, want)
+				}
+			}
+
+			for _, key := range tc.missing {
+				if value, ok, _ := lru.Get(ctx, key); ok {
+					t.Fatalf("%s: got %q, want missing", key, value)
+				}
+			}
+
+			if lru.size > tc.maxBytes {
+				t.Fatalf("size %d over %d", lru.size, tc.maxBytes)
+			}
+		})
+	}
+}
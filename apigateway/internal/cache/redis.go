@@ -0,0 +1,262 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/al8n/shareable-notes/apigateway/config"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+var errRedisClosed = errors.New("redis backend is closed")
+
+// redisError is an error reply of the server.
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// Redis is a Backend speaking RESP to a Redis compatible server, so the
+// gateways share their cache and its invalidations. The keys expire on the
+// server.
+type Redis struct {
+	cfg config.Redis
+	dialer net.Dialer
+
+	// conns holds the idle connections
+	conns chan *redisConn
+	quit chan struct{}
+}
+
+type redisConn struct {
+	net.Conn
+	r *bufio.Reader
+	w *bufio.Writer
+}
+
+func NewRedis(cfg config.Redis) *Redis {
+	return &Redis{
+		cfg: cfg,
+		dialer: net.Dialer{Timeout: cfg.Timeout},
+		conns: make(chan *redisConn, cfg.PoolSize),
+		quit: make(chan struct{}),
+	}
+}
+
+func (c *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := c.do(ctx, "GET", []byte(key))
+	if err != nil {
+		return nil, false, err
+	}
+
+	if reply == nil {
+		return nil, false, nil
+	}
+
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("redis: unexpected reply %T to GET", reply)
+	}
+	return value, true, nil
+}
+
+func (c *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	var args = [][]byte{[]byte(key), value}
+	if ttl > 0 {
+		args = append(args, []byte("PX"), []byte(strconv.FormatInt(ttl.Milliseconds(), 10)))
+	}
+
+	_, err := c.do(ctx, "SET", args...)
+	return err
+}
+
+func (c *Redis) Delete(ctx context.Context, keys ...string) error {
+	var args = make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		args = append(args, []byte(key))
+	}
+
+	_, err := c.do(ctx, "DEL", args...)
+	return err
+}
+
+// Close closes the idle connections, the ones in use are closed once their
+// command is done.
+func (c *Redis) Close() error {
+	close(c.quit)
+	for {
+		select {
+		case conn := <-c.conns:
+			conn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// do sends a command on an idle connection, or a new one, and reads its
+// reply. The connections failing are not reused, and a command failing on
+// an idle one, which the server may have closed meanwhile, is sent again on
+// a new one. The commands sent are idempotent.
+func (c *Redis) do(ctx context.Context, cmd string, args ...[]byte) (reply interface{}, err error) {
+	conn, idle, err := c.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err = conn.do(ctx, c.cfg.Timeout, cmd, args...)
+	if _, ok := err.(redisError); err != nil && !ok {
+		conn.Close()
+		if !idle || ctx.Err() != nil {
+			return nil, err
+		}
+
+		if conn, err = c.dial(ctx); err != nil {
+			return nil, err
+		}
+
+		reply, err = conn.do(ctx, c.cfg.Timeout, cmd, args...)
+		if _, ok := err.(redisError); err != nil && !ok {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	c.put(conn)
+	return reply, err
+}
+
+// conn returns an idle connection, or a new one, idle tells which.
+func (c *Redis) conn(ctx context.Context) (conn *redisConn, idle bool, err error) {
+	select {
+	case <-c.quit:
+		return nil, false, errRedisClosed
+	case conn := <-c.conns:
+		return conn, true, nil
+	default:
+	}
+
+	conn, err = c.dial(ctx)
+	return conn, false, err
+}
+
+func (c *Redis) dial(ctx context.Context) (*redisConn, error) {
+	nc, err := c.dialer.DialContext(ctx, "tcp", c.cfg.Addr)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &redisConn{Conn: nc, r: bufio.NewReader(nc), w: bufio.NewWriter(nc)}
+	if c.cfg.Password != "" {
+		if _, err = conn.do(ctx, c.cfg.Timeout, "AUTH", []byte(c.cfg.Password)); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	if c.cfg.DB != 0 {
+		if _, err = conn.do(ctx, c.cfg.Timeout, "SELECT", []byte(strconv.Itoa(c.cfg.DB))); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+func (c *Redis) put(conn *redisConn) {
+	select {
+	case <-c.quit:
+		conn.Close()
+		return
+	default:
+	}
+
+	select {
+	case c.conns <- conn:
+	default:
+		conn.Close()
+	}
+}
+
+func (conn *redisConn) do(ctx context.Context, timeout time.Duration, cmd string, args ...[]byte) (interface{}, error) {
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	conn.w.WriteString("*" + strconv.Itoa(len(args) + 1) + "\r\n")
+	conn.writeBulk([]byte(cmd))
+	for _, arg := range args {
+		conn.writeBulk(arg)
+	}
+
+	if err := conn.w.Flush(); err != nil {
+		return nil, err
+	}
+	return conn.read()
+}
+
+func (conn *redisConn) writeBulk(b []byte) {
+	conn.w.WriteString("$" + strconv.Itoa(len(b)) + "\r\n")
+	conn.w.Write(b)
+	conn.w.WriteString("\r\n")
+}
+
+// read reads a reply: a string, an int64, a []byte, nil or an []interface{}.
+func (conn *redisConn) read() (interface{}, error) {
+	line, err := conn.r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: malformed reply %q", line)
+	}
+
+	prefix, line := line[0], line[1:len(line)-2]
+	switch prefix {
+	case '+':
+		return line, nil
+	case '-':
+		return nil, redisError(line)
+	case ':':
+		return strconv.ParseInt(line, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line)
+		if err != nil || n < 0 {
+			return nil, err
+		}
+
+		b := make([]byte, n+2)
+		if _, err = io.ReadFull(conn.r, b); err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	case '*':
+		n, err := strconv.Atoi(line)
+		if err != nil || n < 0 {
+			return nil, err
+		}
+
+		var replies = make([]interface{}, n)
+		for i := range replies {
+			if replies[i], err = conn.read(); err != nil {
+				if _, ok := err.(redisError); !ok {
+					return nil, err
+				}
+			}
+		}
+		return replies, nil
+	default:
+		return nil, fmt.Errorf("redis: malformed reply %q", string(prefix) + line)
+	}
+}
@@ -0,0 +1,160 @@
+package cache
+
+import (
+	"context"
+	"github.com/al8n/shareable-notes/apigateway/config"
+	"github.com/alicebob/miniredis/v2"
+	"testing"
+	"time"
+)
+
+func startRedis(t *testing.T, cfg config.Redis) (*miniredis.Miniredis, *Redis) {
+	t.Helper()
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(mr.Close)
+
+	if cfg.Password != "" {
+		mr.RequireAuth(cfg.Password)
+	}
+
+	cfg.Addr = mr.Addr()
+	cfg.PoolSize = 2
+	cfg.Timeout = time.Second
+
+	c := NewRedis(cfg)
+	t.Cleanup(func() { c.Close() })
+	return mr, c
+}
+
+func TestRedis(t *testing.T) {
+	var ctx = context.Background()
+
+	t.Run("get set delete", func(t *testing.T) {
+		_, c := startRedis(t, config.Redis{})
+
+		if _, ok, err := c.Get(ctx, "a"); ok || err != nil {
+			t.Fatalf("got %v %v, want missing", ok, err)
+		}
+
+		if err := c.Set(ctx, "a", []byte("1"), time.Minute); err != nil {
+			t.Fatal(err)
+		}
+
+		if value, ok, err := c.Get(ctx, "a"); !ok || err != nil || string(value) != "1" {
+			t.Fatalf("got %q %v %v", value, ok, err)
+		}
+
+		if err := c.Delete(ctx, "a", "missing"); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, ok, err := c.Get(ctx, "a"); ok || err != nil {
+			t.Fatalf("got %v %v, want deleted", ok, err)
+		}
+	})
+
+	t.Run("binary value", func(t *testing.T) {
+		_, c := startRedis(t, config.Redis{})
+
+		var value = []byte("line\r\n\x00end")
+		if err := c.Set(ctx, "a", value, time.Minute); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, ok, err := c.Get(ctx, "a"); !ok || err != nil || string(got) != string(value) {
+			t.Fatalf("got %q %v %v", got, ok, err)
+		}
+	})
+
+	t.Run("expiry", func(t *testing.T) {
+		mr, c := startRedis(t, config.Redis{})
+
+		if err := c.Set(ctx, "a", []byte("1"), time.Minute); err != nil {
+			t.Fatal(err)
+		}
+
+		if ttl := mr.TTL("a"); ttl != time.Minute {
+			t.Fatalf("ttl %v", ttl)
+		}
+
+		mr.FastForward(time.Minute)
+		if _, ok, err := c.Get(ctx, "a"); ok || err != nil {
+			t.Fatalf("got %v %v, want expired", ok, err)
+		}
+	})
+
+	t.Run("no expiry", func(t *testing.T) {
+		mr, c := startRedis(t, config.Redis{})
+
+		for _, ttl := range []time.Duration{0, -time.Second} {
+			if err := c.Set(ctx, "a", []byte("1"), ttl); err != nil {
+				t.Fatalf("ttl %v: %v", ttl, err)
+			}
+
+			if got := mr.TTL("a"); got != 0 {
+				t.Fatalf("ttl %v: expires in %v", ttl, got)
+			}
+		}
+	})
+
+	t.Run("auth and db", func(t *testing.T) {
+		mr, c := startRedis(t, config.Redis{Password: "secret", DB: 3})
+
+		if err := c.Set(ctx, "a", []byte("1"), time.Minute); err != nil {
+			t.Fatal(err)
+		}
+
+		if value, err := mr.DB(3).Get("a"); err != nil || value != "1" {
+			t.Fatalf("got %q %v", value, err)
+		}
+	})
+
+	t.Run("reconnect", func(t *testing.T) {
+		mr, c := startRedis(t, config.Redis{})
+
+		if err := c.Set(ctx, "a", []byte("1"), time.Minute); err != nil {
+			t.Fatal(err)
+		}
+
+		// the idle connection is closed by the server, the server keeps its data
+		mr.Close()
+		if err := mr.Restart(); err != nil {
+			t.Fatal(err)
+		}
+
+		if value, ok, err := c.Get(ctx, "a"); !ok || err != nil || string(value) != "1" {
+			t.Fatalf("got %q %v %v", value, ok, err)
+		}
+
+		mr.Close()
+		if _, _, err := c.Get(ctx, "a"); err == nil {
+			t.Fatal("got no error from a closed server")
+		}
+
+		if err := mr.Restart(); err != nil {
+			t.Fatal(err)
+		}
+
+		if value, ok, err := c.Get(ctx, "a"); !ok || err != nil || string(value) != "1" {
+			t.Fatalf("got %q %v %v", value, ok, err)
+		}
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		mr, err := miniredis.Run()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mr.Close()
+
+		c := NewRedis(config.Redis{Addr: mr.Addr(), PoolSize: 1, Timeout: time.Second})
+		c.Close()
+		if _, _, err := c.Get(ctx, "a"); err != errRedisClosed {
+			t.Fatalf("got %v", err)
+		}
+	})
+}
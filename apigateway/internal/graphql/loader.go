@@ -0,0 +1,93 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"github.com/al8n/shareable-notes/share-svc/model/requests"
+	"github.com/al8n/shareable-notes/share-svc/model/responses"
+	shareendpoint "github.com/al8n/shareable-notes/share-svc/pkg/endpoint"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/graph-gophers/dataloader"
+	"sync"
+)
+
+type loadersKey struct{}
+
+// loaders batch and cache the lookups of a single GraphQL request, so a note
+// asked for several times is fetched once. The share service has no batch
+// RPC, the lookups of a batch are sent concurrently.
+type loaders struct {
+	endpoints shareendpoint.Set
+	notes *dataloader.Loader
+	forks *dataloader.Loader
+}
+
+func newLoaders(endpoints shareendpoint.Set) *loaders {
+	return &loaders{
+		endpoints: endpoints,
+		notes: dataloader.NewBatchedLoader(batch(endpoints.GetNoteEndpoint, loadNote)),
+		forks: dataloader.NewBatchedLoader(batch(endpoints.ListForksEndpoint, loadForks)),
+	}
+}
+
+func (l *loaders) attach(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// batch returns a dataloader.BatchFunc calling ep once per key with load.
+func batch(ep endpoint.Endpoint, load func(context.Context, endpoint.Endpoint, string) (interface{}, error)) dataloader.BatchFunc {
+	return func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+		var (
+			results = make([]*dataloader.Result, len(keys))
+			wg sync.WaitGroup
+		)
+
+		for i, key := range keys {
+			wg.Add(1)
+			go func(i int, id string) {
+				defer wg.Done()
+				data, err := load(ctx, ep, id)
+				results[i] = &dataloader.Result{Data: data, Error: err}
+			}(i, key.String())
+		}
+		wg.Wait()
+		return results
+	}
+}
+
+// loadNote returns the note with the given id, or a nil note when it cannot be found.
+func loadNote(ctx context.Context, ep endpoint.Endpoint, id string) (interface{}, error) {
+	resp, err := ep(ctx, requests.GetNoteRequest{NoteID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	response := resp.(responses.GetNoteResponse)
+	switch response.Error {
+	case "":
+		return &noteResolver{id: id, name: response.Name, content: response.Content}, nil
+	case common.ErrorNoteNotFound.Error():
+		return (*noteResolver)(nil), nil
+	default:
+		return nil, errors.New(response.Error)
+	}
+}
+
+func loadForks(ctx context.Context, ep endpoint.Endpoint, id string) (interface{}, error) {
+	resp, err := ep(ctx, requests.ListForksRequest{NoteID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	response := resp.(responses.ListForksResponse)
+	if response.Error != "" {
+		return nil, errors.New(response.Error)
+	}
+	return append([]model.NoteFork{}, response.Forks...), nil
+}
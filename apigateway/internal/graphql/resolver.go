@@ -0,0 +1,262 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"github.com/al8n/shareable-notes/share-svc/model/requests"
+	"github.com/al8n/shareable-notes/share-svc/model/responses"
+	shareendpoint "github.com/al8n/shareable-notes/share-svc/pkg/endpoint"
+	gqlgo "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/graph-gophers/dataloader"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// maxNotes bounds the number of notes asked for by a notes query.
+const maxNotes = 100
+
+// NewHandler returns an HTTP handler serving the GraphQL queries posted as
+// JSON, resolved with the share service endpoints.
+func NewHandler(endpoints shareendpoint.Set) http.Handler {
+	var h = &relay.Handler{
+		Schema: gqlgo.MustParseSchema(Schema, &resolver{}),
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := newLoaders(endpoints).attach(r.Context())
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+type resolver struct{}
+
+func (*resolver) Note(ctx context.Context, args struct{ ID gqlgo.ID }) (*noteResolver, error) {
+	data, err := loadersFrom(ctx).notes.Load(ctx, dataloader.StringKey(args.ID))()
+	if err != nil {
+		return nil, err
+	}
+	return data.(*noteResolver), nil
+}
+
+func (*resolver) Notes(ctx context.Context, args struct{ IDs []gqlgo.ID }) ([]*noteResolver, error) {
+	if len(args.IDs) > maxNotes {
+		return nil, fmt.Errorf("at most %d notes can be asked for at once", maxNotes)
+	}
+
+	var keys = make(dataloader.Keys, len(args.IDs))
+	for i, id := range args.IDs {
+		keys[i] = dataloader.StringKey(id)
+	}
+
+	data, errs := loadersFrom(ctx).notes.LoadMany(ctx, keys)()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var notes = make([]*noteResolver, len(data))
+	for i := range data {
+		notes[i] = data[i].(*noteResolver)
+	}
+	return notes, nil
+}
+
+type noteResolver struct {
+	id string
+	name string
+	content string
+}
+
+func (n *noteResolver) ID() gqlgo.ID {
+	return gqlgo.ID(n.id)
+}
+
+func (n *noteResolver) Name() string {
+	return n.name
+}
+
+func (n *noteResolver) Content() string {
+	return n.content
+}
+
+func (n *noteResolver) Stats(ctx context.Context, args struct {
+	OwnerToken string
+	Days       *int32
+}) (*statsResolver, error) {
+	var req = requests.GetNoteStatsRequest{NoteID: n.id, OwnerToken: args.OwnerToken}
+	if args.Days != nil {
+		req.Days = int(*args.Days)
+	}
+
+	resp, err := loadersFrom(ctx).endpoints.GetNoteStatsEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	response := resp.(responses.GetNoteStatsResponse)
+	if response.Error != "" {
+		return nil, errors.New(response.Error)
+	}
+	return &statsResolver{stats: response.Stats}, nil
+}
+
+func (n *noteResolver) Forks(ctx context.Context) ([]*forkResolver, error) {
+	data, err := loadersFrom(ctx).forks.Load(ctx, dataloader.StringKey(n.id))()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		forks = data.([]model.NoteFork)
+		resolvers = make([]*forkResolver, len(forks))
+	)
+	for i := range forks {
+		resolvers[i] = &forkResolver{fork: forks[i]}
+	}
+	return resolvers, nil
+}
+
+type forkResolver struct {
+	fork model.NoteFork
+}
+
+func (f *forkResolver) NoteID() gqlgo.ID {
+	return gqlgo.ID(f.fork.NoteID)
+}
+
+func (f *forkResolver) Name() string {
+	return f.fork.Name
+}
+
+func (f *forkResolver) Owner() *string {
+	if f.fork.Owner == "" {
+		return nil
+	}
+	return &f.fork.Owner
+}
+
+func (f *forkResolver) URL() string {
+	return f.fork.URL
+}
+
+func (f *forkResolver) CreatedAt() long {
+	return long(f.fork.CreatedAt)
+}
+
+type statsResolver struct {
+	stats model.NoteStats
+}
+
+func (s *statsResolver) Views() long {
+	return long(s.stats.Views)
+}
+
+func (s *statsResolver) Transports() []*countResolver {
+	return counts(s.stats.Transports)
+}
+
+func (s *statsResolver) Referrers() []*countResolver {
+	return counts(s.stats.Referrers)
+}
+
+func (s *statsResolver) Daily() []*dailyViewsResolver {
+	var daily = make([]*dailyViewsResolver, len(s.stats.Daily))
+	for i := range s.stats.Daily {
+		daily[i] = &dailyViewsResolver{day: s.stats.Daily[i]}
+	}
+	return daily
+}
+
+func (s *statsResolver) FirstViewedAt() *long {
+	return optionalLong(s.stats.FirstViewedAt)
+}
+
+func (s *statsResolver) LastViewedAt() *long {
+	return optionalLong(s.stats.LastViewedAt)
+}
+
+type countResolver struct {
+	key string
+	views int64
+}
+
+func (c *countResolver) Key() string {
+	return c.key
+}
+
+func (c *countResolver) Views() long {
+	return long(c.views)
+}
+
+// counts returns the counts of the map, the most viewed first.
+func counts(m map[string]int64) []*countResolver {
+	var counts = make([]*countResolver, 0, len(m))
+	for key, views := range m {
+		counts = append(counts, &countResolver{key: key, views: views})
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].views != counts[j].views {
+			return counts[i].views > counts[j].views
+		}
+		return counts[i].key < counts[j].key
+	})
+	return counts
+}
+
+type dailyViewsResolver struct {
+	day model.DailyViews
+}
+
+func (d *dailyViewsResolver) Day() string {
+	return d.day.Day
+}
+
+func (d *dailyViewsResolver) Views() long {
+	return long(d.day.Views)
+}
+
+func (d *dailyViewsResolver) Visitors() long {
+	return long(d.day.Visitors)
+}
+
+// long implements the Long scalar, GraphQL Int being 32 bits.
+type long int64
+
+func (long) ImplementsGraphQLType(name string) bool {
+	return name == "Long"
+}
+
+func (l *long) UnmarshalGraphQL(input interface{}) error {
+	switch input := input.(type) {
+	case int32:
+		*l = long(input)
+	case string:
+		v, err := strconv.ParseInt(input, 10, 64)
+		if err != nil {
+			return err
+		}
+		*l = long(v)
+	default:
+		return fmt.Errorf("wrong type for Long: %T", input)
+	}
+	return nil
+}
+
+func (l long) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(l), 10), nil
+}
+
+// optionalLong returns nil for the zero timestamps of notes never viewed.
+func optionalLong(v int64) *long {
+	if v == 0 {
+		return nil
+	}
+	l := long(v)
+	return &l
+}
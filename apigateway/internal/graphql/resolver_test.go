@@ -0,0 +1,220 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"github.com/al8n/shareable-notes/share-svc/model/requests"
+	"github.com/al8n/shareable-notes/share-svc/model/responses"
+	shareendpoint "github.com/al8n/shareable-notes/share-svc/pkg/endpoint"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// share serves the notes "a" and "b", the other notes cannot be found, and
+// counts the calls of each endpoint.
+type share struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (s *share) count(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[name]++
+}
+
+func (s *share) endpoints() shareendpoint.Set {
+	s.calls = make(map[string]int)
+
+	return shareendpoint.Set{
+		GetNoteEndpoint: func(_ context.Context, request interface{}) (interface{}, error) {
+			s.count("GetNote")
+			req := request.(requests.GetNoteRequest)
+			if req.NoteID != "a" && req.NoteID != "b" {
+				return responses.GetNoteResponse{Error: common.ErrorNoteNotFound.Error()}, nil
+			}
+			return responses.GetNoteResponse{Name: "note " + req.NoteID, Content: "content"}, nil
+		},
+		ListForksEndpoint: func(_ context.Context, request interface{}) (interface{}, error) {
+			s.count("ListForks")
+			if request.(requests.ListForksRequest).NoteID != "a" {
+				return responses.ListForksResponse{Forks: []model.NoteFork{}}, nil
+			}
+			return responses.ListForksResponse{Forks: []model.NoteFork{
+				{NoteID: "b", Name: "note b", Owner: "alice", URL: "url/b", CreatedAt: 1 << 40},
+			}}, nil
+		},
+		GetNoteStatsEndpoint: func(_ context.Context, request interface{}) (interface{}, error) {
+			s.count("GetNoteStats")
+			req := request.(requests.GetNoteStatsRequest)
+			if req.OwnerToken != "token" {
+				return responses.GetNoteStatsResponse{Error: common.ErrorNotNoteOwner.Error()}, nil
+			}
+			return responses.GetNoteStatsResponse{Stats: model.NoteStats{
+				NoteID:        req.NoteID,
+				Views:         int64(req.Days),
+				Transports:    map[string]int64{"grpc": 1, "http": 3},
+				Referrers:     map[string]int64{"b.com": 2, "a.com": 2},
+				Daily:         []model.DailyViews{{Day: "2021-01-01", Views: 4, Visitors: 2}},
+				FirstViewedAt: 1,
+			}}, nil
+		},
+	}
+}
+
+func TestHandler(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		query string
+		data  string
+		err   string
+		calls map[string]int
+	}{
+		{
+			"note",
+			`{ note(id: "a") { id name content } }`,
+			`{"note":{"id":"a","name":"note a","content":"content"}}`,
+			"",
+			map[string]int{"GetNote": 1},
+		},
+		{
+			"note not found",
+			`{ note(id: "c") { id } }`,
+			`{"note":null}`,
+			"",
+			map[string]int{"GetNote": 1},
+		},
+		{
+			"notes in order",
+			`{ notes(ids: ["b", "c", "a"]) { id } }`,
+			`{"notes":[{"id":"b"},null,{"id":"a"}]}`,
+			"",
+			map[string]int{"GetNote": 3},
+		},
+		{
+			"notes loaded once",
+			`{ notes(ids: ["a", "a"]) { id } first: note(id: "a") { name } }`,
+			`{"notes":[{"id":"a"},{"id":"a"}],"first":{"name":"note a"}}`,
+			"",
+			map[string]int{"GetNote": 1},
+		},
+		{
+			"too many notes",
+			`{ notes(ids: [` + strings.Repeat(`"a",`, maxNotes+1) + `]) { id } }`,
+			``,
+			"at most 100 notes can be asked for at once",
+			map[string]int{},
+		},
+		{
+			"forks",
+			`{ note(id: "a") { forks { noteId name owner url createdAt } } }`,
+			`{"note":{"forks":[{"noteId":"b","name":"note b","owner":"alice","url":"url/b","createdAt":1099511627776}]}}`,
+			"",
+			map[string]int{"GetNote": 1, "ListForks": 1},
+		},
+		{
+			"forks of the notes loaded once",
+			`{ notes(ids: ["a", "b", "a"]) { forks { noteId } } }`,
+			`{"notes":[{"forks":[{"noteId":"b"}]},{"forks":[]},{"forks":[{"noteId":"b"}]}]}`,
+			"",
+			map[string]int{"GetNote": 2, "ListForks": 2},
+		},
+		{
+			"stats",
+			`{ note(id: "a") { stats(ownerToken: "token", days: 7) {
+				views transports { key views } referrers { key views }
+				daily { day views visitors } firstViewedAt lastViewedAt
+			} } }`,
+			`{"note":{"stats":{"views":7,` +
+				`"transports":[{"key":"http","views":3},{"key":"grpc","views":1}],` +
+				`"referrers":[{"key":"a.com","views":2},{"key":"b.com","views":2}],` +
+				`"daily":[{"day":"2021-01-01","views":4,"visitors":2}],"firstViewedAt":1,"lastViewedAt":null}}}`,
+			"",
+			map[string]int{"GetNote": 1, "GetNoteStats": 1},
+		},
+		{
+			"stats of another owner",
+			`{ note(id: "a") { stats(ownerToken: "other") { views } } }`,
+			``,
+			common.ErrorNotNoteOwner.Error(),
+			map[string]int{"GetNote": 1, "GetNoteStats": 1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var s = &share{}
+
+			body, err := json.Marshal(map[string]string{"query": tc.query})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+			NewHandler(s.endpoints()).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body)))
+
+			var resp struct {
+				Data   json.RawMessage `json:"data"`
+				Errors []struct {
+					Message string `json:"message"`
+				} `json:"errors"`
+			}
+			if err = json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("%v: %s", err, w.Body)
+			}
+
+			if tc.err != "" {
+				if len(resp.Errors) == 0 || resp.Errors[0].Message != tc.err {
+					t.Fatalf("got errors %+v, want %q", resp.Errors, tc.err)
+				}
+			} else {
+				if len(resp.Errors) != 0 {
+					t.Fatalf("got errors %+v", resp.Errors)
+				}
+				if string(resp.Data) != tc.data {
+					t.Fatalf("got %s, want %s", resp.Data, tc.data)
+				}
+			}
+
+			for name, want := range tc.calls {
+				if got := s.calls[name]; got != want {
+					t.Fatalf("%s called %d times, want %d", name, got, want)
+				}
+			}
+			for name, got := range s.calls {
+				if _, ok := tc.calls[name]; !ok {
+					t.Fatalf("%s called %d times", name, got)
+				}
+			}
+		})
+	}
+}
+
+func TestLongUnmarshal(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		input interface{}
+		want  long
+		err   bool
+	}{
+		{"int", int32(42), 42, false},
+		{"string", "1099511627776", 1 << 40, false},
+		{"invalid string", "many", 0, true},
+		{"float", 1.5, 0, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got long
+			err := got.UnmarshalGraphQL(tc.input)
+			if (err != nil) != tc.err {
+				t.Fatalf("got %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("got %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package graphql
+
+// Schema describes the notes and their metadata served by the gateway.
+const Schema = `
+schema {
+	query: Query
+}
+
+type Query {
+	# note returns a visible note, or null when it cannot be found.
+	note(id: ID!): Note
+	# notes returns the visible notes in the order of ids, with null for the
+	# notes which cannot be found.
+	notes(ids: [ID!]!): [Note]!
+}
+
+# Long is a 64 bits integer.
+scalar Long
+
+type Note {
+	id: ID!
+	name: String!
+	content: String!
+	# stats are only returned for the owner token returned when the note was
+	# shared, forked or instantiated from a template.
+	stats(ownerToken: String!, days: Int): NoteStats!
+	# forks are the visible notes forked from the note.
+	forks: [NoteFork!]!
+}
+
+type NoteFork {
+	noteId: ID!
+	name: String!
+	owner: String
+	url: String!
+	createdAt: Long!
+}
+
+type NoteStats {
+	views: Long!
+	transports: [Count!]!
+	referrers: [Count!]!
+	daily: [DailyViews!]!
+	firstViewedAt: Long
+	lastViewedAt: Long
+}
+
+# Count is the number of views of a note through a transport or a referrer.
+type Count {
+	key: String!
+	views: Long!
+}
+
+type DailyViews {
+	day: String!
+	views: Long!
+	visitors: Long!
+}
+`
@@ -0,0 +1,222 @@
+package openapi
+
+import "net/http"
+
+// ExplorerHandler returns an HTTP handler serving a page which lists the
+// operations of the document served at specURL, relative to the page, and
+// calls them. The page has no external dependencies.
+func ExplorerHandler(specURL string) http.Handler {
+	page := []byte(explorerHead + `<script>const specURL = "` + specURL + `";</script>` + explorerBody)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page)
+	})
+}
+
+const explorerHead = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Share Service API</title>
+<style>
+body { font-family: sans-serif; margin: 2em auto; max-width: 60em; color: #222; }
+details { border: 1px solid #ccc; border-radius: 4px; margin: .5em 0; }
+summary { cursor: pointer; padding: .5em; }
+details > div { padding: 0 1em 1em; }
+.method { display: inline-block; width: 5em; font-weight: bold; text-transform: uppercase; }
+.get { color: #1a7f37; } .post { color: #0550ae; } .put { color: #9a6700; } .delete { color: #cf222e; }
+code, pre, textarea { font-family: monospace; }
+pre { background: #f6f8fa; padding: .5em; overflow: auto; max-height: 30em; }
+textarea { width: 100%; height: 10em; }
+label { display: block; margin: .3em 0; }
+label input { margin-left: .5em; }
+.muted { color: #666; }
+</style>
+</head>
+<body>
+<h1 id="title">Share Service API</h1>
+<p id="description" class="muted"></p>
+<div id="operations"></div>
+`
+
+const explorerBody = `<script>
+"use strict";
+
+let spec;
+
+function el(tag, attrs, ...children) {
+	const e = document.createElement(tag);
+	Object.assign(e, attrs || {});
+	for (const c of children) {
+		e.append(c);
+	}
+	return e;
+}
+
+function resolve(schema) {
+	if (schema && schema.$ref) {
+		return spec.components.schemas[schema.$ref.split("/").pop()];
+	}
+	return schema || {};
+}
+
+// example returns a value of the schema, to show the shape of the models.
+function example(schema, seen) {
+	seen = seen || [];
+	if (schema && schema.$ref) {
+		if (seen.includes(schema.$ref)) {
+			return {};
+		}
+		seen = seen.concat(schema.$ref);
+	}
+	schema = resolve(schema);
+	switch (schema.type) {
+	case "object":
+		if (schema.additionalProperties) {
+			return {key: example(schema.additionalProperties, seen)};
+		}
+		const o = {};
+		for (const [name, p] of Object.entries(schema.properties || {})) {
+			o[name] = example(p, seen);
+		}
+		return o;
+	case "array":
+		return [example(schema.items, seen)];
+	case "integer":
+	case "number":
+		return 0;
+	case "boolean":
+		return false;
+	case "string":
+		return "";
+	}
+	return null;
+}
+
+function base64url(s) {
+	const bytes = new TextEncoder().encode(s);
+	let binary = "";
+	for (const b of bytes) {
+		binary += String.fromCharCode(b);
+	}
+	return btoa(binary).replace(/\+/g, "-").replace(/\//g, "_");
+}
+
+function render(path, method, op) {
+	const inputs = {};
+	const body = el("div");
+
+	for (const p of op.parameters || []) {
+		const input = el("input", {placeholder: p.in === "path" ? "raw id, encoded on send" : ""});
+		inputs[p.name] = {param: p, input: input};
+		body.append(el("label", {}, el("code", {}, p.name), " (" + p.in + ")", input));
+	}
+
+	let payload;
+	const content = op.requestBody ? op.requestBody.content : {};
+	if (content["application/json"]) {
+		payload = el("textarea", {value: JSON.stringify(example(content["application/json"].schema), null, 2)});
+		body.append(el("p", {}, "Body" + (op.requestBody.required ? "" : " (optional)")), payload);
+	} else if (content["multipart/form-data"]) {
+		payload = el("input", {type: "file"});
+		body.append(el("label", {}, "file", payload));
+	}
+
+	for (const [status, r] of Object.entries(op.responses)) {
+		body.append(el("p", {}, el("strong", {}, status), " " + r.description));
+		for (const [type, m] of Object.entries(r.content || {})) {
+			if (m.schema && (m.schema.$ref || m.schema.type === "object")) {
+				body.append(el("pre", {}, type + "\n" + JSON.stringify(example(m.schema), null, 2)));
+			} else {
+				body.append(el("pre", {}, type));
+			}
+		}
+	}
+
+	const output = el("pre");
+	const websocket = op.responses["101"] !== undefined;
+	const send = el("button", {textContent: websocket ? "Cannot be sent from here" : "Send", disabled: websocket});
+	send.onclick = async () => {
+		let url = spec.servers[0].url + path;
+		const query = new URLSearchParams();
+		const headers = {};
+		for (const {param, input} of Object.values(inputs)) {
+			if (param.in === "path") {
+				url = url.replace("{" + param.name + "}", base64url(input.value));
+			} else if (input.value === "") {
+				continue;
+			} else if (param.in === "header") {
+				headers[param.name] = input.value;
+			} else {
+				query.set(param.name, input.value);
+			}
+		}
+		if ([...query].length > 0) {
+			url += "?" + query;
+		}
+
+		const init = {method: method.toUpperCase(), headers: headers};
+		if (payload && payload.type === "file") {
+			init.body = new FormData();
+			if (payload.files.length > 0) {
+				init.body.append("file", payload.files[0]);
+			}
+		} else if (payload && payload.value.trim() !== "") {
+			init.body = payload.value;
+			init.headers["Content-Type"] = "application/json";
+		}
+
+		output.textContent = init.method + " " + url + "\n\n";
+		try {
+			const resp = await fetch(url, init);
+			output.textContent += resp.status + " " + resp.statusText + "\n" +
+				(resp.headers.get("Content-Type") || "") + "\n\n";
+			const reader = resp.body.getReader();
+			const decoder = new TextDecoder();
+			for (;;) {
+				const {done, value} = await reader.read();
+				if (done) {
+					break;
+				}
+				output.textContent += decoder.decode(value, {stream: true});
+			}
+		} catch (err) {
+			output.textContent += err;
+		}
+	};
+	body.append(send, output);
+
+	if (op.description) {
+		body.prepend(el("p", {className: "muted"}, op.description));
+	}
+
+	return el("details", {},
+		el("summary", {},
+			el("span", {className: "method " + method}, method),
+			el("code", {}, path), " ",
+			el("span", {className: "muted"}, op.summary || op.operationId)),
+		body);
+}
+
+fetch(specURL)
+	.then(resp => resp.json())
+	.then(doc => {
+		spec = doc;
+		document.getElementById("title").textContent = spec.info.title;
+		document.getElementById("description").textContent = spec.info.description || "";
+
+		const operations = document.getElementById("operations");
+		for (const path of Object.keys(spec.paths).sort()) {
+			for (const [method, op] of Object.entries(spec.paths[path])) {
+				operations.append(render(path, method, op));
+			}
+		}
+	})
+	.catch(err => {
+		document.getElementById("operations").textContent = "Cannot load " + specURL + ": " + err;
+	});
+</script>
+</body>
+</html>
+`
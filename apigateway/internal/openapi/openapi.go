@@ -0,0 +1,145 @@
+package openapi
+
+import (
+	"encoding/json"
+	bootapi "github.com/al8n/micro-boot/api"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Document is an OpenAPI 3 document, restricted to what describes the share
+// service routes.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Servers    []Server            `json:"servers"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+// PathItem maps the lower case HTTP methods of a path to their operation.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// pathVariable matches the variables of a route path, with their optional
+// pattern.
+var pathVariable = regexp.MustCompile(`{([^}:]+)(:[^}]*)?}`)
+
+// New returns the document of the routes of apis, served under prefix.
+// The routes are documented with the request and response models of their
+// service, the routes of an unknown service only get a summary.
+func New(apis bootapi.APIs, prefix string) *Document {
+	var (
+		doc = &Document{
+			OpenAPI: "3.0.3",
+			Info: Info{
+				Title: "Share Service",
+				Description: "Shares notes behind unguessable links. The ids in the paths " +
+					"are base64url encoded, and failed calls answer with a 500 status and " +
+					"an Error.",
+				Version: "1.0",
+			},
+			Servers: []Server{{URL: prefix}},
+			Paths: map[string]PathItem{},
+		}
+		s = schemas{}
+	)
+
+	s.named("Error", reflect.TypeOf(errorResponse{}))
+
+	for name, api := range apis {
+		if api.Path == "" || api.Method == "" {
+			continue
+		}
+
+		op, ok := operations[name]
+		if !ok {
+			op = operation{summary: name}
+		}
+
+		path := pathVariable.ReplaceAllString(api.Path, "{$1}")
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = PathItem{}
+		}
+		doc.Paths[path][strings.ToLower(api.Method)] = op.build(name, api.Path, s)
+	}
+
+	doc.Components.Schemas = s
+	return doc
+}
+
+// Handler returns an HTTP handler serving doc as JSON.
+func Handler(doc *Document) http.Handler {
+	data, err := json.Marshal(doc)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write(data)
+	})
+}
+
+// errorResponse is the body of the failed calls.
+type errorResponse struct {
+	Error string `json:"error"`
+}
@@ -0,0 +1,180 @@
+package openapi
+
+import (
+	"encoding/json"
+	bootapi "github.com/al8n/micro-boot/api"
+	shareservice "github.com/al8n/shareable-notes/share-svc/pkg/service"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	doc := New(bootapi.APIs{
+		shareservice.ShareNoteServiceName:      {Path: "/note", Method: "POST"},
+		shareservice.GetNoteServiceName:        {Path: "/note/{id}", Method: "GET"},
+		shareservice.ResolveCommentServiceName: {Path: "/note/{id}/comments/{comment:[A-Za-z0-9_=-]+}/resolve", Method: "POST"},
+		shareservice.GetNoteStatsServiceName:   {Path: "/note/{id}/stats", Method: "GET"},
+		"Unknown":                              {Path: "/unknown", Method: "GET"},
+		"NotRouted":                            {Method: "GET"},
+	}, "/share")
+
+	if doc.Servers[0].URL != "/share" {
+		t.Fatalf("got servers %+v", doc.Servers)
+	}
+
+	var paths []string
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	if len(paths) != 5 {
+		t.Fatalf("got paths %v", paths)
+	}
+
+	for _, tc := range []struct {
+		name       string
+		path       string
+		method     string
+		parameters []string
+		body       string
+		required   bool
+		responses  []string
+	}{
+		{"body", "/note", "post", nil, "ShareNoteRequest", true, []string{"200", "500"}},
+		{"conditional", "/note/{id}", "get", []string{"path id", "header If-None-Match", "header If-Modified-Since"}, "", false, []string{"200", "304", "500"}},
+		{"path pattern", "/note/{id}/comments/{comment}/resolve", "post", []string{"path id", "path comment"}, "ResolveCommentRequest", false, []string{"200", "500"}},
+		{"query", "/note/{id}/stats", "get", []string{"path id", "query owner_token", "query days"}, "", false, []string{"200", "500"}},
+		{"unknown service", "/unknown", "get", nil, "", false, []string{"200", "500"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			op := doc.Paths[tc.path][tc.method]
+			if op == nil {
+				t.Fatalf("no %s %s", tc.method, tc.path)
+			}
+
+			var parameters []string
+			for _, p := range op.Parameters {
+				parameters = append(parameters, p.In+" "+p.Name)
+				if p.In == "path" && !p.Required {
+					t.Fatalf("path parameter %s is optional", p.Name)
+				}
+			}
+			if !reflect.DeepEqual(parameters, tc.parameters) {
+				t.Fatalf("got parameters %v, want %v", parameters, tc.parameters)
+			}
+
+			switch {
+			case tc.body == "" && op.RequestBody != nil:
+				t.Fatalf("got body %+v", op.RequestBody)
+			case tc.body != "":
+				if op.RequestBody == nil || op.RequestBody.Required != tc.required ||
+					op.RequestBody.Content["application/json"].Schema.Ref != componentsPrefix+tc.body {
+					t.Fatalf("got body %+v, want %s", op.RequestBody, tc.body)
+				}
+			}
+
+			var codes []string
+			for code := range op.Responses {
+				codes = append(codes, code)
+			}
+			if len(codes) != len(tc.responses) {
+				t.Fatalf("got responses %v, want %v", codes, tc.responses)
+			}
+			for _, code := range tc.responses {
+				if _, ok := op.Responses[code]; !ok {
+					t.Fatalf("got responses %v, want %v", codes, tc.responses)
+				}
+			}
+		})
+	}
+
+	// the fields filled from the path or the query are left out of the body
+	// and the errors out of the responses
+	for _, tc := range []struct {
+		schema string
+		fields []string
+	}{
+		{"ShareNoteRequest", []string{"content", "name"}},
+		{"ResolveCommentRequest", []string{"user"}},
+		{"GetNoteResponse", []string{"content", "etag", "last_modified", "name"}},
+		{"Error", []string{"error"}},
+	} {
+		t.Run(tc.schema, func(t *testing.T) {
+			schema := doc.Components.Schemas[tc.schema]
+			if schema == nil {
+				t.Fatalf("no %s schema", tc.schema)
+			}
+
+			var fields []string
+			for name := range schema.Properties {
+				fields = append(fields, name)
+			}
+			if len(fields) != len(tc.fields) {
+				t.Fatalf("got fields %v, want %v", fields, tc.fields)
+			}
+			for _, name := range tc.fields {
+				if schema.Properties[name] == nil {
+					t.Fatalf("got fields %v, want %v", fields, tc.fields)
+				}
+			}
+		})
+	}
+
+	if days := doc.Paths["/note/{id}/stats"]["get"].Parameters[2].Schema; days.Type != "integer" || days.Format != "int64" {
+		t.Fatalf("got days %+v", days)
+	}
+}
+
+// TestOperations checks the operations against the request models, the
+// fields filled from the path and the query must be fields of the request.
+func TestOperations(t *testing.T) {
+	for name, op := range operations {
+		t.Run(name, func(t *testing.T) {
+			if op.request == nil {
+				t.Fatal("no request")
+			}
+
+			var fields = map[string]bool{}
+			rt := reflect.TypeOf(op.request)
+			for i := 0; i < rt.NumField(); i++ {
+				if name, ok := jsonName(rt.Field(i)); ok {
+					fields[name] = true
+				}
+			}
+
+			for _, field := range append(append([]string{}, op.bound...), op.query...) {
+				if !fields[field] {
+					t.Fatalf("%s is not a field of %s", field, rt.Name())
+				}
+			}
+			if len(op.bound) != len(op.path) {
+				t.Fatalf("%d path variables fill %d fields", len(op.path), len(op.bound))
+			}
+			if op.stream != contentResponse && op.response == nil {
+				t.Fatal("no response")
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	doc := New(bootapi.APIs{
+		shareservice.ShareNoteServiceName: {Path: "/note", Method: "POST"},
+	}, "/share")
+
+	w := httptest.NewRecorder()
+	Handler(doc).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/share/openapi.json", nil))
+
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/json; charset=utf-8" {
+		t.Fatalf("got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["openapi"] != "3.0.3" || got["paths"].(map[string]interface{})["/note"] == nil {
+		t.Fatalf("got %v", got)
+	}
+}
@@ -0,0 +1,358 @@
+package openapi
+
+import (
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"github.com/al8n/shareable-notes/share-svc/model/requests"
+	"github.com/al8n/shareable-notes/share-svc/model/responses"
+	shareservice "github.com/al8n/shareable-notes/share-svc/pkg/service"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+// Bodies read by the HTTP decoders of the share service.
+const (
+	noBody = iota
+	jsonBody
+	// optionalJSONBody may be left empty
+	optionalJSONBody
+	// multipartBody carries the content of an attachment in its file part
+	multipartBody
+)
+
+// Responses written by the HTTP encoders of the share service.
+const (
+	jsonResponse = iota
+	// eventsResponse streams the events as server-sent events
+	eventsResponse
+	// websocketResponse upgrades the connection to a WebSocket
+	websocketResponse
+	// contentResponse streams a content with its own media type
+	contentResponse
+)
+
+const (
+	noteID       = "The note id, base64url encoded."
+	collectionID = "The collection id, base64url encoded."
+	attachmentID = "The attachment id, base64url encoded."
+	commentID    = "The comment id, base64url encoded."
+)
+
+// operation describes how the HTTP transport of the share service maps a
+// route to the request and response models of a service.
+type operation struct {
+	summary     string
+	description string
+
+	request interface{}
+	// path describes the path variables, bound lists the request fields they
+	// fill and query the request fields read from the query
+	path  map[string]string
+	bound []string
+	query []string
+	body  int
+
+	// conditional reads answer 304 when the client holds the version already
+	conditional bool
+
+	response interface{}
+	stream   int
+}
+
+var operations = map[string]operation{
+	shareservice.ShareNoteServiceName: {
+		summary: "Share a note",
+		request: requests.ShareNoteRequest{},
+		body: jsonBody,
+		response: responses.ShareNoteResponse{},
+	},
+	shareservice.PrivateNoteServiceName: {
+		summary: "Make a note private",
+		description: "The note can no longer be read through its link.",
+		request: requests.PrivateNoteRequest{},
+		body: jsonBody,
+		response: responses.PrivateNoteResponse{},
+	},
+	shareservice.GetNoteServiceName: {
+		summary: "Read a note",
+		description: "The response carries the ETag and Last-Modified of the note, " +
+			"If-None-Match and If-Modified-Since make the read conditional.",
+		request: requests.GetNoteRequest{},
+		path: map[string]string{"id": noteID},
+		bound: []string{"note_id"},
+		conditional: true,
+		response: responses.GetNoteResponse{},
+	},
+	shareservice.WatchNoteServiceName: {
+		summary: "Watch the changes of a note",
+		description: "Streams a NoteEvent per change as server-sent events, until " +
+			"the note is made private or deleted.",
+		request: requests.WatchNoteRequest{},
+		path: map[string]string{"id": noteID},
+		bound: []string{"note_id"},
+		response: model.NoteEvent{},
+		stream: eventsResponse,
+	},
+	shareservice.EditNoteServiceName: {
+		summary: "Edit a note collaboratively",
+		description: "Upgrades to a WebSocket exchanging EditMessages. The first " +
+			"message joins the note at a revision, operations are then sent " +
+			"against the latest revision seen.",
+		request: requests.EditNoteRequest{},
+		path: map[string]string{"id": noteID},
+		bound: []string{"note_id"},
+		query: []string{"user"},
+		response: model.EditMessage{},
+		stream: websocketResponse,
+	},
+	shareservice.SyncNotesServiceName: {
+		summary: "Sync notes with a client",
+		description: "Applies the local changes, and returns the server changes " +
+			"made after since with the conflicts met.",
+		request: requests.SyncNotesRequest{},
+		body: jsonBody,
+		response: responses.SyncNotesResponse{},
+	},
+	shareservice.ForkNoteServiceName: {
+		summary: "Fork a note",
+		request: requests.ForkNoteRequest{},
+		path: map[string]string{"id": noteID},
+		bound: []string{"note_id"},
+		body: optionalJSONBody,
+		response: responses.ForkNoteResponse{},
+	},
+	shareservice.ListForksServiceName: {
+		summary: "List the forks of a note",
+		request: requests.ListForksRequest{},
+		path: map[string]string{"id": noteID},
+		bound: []string{"note_id"},
+		response: responses.ListForksResponse{},
+	},
+	shareservice.MarkTemplateServiceName: {
+		summary: "Mark a note as a template",
+		description: "Returns the {{placeholders}} of the template.",
+		request: requests.MarkTemplateRequest{},
+		path: map[string]string{"id": noteID},
+		bound: []string{"note_id"},
+		body: jsonBody,
+		response: responses.MarkTemplateResponse{},
+	},
+	shareservice.InstantiateTemplateServiceName: {
+		summary: "Create a note from a template",
+		request: requests.InstantiateTemplateRequest{},
+		path: map[string]string{"id": noteID},
+		bound: []string{"note_id"},
+		body: jsonBody,
+		response: responses.InstantiateTemplateResponse{},
+	},
+	shareservice.CreateCollectionServiceName: {
+		summary: "Create a collection of notes",
+		request: requests.CreateCollectionRequest{},
+		body: jsonBody,
+		response: responses.CreateCollectionResponse{},
+	},
+	shareservice.AddCollectionNotesServiceName: {
+		summary: "Add notes to a collection",
+		request: requests.AddCollectionNotesRequest{},
+		path: map[string]string{"id": collectionID},
+		bound: []string{"collection_id"},
+		body: jsonBody,
+		response: responses.AddCollectionNotesResponse{},
+	},
+	shareservice.RemoveCollectionNotesServiceName: {
+		summary: "Remove notes from a collection",
+		request: requests.RemoveCollectionNotesRequest{},
+		path: map[string]string{"id": collectionID},
+		bound: []string{"collection_id"},
+		body: jsonBody,
+		response: responses.RemoveCollectionNotesResponse{},
+	},
+	shareservice.ReorderCollectionServiceName: {
+		summary: "Reorder the notes of a collection",
+		request: requests.ReorderCollectionRequest{},
+		path: map[string]string{"id": collectionID},
+		bound: []string{"collection_id"},
+		body: jsonBody,
+		response: responses.ReorderCollectionResponse{},
+	},
+	shareservice.GetCollectionServiceName: {
+		summary: "Read a collection",
+		request: requests.GetCollectionRequest{},
+		path: map[string]string{"id": collectionID},
+		bound: []string{"collection_id"},
+		response: responses.GetCollectionResponse{},
+	},
+	shareservice.UploadAttachmentServiceName: {
+		summary: "Attach a file to a note",
+		request: requests.UploadAttachmentRequest{},
+		path: map[string]string{"id": noteID},
+		bound: []string{"note_id"},
+		body: multipartBody,
+		response: responses.UploadAttachmentResponse{},
+	},
+	shareservice.DownloadAttachmentServiceName: {
+		summary: "Download an attachment",
+		description: "Returns the content with the media type of the attachment.",
+		request: requests.DownloadAttachmentRequest{},
+		path: map[string]string{"id": noteID, "attachment": attachmentID},
+		bound: []string{"note_id", "attachment_id"},
+		stream: contentResponse,
+	},
+	shareservice.AddCommentServiceName: {
+		summary: "Comment a note",
+		description: "The comment is anchored to the lines start_line to end_line " +
+			"of the content, unless start_line is left out.",
+		request: requests.AddCommentRequest{},
+		path: map[string]string{"id": noteID},
+		bound: []string{"note_id"},
+		body: jsonBody,
+		response: responses.AddCommentResponse{},
+	},
+	shareservice.ListCommentsServiceName: {
+		summary: "List the comments of a note",
+		request: requests.ListCommentsRequest{},
+		path: map[string]string{"id": noteID},
+		bound: []string{"note_id"},
+		query: []string{"include_resolved"},
+		response: responses.ListCommentsResponse{},
+	},
+	shareservice.ResolveCommentServiceName: {
+		summary: "Resolve a comment",
+		request: requests.ResolveCommentRequest{},
+		path: map[string]string{"id": noteID, "comment": commentID},
+		bound: []string{"note_id", "comment_id"},
+		body: optionalJSONBody,
+		response: responses.ResolveCommentResponse{},
+	},
+	shareservice.GetNoteStatsServiceName: {
+		summary: "Read the views of a note",
+		description: "The stats of a note are only returned for the owner token returned when it was shared, forked or instantiated from a template.",
+		request: requests.GetNoteStatsRequest{},
+		path: map[string]string{"id": noteID},
+		bound: []string{"note_id"},
+		query: []string{"owner_token", "days"},
+		response: responses.GetNoteStatsResponse{},
+	},
+}
+
+// build returns the operation of the route of the service name at path.
+func (op operation) build(name, path string, s schemas) *Operation {
+	var o = &Operation{
+		OperationID: name,
+		Summary: op.summary,
+		Description: op.description,
+		Responses: map[string]Response{
+			strconv.Itoa(http.StatusInternalServerError): {
+				Description: "The call failed.",
+				Content: negotiated(&Schema{Ref: componentsPrefix + "Error"}),
+			},
+		},
+	}
+
+	for _, match := range pathVariable.FindAllStringSubmatch(path, -1) {
+		o.Parameters = append(o.Parameters, Parameter{
+			Name: match[1],
+			In: "path",
+			Description: op.path[match[1]],
+			Required: true,
+			Schema: &Schema{Type: "string"},
+		})
+	}
+
+	if op.request != nil {
+		t := reflect.TypeOf(op.request)
+		for _, q := range op.query {
+			o.Parameters = append(o.Parameters, Parameter{
+				Name: q,
+				In: "query",
+				Schema: s.field(t, q),
+			})
+		}
+
+		if op.conditional {
+			o.Parameters = append(o.Parameters,
+				Parameter{
+					Name: "If-None-Match",
+					In: "header",
+					Description: "The ETags held by the client.",
+					Schema: &Schema{Type: "string"},
+				},
+				Parameter{
+					Name: "If-Modified-Since",
+					In: "header",
+					Description: "The Last-Modified held by the client, ignored with If-None-Match.",
+					Schema: &Schema{Type: "string"},
+				},
+			)
+			o.Responses[strconv.Itoa(http.StatusNotModified)] = Response{
+				Description: "The client holds the version of the note already.",
+			}
+		}
+
+		switch op.body {
+		case jsonBody, optionalJSONBody:
+			exclude := append(append([]string{}, op.bound...), op.query...)
+			o.RequestBody = &RequestBody{
+				Required: op.body == jsonBody,
+				Content: negotiated(s.named(t.Name(), t, exclude...)),
+			}
+		case multipartBody:
+			o.RequestBody = &RequestBody{
+				Required: true,
+				Content: map[string]MediaType{
+					"multipart/form-data": {Schema: &Schema{
+						Type: "object",
+						Properties: map[string]*Schema{
+							"file": {Type: "string", Format: "binary", Description: "The attachment, named after the file name."},
+						},
+					}},
+				},
+			}
+		}
+	}
+
+	var ok = strconv.Itoa(http.StatusOK)
+	switch op.stream {
+	case eventsResponse:
+		o.Responses[ok] = Response{
+			Description: "The events, their data is encoded as JSON.",
+			Content: map[string]MediaType{
+				"text/event-stream": {Schema: s.of(reflect.TypeOf(op.response))},
+			},
+		}
+	case websocketResponse:
+		o.Responses[strconv.Itoa(http.StatusSwitchingProtocols)] = Response{
+			Description: "The connection is upgraded, the messages are encoded as JSON.",
+			Content: map[string]MediaType{
+				"application/json": {Schema: s.of(reflect.TypeOf(op.response))},
+			},
+		}
+	case contentResponse:
+		o.Responses[ok] = Response{
+			Description: "The content.",
+			Content: map[string]MediaType{
+				"application/octet-stream": {Schema: &Schema{Type: "string", Format: "binary"}},
+			},
+		}
+	default:
+		var response = Response{Description: "The call succeeded."}
+		if op.response != nil {
+			// the failed calls answer with an Error instead
+			t := reflect.TypeOf(op.response)
+			response.Content = negotiated(s.named(t.Name(), t, "error"))
+		}
+		o.Responses[ok] = response
+	}
+	return o
+}
+
+// negotiated returns the media types of the bodies negotiated by the routes,
+// the MessagePack bodies have the fields of the JSON ones and the protobuf
+// bodies are the messages of the gRPC service.
+func negotiated(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{
+		"application/json": {Schema: schema},
+		"application/msgpack": {Schema: schema},
+		"application/x-protobuf": {Schema: &Schema{Type: "string", Format: "binary"}},
+	}
+}
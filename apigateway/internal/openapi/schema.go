@@ -0,0 +1,109 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+)
+
+const componentsPrefix = "#/components/schemas/"
+
+// schemas holds the component schemas of the structs, named after their type.
+type schemas map[string]*Schema
+
+// of returns the schema of the JSON encoding of t, the structs are added to
+// the components and referenced.
+func (s schemas) of(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return s.of(t.Elem())
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.of(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.of(t.Elem())}
+	case reflect.Struct:
+		return s.named(t.Name(), t)
+	default:
+		return &Schema{}
+	}
+}
+
+// named adds the struct t to the components under name, without the fields
+// encoded as exclude, and returns a reference to it.
+func (s schemas) named(name string, t reflect.Type, exclude ...string) *Schema {
+	if _, ok := s[name]; !ok {
+		// reserve the name first, so a recursive type ends up referencing itself
+		s[name] = &Schema{}
+		s[name] = s.object(t, exclude)
+	}
+	return &Schema{Ref: componentsPrefix + name}
+}
+
+func (s schemas) object(t reflect.Type, exclude []string) *Schema {
+	var object = &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		name, ok := jsonName(f)
+		if !ok || contains(exclude, name) {
+			continue
+		}
+
+		switch f.Type.Kind() {
+		case reflect.Chan, reflect.Func:
+			continue
+		}
+		object.Properties[name] = s.of(f.Type)
+	}
+	return object
+}
+
+// field returns the schema of the field of t encoded as name.
+func (s schemas) field(t reflect.Type, name string) *Schema {
+	for i := 0; i < t.NumField(); i++ {
+		if n, ok := jsonName(t.Field(i)); ok && n == name {
+			return s.of(t.Field(i).Type)
+		}
+	}
+	return &Schema{Type: "string"}
+}
+
+// jsonName returns the name f is encoded as, or false when it is not encoded.
+func jsonName(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+
+	if name := strings.Split(tag, ",")[0]; name != "" {
+		return name, true
+	}
+	return f.Name, true
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package openapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+type node struct {
+	Name     string           `json:"name"`
+	Children []*node          `json:"children,omitempty"`
+	Labels   map[string]int64 `json:"labels"`
+	Data     []byte           `json:"data"`
+	Hidden   string           `json:"-"`
+	Untagged bool
+	Events   chan struct{}     `json:"events"`
+	Score    float32           `json:"score"`
+	Weights  map[string]uint16 `json:"weights"`
+	private  string
+}
+
+func TestSchemas(t *testing.T) {
+	s := schemas{}
+
+	if ref := s.of(reflect.TypeOf(&node{})); ref.Ref != componentsPrefix+"node" {
+		t.Fatalf("got %+v", ref)
+	}
+
+	want := &Schema{Type: "object", Properties: map[string]*Schema{
+		"name":     {Type: "string"},
+		"children": {Type: "array", Items: &Schema{Ref: componentsPrefix + "node"}},
+		"labels":   {Type: "object", AdditionalProperties: &Schema{Type: "integer", Format: "int64"}},
+		"data":     {Type: "string", Format: "byte"},
+		"Untagged": {Type: "boolean"},
+		"score":    {Type: "number", Format: "float"},
+		"weights":  {Type: "object", AdditionalProperties: &Schema{Type: "integer", Format: "int32"}},
+	}}
+	if got := s["node"]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestSchemasNamed(t *testing.T) {
+	s := schemas{}
+	s.named("Body", reflect.TypeOf(node{}), "name", "labels", "data", "Untagged", "score", "weights")
+
+	if got := s["Body"]; len(got.Properties) != 1 || got.Properties["children"] == nil {
+		t.Fatalf("got %+v", got.Properties)
+	}
+	// the children still reference the whole node
+	if s["node"] == nil || len(s["node"].Properties) != 7 {
+		t.Fatalf("got %+v", s["node"])
+	}
+}
+
+func TestSchemasField(t *testing.T) {
+	s := schemas{}
+
+	for _, tc := range []struct {
+		field string
+		want  *Schema
+	}{
+		{"name", &Schema{Type: "string"}},
+		{"score", &Schema{Type: "number", Format: "float"}},
+		{"Untagged", &Schema{Type: "boolean"}},
+		{"missing", &Schema{Type: "string"}},
+	} {
+		t.Run(tc.field, func(t *testing.T) {
+			if got := s.field(reflect.TypeOf(node{}), tc.field); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
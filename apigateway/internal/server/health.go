@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"github.com/go-kit/kit/sd"
+	"sync"
+)
+
+var errNoInstances = errors.New("no share service instance is passing its checks")
+
+// instancesState keeps the latest instances discovered by an instancer, so
+// the gateway is only ready when it can reach the share service.
+type instancesState struct {
+	instancer sd.Instancer
+	events chan sd.Event
+	quit chan struct{}
+
+	mu sync.RWMutex
+	event sd.Event
+}
+
+func watchInstances(instancer sd.Instancer) *instancesState {
+	s := &instancesState{
+		instancer: instancer,
+		events: make(chan sd.Event),
+		quit: make(chan struct{}),
+	}
+
+	// the instancer blocks until the current instances are received
+	go s.watch()
+	instancer.Register(s.events)
+	return s
+}
+
+func (s *instancesState) watch() {
+	for {
+		select {
+		case event := <-s.events:
+			s.mu.Lock()
+			s.event = event
+			s.mu.Unlock()
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// Check fails when the instancer failed or discovered no instance.
+func (s *instancesState) Check(context.Context) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.event.Err != nil {
+		return s.event.Err
+	}
+
+	if len(s.event.Instances) == 0 {
+		return errNoInstances
+	}
+	return nil
+}
+
+func (s *instancesState) Close() {
+	s.instancer.Deregister(s.events)
+	close(s.quit)
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"github.com/go-kit/kit/sd"
+	"testing"
+	"time"
+)
+
+func TestInstancesState(t *testing.T) {
+	in := &instancer{instances: []string{"10.0.0.1:8081"}}
+	s := watchInstances(in)
+
+	// the events are applied by the watching goroutine
+	eventually := func(want error) {
+		t.Helper()
+
+		deadline := time.Now().Add(time.Second)
+		for {
+			err := s.Check(context.Background())
+			if err == want {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("got %v, want %v", err, want)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	eventually(nil)
+
+	in.send(sd.Event{})
+	eventually(errNoInstances)
+
+	errConsul := errors.New("consul is unreachable")
+	in.send(sd.Event{Instances: []string{"10.0.0.1:8081"}, Err: errConsul})
+	eventually(errConsul)
+
+	in.send(sd.Event{Instances: []string{"10.0.0.2:8081"}})
+	eventually(nil)
+
+	s.Close()
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if in.ch != nil {
+		t.Fatal("state is still registered")
+	}
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"github.com/go-kit/kit/sd"
+	"google.golang.org/grpc/resolver"
+)
+
+// instancerScheme is the scheme of the gRPC targets resolved by an instancer.
+const instancerScheme = "instancer"
+
+// instancerBuilder resolves gRPC targets to the instances discovered by
+// instancer, so a single connection balances over the share-svc instances.
+type instancerBuilder struct {
+	instancer sd.Instancer
+}
+
+func (b instancerBuilder) Build(_ resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	r := &instancerResolver{
+		instancer: b.instancer,
+		events: make(chan sd.Event),
+		quit: make(chan struct{}),
+	}
+
+	// the instancer sends the current instances as soon as the channel is
+	// registered, and blocks until they are received
+	go r.watch(cc)
+	b.instancer.Register(r.events)
+	return r, nil
+}
+
+func (instancerBuilder) Scheme() string {
+	return instancerScheme
+}
+
+type instancerResolver struct {
+	instancer sd.Instancer
+	events chan sd.Event
+	quit chan struct{}
+}
+
+func (r *instancerResolver) watch(cc resolver.ClientConn) {
+	for {
+		select {
+		case event := <-r.events:
+			if event.Err != nil {
+				cc.ReportError(event.Err)
+				continue
+			}
+
+			addresses := make([]resolver.Address, len(event.Instances))
+			for i, instance := range event.Instances {
+				addresses[i] = resolver.Address{Addr: instance}
+			}
+			cc.UpdateState(resolver.State{Addresses: addresses})
+		case <-r.quit:
+			return
+		}
+	}
+}
+
+// ResolveNow does nothing, the instancer pushes the changes.
+func (*instancerResolver) ResolveNow(resolver.ResolveNowOptions) {}
+
+func (r *instancerResolver) Close() {
+	r.instancer.Deregister(r.events)
+	close(r.quit)
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"errors"
+	"github.com/go-kit/kit/sd"
+	"google.golang.org/grpc/resolver"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// instancer sends the instances to the registered channel as the Consul
+// instancer does, blocking until they are received.
+type instancer struct {
+	mu        sync.Mutex
+	instances []string
+	ch        chan<- sd.Event
+}
+
+func (i *instancer) Register(ch chan<- sd.Event) {
+	i.mu.Lock()
+	i.ch = ch
+	instances := i.instances
+	i.mu.Unlock()
+
+	ch <- sd.Event{Instances: instances}
+}
+
+func (i *instancer) Deregister(ch chan<- sd.Event) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.ch == ch {
+		i.ch = nil
+	}
+}
+
+func (i *instancer) Stop() {}
+
+func (i *instancer) send(event sd.Event) {
+	i.mu.Lock()
+	ch := i.ch
+	i.mu.Unlock()
+
+	ch <- event
+}
+
+// clientConn forwards the states and the errors reported by the resolver.
+type clientConn struct {
+	resolver.ClientConn
+	states chan resolver.State
+	errs   chan error
+}
+
+func (c *clientConn) UpdateState(state resolver.State) error {
+	c.states <- state
+	return nil
+}
+
+func (c *clientConn) ReportError(err error) {
+	c.errs <- err
+}
+
+func TestInstancerResolver(t *testing.T) {
+	var (
+		in = &instancer{instances: []string{"10.0.0.1:8081"}}
+		cc = &clientConn{states: make(chan resolver.State, 1), errs: make(chan error, 1)}
+	)
+
+	r, err := instancerBuilder{instancer: in}.Build(resolver.Target{}, cc, resolver.BuildOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addresses := func(instances ...string) []resolver.Address {
+		var addresses = make([]resolver.Address, len(instances))
+		for i, instance := range instances {
+			addresses[i] = resolver.Address{Addr: instance}
+		}
+		return addresses
+	}
+
+	expect := func(want []resolver.Address) {
+		t.Helper()
+
+		select {
+		case state := <-cc.states:
+			if !reflect.DeepEqual(state.Addresses, want) {
+				t.Fatalf("got %+v, want %+v", state.Addresses, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("no state")
+		}
+	}
+
+	expect(addresses("10.0.0.1:8081"))
+
+	in.send(sd.Event{Instances: []string{"10.0.0.1:8081", "10.0.0.2:8081"}})
+	expect(addresses("10.0.0.1:8081", "10.0.0.2:8081"))
+
+	errConsul := errors.New("consul is unreachable")
+	in.send(sd.Event{Err: errConsul})
+	select {
+	case err = <-cc.errs:
+		if err != errConsul {
+			t.Fatalf("got %v, want %v", err, errConsul)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no error reported")
+	}
+
+	in.send(sd.Event{})
+	expect(addresses())
+
+	r.Close()
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if in.ch != nil {
+		t.Fatal("resolver is still registered")
+	}
+}
@@ -1,8 +1,15 @@
 package server
 
 import (
+	"context"
 	"github.com/al8n/shareable-notes/apigateway/config"
+	"github.com/al8n/shareable-notes/apigateway/internal/cache"
+	"github.com/al8n/shareable-notes/apigateway/internal/graphql"
+	"github.com/al8n/shareable-notes/apigateway/internal/openapi"
+	sharerequests "github.com/al8n/shareable-notes/share-svc/model/requests"
+	sharepb "github.com/al8n/shareable-notes/share-svc/pb"
 	shareendpoint "github.com/al8n/shareable-notes/share-svc/pkg/endpoint"
+	"github.com/al8n/shareable-notes/share-svc/pkg/health"
 	shareservice "github.com/al8n/shareable-notes/share-svc/pkg/service"
 	sharetransport "github.com/al8n/shareable-notes/share-svc/pkg/transport"
 	"github.com/go-kit/kit/log"
@@ -14,6 +21,7 @@ import (
 	"github.com/uber/jaeger-client-go"
 	jaegerconfig "github.com/uber/jaeger-client-go/config"
 	"google.golang.org/grpc"
+	"hash/fnv"
 	"io"
 	"net/http"
 	"sync"
@@ -27,6 +35,12 @@ import (
 type Server struct {
 	tracerCloser io.Closer
 	handler http.Handler
+	httpServer *http.Server
+	restConn *grpc.ClientConn
+	checker *health.Checker
+	instances *instancesState
+	cache *cache.Cache
+	logger log.Logger
 	wg sync.WaitGroup
 }
 
@@ -39,6 +53,7 @@ func (s *Server) Serve() (err error) {
 		logger = log.NewLogfmtLogger(os.Stderr)
 		logger = log.With(logger, "ts", log.DefaultTimestampUTC)
 		logger = log.With(logger, "caller", log.DefaultCaller)
+		s.logger = logger
 	}
 
 	// Service discovery domain. In this example we use Consul.
@@ -60,6 +75,7 @@ func (s *Server) Serve() (err error) {
 	var (
 		tracer stdopentracing.Tracer // no-op
 		jaegerCfg *jaegerconfig.Configuration
+		reporter = health.NewReporterState()
 	)
 	{
 		jaegerCfg, err = jaegerconfig.FromEnv()
@@ -81,7 +97,10 @@ func (s *Server) Serve() (err error) {
 
 		jaegerCfg.ServiceName = "API Gateway"
 
-		tracer, s.tracerCloser, err = jaegerCfg.NewTracer(jaegerconfig.Logger(jaeger.StdLogger))
+		tracer, s.tracerCloser, err = jaegerCfg.NewTracer(
+			jaegerconfig.Logger(jaeger.StdLogger),
+			jaegerconfig.Metrics(reporter),
+		)
 		if err != nil {
 			return err
 		}
@@ -97,6 +116,28 @@ func (s *Server) Serve() (err error) {
 			endpoints   = shareendpoint.Set{}
 			instancer   = consulsd.NewInstancer(client, logger, cfg.ShareSVC.Name, tags, passingOnly)
 		)
+		{
+			factory := sharesvcFactory(shareendpoint.MakeWatchNoteEndpoint, tracer, logger)
+			endpointer := sd.NewEndpointer(instancer, factory, logger)
+			balancer := lb.NewRoundRobin(endpointer)
+			endpoints.WatchNoteEndpoint = streamEndpoint(balancer)
+		}
+		{
+			factory := sharesvcFactory(shareendpoint.MakeWatchNotesEndpoint, tracer, logger)
+			endpointer := sd.NewEndpointer(instancer, factory, logger)
+			balancer := lb.NewRoundRobin(endpointer)
+			endpoints.WatchNotesEndpoint = streamEndpoint(balancer)
+		}
+
+		// the notes read through the gateway are cached, in process or in
+		// Redis, while the notes are watched
+		{
+			var backend cache.Backend = cache.NewLRU(cfg.Cache.MaxBytes)
+			if cfg.Cache.Redis.Addr != "" {
+				backend = cache.NewRedis(cfg.Cache.Redis)
+			}
+			s.cache = cache.New(backend, cfg.Cache, endpoints.WatchNotesEndpoint, logger)
+		}
 		{
 			factory := sharesvcFactory(shareendpoint.MakeShareNoteEndpoint, tracer, logger)
 			endpointer := sd.NewEndpointer(instancer, factory, logger)
@@ -109,16 +150,140 @@ func (s *Server) Serve() (err error) {
 			endpointer := sd.NewEndpointer(instancer, factory, logger)
 			balancer := lb.NewRoundRobin(endpointer)
 			retry := lb.Retry(cfg.RetryMax, cfg.RetryTimeout, balancer)
-			endpoints.PrivateNoteEndpoint = retry
+			endpoints.PrivateNoteEndpoint = s.cache.PrivateNote(retry)
 		}
 		{
 			factory := sharesvcFactory(shareendpoint.MakeGetNoteEndpoint, tracer, logger)
 			endpointer := sd.NewEndpointer(instancer, factory, logger)
 			balancer := lb.NewRoundRobin(endpointer)
 			retry := lb.Retry(cfg.RetryMax, cfg.RetryTimeout, balancer)
-			endpoints.GetNoteEndpoint = retry
+			endpoints.GetNoteEndpoint = s.cache.GetNote(retry)
+		}
+		{
+			factory := sharesvcFactory(shareendpoint.MakeEditNoteEndpoint, tracer, logger)
+			endpointer := sd.NewEndpointer(instancer, factory, logger)
+			endpoints.EditNoteEndpoint = noteAffinityEndpoint(endpointer)
+		}
+		{
+			factory := sharesvcFactory(shareendpoint.MakeSyncNotesEndpoint, tracer, logger)
+			endpointer := sd.NewEndpointer(instancer, factory, logger)
+			balancer := lb.NewRoundRobin(endpointer)
+			retry := lb.Retry(cfg.RetryMax, cfg.RetryTimeout, balancer)
+			endpoints.SyncNotesEndpoint = s.cache.SyncNotes(retry)
+		}
+		{
+			factory := sharesvcFactory(shareendpoint.MakeForkNoteEndpoint, tracer, logger)
+			endpointer := sd.NewEndpointer(instancer, factory, logger)
+			balancer := lb.NewRoundRobin(endpointer)
+			retry := lb.Retry(cfg.RetryMax, cfg.RetryTimeout, balancer)
+			endpoints.ForkNoteEndpoint = retry
+		}
+		{
+			factory := sharesvcFactory(shareendpoint.MakeListForksEndpoint, tracer, logger)
+			endpointer := sd.NewEndpointer(instancer, factory, logger)
+			balancer := lb.NewRoundRobin(endpointer)
+			retry := lb.Retry(cfg.RetryMax, cfg.RetryTimeout, balancer)
+			endpoints.ListForksEndpoint = retry
+		}
+		{
+			factory := sharesvcFactory(shareendpoint.MakeMarkTemplateEndpoint, tracer, logger)
+			endpointer := sd.NewEndpointer(instancer, factory, logger)
+			balancer := lb.NewRoundRobin(endpointer)
+			retry := lb.Retry(cfg.RetryMax, cfg.RetryTimeout, balancer)
+			endpoints.MarkTemplateEndpoint = retry
+		}
+		{
+			factory := sharesvcFactory(shareendpoint.MakeInstantiateTemplateEndpoint, tracer, logger)
+			endpointer := sd.NewEndpointer(instancer, factory, logger)
+			balancer := lb.NewRoundRobin(endpointer)
+			retry := lb.Retry(cfg.RetryMax, cfg.RetryTimeout, balancer)
+			endpoints.InstantiateTemplateEndpoint = retry
+		}
+		{
+			factory := sharesvcFactory(shareendpoint.MakeCreateCollectionEndpoint, tracer, logger)
+			endpointer := sd.NewEndpointer(instancer, factory, logger)
+			balancer := lb.NewRoundRobin(endpointer)
+			retry := lb.Retry(cfg.RetryMax, cfg.RetryTimeout, balancer)
+			endpoints.CreateCollectionEndpoint = retry
+		}
+		{
+			factory := sharesvcFactory(shareendpoint.MakeAddCollectionNotesEndpoint, tracer, logger)
+			endpointer := sd.NewEndpointer(instancer, factory, logger)
+			balancer := lb.NewRoundRobin(endpointer)
+			retry := lb.Retry(cfg.RetryMax, cfg.RetryTimeout, balancer)
+			endpoints.AddCollectionNotesEndpoint = retry
+		}
+		{
+			factory := sharesvcFactory(shareendpoint.MakeRemoveCollectionNotesEndpoint, tracer, logger)
+			endpointer := sd.NewEndpointer(instancer, factory, logger)
+			balancer := lb.NewRoundRobin(endpointer)
+			retry := lb.Retry(cfg.RetryMax, cfg.RetryTimeout, balancer)
+			endpoints.RemoveCollectionNotesEndpoint = retry
+		}
+		{
+			factory := sharesvcFactory(shareendpoint.MakeReorderCollectionEndpoint, tracer, logger)
+			endpointer := sd.NewEndpointer(instancer, factory, logger)
+			balancer := lb.NewRoundRobin(endpointer)
+			retry := lb.Retry(cfg.RetryMax, cfg.RetryTimeout, balancer)
+			endpoints.ReorderCollectionEndpoint = retry
+		}
+		{
+			factory := sharesvcFactory(shareendpoint.MakeGetCollectionEndpoint, tracer, logger)
+			endpointer := sd.NewEndpointer(instancer, factory, logger)
+			balancer := lb.NewRoundRobin(endpointer)
+			retry := lb.Retry(cfg.RetryMax, cfg.RetryTimeout, balancer)
+			endpoints.GetCollectionEndpoint = retry
+		}
+		{
+			factory := sharesvcFactory(shareendpoint.MakeUploadAttachmentEndpoint, tracer, logger)
+			endpointer := sd.NewEndpointer(instancer, factory, logger)
+			balancer := lb.NewRoundRobin(endpointer)
+			endpoints.UploadAttachmentEndpoint = streamEndpoint(balancer)
+		}
+		{
+			factory := sharesvcFactory(shareendpoint.MakeDownloadAttachmentEndpoint, tracer, logger)
+			endpointer := sd.NewEndpointer(instancer, factory, logger)
+			balancer := lb.NewRoundRobin(endpointer)
+			endpoints.DownloadAttachmentEndpoint = streamEndpoint(balancer)
+		}
+		{
+			factory := sharesvcFactory(shareendpoint.MakeAddCommentEndpoint, tracer, logger)
+			endpointer := sd.NewEndpointer(instancer, factory, logger)
+			balancer := lb.NewRoundRobin(endpointer)
+			retry := lb.Retry(cfg.RetryMax, cfg.RetryTimeout, balancer)
+			endpoints.AddCommentEndpoint = retry
+		}
+		{
+			factory := sharesvcFactory(shareendpoint.MakeListCommentsEndpoint, tracer, logger)
+			endpointer := sd.NewEndpointer(instancer, factory, logger)
+			balancer := lb.NewRoundRobin(endpointer)
+			retry := lb.Retry(cfg.RetryMax, cfg.RetryTimeout, balancer)
+			endpoints.ListCommentsEndpoint = retry
+		}
+		{
+			factory := sharesvcFactory(shareendpoint.MakeResolveCommentEndpoint, tracer, logger)
+			endpointer := sd.NewEndpointer(instancer, factory, logger)
+			balancer := lb.NewRoundRobin(endpointer)
+			retry := lb.Retry(cfg.RetryMax, cfg.RetryTimeout, balancer)
+			endpoints.ResolveCommentEndpoint = retry
+		}
+		{
+			factory := sharesvcFactory(shareendpoint.MakeGetNoteStatsEndpoint, tracer, logger)
+			endpointer := sd.NewEndpointer(instancer, factory, logger)
+			balancer := lb.NewRoundRobin(endpointer)
+			retry := lb.Retry(cfg.RetryMax, cfg.RetryTimeout, balancer)
+			endpoints.GetNoteStatsEndpoint = retry
 		}
 
+		// the documentation of the share routes, registered first so the
+		// share routes do not shadow it
+		r.Methods(http.MethodGet).Path("/share/openapi.json").Handler(
+			openapi.Handler(openapi.New(cfg.ShareSVC.APIs, "/share")),
+		)
+		r.Methods(http.MethodGet).Path("/share/explorer").Handler(
+			openapi.ExplorerHandler("openapi.json"),
+		)
+
 		r.PathPrefix("/share").Handler(
 				http.StripPrefix(
 					"/share",
@@ -130,25 +295,138 @@ func (s *Server) Serve() (err error) {
 				),
 			)
 
+		r.Handle("/graphql", graphql.NewHandler(endpoints))
+
+		// the REST mapping of the RPCs, balanced over the instances by gRPC
+		{
+			s.restConn, err = grpc.Dial(
+				instancerScheme + ":///" + cfg.ShareSVC.Name,
+				grpc.WithInsecure(),
+				grpc.WithResolvers(instancerBuilder{instancer: instancer}),
+				grpc.WithDefaultServiceConfig(`{"loadBalancingPolicy": "round_robin"}`),
+			)
+			if err != nil {
+				return err
+			}
+
+			rest, err := sharetransport.NewRESTHandler(sharepb.NewShareClient(s.restConn))
+			if err != nil {
+				return err
+			}
+			r.PathPrefix("/v1/").Handler(rest)
+		}
+
 		r.Handle("/metrics", promhttp.Handler())
+
+		// the gateway is ready once it traces and reaches the share service
+		s.instances = watchInstances(instancer)
+		s.checker = health.NewChecker(cfg.HealthInterval, cfg.HealthTimeout)
+		s.checker.Add("tracer", reporter.Check)
+		s.checker.Add("share-svc", s.instances.Check)
+		s.checker.Start()
+
+		r.Methods(http.MethodGet).Path(health.LivenessPath).Handler(health.LivenessHandler())
+		r.Methods(http.MethodGet).Path(health.ReadinessPath).Handler(health.ReadinessHandler(s.checker))
 	}
 
 
 	s.handler = r
+	s.httpServer = &http.Server{Addr: ":" + cfg.HTTP.Port, Handler: r}
+
+	s.wg.Add(1)
 	go func() {
-		s.wg.Add(1)
+		defer s.wg.Done()
 		logger.Log("transport", "HTTP", "addr", cfg.HTTP.Port)
-		http.ListenAndServe(":" + cfg.HTTP.Port, r)
+		if err := s.httpServer.ListenAndServe(); err != http.ErrServerClosed {
+			logger.Log("transport", "HTTP", "during", "Serve", "err", err)
+		}
 	}()
 	s.wg.Wait()
 	return nil
 }
 
+// Close reports the gateway unready, then drains the calls in flight within
+// the shutdown timeout before closing the connections to the share service
+// and flushing the tracer.
 func (s *Server) Close() (err error) {
-	s.wg.Done()
+	if s.checker != nil {
+		s.checker.Shutdown()
+	}
+
+	if s.httpServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), config.GetConfig().ShutdownTimeout)
+		defer cancel()
+
+		err := s.httpServer.Shutdown(ctx)
+		if err != nil {
+			s.httpServer.Close()
+		}
+		s.logger.Log("transport", "HTTP", "op", "Shutdown", "error", err)
+	}
+
+	if s.restConn != nil {
+		s.restConn.Close()
+	}
+
+	if s.instances != nil {
+		s.instances.Close()
+	}
+
+	if s.cache != nil {
+		s.cache.Close()
+	}
+
+	if s.tracerCloser != nil {
+		s.tracerCloser.Close()
+	}
 	return nil
 }
 
+// streamEndpoint picks an instance per call like lb.Retry, but without
+// retries and their deadline, which would cut long-lived streams short.
+func streamEndpoint(balancer lb.Balancer) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		ep, err := balancer.Endpoint()
+		if err != nil {
+			return nil, err
+		}
+		return ep(ctx, request)
+	}
+}
+
+// noteAffinityEndpoint sends all the edit sessions of a note to the same
+// instance, whose hub merges their edits. The instance is picked by jump
+// consistent hashing of the note id over the instances, which the endpointer
+// orders, so only a share of the notes move when instances come and go.
+func noteAffinityEndpoint(endpointer sd.Endpointer) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		endpoints, err := endpointer.Endpoints()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(endpoints) == 0 {
+			return nil, lb.ErrNoEndpoints
+		}
+
+		h := fnv.New64a()
+		h.Write([]byte(request.(sharerequests.EditNoteRequest).NoteID))
+		return endpoints[jumpHash(h.Sum64(), len(endpoints))](ctx, request)
+	}
+}
+
+// jumpHash maps key to a bucket in [0, buckets), see "A Fast, Minimal Memory,
+// Consistent Hash Algorithm" by Lamping and Veach.
+func jumpHash(key uint64, buckets int) int {
+	var b, j int64 = -1, 0
+	for j < int64(buckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
+
 func sharesvcFactory(makeEndpoint func(service shareservice.Service) endpoint.Endpoint, tracer stdopentracing.Tracer, logger log.Logger) sd.Factory {
 	return func(instance string) (endpoint.Endpoint, io.Closer, error) {
 
@@ -12,10 +12,87 @@ var (
 	ErrorConfigurationFileType = errors.New("config file type is not supported")
 	ErrorNoServicesConfig = errors.New("no services configurations are provided")
 	ErrorInvalidPort = errors.New("invalid port number")
+	ErrorUnknownAttachmentStore = errors.New("attachment store is not supported")
+	ErrorUnknownCompressionCodec = errors.New("compression codec is not supported")
+	ErrorUnknownThriftProtocol = errors.New("thrift protocol is not supported")
+	ErrorInvalidTrustedProxy = errors.New("trusted proxy is neither a CIDR nor an IP")
 
 	// Mongo config
 	ErrorNoMongoDBConfig = errors.New("no MongoDB configuration is provided")
 	ErrorNoDatabaseName = errors.New("MongoDB database name cannot be null")
 	ErrorNoCollectionName = errors.New("MongoDB collection name cannot be null")
 
+	// Note
+	ErrorNoteNotFound = errors.New("note cannot be found")
+	ErrorInvalidNoteID = errors.New("note id is invalid")
+	ErrorEmptyNoteStream = errors.New("note stream is empty")
+	ErrorNoteNotModified = errors.New("note has not been modified")
+	ErrorNoteTooLarge = errors.New("note content is too large for GetNote, use GetNoteStream")
+	ErrorContentContention = errors.New("note content is being stored concurrently, retry")
+	ErrorNoteEncrypted = errors.New("note is encrypted and no encryption keyfile is configured")
+
+	// Edit
+	ErrorInvalidEditOperation = errors.New("edit operation does not apply to the note")
+	ErrorUnknownEditMessage = errors.New("edit message type is not supported")
+	ErrorEditRevision = errors.New("edit revision is out of date, rejoin the note")
+	ErrorEditConflict = errors.New("note has been changed elsewhere, rejoin the note")
+	ErrorEditSessionLagging = errors.New("edit session cannot keep up, rejoin the note")
+	ErrorEditSessionClosed = errors.New("edit session is closed")
+	ErrorEditShutdown = errors.New("edit server is shutting down, rejoin the note")
+	ErrorEditRevisionTaken = errors.New("edit revision has been taken by another instance")
+
+	// Sync
+	ErrorInvalidSyncClient = errors.New("sync client id is invalid")
+	ErrorSyncContention = errors.New("note is being changed concurrently, sync again")
+
+	// Template
+	ErrorNotTemplate = errors.New("note is not a template")
+	ErrorMissingTemplateVariables = errors.New("template variables are missing")
+	ErrorUnknownTemplateVariables = errors.New("template variables are unknown")
+
+	// Collection
+	ErrorCollectionNotFound = errors.New("collection cannot be found")
+	ErrorInvalidCollectionOrder = errors.New("collection order must list every note of the collection once")
+
+	// Attachment
+	ErrorAttachmentNotFound = errors.New("attachment cannot be found")
+	ErrorAttachmentTooLarge = errors.New("attachment exceeds the maximum size")
+	ErrorEmptyAttachmentStream = errors.New("attachment stream is empty")
+
+	// Comment
+	ErrorCommentNotFound = errors.New("comment cannot be found")
+	ErrorEmptyComment = errors.New("comment body cannot be empty")
+	ErrorInvalidCommentAnchor = errors.New("comment line range is outside of the note")
+
+	// Analytics
+	ErrorNotNoteOwner = errors.New("note stats are only available with the owner token of the note")
+
 )
+
+// serviceErrors are the errors of the service by message, the responses
+// carry their errors as strings.
+var serviceErrors = make(map[string]error)
+
+func init() {
+	for _, err := range []error{
+		ErrorNoteNotFound, ErrorInvalidNoteID, ErrorEmptyNoteStream, ErrorNoteNotModified,
+		ErrorNoteTooLarge, ErrorContentContention, ErrorNoteEncrypted,
+		ErrorInvalidEditOperation, ErrorUnknownEditMessage, ErrorEditRevision, ErrorEditConflict,
+		ErrorEditSessionLagging, ErrorEditSessionClosed, ErrorEditShutdown,
+		ErrorInvalidSyncClient, ErrorSyncContention,
+		ErrorNotTemplate, ErrorMissingTemplateVariables, ErrorUnknownTemplateVariables,
+		ErrorCollectionNotFound, ErrorInvalidCollectionOrder,
+		ErrorAttachmentNotFound, ErrorAttachmentTooLarge, ErrorEmptyAttachmentStream,
+		ErrorCommentNotFound, ErrorEmptyComment, ErrorInvalidCommentAnchor,
+		ErrorNotNoteOwner,
+	} {
+		serviceErrors[err.Error()] = err
+	}
+}
+
+// ServiceError returns the error of the service of message msg, so that the
+// errors read from a response can be compared with errors.Is.
+func ServiceError(msg string) (err error, ok bool) {
+	err, ok = serviceErrors[msg]
+	return err, ok
+}
@@ -1,19 +1,587 @@
 package config
 
 import (
+	"github.com/al8n/shareable-notes/share-svc/common"
 	bootapi "github.com/al8n/micro-boot/api"
 	bootflag "github.com/al8n/micro-boot/flag"
+	"net"
+	"time"
+)
+
+const (
+	// DefaultStreamChunkSize is used when no chunk size is configured, e.g. by clients.
+	DefaultStreamChunkSize = 64 << 10
+	defaultStreamThreshold = 1 << 20
+	defaultStreamBucket = "contents"
+	defaultStreamMaxUnarySize = 4<<20 - 64<<10
+	defaultCompressionCodec = "zstd"
+	defaultCompressionThreshold = 4 << 10
+	defaultEncryptionRewrapInterval = time.Hour
+	defaultEncryptionRewrapBatch = 100
+	defaultCollabSnapshotInterval = 5 * time.Second
+	defaultCollabHistory = 1000
+	defaultCollabRelayInterval = 200 * time.Millisecond
+	defaultAttachmentStore = AttachmentStoreGridFS
+	defaultAttachmentBucket = "attachments"
+	defaultAttachmentDir = "attachments"
+	defaultAttachmentMaxSize = 10 << 20
+	defaultAnalyticsBuffer = 4096
+	defaultAnalyticsBatchSize = 512
+	defaultAnalyticsFlushInterval = 5 * time.Second
+	defaultJSONRPCPath = "/rpc"
+	defaultJSONRPCMaxBatch = 100
+	defaultNATSQueue = "sharesvc"
+	defaultNATSShareNoteSubject = "share.note.share"
+	defaultNATSPrivateNoteSubject = "share.note.private"
+	defaultNATSGetNoteSubject = "share.note.get"
+	defaultThriftProtocol = ThriftProtocolBinary
+	defaultThriftBufferSize = 8 << 10
+	defaultHealthInterval = 10 * time.Second
+	defaultHealthTimeout = 2 * time.Second
+	defaultDeadline = 30 * time.Second
+	defaultShutdownTimeout = 30 * time.Second
+	defaultShutdownGRPCTimeout = 30 * time.Second
+)
+
+// defaultAnalyticsTrustedProxies trusts the proxies of the same host only.
+var defaultAnalyticsTrustedProxies = []string{"127.0.0.0/8", "::1/128"}
+
+// The blob stores attachments can be kept in.
+const (
+	AttachmentStoreGridFS = "gridfs"
+	AttachmentStoreLocal = "local"
+)
+
+// The protocols the Thrift calls can be encoded with.
+const (
+	ThriftProtocolBinary = "binary"
+	ThriftProtocolCompact = "compact"
+	ThriftProtocolJSON = "json"
 )
 
 type Share struct {
 	Name string `json:"name" yaml:"name"`
 	APIs bootapi.APIs `json:"apis" yaml:"apis"`
+
+	// Stream
+	Stream Stream `json:"stream" yaml:"stream"`
+
+	// Compression
+	Compression Compression `json:"compression" yaml:"compression"`
+
+	// Encryption
+	Encryption Encryption `json:"encryption" yaml:"encryption"`
+
+	// Collab
+	Collab Collab `json:"collab" yaml:"collab"`
+
+	// Attachments
+	Attachments Attachments `json:"attachments" yaml:"attachments"`
+
+	// Analytics
+	Analytics Analytics `json:"analytics" yaml:"analytics"`
+
+	// JSONRPC
+	JSONRPC JSONRPC `json:"jsonrpc" yaml:"jsonrpc"`
+
+	// NATS
+	NATS NATS `json:"nats" yaml:"nats"`
+
+	// Thrift
+	Thrift Thrift `json:"thrift" yaml:"thrift"`
+
+	// Health
+	Health Health `json:"health" yaml:"health"`
+
+	// Deadlines
+	Deadlines Deadlines `json:"deadlines" yaml:"deadlines"`
+
+	// Shutdown
+	Shutdown Shutdown `json:"shutdown" yaml:"shutdown"`
 }
 
 
 func (s *Share) BindFlags(fs *bootflag.FlagSet)  {
 	fs.StringVar(&s.Name, "name", "sharesvc", "specify the micro service name")
+	s.Stream.BindFlags(fs)
+	s.Compression.BindFlags(fs)
+	s.Encryption.BindFlags(fs)
+	s.Collab.BindFlags(fs)
+	s.Attachments.BindFlags(fs)
+	s.Analytics.BindFlags(fs)
+	s.JSONRPC.BindFlags(fs)
+	s.NATS.BindFlags(fs)
+	s.Thrift.BindFlags(fs)
+	s.Health.BindFlags(fs)
+	s.Deadlines.BindFlags(fs)
+	s.Shutdown.BindFlags(fs)
+}
+
+func (s *Share) Parse() (err error) {
+	if err = s.Stream.Parse(); err != nil {
+		return err
+	}
+	if err = s.Compression.Parse(); err != nil {
+		return err
+	}
+	if err = s.Encryption.Parse(); err != nil {
+		return err
+	}
+	if err = s.Collab.Parse(); err != nil {
+		return err
+	}
+	if err = s.Attachments.Parse(); err != nil {
+		return err
+	}
+	if err = s.Analytics.Parse(); err != nil {
+		return err
+	}
+	if err = s.JSONRPC.Parse(); err != nil {
+		return err
+	}
+	if err = s.NATS.Parse(); err != nil {
+		return err
+	}
+	if err = s.Thrift.Parse(); err != nil {
+		return err
+	}
+	if err = s.Health.Parse(); err != nil {
+		return err
+	}
+	if err = s.Deadlines.Parse(); err != nil {
+		return err
+	}
+	return s.Shutdown.Parse()
+}
+
+// Stream configures how note content is moved in chunks, and when it is
+// offloaded from the note document to GridFS.
+type Stream struct {
+	// ChunkSize is the maximum number of content bytes carried by one gRPC stream message.
+	ChunkSize int `json:"chunk-size" yaml:"chunk-size"`
+
+	// Threshold is the content size in bytes above which the content is stored in GridFS.
+	Threshold int `json:"threshold" yaml:"threshold"`
+
+	// Bucket is the name of the GridFS bucket.
+	Bucket string `json:"bucket" yaml:"bucket"`
+
+	// MaxUnarySize is the content size in bytes above which GetNote fails and
+	// GetNoteStream has to be used, it keeps the responses below the default
+	// 4MiB message limit of the gRPC clients.
+	MaxUnarySize int `json:"max-unary-size" yaml:"max-unary-size"`
+}
+
+func (s *Stream) BindFlags(fs *bootflag.FlagSet)  {
+	fs.IntVar(&s.ChunkSize, "stream-chunk-size", 0, "specify the content bytes per stream message (default 64KiB)")
+	fs.IntVar(&s.Threshold, "stream-threshold", 0, "specify the content size above which content is stored in GridFS (default 1MiB)")
+	fs.StringVar(&s.Bucket, "stream-bucket", "", "specify the GridFS bucket name (default \"contents\")")
+	fs.IntVar(&s.MaxUnarySize, "stream-max-unary-size", 0, "specify the content size above which GetNote fails in favour of GetNoteStream (default 4MiB-64KiB)")
+}
+
+func (s *Stream) Parse() (err error) {
+	if s.ChunkSize <= 0 {
+		s.ChunkSize = DefaultStreamChunkSize
+	}
+
+	if s.Threshold <= 0 {
+		s.Threshold = defaultStreamThreshold
+	}
+
+	if s.Bucket == "" {
+		s.Bucket = defaultStreamBucket
+	}
+
+	if s.MaxUnarySize <= 0 {
+		s.MaxUnarySize = defaultStreamMaxUnarySize
+	}
+	return nil
+}
+
+// Compression configures how note content is compressed at rest, in the
+// note document and in GridFS.
+type Compression struct {
+	// Codec is the codec of the content written, "zstd", "gzip" or "none".
+	// The content written before is read with the codec it was written with.
+	Codec string `json:"codec" yaml:"codec"`
+
+	// Threshold is the content size in bytes from which the content is
+	// compressed. The content stored in GridFS is always compressed.
+	Threshold int `json:"threshold" yaml:"threshold"`
+}
+
+func (c *Compression) BindFlags(fs *bootflag.FlagSet)  {
+	fs.StringVar(&c.Codec, "compression-codec", "", "specify the codec of note content at rest, zstd, gzip or none (default \"zstd\")")
+	fs.IntVar(&c.Threshold, "compression-threshold", 0, "specify the content size from which note content is compressed (default 4KiB)")
+}
+
+func (c *Compression) Parse() (err error) {
+	switch c.Codec {
+	case "":
+		c.Codec = defaultCompressionCodec
+	case "zstd", "gzip", "none":
+	default:
+		return common.ErrorUnknownCompressionCodec
+	}
+
+	if c.Threshold <= 0 {
+		c.Threshold = defaultCompressionThreshold
+	}
+	return nil
+}
+
+// Encryption configures the encryption at rest of the note names and
+// contents, each note is encrypted with its own data key, which is stored
+// wrapped by a master key of the keyfile.
+type Encryption struct {
+	// Keyfile is the path of the YAML file holding the master keys, the notes
+	// are not encrypted when empty.
+	Keyfile string `json:"keyfile" yaml:"keyfile"`
+
+	// RewrapInterval is the time between two rewraps of the data keys wrapped
+	// by a former master key, which also encrypt the notes written before the
+	// notes were encrypted.
+	RewrapInterval time.Duration `json:"rewrap-interval" yaml:"rewrap-interval"`
+
+	// RewrapBatch is the number of data keys rewrapped at once.
+	RewrapBatch int `json:"rewrap-batch" yaml:"rewrap-batch"`
+}
+
+func (e *Encryption) BindFlags(fs *bootflag.FlagSet)  {
+	fs.StringVar(&e.Keyfile, "encryption-keyfile", "", "specify the keyfile of the master keys, notes are not encrypted when empty")
+	fs.DurationVar(&e.RewrapInterval, "encryption-rewrap-interval", 0, "specify the time between two rewraps of the data keys (default 1h)")
+	fs.IntVar(&e.RewrapBatch, "encryption-rewrap-batch", 0, "specify the number of data keys rewrapped at once (default 100)")
 }
 
-func (s *Share) Parse() (err error) { return nil }
+func (e *Encryption) Parse() (err error) {
+	if e.RewrapInterval <= 0 {
+		e.RewrapInterval = defaultEncryptionRewrapInterval
+	}
+
+	if e.RewrapBatch <= 0 {
+		e.RewrapBatch = defaultEncryptionRewrapBatch
+	}
+	return nil
+}
+
+// Collab configures the collaborative editing of notes.
+type Collab struct {
+	// SnapshotInterval is the period at which the content of the notes being edited is persisted.
+	SnapshotInterval time.Duration `json:"snapshot-interval" yaml:"snapshot-interval"`
+
+	// History is the number of recent revisions an edit can be based on.
+	History int `json:"history" yaml:"history"`
+
+	// RelayInterval is the period at which the edits made on the other
+	// instances are fetched from the edit log.
+	RelayInterval time.Duration `json:"relay-interval" yaml:"relay-interval"`
+
+	// AllowedOrigins are the origins of the pages allowed to open WebSocket
+	// edit sessions besides the origin of the service, "*" allows any origin.
+	AllowedOrigins []string `json:"allowed-origins" yaml:"allowed-origins"`
+}
+
+func (c *Collab) BindFlags(fs *bootflag.FlagSet)  {
+	fs.DurationVar(&c.SnapshotInterval, "collab-snapshot-interval", 0, "specify the period at which edited notes are persisted (default 5s)")
+	fs.IntVar(&c.History, "collab-history", 0, "specify the number of recent revisions an edit can be based on (default 1000)")
+	fs.DurationVar(&c.RelayInterval, "collab-relay-interval", 0, "specify the period at which the edits of the other instances are fetched (default 200ms)")
+	fs.StringSliceVar(&c.AllowedOrigins, "collab-allowed-origins", nil, "specify the origins allowed to open WebSocket edit sessions besides the service one")
+}
+
+func (c *Collab) Parse() (err error) {
+	if c.SnapshotInterval <= 0 {
+		c.SnapshotInterval = defaultCollabSnapshotInterval
+	}
+
+	if c.History <= 0 {
+		c.History = defaultCollabHistory
+	}
+
+	if c.RelayInterval <= 0 {
+		c.RelayInterval = defaultCollabRelayInterval
+	}
+	return nil
+}
+
+// Attachments configures where the files attached to notes are stored.
+type Attachments struct {
+	// Store is the blob store holding the attachments, "gridfs" or "local".
+	Store string `json:"store" yaml:"store"`
+
+	// Bucket is the name of the GridFS bucket used by the gridfs store.
+	Bucket string `json:"bucket" yaml:"bucket"`
+
+	// Dir is the directory used by the local store.
+	Dir string `json:"dir" yaml:"dir"`
+
+	// MaxSize is the maximum size in bytes of one attachment.
+	MaxSize int64 `json:"max-size" yaml:"max-size"`
+}
+
+func (a *Attachments) BindFlags(fs *bootflag.FlagSet)  {
+	fs.StringVar(&a.Store, "attachments-store", "", "specify the attachment blob store, gridfs or local (default \"gridfs\")")
+	fs.StringVar(&a.Bucket, "attachments-bucket", "", "specify the GridFS bucket name of attachments (default \"attachments\")")
+	fs.StringVar(&a.Dir, "attachments-dir", "", "specify the directory of the local attachment store (default \"attachments\")")
+	fs.Int64Var(&a.MaxSize, "attachments-max-size", 0, "specify the maximum size in bytes of one attachment (default 10MiB)")
+}
+
+func (a *Attachments) Parse() (err error) {
+	switch a.Store {
+	case "":
+		a.Store = defaultAttachmentStore
+	case AttachmentStoreGridFS, AttachmentStoreLocal:
+	default:
+		return common.ErrorUnknownAttachmentStore
+	}
+
+	if a.Bucket == "" {
+		a.Bucket = defaultAttachmentBucket
+	}
+
+	if a.Dir == "" {
+		a.Dir = defaultAttachmentDir
+	}
 
+	if a.MaxSize <= 0 {
+		a.MaxSize = defaultAttachmentMaxSize
+	}
+	return nil
+}
+
+// Analytics configures the recording of note views.
+type Analytics struct {
+	// Buffer is the number of views queued before views are dropped.
+	Buffer int `json:"buffer" yaml:"buffer"`
+
+	// BatchSize is the number of queued views stored at once.
+	BatchSize int `json:"batch-size" yaml:"batch-size"`
+
+	// FlushInterval is the maximum time a view stays queued.
+	FlushInterval time.Duration `json:"flush-interval" yaml:"flush-interval"`
+
+	// Salt is hashed with the client IPs, it must be shared by the instances
+	// for their visitors to be counted once.
+	Salt string `json:"salt" yaml:"salt"`
+
+	// TrustedProxies are the CIDRs or the IPs of the proxies trusted to
+	// forward the client IP of a viewer, e.g. the gateway.
+	TrustedProxies []string `json:"trusted-proxies" yaml:"trusted-proxies"`
+}
+
+func (a *Analytics) BindFlags(fs *bootflag.FlagSet)  {
+	fs.IntVar(&a.Buffer, "analytics-buffer", 0, "specify the number of note views queued before views are dropped (default 4096)")
+	fs.IntVar(&a.BatchSize, "analytics-batch-size", 0, "specify the number of note views stored at once (default 512)")
+	fs.DurationVar(&a.FlushInterval, "analytics-flush-interval", 0, "specify the maximum time a note view stays queued (default 5s)")
+	fs.StringVar(&a.Salt, "analytics-salt", "", "specify the salt hashed with client IPs (default random)")
+	fs.StringSliceVar(&a.TrustedProxies, "analytics-trusted-proxies", nil, "specify the CIDRs or IPs of the proxies trusted to forward the client IP (default loopback)")
+}
+
+func (a *Analytics) Parse() (err error) {
+	if a.Buffer <= 0 {
+		a.Buffer = defaultAnalyticsBuffer
+	}
+
+	if a.BatchSize <= 0 {
+		a.BatchSize = defaultAnalyticsBatchSize
+	}
+
+	if a.FlushInterval <= 0 {
+		a.FlushInterval = defaultAnalyticsFlushInterval
+	}
+
+	if a.TrustedProxies == nil {
+		a.TrustedProxies = defaultAnalyticsTrustedProxies
+	}
+
+	for _, proxy := range a.TrustedProxies {
+		if _, _, err = net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			return common.ErrorInvalidTrustedProxy
+		}
+	}
+	return nil
+}
+
+// JSONRPC configures the JSON-RPC 2.0 endpoint of the HTTP server.
+type JSONRPC struct {
+	// Path is the path the JSON-RPC calls are posted to.
+	Path string `json:"path" yaml:"path"`
+
+	// MaxBatch is the maximum number of calls in one batch.
+	MaxBatch int `json:"max-batch" yaml:"max-batch"`
+}
+
+func (j *JSONRPC) BindFlags(fs *bootflag.FlagSet)  {
+	fs.StringVar(&j.Path, "jsonrpc-path", "", "specify the path of the JSON-RPC endpoint (default \"/rpc\")")
+	fs.IntVar(&j.MaxBatch, "jsonrpc-max-batch", 0, "specify the maximum number of calls in a JSON-RPC batch (default 100)")
+}
+
+func (j *JSONRPC) Parse() (err error) {
+	if j.Path == "" {
+		j.Path = defaultJSONRPCPath
+	}
+
+	if j.MaxBatch <= 0 {
+		j.MaxBatch = defaultJSONRPCMaxBatch
+	}
+	return nil
+}
+
+// NATS configures the request-reply subjects the service subscribes to on a
+// NATS server.
+type NATS struct {
+	// URL is the URL of the NATS server, the service does not connect to NATS when empty.
+	URL string `json:"url" yaml:"url"`
+
+	// Queue is the queue group of the subscriptions, each request is served by one instance.
+	Queue string `json:"queue" yaml:"queue"`
+
+	// Subjects are the subjects the methods are served on.
+	Subjects NATSSubjects `json:"subjects" yaml:"subjects"`
+}
+
+type NATSSubjects struct {
+	ShareNote string `json:"share-note" yaml:"share-note"`
+	PrivateNote string `json:"private-note" yaml:"private-note"`
+	GetNote string `json:"get-note" yaml:"get-note"`
+}
+
+func (n *NATS) BindFlags(fs *bootflag.FlagSet)  {
+	fs.StringVar(&n.URL, "nats-url", "", "specify the URL of the NATS server, NATS is disabled when empty")
+	fs.StringVar(&n.Queue, "nats-queue", "", "specify the queue group of the NATS subscriptions (default \"sharesvc\")")
+	fs.StringVar(&n.Subjects.ShareNote, "nats-share-note-subject", "", "specify the NATS subject of ShareNote (default \"share.note.share\")")
+	fs.StringVar(&n.Subjects.PrivateNote, "nats-private-note-subject", "", "specify the NATS subject of PrivateNote (default \"share.note.private\")")
+	fs.StringVar(&n.Subjects.GetNote, "nats-get-note-subject", "", "specify the NATS subject of GetNote (default \"share.note.get\")")
+}
+
+func (n *NATS) Parse() (err error) {
+	if n.Queue == "" {
+		n.Queue = defaultNATSQueue
+	}
+
+	if n.Subjects.ShareNote == "" {
+		n.Subjects.ShareNote = defaultNATSShareNoteSubject
+	}
+
+	if n.Subjects.PrivateNote == "" {
+		n.Subjects.PrivateNote = defaultNATSPrivateNoteSubject
+	}
+
+	if n.Subjects.GetNote == "" {
+		n.Subjects.GetNote = defaultNATSGetNoteSubject
+	}
+	return nil
+}
+
+// Thrift configures the Thrift server of the service, its clients have to
+// use the same protocol and framing.
+type Thrift struct {
+	// Addr is the address the Thrift server listens on, the service does not serve Thrift when empty.
+	Addr string `json:"addr" yaml:"addr"`
+
+	// Protocol is the protocol of the calls, "binary", "compact" or "json".
+	Protocol string `json:"protocol" yaml:"protocol"`
+
+	// BufferSize is the size in bytes of the read and write buffers of a connection.
+	BufferSize int `json:"buffer-size" yaml:"buffer-size"`
+
+	// Framed frames the calls, as the non-blocking Thrift clients expect.
+	Framed bool `json:"framed" yaml:"framed"`
+}
+
+func (t *Thrift) BindFlags(fs *bootflag.FlagSet)  {
+	fs.StringVar(&t.Addr, "thrift-addr", "", "specify the address of the Thrift server, Thrift is disabled when empty")
+	fs.StringVar(&t.Protocol, "thrift-protocol", "", "specify the protocol of the Thrift calls, binary, compact or json (default \"binary\")")
+	fs.IntVar(&t.BufferSize, "thrift-buffer-size", 0, "specify the buffer size of the Thrift connections (default 8KiB)")
+	fs.BoolVar(&t.Framed, "thrift-framed", false, "specify whether the Thrift calls are framed")
+}
+
+func (t *Thrift) Parse() (err error) {
+	switch t.Protocol {
+	case "":
+		t.Protocol = defaultThriftProtocol
+	case ThriftProtocolBinary, ThriftProtocolCompact, ThriftProtocolJSON:
+	default:
+		return common.ErrorUnknownThriftProtocol
+	}
+
+	if t.BufferSize <= 0 {
+		t.BufferSize = defaultThriftBufferSize
+	}
+	return nil
+}
+
+// Health configures the checks of the dependencies of the service, which
+// the health of the instance reported to Consul depends on.
+type Health struct {
+	// Interval is the time between two checks, for the service and for Consul.
+	Interval time.Duration `json:"interval" yaml:"interval"`
+
+	// Timeout is the maximum time a check takes before it fails.
+	Timeout time.Duration `json:"timeout" yaml:"timeout"`
+}
+
+func (h *Health) BindFlags(fs *bootflag.FlagSet)  {
+	fs.DurationVar(&h.Interval, "health-interval", 0, "specify the time between two health checks (default 10s)")
+	fs.DurationVar(&h.Timeout, "health-timeout", 0, "specify the maximum time a health check takes (default 2s)")
+}
+
+func (h *Health) Parse() (err error) {
+	if h.Interval <= 0 {
+		h.Interval = defaultHealthInterval
+	}
+
+	if h.Timeout <= 0 {
+		h.Timeout = defaultHealthTimeout
+	}
+	return nil
+}
+
+// Deadlines bounds the time the gRPC calls are served for, when the clients
+// leave more time.
+type Deadlines struct {
+	// Default is the deadline of the unary calls.
+	Default time.Duration `json:"default" yaml:"default"`
+
+	// Methods overrides the deadline of the methods, named as in the APIs.
+	// The streams have no deadline unless they are listed, and a zero
+	// deadline lifts the default one.
+	Methods map[string]time.Duration `json:"methods" yaml:"methods"`
+}
+
+func (d *Deadlines) BindFlags(fs *bootflag.FlagSet)  {
+	fs.DurationVar(&d.Default, "deadlines-default", 0, "specify the deadline of the unary gRPC calls (default 30s)")
+}
+
+func (d *Deadlines) Parse() (err error) {
+	if d.Default <= 0 {
+		d.Default = defaultDeadline
+	}
+	return nil
+}
+
+// Shutdown configures how the service stops.
+type Shutdown struct {
+	// Timeout is the time left to the calls in flight to finish, the
+	// remaining ones are cut.
+	Timeout time.Duration `json:"timeout" yaml:"timeout"`
+
+	// GRPCTimeout is the time left to the gRPC calls in flight, counted once
+	// the other transports are drained.
+	GRPCTimeout time.Duration `json:"grpc-timeout" yaml:"grpc-timeout"`
+}
+
+func (s *Shutdown) BindFlags(fs *bootflag.FlagSet)  {
+	fs.DurationVar(&s.Timeout, "shutdown-timeout", 0, "specify the time left to the calls in flight when the service stops (default 30s)")
+	fs.DurationVar(&s.GRPCTimeout, "shutdown-grpc-timeout", 0, "specify the time left to the gRPC calls in flight once the other transports are drained (default 30s)")
+}
+
+func (s *Shutdown) Parse() (err error) {
+	if s.Timeout <= 0 {
+		s.Timeout = defaultShutdownTimeout
+	}
+
+	if s.GRPCTimeout <= 0 {
+		s.GRPCTimeout = defaultShutdownGRPCTimeout
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package analytics
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// storeTimeout bounds writing a batch of views.
+	storeTimeout = 30 * time.Second
+
+	// clientHashSize is the number of bytes of the client IP hash kept.
+	clientHashSize = 8
+
+	// DirectReferrer is the referrer of the views without one.
+	DirectReferrer = "direct"
+)
+
+// Store aggregates the views of notes.
+type Store interface {
+	RecordViews(ctx context.Context, views []model.NoteView) error
+}
+
+// Recorder records note views off the read path: Record only queues the
+// view, and a goroutine hands the queued views to the store in batches.
+// Views are dropped rather than delaying reads when the queue is full, or
+// when the store keeps failing.
+type Recorder struct {
+	store    Store
+	salt     []byte
+	batch    int
+	interval time.Duration
+
+	views chan model.NoteView
+	stop  chan struct{}
+	done  chan struct{}
+	once  sync.Once
+}
+
+// NewRecorder returns a recorder queuing up to buffer views, and storing them
+// every interval or as soon as batch views are queued. The client IPs are
+// hashed with salt, a random one is used when salt is empty, so the hashes
+// of different instances do not match.
+func NewRecorder(store Store, salt string, buffer, batch int, interval time.Duration) *Recorder {
+	r := &Recorder{
+		store:    store,
+		salt:     []byte(salt),
+		batch:    batch,
+		interval: interval,
+		views:    make(chan model.NoteView, buffer),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	if len(r.salt) == 0 {
+		r.salt = make([]byte, sha256.Size)
+		_, _ = rand.Read(r.salt)
+	}
+
+	go r.run()
+	return r
+}
+
+// Record queues a view of the note with the given id by the viewer stored in ctx.
+func (r *Recorder) Record(ctx context.Context, noteID string) {
+	viewer, _ := FromContext(ctx)
+
+	view := model.NoteView{
+		NoteID:     noteID,
+		Timestamp:  time.Now().Unix(),
+		Transport:  viewer.Transport,
+		Referrer:   coarseReferrer(viewer.Referrer),
+		ClientHash: r.hashClient(viewer.ClientIP),
+	}
+
+	select {
+	case r.views <- view:
+	default:
+	}
+}
+
+// Close stores the queued views and stops the recorder.
+func (r *Recorder) Close() error {
+	r.once.Do(func() {
+		close(r.stop)
+	})
+	<-r.done
+	return nil
+}
+
+func (r *Recorder) run() {
+	defer close(r.done)
+
+	var (
+		ticker = time.NewTicker(r.interval)
+		pending = make([]model.NoteView, 0, r.batch)
+	)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case view := <-r.views:
+			pending = append(pending, view)
+			if len(pending) < r.batch {
+				continue
+			}
+		case <-ticker.C:
+		case <-r.stop:
+			// only this goroutine receives, so the length cannot shrink meanwhile
+			for len(r.views) > 0 {
+				pending = append(pending, <-r.views)
+			}
+			r.flush(pending)
+			return
+		}
+
+		if r.flush(pending) || len(pending) >= cap(r.views) {
+			pending = pending[:0]
+		}
+	}
+}
+
+// flush reports whether the views are stored, the views are kept for the
+// next flush otherwise.
+func (r *Recorder) flush(views []model.NoteView) bool {
+	if len(views) == 0 {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	return r.store.RecordViews(ctx, views) == nil
+}
+
+func (r *Recorder) hashClient(ip string) string {
+	if ip == "" {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, r.salt)
+	mac.Write([]byte(ip))
+	return hex.EncodeToString(mac.Sum(nil)[:clientHashSize])
+}
+
+// coarseReferrer keeps the host of the referrer only.
+func coarseReferrer(referrer string) string {
+	u, err := url.Parse(referrer)
+	if err != nil || u.Hostname() == "" {
+		return DirectReferrer
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
@@ -0,0 +1,190 @@
+package analytics
+
+import (
+	"context"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// The transports a note can be viewed through.
+const (
+	TransportHTTP = "http"
+	TransportGRPC = "grpc"
+	TransportNATS = "nats"
+	TransportThrift = "thrift"
+)
+
+// The metadata keys forwarding the viewer of a request proxied over gRPC,
+// e.g. by the gateway.
+const (
+	transportKey = "x-viewer-transport"
+	referrerKey  = "x-viewer-referrer"
+	clientIPKey  = "x-viewer-ip"
+)
+
+type viewerKey struct{}
+
+// Viewer describes who is reading a note, as seen by the transport.
+type Viewer struct {
+	Transport string
+	Referrer  string
+	ClientIP  string
+}
+
+func NewContext(ctx context.Context, viewer Viewer) context.Context {
+	return context.WithValue(ctx, viewerKey{}, viewer)
+}
+
+func FromContext(ctx context.Context) (Viewer, bool) {
+	viewer, ok := ctx.Value(viewerKey{}).(Viewer)
+	return viewer, ok
+}
+
+// Proxies are the networks of the proxies trusted to forward the viewer of a
+// request, e.g. the gateway or a load balancer.
+type Proxies []*net.IPNet
+
+// ParseProxies parses the CIDRs or the IPs of the trusted proxies.
+func ParseProxies(proxies []string) (Proxies, error) {
+	var nets = make(Proxies, 0, len(proxies))
+
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, &net.ParseError{Type: "IP address", Text: proxy}
+			}
+
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8 * net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// Trusted reports whether addr, an IP with or without a port, is the address
+// of a trusted proxy.
+func (p Proxies) Trusted(addr string) bool {
+	ip := net.ParseIP(hostOnly(addr))
+	if ip == nil {
+		return false
+	}
+
+	for _, ipNet := range p {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// HTTPToContext returns an http RequestFunc storing the viewer of the request
+// in the context. The client IP is taken from the proxy headers only when the
+// request comes from a trusted proxy, as anyone can send them.
+func HTTPToContext(proxies Proxies) func(ctx context.Context, r *http.Request) context.Context {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		ip := hostOnly(r.RemoteAddr)
+		if proxies.Trusted(ip) {
+			ip = forwardedIP(r.Header, proxies, ip)
+		}
+
+		return NewContext(ctx, Viewer{
+			Transport: TransportHTTP,
+			Referrer:  r.Referer(),
+			ClientIP:  ip,
+		})
+	}
+}
+
+// forwardedIP returns the client IP forwarded to a trusted proxy. The
+// X-Forwarded-For entries are appended by each proxy, so the client is the
+// last entry which is not a trusted proxy, the entries before it may be
+// forged by the client.
+func forwardedIP(header http.Header, proxies Proxies, remote string) string {
+	var forwarded []string
+
+	for _, value := range header.Values("X-Forwarded-For") {
+		forwarded = append(forwarded, strings.Split(value, ",")...)
+	}
+
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		ip := strings.TrimSpace(forwarded[i])
+		if ip == "" {
+			continue
+		}
+
+		if !proxies.Trusted(ip) || i == 0 {
+			return ip
+		}
+	}
+
+	if ip := strings.TrimSpace(header.Get("X-Real-Ip")); ip != "" {
+		return ip
+	}
+	return remote
+}
+
+// GRPCToContext returns a grpc ServerRequestFunc storing the viewer of the
+// request in the context, the viewer forwarded by ContextToGRPC wins over the
+// peer of the connection when the peer is a trusted proxy.
+func GRPCToContext(proxies Proxies) func(ctx context.Context, md metadata.MD) context.Context {
+	return func(ctx context.Context, md metadata.MD) context.Context {
+		var viewer = Viewer{
+			Transport: TransportGRPC,
+			Referrer:  first(md, "referer"),
+		}
+
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			viewer.ClientIP = hostOnly(p.Addr.String())
+		}
+
+		if transport := first(md, transportKey); transport != "" && proxies.Trusted(viewer.ClientIP) {
+			viewer = Viewer{
+				Transport: transport,
+				Referrer:  first(md, referrerKey),
+				ClientIP:  first(md, clientIPKey),
+			}
+		}
+		return NewContext(ctx, viewer)
+	}
+}
+
+// ContextToGRPC returns a grpc ClientRequestFunc forwarding the viewer stored
+// in the context, if any.
+func ContextToGRPC() func(ctx context.Context, md *metadata.MD) context.Context {
+	return func(ctx context.Context, md *metadata.MD) context.Context {
+		if viewer, ok := FromContext(ctx); ok {
+			md.Set(transportKey, viewer.Transport)
+			md.Set(referrerKey, viewer.Referrer)
+			md.Set(clientIPKey, viewer.ClientIP)
+		}
+		return ctx
+	}
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func hostOnly(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
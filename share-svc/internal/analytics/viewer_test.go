@@ -0,0 +1,131 @@
+package analytics
+
+import (
+	"context"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"net"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseProxies(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		proxies []string
+		trusted []string
+		other   []string
+		err     bool
+	}{
+		{"none", nil, nil, []string{"10.0.0.1"}, false},
+		{"ipv4", []string{"10.0.0.1"}, []string{"10.0.0.1", "10.0.0.1:8080"}, []string{"10.0.0.2"}, false},
+		{"ipv4 cidr", []string{"10.0.0.0/24"}, []string{"10.0.0.1", "10.0.0.255"}, []string{"10.0.1.1"}, false},
+		{"ipv6", []string{"::1"}, []string{"::1", "[::1]:8080"}, []string{"::2", "127.0.0.1"}, false},
+		{"ipv6 cidr", []string{"fd00::/8"}, []string{"fd00::1"}, []string{"fe80::1"}, false},
+		{"not an address", nil, nil, []string{"", "proxy", "proxy:8080"}, false},
+		{"invalid ip", []string{"proxy"}, nil, nil, true},
+		{"invalid cidr", []string{"10.0.0.0/33"}, nil, nil, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			proxies, err := ParseProxies(tc.proxies)
+			if (err != nil) != tc.err {
+				t.Fatalf("got %v", err)
+			}
+
+			for _, addr := range tc.trusted {
+				if !proxies.Trusted(addr) {
+					t.Fatalf("%s is not trusted", addr)
+				}
+			}
+			for _, addr := range tc.other {
+				if proxies.Trusted(addr) {
+					t.Fatalf("%s is trusted", addr)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPToContext(t *testing.T) {
+	proxies, err := ParseProxies([]string{"10.0.0.0/24"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name      string
+		remote    string
+		forwarded []string
+		realIP    string
+		want      string
+	}{
+		{"direct", "203.0.113.1:1234", nil, "", "203.0.113.1"},
+		{"forged by the client", "203.0.113.1:1234", []string{"198.51.100.1"}, "198.51.100.2", "203.0.113.1"},
+		{"proxied", "10.0.0.1:1234", []string{"198.51.100.1"}, "", "198.51.100.1"},
+		{"proxied twice", "10.0.0.1:1234", []string{"198.51.100.1, 10.0.0.2"}, "", "198.51.100.1"},
+		{"forged before the proxy", "10.0.0.1:1234", []string{"192.0.2.1, 198.51.100.1"}, "", "198.51.100.1"},
+		{"forwarded headers", "10.0.0.1:1234", []string{"192.0.2.1", "198.51.100.1"}, "", "198.51.100.1"},
+		{"only proxies", "10.0.0.1:1234", []string{"10.0.0.3, 10.0.0.2"}, "", "10.0.0.3"},
+		{"real ip", "10.0.0.1:1234", nil, "198.51.100.1", "198.51.100.1"},
+		{"no header", "10.0.0.1:1234", nil, "", "10.0.0.1"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/note/id", nil)
+			r.RemoteAddr = tc.remote
+			r.Header.Set("Referer", "https://example.com")
+			for _, value := range tc.forwarded {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+			if tc.realIP != "" {
+				r.Header.Set("X-Real-Ip", tc.realIP)
+			}
+
+			viewer, ok := FromContext(HTTPToContext(proxies)(context.Background(), r))
+			if !ok {
+				t.Fatal("no viewer")
+			}
+			want := Viewer{Transport: TransportHTTP, Referrer: "https://example.com", ClientIP: tc.want}
+			if viewer != want {
+				t.Fatalf("got %+v, want %+v", viewer, want)
+			}
+		})
+	}
+}
+
+func TestGRPCToContext(t *testing.T) {
+	proxies, err := ParseProxies([]string{"10.0.0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the gateway forwards the viewer of a request it proxies
+	forwarded := Viewer{Transport: TransportHTTP, Referrer: "https://example.com", ClientIP: "198.51.100.1"}
+	md := metadata.MD{}
+	ContextToGRPC()(NewContext(context.Background(), forwarded), &md)
+
+	for _, tc := range []struct {
+		name string
+		peer string
+		md   metadata.MD
+		want Viewer
+	}{
+		{"direct", "203.0.113.1", metadata.Pairs("referer", "https://example.org"), Viewer{TransportGRPC, "https://example.org", "203.0.113.1"}},
+		{"proxied", "10.0.0.1", md, forwarded},
+		{"forged by the client", "203.0.113.1", md, Viewer{TransportGRPC, "", "203.0.113.1"}},
+		{"proxy without viewer", "10.0.0.1", metadata.MD{}, Viewer{TransportGRPC, "", "10.0.0.1"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := peer.NewContext(context.Background(), &peer.Peer{
+				Addr: &net.TCPAddr{IP: net.ParseIP(tc.peer), Port: 1234},
+			})
+
+			viewer, ok := FromContext(GRPCToContext(proxies)(ctx, tc.md))
+			if !ok {
+				t.Fatal("no viewer")
+			}
+			if viewer != tc.want {
+				t.Fatalf("got %+v, want %+v", viewer, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned when no blob is stored under a key.
+var ErrNotFound = errors.New("blob cannot be found")
+
+// Store keeps binary objects, e.g. note attachments, under the keys it
+// generates for them.
+type Store interface {
+	// Put stores the content read from content, and returns the key it is
+	// stored under. Nothing is kept when reading content fails.
+	Put(ctx context.Context, name string, content io.Reader) (key string, size int64, err error)
+
+	// Open returns a reader over the blob stored under key.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the blob stored under key.
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,104 @@
+package blobstore
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"io"
+)
+
+// GridFS stores blobs in a MongoDB GridFS bucket, keyed by their file id.
+type GridFS struct {
+	db *mongo.Database
+	bucket string
+}
+
+func NewGridFS(db *mongo.Database, bucket string) *GridFS {
+	return &GridFS{
+		db: db,
+		bucket: bucket,
+	}
+}
+
+func (g *GridFS) Put(ctx context.Context, name string, content io.Reader) (key string, size int64, err error)  {
+	var (
+		bucket *gridfs.Bucket
+		stream *gridfs.UploadStream
+	)
+
+	bucket, err = g.open()
+	if err != nil {
+		return "", 0, err
+	}
+
+	stream, err = bucket.OpenUploadStream(name)
+	if err != nil {
+		return "", 0, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		stream.SetWriteDeadline(deadline)
+	}
+
+	size, err = io.Copy(stream, content)
+	if err != nil {
+		stream.Abort()
+		return "", 0, err
+	}
+
+	if err = stream.Close(); err != nil {
+		return "", 0, err
+	}
+
+	return stream.FileID.(primitive.ObjectID).Hex(), size, nil
+}
+
+func (g *GridFS) Open(ctx context.Context, key string) (io.ReadCloser, error)  {
+	fileID, err := primitive.ObjectIDFromHex(key)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	bucket, err := g.open()
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		bucket.SetReadDeadline(deadline)
+	}
+
+	stream, err := bucket.OpenDownloadStream(fileID)
+	if err == gridfs.ErrFileNotFound {
+		return nil, ErrNotFound
+	}
+	return stream, err
+}
+
+func (g *GridFS) Delete(ctx context.Context, key string) error  {
+	fileID, err := primitive.ObjectIDFromHex(key)
+	if err != nil {
+		return ErrNotFound
+	}
+
+	bucket, err := g.open()
+	if err != nil {
+		return err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		bucket.SetWriteDeadline(deadline)
+	}
+
+	err = bucket.Delete(fileID)
+	if err == gridfs.ErrFileNotFound {
+		return ErrNotFound
+	}
+	return err
+}
+
+func (g *GridFS) open() (*gridfs.Bucket, error) {
+	return gridfs.NewBucket(g.db, options.GridFSBucket().SetName(g.bucket))
+}
@@ -0,0 +1,102 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// keySize is the number of random bytes of a local blob key.
+const keySize = 16
+
+// Local stores blobs as files of a directory, keyed by random names.
+type Local struct {
+	dir string
+}
+
+// NewLocal returns a store keeping its blobs in dir, which is created when
+// it does not exist.
+func NewLocal(dir string) (*Local, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &Local{dir: dir}, nil
+}
+
+func (l *Local) Put(ctx context.Context, name string, content io.Reader) (key string, size int64, err error)  {
+	var (
+		raw = make([]byte, keySize)
+		file *os.File
+	)
+
+	if _, err = rand.Read(raw); err != nil {
+		return "", 0, err
+	}
+	key = hex.EncodeToString(raw)
+
+	// the blob only becomes visible under its key once it is complete
+	file, err = ioutil.TempFile(l.dir, ".upload-*")
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(file.Name())
+
+	size, err = io.Copy(file, content)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		return "", 0, err
+	}
+
+	if err = os.Rename(file.Name(), filepath.Join(l.dir, key)); err != nil {
+		return "", 0, err
+	}
+	return key, size, nil
+}
+
+func (l *Local) Open(ctx context.Context, key string) (io.ReadCloser, error)  {
+	path, ok := l.path(key)
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+func (l *Local) Delete(ctx context.Context, key string) error  {
+	path, ok := l.path(key)
+	if !ok {
+		return ErrNotFound
+	}
+
+	err := os.Remove(path)
+	if os.IsNotExist(err) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// path returns the file of the blob stored under key, keys not generated by
+// Put are rejected so they cannot point outside of the directory.
+func (l *Local) path(key string) (string, bool) {
+	raw, err := hex.DecodeString(key)
+	if err != nil || len(raw) != keySize {
+		return "", false
+	}
+	return filepath.Join(l.dir, key), true
+}
@@ -0,0 +1,122 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocal(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewLocal(filepath.Join(dir, "blobs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		content []byte
+	}{
+		{"empty", nil},
+		{"text", []byte("attachment")},
+		{"binary", bytes.Repeat([]byte{0, 1, 2, 255}, 1024)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			key, size, err := store.Put(ctx, "file", bytes.NewReader(tc.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if size != int64(len(tc.content)) {
+				t.Fatalf("got size %d, want %d", size, len(tc.content))
+			}
+
+			blob, err := store.Open(ctx, key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ioutil.ReadAll(blob)
+			blob.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, tc.content) {
+				t.Fatalf("got %q, want %q", got, tc.content)
+			}
+
+			if err = store.Delete(ctx, key); err != nil {
+				t.Fatal(err)
+			}
+			if _, err = store.Open(ctx, key); err != ErrNotFound {
+				t.Fatalf("got %v after delete, want %v", err, ErrNotFound)
+			}
+			if err = store.Delete(ctx, key); err != ErrNotFound {
+				t.Fatalf("got %v on second delete, want %v", err, ErrNotFound)
+			}
+		})
+	}
+}
+
+func TestLocalKeys(t *testing.T) {
+	store, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{
+		"",
+		"blob",
+		"../../etc/passwd",
+		"0123456789abcdef",
+		"0123456789abcdef0123456789abcdef00",
+		"0123456789abcdef0123456789abcdeg",
+	} {
+		t.Run(key, func(t *testing.T) {
+			if _, err := store.Open(context.Background(), key); err != ErrNotFound {
+				t.Fatalf("open: got %v, want %v", err, ErrNotFound)
+			}
+			if err := store.Delete(context.Background(), key); err != ErrNotFound {
+				t.Fatalf("delete: got %v, want %v", err, ErrNotFound)
+			}
+		})
+	}
+}
+
+func TestLocalPutFailure(t *testing.T) {
+	store, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errRead := errors.New("read failed")
+	if _, _, err = store.Put(context.Background(), "file", &failingReader{err: errRead}); err != errRead {
+		t.Fatalf("got %v, want %v", err, errRead)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err = store.Put(ctx, "file", bytes.NewReader([]byte("attachment"))); err != context.Canceled {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+
+	// failed uploads leave nothing behind
+	files, err := ioutil.ReadDir(store.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Fatalf("%d files left", len(files))
+	}
+}
+
+type failingReader struct {
+	err error
+}
+
+func (r *failingReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,66 @@
+package broker
+
+import (
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"sync"
+)
+
+// subscriberBuffer is the number of events buffered for a subscriber, events
+// published to a full subscriber are dropped rather than blocking the publisher.
+const subscriberBuffer = 16
+
+// allNotes is the id subscribed to by the subscribers of every note.
+const allNotes = ""
+
+// Broker is an in-process publish/subscribe hub for note events, it is used
+// when MongoDB change streams are not available.
+type Broker struct {
+	mu   sync.RWMutex
+	subs map[string]map[chan model.NoteEvent]struct{}
+}
+
+func New() *Broker {
+	return &Broker{
+		subs: make(map[string]map[chan model.NoteEvent]struct{}),
+	}
+}
+
+func (b *Broker) Publish(event model.NoteEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, id := range []string{event.NoteID, allNotes} {
+		for ch := range b.subs[id] {
+			select {
+			case ch <- event:
+			default:
+			}
+		}
+	}
+}
+
+// Subscribe returns a channel receiving the events of the note with the given
+// id, or of every note when id is empty, cancel must be called to release the
+// subscription.
+func (b *Broker) Subscribe(id string) (events <-chan model.NoteEvent, cancel func()) {
+	ch := make(chan model.NoteEvent, subscriberBuffer)
+
+	b.mu.Lock()
+	if b.subs[id] == nil {
+		b.subs[id] = make(map[chan model.NoteEvent]struct{})
+	}
+	b.subs[id][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs[id], ch)
+			if len(b.subs[id]) == 0 {
+				delete(b.subs, id)
+			}
+			b.mu.Unlock()
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package broker
+
+import (
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"reflect"
+	"testing"
+)
+
+func TestBroker(t *testing.T) {
+	var published = []model.NoteEvent{
+		{NoteID: "a", Type: model.NoteEventUpdate, Timestamp: 1},
+		{NoteID: "b", Type: model.NoteEventUpdate, Timestamp: 2},
+		{NoteID: "a", Type: model.NoteEventDelete, Timestamp: 3},
+	}
+
+	for _, tc := range []struct {
+		name string
+		id   string
+		want []model.NoteEvent
+	}{
+		{"one note", "a", []model.NoteEvent{published[0], published[2]}},
+		{"other note", "b", []model.NoteEvent{published[1]}},
+		{"unknown note", "c", nil},
+		{"every note", "", published},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b := New()
+			events, cancel := b.Subscribe(tc.id)
+			defer cancel()
+
+			for _, event := range published {
+				b.Publish(event)
+			}
+
+			var got []model.NoteEvent
+			for len(events) > 0 {
+				got = append(got, <-events)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBrokerCancel(t *testing.T) {
+	b := New()
+	events, cancel := b.Subscribe("a")
+	cancel()
+	cancel()
+
+	b.Publish(model.NoteEvent{NoteID: "a", Type: model.NoteEventUpdate})
+	if len(events) != 0 {
+		t.Fatal("canceled subscriber receives events")
+	}
+	if len(b.subs) != 0 {
+		t.Fatalf("%d subscriptions left", len(b.subs))
+	}
+}
+
+func TestBrokerFullSubscriber(t *testing.T) {
+	b := New()
+	events, cancel := b.Subscribe("a")
+	defer cancel()
+
+	// the events past the buffer are dropped, the publisher is not blocked
+	for i := 0; i < subscriberBuffer+1; i++ {
+		b.Publish(model.NoteEvent{NoteID: "a", Type: model.NoteEventUpdate, Timestamp: int64(i)})
+	}
+	if len(events) != subscriberBuffer {
+		t.Fatalf("%d events buffered", len(events))
+	}
+}
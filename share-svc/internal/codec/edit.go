@@ -0,0 +1,62 @@
+package codec
+
+import (
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"io"
+	"sync"
+)
+
+// EditSession adapts the client side of an edit stream, whatever its
+// transport, to a model.EditSession.
+type EditSession struct {
+	first *model.EditMessage
+	send func(model.EditMessage) error
+	recv func() (model.EditMessage, error)
+	close func() error
+
+	once sync.Once
+	done chan struct{}
+}
+
+// NewEditSession returns a session delivering first, the join message
+// already read from the stream, before the messages pulled from recv.
+func NewEditSession(first model.EditMessage, send func(model.EditMessage) error, recv func() (model.EditMessage, error), close func() error) *EditSession {
+	return &EditSession{
+		first: &first,
+		send:  send,
+		recv:  recv,
+		close: close,
+		done:  make(chan struct{}),
+	}
+}
+
+func (s *EditSession) Send(msg model.EditMessage) error {
+	return s.send(msg)
+}
+
+func (s *EditSession) Recv() (model.EditMessage, error) {
+	if s.first != nil {
+		msg := *s.first
+		s.first = nil
+		return msg, nil
+	}
+
+	msg, err := s.recv()
+	if err != nil {
+		select {
+		case <-s.done:
+			// the stream failed because the session was closed
+			return model.EditMessage{}, io.EOF
+		default:
+		}
+	}
+	return msg, err
+}
+
+func (s *EditSession) Close() (err error) {
+	s.once.Do(func() {
+		close(s.done)
+		err = s.close()
+	})
+	return err
+}
@@ -0,0 +1,71 @@
+package grpccodec
+
+import (
+	"io"
+)
+
+// ChunkReader adapts a stream of content chunks to an io.ReadCloser, recv is
+// called whenever the buffered chunk is exhausted and must return io.EOF at
+// the end of the stream.
+type ChunkReader struct {
+	buf []byte
+	recv func() ([]byte, error)
+	err error
+
+	// OnClose, if set, is called by Close to release the underlying stream.
+	OnClose func()
+}
+
+func NewChunkReader(first []byte, recv func() ([]byte, error)) *ChunkReader {
+	return &ChunkReader{
+		buf:  first,
+		recv: recv,
+	}
+}
+
+func (r *ChunkReader) Read(p []byte) (n int, err error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		r.buf, r.err = r.recv()
+	}
+
+	n = copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *ChunkReader) Close() error {
+	if r.OnClose != nil {
+		r.OnClose()
+	}
+	return nil
+}
+
+// SendChunks reads content until EOF and passes it to send in chunks of at
+// most size bytes. It returns the number of chunks sent.
+func SendChunks(content io.Reader, size int, send func([]byte) error) (chunks int, err error) {
+	var (
+		buf = make([]byte, size)
+		n int
+	)
+
+	for {
+		n, err = io.ReadFull(content, buf)
+		switch err {
+		case nil:
+		case io.EOF:
+			return chunks, nil
+		case io.ErrUnexpectedEOF:
+			return chunks + 1, send(buf[:n])
+		default:
+			return chunks, err
+		}
+
+		if err = send(buf[:n]); err != nil {
+			return chunks, err
+		}
+		chunks++
+	}
+}
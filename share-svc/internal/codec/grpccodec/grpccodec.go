@@ -1,6 +1,7 @@
 package grpccodec
 
 import (
+	"github.com/al8n/shareable-notes/share-svc/model"
 	"github.com/al8n/shareable-notes/share-svc/model/requests"
 	"github.com/al8n/shareable-notes/share-svc/model/responses"
 	"github.com/al8n/shareable-notes/share-svc/pb"
@@ -36,6 +37,7 @@ func ShareNoteResp2pbResp(resp responses.ShareNoteResponse) (pbResp *pb.ShareNot
 	pbResp = &pb.ShareNoteResponse{}
 	pbResp.NoteId = resp.NoteID
 	pbResp.Url = resp.URL
+	pbResp.OwnerToken = resp.OwnerToken
 	pbResp.Error = resp.Error
 	return
 }
@@ -44,6 +46,7 @@ func ShareNotepbResp2Resp(pbResp pb.ShareNoteResponse) (resp *responses.ShareNot
 	resp = &responses.ShareNoteResponse{}
 	resp.NoteID = pbResp.NoteId
 	resp.URL = pbResp.Url
+	resp.OwnerToken = pbResp.OwnerToken
 	resp.Error = pbResp.Error
 	return
 }
@@ -52,6 +55,8 @@ func ShareNotepbResp2Resp(pbResp pb.ShareNoteResponse) (resp *responses.ShareNot
 func GetNoteReq2pbReq(req requests.GetNoteRequest) (pbReq *pb.GetNoteRequest)  {
 	pbReq = &pb.GetNoteRequest{
 		Id: req.NoteID,
+		IfNoneMatch: req.Condition.IfNoneMatch,
+		IfModifiedSince: req.Condition.IfModifiedSince,
 	}
 	return
 }
@@ -60,6 +65,9 @@ func GetNoteResp2pbResp(resp responses.GetNoteResponse) (pbResp *pb.GetNoteRespo
 	pbResp = &pb.GetNoteResponse{
 		Name:                 resp.Name,
 		Content:              resp.Content,
+		Etag:                 resp.ETag,
+		LastModified:         resp.LastModified,
+		NotModified:          resp.NotModified,
 	}
 	return
 }
@@ -68,8 +76,502 @@ func GetNotepbResp2Resp(pbResp pb.GetNoteResponse) (resp *responses.GetNoteRespo
 	resp = &responses.GetNoteResponse{
 		Name:    pbResp.Name,
 		Content: pbResp.Content,
+		ETag:    pbResp.Etag,
+		LastModified: pbResp.LastModified,
+		NotModified: pbResp.NotModified,
 		Error:   pbResp.Error,
 	}
 	return resp
 }
 
+func NoteEvent2pbNoteEvent(event model.NoteEvent) (pbEvent *pb.NoteEvent)  {
+	pbEvent = &pb.NoteEvent{
+		NoteId:    event.NoteID,
+		Type:      event.Type,
+		Timestamp: event.Timestamp,
+	}
+	return
+}
+
+func NoteEventpb2NoteEvent(pbEvent pb.NoteEvent) (event model.NoteEvent)  {
+	event = model.NoteEvent{
+		NoteID:    pbEvent.NoteId,
+		Type:      pbEvent.Type,
+		Timestamp: pbEvent.Timestamp,
+	}
+	return
+}
+
+func EditMessage2pbEditMessage(msg model.EditMessage) (pbMsg *pb.EditMessage)  {
+	pbMsg = &pb.EditMessage{
+		Type:     msg.Type,
+		NoteId:   msg.NoteID,
+		User:     msg.User,
+		Revision: msg.Revision,
+		Content:  msg.Content,
+		Users:    msg.Users,
+		Error:    msg.Error,
+	}
+
+	for _, op := range msg.Operation {
+		pbMsg.Operation = append(pbMsg.Operation, &pb.EditOp{
+			Retain: int64(op.Retain),
+			Insert: op.Insert,
+			Delete: int64(op.Delete),
+		})
+	}
+	return
+}
+
+func EditMessagepb2EditMessage(pbMsg *pb.EditMessage) (msg model.EditMessage)  {
+	msg = model.EditMessage{
+		Type:     pbMsg.Type,
+		NoteID:   pbMsg.NoteId,
+		User:     pbMsg.User,
+		Revision: pbMsg.Revision,
+		Content:  pbMsg.Content,
+		Users:    pbMsg.Users,
+		Error:    pbMsg.Error,
+	}
+
+	for _, op := range pbMsg.Operation {
+		msg.Operation = append(msg.Operation, model.EditOp{
+			Retain: int(op.Retain),
+			Insert: op.Insert,
+			Delete: int(op.Delete),
+		})
+	}
+	return
+}
+
+func SyncNotesReq2pbReq(req requests.SyncNotesRequest) (pbReq *pb.SyncNotesRequest)  {
+	pbReq = &pb.SyncNotesRequest{
+		ClientId: req.ClientID,
+		Since:    req.Since,
+		NoteIds:  req.NoteIDs,
+	}
+
+	for _, change := range req.Changes {
+		pbReq.Changes = append(pbReq.Changes, NoteChange2pbNoteChange(change))
+	}
+	return
+}
+
+func SyncNotespbReq2Req(pbReq *pb.SyncNotesRequest) (req requests.SyncNotesRequest)  {
+	req = requests.SyncNotesRequest{
+		ClientID: pbReq.ClientId,
+		Since:    pbReq.Since,
+		NoteIDs:  pbReq.NoteIds,
+	}
+
+	for _, change := range pbReq.Changes {
+		req.Changes = append(req.Changes, NoteChangepb2NoteChange(change))
+	}
+	return
+}
+
+func SyncNotesResp2pbResp(resp responses.SyncNotesResponse) (pbResp *pb.SyncNotesResponse)  {
+	pbResp = &pb.SyncNotesResponse{
+		Version: resp.Version,
+		Error:   resp.Error,
+	}
+
+	for _, change := range resp.Changes {
+		pbResp.Changes = append(pbResp.Changes, NoteChange2pbNoteChange(change))
+	}
+
+	for _, conflict := range resp.Conflicts {
+		pbResp.Conflicts = append(pbResp.Conflicts, &pb.SyncConflict{
+			NoteId:     conflict.NoteID,
+			Local:      NoteChange2pbNoteChange(conflict.Local),
+			Server:     NoteChange2pbNoteChange(conflict.Server),
+			Resolution: conflict.Resolution,
+		})
+	}
+	return
+}
+
+func SyncNotespbResp2Resp(pbResp pb.SyncNotesResponse) (resp *responses.SyncNotesResponse)  {
+	resp = &responses.SyncNotesResponse{
+		Version: pbResp.Version,
+		Error:   pbResp.Error,
+	}
+
+	for _, change := range pbResp.Changes {
+		resp.Changes = append(resp.Changes, NoteChangepb2NoteChange(change))
+	}
+
+	for _, conflict := range pbResp.Conflicts {
+		resp.Conflicts = append(resp.Conflicts, model.SyncConflict{
+			NoteID:     conflict.NoteId,
+			Local:      NoteChangepb2NoteChange(conflict.Local),
+			Server:     NoteChangepb2NoteChange(conflict.Server),
+			Resolution: conflict.Resolution,
+		})
+	}
+	return
+}
+
+func NoteChange2pbNoteChange(change model.NoteChange) (pbChange *pb.NoteChange)  {
+	pbChange = &pb.NoteChange{
+		NoteId:      change.NoteID,
+		Name:        change.Name,
+		Content:     change.Content,
+		Versions:    change.Versions,
+		UpdatedAt:   change.UpdatedAt,
+		UpdatedBy:   change.UpdatedBy,
+		Deactivated: change.Deactivated,
+	}
+	return
+}
+
+func NoteChangepb2NoteChange(pbChange *pb.NoteChange) (change model.NoteChange)  {
+	if pbChange == nil {
+		return
+	}
+
+	change = model.NoteChange{
+		NoteID:      pbChange.NoteId,
+		Name:        pbChange.Name,
+		Content:     pbChange.Content,
+		Versions:    pbChange.Versions,
+		UpdatedAt:   pbChange.UpdatedAt,
+		UpdatedBy:   pbChange.UpdatedBy,
+		Deactivated: pbChange.Deactivated,
+	}
+	return
+}
+
+func ForkNoteReq2pbReq(req requests.ForkNoteRequest) (pbReq *pb.ForkNoteRequest)  {
+	pbReq = &pb.ForkNoteRequest{
+		Id:    req.NoteID,
+		Owner: req.Owner,
+	}
+	return
+}
+
+func ForkNoteResp2pbResp(resp responses.ForkNoteResponse) (pbResp *pb.ForkNoteResponse)  {
+	pbResp = &pb.ForkNoteResponse{
+		Url:        resp.URL,
+		NoteId:     resp.NoteID,
+		OwnerToken: resp.OwnerToken,
+		Error:      resp.Error,
+	}
+	return
+}
+
+func ForkNotepbResp2Resp(pbResp pb.ForkNoteResponse) (resp *responses.ForkNoteResponse)  {
+	resp = &responses.ForkNoteResponse{
+		URL:        pbResp.Url,
+		NoteID:     pbResp.NoteId,
+		OwnerToken: pbResp.OwnerToken,
+		Error:      pbResp.Error,
+	}
+	return
+}
+
+func ListForksResp2pbResp(resp responses.ListForksResponse) (pbResp *pb.ListForksResponse)  {
+	pbResp = &pb.ListForksResponse{
+		Error: resp.Error,
+	}
+
+	for _, fork := range resp.Forks {
+		pbResp.Forks = append(pbResp.Forks, &pb.NoteFork{
+			NoteId:    fork.NoteID,
+			Name:      fork.Name,
+			Owner:     fork.Owner,
+			Url:       fork.URL,
+			CreatedAt: fork.CreatedAt,
+		})
+	}
+	return
+}
+
+func ListForkspbResp2Resp(pbResp pb.ListForksResponse) (resp *responses.ListForksResponse)  {
+	resp = &responses.ListForksResponse{
+		Forks: []model.NoteFork{},
+		Error: pbResp.Error,
+	}
+
+	for _, fork := range pbResp.Forks {
+		resp.Forks = append(resp.Forks, model.NoteFork{
+			NoteID:    fork.NoteId,
+			Name:      fork.Name,
+			Owner:     fork.Owner,
+			URL:       fork.Url,
+			CreatedAt: fork.CreatedAt,
+		})
+	}
+	return
+}
+
+func MarkTemplateResp2pbResp(resp responses.MarkTemplateResponse) (pbResp *pb.MarkTemplateResponse)  {
+	pbResp = &pb.MarkTemplateResponse{
+		Error: resp.Error,
+	}
+
+	for _, variable := range resp.Variables {
+		pbResp.Variables = append(pbResp.Variables, &pb.TemplateVariable{
+			Name:         variable.Name,
+			Required:     variable.Required,
+			DefaultValue: variable.Default,
+		})
+	}
+	return
+}
+
+func MarkTemplatepbResp2Resp(pbResp pb.MarkTemplateResponse) (resp *responses.MarkTemplateResponse)  {
+	resp = &responses.MarkTemplateResponse{
+		Variables: []model.TemplateVariable{},
+		Error:     pbResp.Error,
+	}
+
+	for _, variable := range pbResp.Variables {
+		resp.Variables = append(resp.Variables, model.TemplateVariable{
+			Name:     variable.Name,
+			Required: variable.Required,
+			Default:  variable.DefaultValue,
+		})
+	}
+	return
+}
+
+func InstantiateTemplateResp2pbResp(resp responses.InstantiateTemplateResponse) (pbResp *pb.InstantiateTemplateResponse)  {
+	pbResp = &pb.InstantiateTemplateResponse{
+		Url:        resp.URL,
+		NoteId:     resp.NoteID,
+		OwnerToken: resp.OwnerToken,
+		Error:      resp.Error,
+	}
+	return
+}
+
+func InstantiateTemplatepbResp2Resp(pbResp pb.InstantiateTemplateResponse) (resp *responses.InstantiateTemplateResponse)  {
+	resp = &responses.InstantiateTemplateResponse{
+		URL:        pbResp.Url,
+		NoteID:     pbResp.NoteId,
+		OwnerToken: pbResp.OwnerToken,
+		Error:      pbResp.Error,
+	}
+	return
+}
+
+func GetCollectionResp2pbResp(resp responses.GetCollectionResponse) (pbResp *pb.GetCollectionResponse)  {
+	pbResp = &pb.GetCollectionResponse{
+		Name:  resp.Name,
+		Error: resp.Error,
+	}
+
+	for _, note := range resp.Notes {
+		pbResp.Notes = append(pbResp.Notes, &pb.CollectionNote{
+			NoteId: note.NoteID,
+			Name:   note.Name,
+			Url:    note.URL,
+		})
+	}
+	return
+}
+
+func GetCollectionpbResp2Resp(pbResp pb.GetCollectionResponse) (resp *responses.GetCollectionResponse)  {
+	resp = &responses.GetCollectionResponse{
+		Name:  pbResp.Name,
+		Notes: []model.CollectionNote{},
+		Error: pbResp.Error,
+	}
+
+	for _, note := range pbResp.Notes {
+		resp.Notes = append(resp.Notes, model.CollectionNote{
+			NoteID: note.NoteId,
+			Name:   note.Name,
+			URL:    note.Url,
+		})
+	}
+	return
+}
+
+func Attachment2pbAttachment(attachment model.AttachmentInfo) (pbAttachment *pb.Attachment)  {
+	return &pb.Attachment{
+		Id:          attachment.ID,
+		NoteId:      attachment.NoteID,
+		Name:        attachment.Name,
+		ContentType: attachment.ContentType,
+		Size_:       attachment.Size,
+		Url:         attachment.URL,
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
+
+func Attachmentpb2Attachment(pbAttachment *pb.Attachment) (attachment model.AttachmentInfo)  {
+	if pbAttachment == nil {
+		return
+	}
+
+	return model.AttachmentInfo{
+		ID:          pbAttachment.Id,
+		NoteID:      pbAttachment.NoteId,
+		Name:        pbAttachment.Name,
+		ContentType: pbAttachment.ContentType,
+		Size:        pbAttachment.Size_,
+		URL:         pbAttachment.Url,
+		CreatedAt:   pbAttachment.CreatedAt,
+	}
+}
+
+func UploadAttachmentResp2pbResp(resp responses.UploadAttachmentResponse) (pbResp *pb.UploadAttachmentResponse)  {
+	return &pb.UploadAttachmentResponse{
+		Attachment: Attachment2pbAttachment(resp.Attachment),
+		Error:      resp.Error,
+	}
+}
+
+func UploadAttachmentpbResp2Resp(pbResp pb.UploadAttachmentResponse) (resp *responses.UploadAttachmentResponse)  {
+	return &responses.UploadAttachmentResponse{
+		Attachment: Attachmentpb2Attachment(pbResp.Attachment),
+		Error:      pbResp.Error,
+	}
+}
+
+func Comment2pbComment(comment model.NoteComment) (pbComment *pb.Comment)  {
+	return &pb.Comment{
+		Id:         comment.ID,
+		NoteId:     comment.NoteID,
+		Author:     comment.Author,
+		Body:       comment.Body,
+		StartLine:  comment.StartLine,
+		EndLine:    comment.EndLine,
+		Resolved:   comment.Resolved,
+		ResolvedBy: comment.ResolvedBy,
+		CreatedAt:  comment.CreatedAt,
+		ResolvedAt: comment.ResolvedAt,
+	}
+}
+
+func Commentpb2Comment(pbComment *pb.Comment) (comment model.NoteComment)  {
+	if pbComment == nil {
+		return
+	}
+
+	return model.NoteComment{
+		ID:         pbComment.Id,
+		NoteID:     pbComment.NoteId,
+		Author:     pbComment.Author,
+		Body:       pbComment.Body,
+		StartLine:  pbComment.StartLine,
+		EndLine:    pbComment.EndLine,
+		Resolved:   pbComment.Resolved,
+		ResolvedBy: pbComment.ResolvedBy,
+		CreatedAt:  pbComment.CreatedAt,
+		ResolvedAt: pbComment.ResolvedAt,
+	}
+}
+
+func AddCommentResp2pbResp(resp responses.AddCommentResponse) (pbResp *pb.AddCommentResponse)  {
+	return &pb.AddCommentResponse{
+		Comment: Comment2pbComment(resp.Comment),
+		Error:   resp.Error,
+	}
+}
+
+func AddCommentpbResp2Resp(pbResp pb.AddCommentResponse) (resp *responses.AddCommentResponse)  {
+	return &responses.AddCommentResponse{
+		Comment: Commentpb2Comment(pbResp.Comment),
+		Error:   pbResp.Error,
+	}
+}
+
+func ListCommentsResp2pbResp(resp responses.ListCommentsResponse) (pbResp *pb.ListCommentsResponse)  {
+	pbResp = &pb.ListCommentsResponse{
+		Error: resp.Error,
+	}
+
+	for _, comment := range resp.Comments {
+		pbResp.Comments = append(pbResp.Comments, Comment2pbComment(comment))
+	}
+	return
+}
+
+func ListCommentspbResp2Resp(pbResp pb.ListCommentsResponse) (resp *responses.ListCommentsResponse)  {
+	resp = &responses.ListCommentsResponse{
+		Comments: []model.NoteComment{},
+		Error:    pbResp.Error,
+	}
+
+	for _, comment := range pbResp.Comments {
+		resp.Comments = append(resp.Comments, Commentpb2Comment(comment))
+	}
+	return
+}
+
+func ResolveCommentResp2pbResp(resp responses.ResolveCommentResponse) (pbResp *pb.ResolveCommentResponse)  {
+	return &pb.ResolveCommentResponse{
+		Comment: Comment2pbComment(resp.Comment),
+		Error:   resp.Error,
+	}
+}
+
+func ResolveCommentpbResp2Resp(pbResp pb.ResolveCommentResponse) (resp *responses.ResolveCommentResponse)  {
+	return &responses.ResolveCommentResponse{
+		Comment: Commentpb2Comment(pbResp.Comment),
+		Error:   pbResp.Error,
+	}
+}
+
+func GetNoteStatsResp2pbResp(resp responses.GetNoteStatsResponse) (pbResp *pb.GetNoteStatsResponse)  {
+	var stats = resp.Stats
+
+	pbResp = &pb.GetNoteStatsResponse{
+		Stats: &pb.NoteStats{
+			NoteId:        stats.NoteID,
+			Views:         stats.Views,
+			Transports:    stats.Transports,
+			Referrers:     stats.Referrers,
+			FirstViewedAt: stats.FirstViewedAt,
+			LastViewedAt:  stats.LastViewedAt,
+		},
+		Error: resp.Error,
+	}
+
+	for _, day := range stats.Daily {
+		pbResp.Stats.Daily = append(pbResp.Stats.Daily, &pb.DailyViews{
+			Day:      day.Day,
+			Views:    day.Views,
+			Visitors: day.Visitors,
+		})
+	}
+	return
+}
+
+func GetNoteStatspbResp2Resp(pbResp pb.GetNoteStatsResponse) (resp *responses.GetNoteStatsResponse)  {
+	resp = &responses.GetNoteStatsResponse{
+		Stats: model.NoteStats{
+			Transports: map[string]int64{},
+			Referrers:  map[string]int64{},
+			Daily:      []model.DailyViews{},
+		},
+		Error: pbResp.Error,
+	}
+
+	if pbStats := pbResp.Stats; pbStats != nil {
+		resp.Stats.NoteID = pbStats.NoteId
+		resp.Stats.Views = pbStats.Views
+		resp.Stats.FirstViewedAt = pbStats.FirstViewedAt
+		resp.Stats.LastViewedAt = pbStats.LastViewedAt
+
+		for transport, views := range pbStats.Transports {
+			resp.Stats.Transports[transport] = views
+		}
+
+		for referrer, views := range pbStats.Referrers {
+			resp.Stats.Referrers[referrer] = views
+		}
+
+		for _, day := range pbStats.Daily {
+			resp.Stats.Daily = append(resp.Stats.Daily, model.DailyViews{
+				Day:      day.Day,
+				Views:    day.Views,
+				Visitors: day.Visitors,
+			})
+		}
+	}
+	return
+}
@@ -0,0 +1,145 @@
+package grpccodec
+
+import (
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"github.com/al8n/shareable-notes/share-svc/model/requests"
+	"github.com/al8n/shareable-notes/share-svc/model/responses"
+	"reflect"
+	"testing"
+)
+
+func TestForkNote(t *testing.T) {
+	req := requests.ForkNoteRequest{NoteID: "id", Owner: "owner"}
+	if pbReq := ForkNoteReq2pbReq(req); pbReq.Id != req.NoteID || pbReq.Owner != req.Owner {
+		t.Fatalf("got %+v", pbReq)
+	}
+
+	for _, tc := range []struct {
+		name string
+		resp responses.ForkNoteResponse
+	}{
+		{"fork", responses.ForkNoteResponse{URL: "url", NoteID: "id", OwnerToken: "token"}},
+		{"error", responses.ForkNoteResponse{Error: "note not found"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ForkNotepbResp2Resp(*ForkNoteResp2pbResp(tc.resp))
+			if !reflect.DeepEqual(*got, tc.resp) {
+				t.Fatalf("got %+v, want %+v", *got, tc.resp)
+			}
+		})
+	}
+}
+
+func TestListForks(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		resp responses.ListForksResponse
+	}{
+		{"no fork", responses.ListForksResponse{Forks: []model.NoteFork{}}},
+		{"forks", responses.ListForksResponse{Forks: []model.NoteFork{
+			{NoteID: "a", Name: "first", Owner: "alice", URL: "url/a", CreatedAt: 1},
+			{NoteID: "b", Name: "second", URL: "url/b", CreatedAt: 2},
+		}}},
+		{"error", responses.ListForksResponse{Forks: []model.NoteFork{}, Error: "note not found"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ListForkspbResp2Resp(*ListForksResp2pbResp(tc.resp))
+			if !reflect.DeepEqual(*got, tc.resp) {
+				t.Fatalf("got %+v, want %+v", *got, tc.resp)
+			}
+		})
+	}
+}
+
+func TestGetCollection(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		resp responses.GetCollectionResponse
+	}{
+		{"empty", responses.GetCollectionResponse{Name: "empty", Notes: []model.CollectionNote{}}},
+		{"ordered", responses.GetCollectionResponse{Name: "reading", Notes: []model.CollectionNote{
+			{NoteID: "b", Name: "second", URL: "url/b"},
+			{NoteID: "a", Name: "first", URL: "url/a"},
+		}}},
+		{"error", responses.GetCollectionResponse{Notes: []model.CollectionNote{}, Error: "collection cannot be found"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := GetCollectionpbResp2Resp(*GetCollectionResp2pbResp(tc.resp))
+			if !reflect.DeepEqual(*got, tc.resp) {
+				t.Fatalf("got %+v, want %+v", *got, tc.resp)
+			}
+		})
+	}
+}
+
+func TestComments(t *testing.T) {
+	var (
+		anchored = model.NoteComment{ID: "c1", NoteID: "id", Author: "alice", Body: "typo", StartLine: 2, EndLine: 4, CreatedAt: 1}
+		resolved = model.NoteComment{ID: "c2", NoteID: "id", Body: "done", Resolved: true, ResolvedBy: "bob", CreatedAt: 1, ResolvedAt: 2}
+	)
+
+	for _, tc := range []struct {
+		name string
+		resp interface{}
+		got  func(resp interface{}) interface{}
+	}{
+		{"add anchored", responses.AddCommentResponse{Comment: anchored}, func(resp interface{}) interface{} {
+			return *AddCommentpbResp2Resp(*AddCommentResp2pbResp(resp.(responses.AddCommentResponse)))
+		}},
+		{"add error", responses.AddCommentResponse{Error: "comment line range is outside of the note"}, func(resp interface{}) interface{} {
+			return *AddCommentpbResp2Resp(*AddCommentResp2pbResp(resp.(responses.AddCommentResponse)))
+		}},
+		{"list", responses.ListCommentsResponse{Comments: []model.NoteComment{anchored, resolved}}, func(resp interface{}) interface{} {
+			return *ListCommentspbResp2Resp(*ListCommentsResp2pbResp(resp.(responses.ListCommentsResponse)))
+		}},
+		{"list empty", responses.ListCommentsResponse{Comments: []model.NoteComment{}}, func(resp interface{}) interface{} {
+			return *ListCommentspbResp2Resp(*ListCommentsResp2pbResp(resp.(responses.ListCommentsResponse)))
+		}},
+		{"resolve", responses.ResolveCommentResponse{Comment: resolved}, func(resp interface{}) interface{} {
+			return *ResolveCommentpbResp2Resp(*ResolveCommentResp2pbResp(resp.(responses.ResolveCommentResponse)))
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.got(tc.resp); !reflect.DeepEqual(got, tc.resp) {
+				t.Fatalf("got %+v, want %+v", got, tc.resp)
+			}
+		})
+	}
+}
+
+func TestGetNoteStats(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		resp responses.GetNoteStatsResponse
+	}{
+		{"no view", responses.GetNoteStatsResponse{Stats: model.NoteStats{
+			NoteID:     "id",
+			Transports: map[string]int64{},
+			Referrers:  map[string]int64{},
+			Daily:      []model.DailyViews{},
+		}}},
+		{"views", responses.GetNoteStatsResponse{Stats: model.NoteStats{
+			NoteID:     "id",
+			Views:      5,
+			Transports: map[string]int64{"http": 3, "grpc": 2},
+			Referrers:  map[string]int64{"direct": 4, "example.com": 1},
+			Daily: []model.DailyViews{
+				{Day: "2021-01-01", Views: 2, Visitors: 1},
+				{Day: "2021-01-02", Views: 3, Visitors: 3},
+			},
+			FirstViewedAt: 1,
+			LastViewedAt:  2,
+		}}},
+		{"error", responses.GetNoteStatsResponse{
+			Stats: model.NoteStats{Transports: map[string]int64{}, Referrers: map[string]int64{}, Daily: []model.DailyViews{}},
+			Error: "note stats are only available with the owner token of the note",
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := GetNoteStatspbResp2Resp(*GetNoteStatsResp2pbResp(tc.resp))
+			if !reflect.DeepEqual(*got, tc.resp) {
+				t.Fatalf("got %+v, want %+v", *got, tc.resp)
+			}
+		})
+	}
+}
@@ -2,8 +2,12 @@ package grpcdecode
 
 import (
 	"context"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/internal/codec"
 	"github.com/al8n/shareable-notes/share-svc/internal/codec/grpccodec"
+	"github.com/al8n/shareable-notes/share-svc/model"
 	"github.com/al8n/shareable-notes/share-svc/model/requests"
+	"github.com/al8n/shareable-notes/share-svc/model/responses"
 	"github.com/al8n/shareable-notes/share-svc/pb"
 )
 
@@ -38,10 +42,413 @@ func GetNoteRequest(_ context.Context, grpcReq interface{}) (interface{}, error)
 
 	return requests.GetNoteRequest{
 		NoteID: req.Id,
+		Condition: model.NoteCondition{
+			IfNoneMatch: req.IfNoneMatch,
+			IfModifiedSince: req.IfModifiedSince,
+		},
 	}, nil
 }
 
 func GetNoteResponse(_ context.Context, grpcReq interface{}) (interface{}, error)  {
 	req := grpcReq.(*pb.GetNoteResponse)
 	return grpccodec.GetNotepbResp2Resp(*req), nil
-}
\ No newline at end of file
+}
+
+func GetNoteStreamRequest(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.GetNoteRequest)
+
+	return requests.GetNoteStreamRequest{
+		NoteID: req.Id,
+	}, nil
+}
+
+// ShareNoteStreamRequest builds the request from the first chunk of a stream,
+// the content is pulled from recv while the request is being served.
+func ShareNoteStreamRequest(first *pb.ShareNoteChunk, recv func() (*pb.ShareNoteChunk, error)) (interface{}, error)  {
+	return requests.ShareNoteStreamRequest{
+		Name: first.Name,
+		Content: grpccodec.NewChunkReader(first.Content, func() ([]byte, error) {
+			chunk, err := recv()
+			if err != nil {
+				return nil, err
+			}
+			return chunk.Content, nil
+		}),
+	}, nil
+}
+
+// GetNoteStreamResponse builds the response from the first chunk of a stream,
+// the content is pulled from recv while the caller reads it, and cancel is
+// called when the caller closes it.
+func GetNoteStreamResponse(first *pb.GetNoteChunk, recv func() (*pb.GetNoteChunk, error), cancel func()) (interface{}, error)  {
+	content := grpccodec.NewChunkReader(first.Content, func() ([]byte, error) {
+		chunk, err := recv()
+		if err != nil {
+			return nil, err
+		}
+		return chunk.Content, nil
+	})
+	content.OnClose = cancel
+
+	return &responses.GetNoteStreamResponse{
+		Name: first.Name,
+		Content: content,
+		Error: first.Error,
+	}, nil
+}
+
+func WatchNoteRequest(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.WatchNoteRequest)
+
+	return requests.WatchNoteRequest{
+		NoteID: req.Id,
+	}, nil
+}
+
+// WatchNoteResponse delivers the events pulled from recv on a channel, which
+// is closed when recv fails or ctx is done.
+func WatchNoteResponse(ctx context.Context, recv func() (*pb.NoteEvent, error)) (interface{}, error)  {
+	return &responses.WatchNoteResponse{
+		Events: noteEvents(ctx, recv),
+	}, nil
+}
+
+func WatchNotesRequest(_ context.Context, _ interface{}) (interface{}, error)  {
+	return requests.WatchNotesRequest{}, nil
+}
+
+// WatchNotesResponse delivers the events pulled from recv on a channel, which
+// is closed when recv fails or ctx is done.
+func WatchNotesResponse(ctx context.Context, recv func() (*pb.NoteEvent, error)) (interface{}, error)  {
+	return &responses.WatchNotesResponse{
+		Events: noteEvents(ctx, recv),
+	}, nil
+}
+
+func noteEvents(ctx context.Context, recv func() (*pb.NoteEvent, error)) <-chan model.NoteEvent {
+	var events = make(chan model.NoteEvent)
+
+	go func() {
+		defer close(events)
+		for {
+			event, err := recv()
+			if err != nil {
+				return
+			}
+
+			select {
+			case events <- grpccodec.NoteEventpb2NoteEvent(*event):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events
+}
+
+// EditNoteRequest decodes the join message opening an EditNote stream.
+func EditNoteRequest(_ context.Context, first *pb.EditMessage) (interface{}, error)  {
+	if first.Type != model.EditMessageJoin {
+		return nil, common.ErrorUnknownEditMessage
+	}
+
+	return requests.EditNoteRequest{
+		NoteID: first.NoteId,
+		User: first.User,
+	}, nil
+}
+
+// EditNoteResponse decodes the first message of an EditNote stream, the
+// session ends the stream through cancel.
+func EditNoteResponse(first *pb.EditMessage, stream pb.Share_EditNoteClient, cancel func()) (interface{}, error)  {
+	if first.Type == model.EditMessageError {
+		cancel()
+		return &responses.EditNoteResponse{
+			Error: first.Error,
+		}, nil
+	}
+
+	session := codec.NewEditSession(
+		grpccodec.EditMessagepb2EditMessage(first),
+		func(msg model.EditMessage) error {
+			return stream.Send(grpccodec.EditMessage2pbEditMessage(msg))
+		},
+		func() (model.EditMessage, error) {
+			msg, err := stream.Recv()
+			if err != nil {
+				return model.EditMessage{}, err
+			}
+			return grpccodec.EditMessagepb2EditMessage(msg), nil
+		},
+		func() error {
+			cancel()
+			return nil
+		},
+	)
+
+	return &responses.EditNoteResponse{
+		Session: session,
+	}, nil
+}
+
+func SyncNotesRequest(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.SyncNotesRequest)
+	return grpccodec.SyncNotespbReq2Req(req), nil
+}
+
+func SyncNotesResponse(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.SyncNotesResponse)
+	return grpccodec.SyncNotespbResp2Resp(*req), nil
+}
+
+func ForkNoteRequest(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.ForkNoteRequest)
+
+	return requests.ForkNoteRequest{
+		NoteID: req.Id,
+		Owner: req.Owner,
+	}, nil
+}
+
+func ForkNoteResponse(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.ForkNoteResponse)
+	return grpccodec.ForkNotepbResp2Resp(*req), nil
+}
+
+func ListForksRequest(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.ListForksRequest)
+
+	return requests.ListForksRequest{
+		NoteID: req.Id,
+	}, nil
+}
+
+func ListForksResponse(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.ListForksResponse)
+	return grpccodec.ListForkspbResp2Resp(*req), nil
+}
+
+func MarkTemplateRequest(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.MarkTemplateRequest)
+
+	return requests.MarkTemplateRequest{
+		NoteID: req.Id,
+		Template: req.Template,
+	}, nil
+}
+
+func MarkTemplateResponse(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.MarkTemplateResponse)
+	return grpccodec.MarkTemplatepbResp2Resp(*req), nil
+}
+
+func InstantiateTemplateRequest(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.InstantiateTemplateRequest)
+
+	return requests.InstantiateTemplateRequest{
+		NoteID: req.Id,
+		Vars: req.Vars,
+	}, nil
+}
+
+func InstantiateTemplateResponse(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.InstantiateTemplateResponse)
+	return grpccodec.InstantiateTemplatepbResp2Resp(*req), nil
+}
+
+func CreateCollectionRequest(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.CreateCollectionRequest)
+
+	return requests.CreateCollectionRequest{
+		Name: req.Name,
+		NoteIDs: req.NoteIds,
+	}, nil
+}
+
+func CreateCollectionResponse(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	resp := grpcReq.(*pb.CreateCollectionResponse)
+
+	return &responses.CreateCollectionResponse{
+		URL: resp.Url,
+		CollectionID: resp.CollectionId,
+		Error: resp.Error,
+	}, nil
+}
+
+func AddCollectionNotesRequest(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.AddCollectionNotesRequest)
+
+	return requests.AddCollectionNotesRequest{
+		CollectionID: req.Id,
+		NoteIDs: req.NoteIds,
+	}, nil
+}
+
+func AddCollectionNotesResponse(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	resp := grpcReq.(*pb.AddCollectionNotesResponse)
+
+	return &responses.AddCollectionNotesResponse{
+		Error: resp.Error,
+	}, nil
+}
+
+func RemoveCollectionNotesRequest(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.RemoveCollectionNotesRequest)
+
+	return requests.RemoveCollectionNotesRequest{
+		CollectionID: req.Id,
+		NoteIDs: req.NoteIds,
+	}, nil
+}
+
+func RemoveCollectionNotesResponse(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	resp := grpcReq.(*pb.RemoveCollectionNotesResponse)
+
+	return &responses.RemoveCollectionNotesResponse{
+		Error: resp.Error,
+	}, nil
+}
+
+func ReorderCollectionRequest(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.ReorderCollectionRequest)
+
+	return requests.ReorderCollectionRequest{
+		CollectionID: req.Id,
+		NoteIDs: req.NoteIds,
+	}, nil
+}
+
+func ReorderCollectionResponse(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	resp := grpcReq.(*pb.ReorderCollectionResponse)
+
+	return &responses.ReorderCollectionResponse{
+		Error: resp.Error,
+	}, nil
+}
+
+func GetCollectionRequest(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.GetCollectionRequest)
+
+	return requests.GetCollectionRequest{
+		CollectionID: req.Id,
+	}, nil
+}
+
+func GetCollectionResponse(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.GetCollectionResponse)
+	return grpccodec.GetCollectionpbResp2Resp(*req), nil
+}
+
+// UploadAttachmentRequest builds the request from the first chunk of a stream,
+// the content is pulled from recv while the request is being served.
+func UploadAttachmentRequest(first *pb.AttachmentChunk, recv func() (*pb.AttachmentChunk, error)) (interface{}, error)  {
+	attachment := grpccodec.Attachmentpb2Attachment(first.Attachment)
+
+	return requests.UploadAttachmentRequest{
+		NoteID: attachment.NoteID,
+		Name: attachment.Name,
+		Content: grpccodec.NewChunkReader(first.Content, func() ([]byte, error) {
+			chunk, err := recv()
+			if err != nil {
+				return nil, err
+			}
+			return chunk.Content, nil
+		}),
+	}, nil
+}
+
+func UploadAttachmentResponse(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.UploadAttachmentResponse)
+	return grpccodec.UploadAttachmentpbResp2Resp(*req), nil
+}
+
+func DownloadAttachmentRequest(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.DownloadAttachmentRequest)
+
+	return requests.DownloadAttachmentRequest{
+		NoteID: req.NoteId,
+		AttachmentID: req.Id,
+	}, nil
+}
+
+// DownloadAttachmentResponse builds the response from the first chunk of a
+// stream, the content is pulled from recv while the caller reads it, and
+// cancel is called when the caller closes it.
+func DownloadAttachmentResponse(first *pb.AttachmentChunk, recv func() (*pb.AttachmentChunk, error), cancel func()) (interface{}, error)  {
+	content := grpccodec.NewChunkReader(first.Content, func() ([]byte, error) {
+		chunk, err := recv()
+		if err != nil {
+			return nil, err
+		}
+		return chunk.Content, nil
+	})
+	content.OnClose = cancel
+
+	return &responses.DownloadAttachmentResponse{
+		Attachment: grpccodec.Attachmentpb2Attachment(first.Attachment),
+		Content: content,
+		Error: first.Error,
+	}, nil
+}
+
+func AddCommentRequest(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.AddCommentRequest)
+
+	return requests.AddCommentRequest{
+		NoteID: req.NoteId,
+		Author: req.Author,
+		Body: req.Body,
+		StartLine: req.StartLine,
+		EndLine: req.EndLine,
+	}, nil
+}
+
+func AddCommentResponse(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.AddCommentResponse)
+	return grpccodec.AddCommentpbResp2Resp(*req), nil
+}
+
+func ListCommentsRequest(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.ListCommentsRequest)
+
+	return requests.ListCommentsRequest{
+		NoteID: req.NoteId,
+		IncludeResolved: req.IncludeResolved,
+	}, nil
+}
+
+func ListCommentsResponse(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.ListCommentsResponse)
+	return grpccodec.ListCommentspbResp2Resp(*req), nil
+}
+
+func ResolveCommentRequest(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.ResolveCommentRequest)
+
+	return requests.ResolveCommentRequest{
+		NoteID: req.NoteId,
+		CommentID: req.Id,
+		User: req.User,
+	}, nil
+}
+
+func ResolveCommentResponse(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.ResolveCommentResponse)
+	return grpccodec.ResolveCommentpbResp2Resp(*req), nil
+}
+
+func GetNoteStatsRequest(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.GetNoteStatsRequest)
+
+	return requests.GetNoteStatsRequest{
+		NoteID: req.Id,
+		OwnerToken: req.OwnerToken,
+		Days: int(req.Days),
+	}, nil
+}
+
+func GetNoteStatsResponse(_ context.Context, grpcReq interface{}) (interface{}, error)  {
+	req := grpcReq.(*pb.GetNoteStatsResponse)
+	return grpccodec.GetNoteStatspbResp2Resp(*req), nil
+}
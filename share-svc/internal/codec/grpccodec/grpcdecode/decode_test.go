@@ -0,0 +1,504 @@
+package grpcdecode
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/internal/codec/grpccodec/grpcencode"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"github.com/al8n/shareable-notes/share-svc/model/requests"
+	"github.com/al8n/shareable-notes/share-svc/model/responses"
+	"github.com/al8n/shareable-notes/share-svc/pb"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+const chunkSize = 4
+
+var chunkCases = []struct {
+	name   string
+	size   int
+	chunks int
+}{
+	{"empty", 0, 1},
+	{"short", 3, 1},
+	{"one chunk", chunkSize, 1},
+	{"exact chunks", 3 * chunkSize, 3},
+	{"partial last chunk", 3*chunkSize + 1, 4},
+}
+
+// recvAll returns a recv func pulling the chunks in order, then io.EOF.
+func recvAll(chunks [][]byte) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		if len(chunks) == 0 {
+			return nil, io.EOF
+		}
+		chunk := chunks[0]
+		chunks = chunks[1:]
+		return chunk, nil
+	}
+}
+
+func TestShareNoteStreamRequest(t *testing.T) {
+	for _, tc := range chunkCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content := bytes.Repeat([]byte("n"), tc.size)
+
+			var sent []*pb.ShareNoteChunk
+			err := grpcencode.ShareNoteStreamRequest(context.Background(), requests.ShareNoteStreamRequest{
+				Name:    "name",
+				Content: bytes.NewReader(content),
+			}, chunkSize, func(chunk *pb.ShareNoteChunk) error {
+				// the chunks are sent from a reused buffer
+				chunk.Content = append([]byte(nil), chunk.Content...)
+				sent = append(sent, chunk)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(sent) != tc.chunks {
+				t.Fatalf("%d chunks, want %d", len(sent), tc.chunks)
+			}
+			for i, chunk := range sent {
+				if len(chunk.Content) > chunkSize {
+					t.Fatalf("chunk %d has %d bytes", i, len(chunk.Content))
+				}
+				if name := chunk.Name; (i == 0) != (name == "name") {
+					t.Fatalf("chunk %d named %q", i, name)
+				}
+			}
+
+			var rest [][]byte
+			for _, chunk := range sent[1:] {
+				rest = append(rest, chunk.Content)
+			}
+			recv := recvAll(rest)
+
+			request, err := ShareNoteStreamRequest(sent[0], func() (*pb.ShareNoteChunk, error) {
+				content, err := recv()
+				if err != nil {
+					return nil, err
+				}
+				return &pb.ShareNoteChunk{Content: content}, nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req := request.(requests.ShareNoteStreamRequest)
+			got, err := ioutil.ReadAll(req.Content)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if req.Name != "name" || !bytes.Equal(got, content) {
+				t.Fatalf("got %q %q", req.Name, got)
+			}
+		})
+	}
+}
+
+func TestGetNoteStreamResponse(t *testing.T) {
+	for _, tc := range chunkCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content := bytes.Repeat([]byte("n"), tc.size)
+
+			var sent []*pb.GetNoteChunk
+			err := grpcencode.GetNoteStreamResponse(context.Background(), responses.GetNoteStreamResponse{
+				Name:    "name",
+				Content: ioutil.NopCloser(bytes.NewReader(content)),
+			}, chunkSize, func(chunk *pb.GetNoteChunk) error {
+				chunk.Content = append([]byte(nil), chunk.Content...)
+				sent = append(sent, chunk)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(sent) != tc.chunks {
+				t.Fatalf("%d chunks, want %d", len(sent), tc.chunks)
+			}
+
+			var rest [][]byte
+			for _, chunk := range sent[1:] {
+				if chunk.Name != "" {
+					t.Fatalf("chunk named %q", chunk.Name)
+				}
+				rest = append(rest, chunk.Content)
+			}
+			recv := recvAll(rest)
+
+			var canceled bool
+			response, err := GetNoteStreamResponse(sent[0], func() (*pb.GetNoteChunk, error) {
+				content, err := recv()
+				if err != nil {
+					return nil, err
+				}
+				return &pb.GetNoteChunk{Content: content}, nil
+			}, func() { canceled = true })
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp := response.(*responses.GetNoteStreamResponse)
+			got, err := ioutil.ReadAll(resp.Content)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Name != "name" || !bytes.Equal(got, content) {
+				t.Fatalf("got %q %q", resp.Name, got)
+			}
+
+			resp.Content.Close()
+			if !canceled {
+				t.Fatal("stream is not canceled on close")
+			}
+		})
+	}
+}
+
+func TestGetNoteStreamResponseError(t *testing.T) {
+	var sent int
+	err := grpcencode.GetNoteStreamResponse(context.Background(), responses.GetNoteStreamResponse{
+		Error: common.ErrorNoteNotFound.Error(),
+	}, chunkSize, func(*pb.GetNoteChunk) error {
+		sent++
+		return nil
+	})
+	if !errors.Is(err, common.ErrorNoteNotFound) || sent != 0 {
+		t.Fatalf("got %v after %d chunks", err, sent)
+	}
+}
+
+func TestShareNoteStreamRequestBroken(t *testing.T) {
+	var (
+		errRecv = errors.New("stream broken")
+		chunks  = 0
+	)
+
+	request, err := ShareNoteStreamRequest(&pb.ShareNoteChunk{Name: "name", Content: []byte("abc")}, func() (*pb.ShareNoteChunk, error) {
+		chunks++
+		if chunks > 1 {
+			return nil, errRecv
+		}
+		return &pb.ShareNoteChunk{Content: []byte("def")}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ioutil.ReadAll(request.(requests.ShareNoteStreamRequest).Content)
+	if string(got) != "abcdef" || err != errRecv {
+		t.Fatalf("got %q %v", got, err)
+	}
+}
+
+func TestCollectionRequests(t *testing.T) {
+	ids := []string{"c", "a", "b"}
+
+	for _, tc := range []struct {
+		name   string
+		req    interface{}
+		encode func(context.Context, interface{}) (interface{}, error)
+		decode func(context.Context, interface{}) (interface{}, error)
+	}{
+		{"create", requests.CreateCollectionRequest{Name: "reading", NoteIDs: ids}, grpcencode.CreateCollectionRequest, CreateCollectionRequest},
+		{"add", requests.AddCollectionNotesRequest{CollectionID: "id", NoteIDs: ids}, grpcencode.AddCollectionNotesRequest, AddCollectionNotesRequest},
+		{"remove", requests.RemoveCollectionNotesRequest{CollectionID: "id", NoteIDs: ids}, grpcencode.RemoveCollectionNotesRequest, RemoveCollectionNotesRequest},
+		{"reorder", requests.ReorderCollectionRequest{CollectionID: "id", NoteIDs: ids}, grpcencode.ReorderCollectionRequest, ReorderCollectionRequest},
+		{"get", requests.GetCollectionRequest{CollectionID: "id"}, grpcencode.GetCollectionRequest, GetCollectionRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pbReq, err := tc.encode(context.Background(), tc.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := tc.decode(context.Background(), pbReq)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.req) {
+				t.Fatalf("got %+v, want %+v", got, tc.req)
+			}
+		})
+	}
+}
+
+func TestCollectionResponses(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		resp   interface{}
+		want   interface{}
+		err    error
+		encode func(context.Context, interface{}) (interface{}, error)
+		decode func(context.Context, interface{}) (interface{}, error)
+	}{
+		{
+			"create",
+			responses.CreateCollectionResponse{URL: "url", CollectionID: "id"},
+			&responses.CreateCollectionResponse{URL: "url", CollectionID: "id"},
+			nil,
+			grpcencode.CreateCollectionResponse, CreateCollectionResponse,
+		},
+		{
+			"add",
+			responses.AddCollectionNotesResponse{},
+			&responses.AddCollectionNotesResponse{},
+			nil,
+			grpcencode.AddCollectionNotesResponse, AddCollectionNotesResponse,
+		},
+		{
+			"remove missing collection",
+			responses.RemoveCollectionNotesResponse{Error: common.ErrorCollectionNotFound.Error()},
+			nil,
+			common.ErrorCollectionNotFound,
+			grpcencode.RemoveCollectionNotesResponse, RemoveCollectionNotesResponse,
+		},
+		{
+			"reorder",
+			responses.ReorderCollectionResponse{},
+			&responses.ReorderCollectionResponse{},
+			nil,
+			grpcencode.ReorderCollectionResponse, ReorderCollectionResponse,
+		},
+		{
+			"reorder invalid order",
+			responses.ReorderCollectionResponse{Error: common.ErrorInvalidCollectionOrder.Error()},
+			nil,
+			common.ErrorInvalidCollectionOrder,
+			grpcencode.ReorderCollectionResponse, ReorderCollectionResponse,
+		},
+		{
+			"get",
+			responses.GetCollectionResponse{Name: "reading", Notes: []model.CollectionNote{{NoteID: "b"}, {NoteID: "a"}}},
+			&responses.GetCollectionResponse{Name: "reading", Notes: []model.CollectionNote{{NoteID: "b"}, {NoteID: "a"}}},
+			nil,
+			grpcencode.GetCollectionResponse, GetCollectionResponse,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pbResp, err := tc.encode(context.Background(), tc.resp)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("got %v, want %v", err, tc.err)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := tc.decode(context.Background(), pbResp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUploadAttachmentRequest(t *testing.T) {
+	for _, tc := range chunkCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content := bytes.Repeat([]byte("a"), tc.size)
+
+			var sent []*pb.AttachmentChunk
+			err := grpcencode.UploadAttachmentRequest(context.Background(), requests.UploadAttachmentRequest{
+				NoteID:  "id",
+				Name:    "file.txt",
+				Content: bytes.NewReader(content),
+			}, chunkSize, func(chunk *pb.AttachmentChunk) error {
+				chunk.Content = append([]byte(nil), chunk.Content...)
+				sent = append(sent, chunk)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(sent) != tc.chunks {
+				t.Fatalf("%d chunks, want %d", len(sent), tc.chunks)
+			}
+
+			var rest [][]byte
+			for _, chunk := range sent[1:] {
+				if chunk.Attachment != nil {
+					t.Fatalf("chunk carries %+v", chunk.Attachment)
+				}
+				rest = append(rest, chunk.Content)
+			}
+			recv := recvAll(rest)
+
+			request, err := UploadAttachmentRequest(sent[0], func() (*pb.AttachmentChunk, error) {
+				content, err := recv()
+				if err != nil {
+					return nil, err
+				}
+				return &pb.AttachmentChunk{Content: content}, nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req := request.(requests.UploadAttachmentRequest)
+			got, err := ioutil.ReadAll(req.Content)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if req.NoteID != "id" || req.Name != "file.txt" || !bytes.Equal(got, content) {
+				t.Fatalf("got %q %q %q", req.NoteID, req.Name, got)
+			}
+		})
+	}
+}
+
+func TestDownloadAttachmentResponse(t *testing.T) {
+	attachment := model.AttachmentInfo{
+		ID:          "attachment",
+		NoteID:      "id",
+		Name:        "file.txt",
+		ContentType: "text/plain; charset=utf-8",
+		URL:         "url",
+		CreatedAt:   1,
+	}
+
+	for _, tc := range chunkCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content := bytes.Repeat([]byte("a"), tc.size)
+			attachment := attachment
+			attachment.Size = int64(tc.size)
+
+			var sent []*pb.AttachmentChunk
+			err := grpcencode.DownloadAttachmentResponse(context.Background(), responses.DownloadAttachmentResponse{
+				Attachment: attachment,
+				Content:    ioutil.NopCloser(bytes.NewReader(content)),
+			}, chunkSize, func(chunk *pb.AttachmentChunk) error {
+				chunk.Content = append([]byte(nil), chunk.Content...)
+				sent = append(sent, chunk)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(sent) != tc.chunks {
+				t.Fatalf("%d chunks, want %d", len(sent), tc.chunks)
+			}
+
+			var rest [][]byte
+			for _, chunk := range sent[1:] {
+				rest = append(rest, chunk.Content)
+			}
+			recv := recvAll(rest)
+
+			var canceled bool
+			response, err := DownloadAttachmentResponse(sent[0], func() (*pb.AttachmentChunk, error) {
+				content, err := recv()
+				if err != nil {
+					return nil, err
+				}
+				return &pb.AttachmentChunk{Content: content}, nil
+			}, func() { canceled = true })
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp := response.(*responses.DownloadAttachmentResponse)
+			got, err := ioutil.ReadAll(resp.Content)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Attachment != attachment || !bytes.Equal(got, content) {
+				t.Fatalf("got %+v %q, want %+v", resp.Attachment, got, attachment)
+			}
+
+			resp.Content.Close()
+			if !canceled {
+				t.Fatal("stream is not canceled on close")
+			}
+		})
+	}
+}
+
+func TestDownloadAttachmentResponseError(t *testing.T) {
+	var sent int
+	err := grpcencode.DownloadAttachmentResponse(context.Background(), responses.DownloadAttachmentResponse{
+		Error: common.ErrorAttachmentNotFound.Error(),
+	}, chunkSize, func(*pb.AttachmentChunk) error {
+		sent++
+		return nil
+	})
+	if !errors.Is(err, common.ErrorAttachmentNotFound) || sent != 0 {
+		t.Fatalf("got %v after %d chunks", err, sent)
+	}
+}
+
+func TestCommentRequests(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		req    interface{}
+		encode func(context.Context, interface{}) (interface{}, error)
+		decode func(context.Context, interface{}) (interface{}, error)
+	}{
+		{"add", requests.AddCommentRequest{NoteID: "id", Author: "alice", Body: "body"}, grpcencode.AddCommentRequest, AddCommentRequest},
+		{"add anchored", requests.AddCommentRequest{NoteID: "id", Body: "body", StartLine: 2, EndLine: 4}, grpcencode.AddCommentRequest, AddCommentRequest},
+		{"list", requests.ListCommentsRequest{NoteID: "id"}, grpcencode.ListCommentsRequest, ListCommentsRequest},
+		{"list resolved", requests.ListCommentsRequest{NoteID: "id", IncludeResolved: true}, grpcencode.ListCommentsRequest, ListCommentsRequest},
+		{"resolve", requests.ResolveCommentRequest{NoteID: "id", CommentID: "comment", User: "bob"}, grpcencode.ResolveCommentRequest, ResolveCommentRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pbReq, err := tc.encode(context.Background(), tc.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := tc.decode(context.Background(), pbReq)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.req) {
+				t.Fatalf("got %+v, want %+v", got, tc.req)
+			}
+		})
+	}
+}
+
+func TestGetNoteStatsRequest(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		req  requests.GetNoteStatsRequest
+	}{
+		{"default days", requests.GetNoteStatsRequest{NoteID: "id", OwnerToken: "token"}},
+		{"days", requests.GetNoteStatsRequest{NoteID: "id", OwnerToken: "token", Days: 7}},
+		{"no token", requests.GetNoteStatsRequest{NoteID: "id"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pbReq, err := grpcencode.GetNoteStatsRequest(context.Background(), tc.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := GetNoteStatsRequest(context.Background(), pbReq)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.req) {
+				t.Fatalf("got %+v, want %+v", got, tc.req)
+			}
+		})
+	}
+}
+
+func TestGetNoteStatsResponseNotOwner(t *testing.T) {
+	_, err := grpcencode.GetNoteStatsResponse(context.Background(), responses.GetNoteStatsResponse{
+		Error: common.ErrorNotNoteOwner.Error(),
+	})
+	if !errors.Is(err, common.ErrorNotNoteOwner) {
+		t.Fatalf("got %v, want %v", err, common.ErrorNotNoteOwner)
+	}
+}
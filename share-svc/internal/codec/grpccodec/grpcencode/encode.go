@@ -4,9 +4,11 @@ import (
 	"context"
 	"github.com/al8n/shareable-notes/share-svc/internal/codec/grpccodec"
 	"github.com/al8n/shareable-notes/share-svc/internal/utils"
+	"github.com/al8n/shareable-notes/share-svc/model"
 	"github.com/al8n/shareable-notes/share-svc/model/requests"
 	"github.com/al8n/shareable-notes/share-svc/model/responses"
 	"github.com/al8n/shareable-notes/share-svc/pb"
+	"google.golang.org/grpc/metadata"
 )
 
 func ShareNoteRequest(_ context.Context, request interface{}) ( interface{}, error)  {
@@ -19,7 +21,6 @@ func ShareNoteRequest(_ context.Context, request interface{}) ( interface{}, err
 }
 
 func ShareNoteResponse(_ context.Context, resp interface{}) (interface{}, error) {
-	pbReply := &pb.ShareNoteResponse{}
 	res, ok := resp.(responses.ShareNoteResponse)
 	if !ok {
 		return nil, utils.ErrorCodecCasting("ShareNote", utils.Response, utils.GRPC)
@@ -29,11 +30,7 @@ func ShareNoteResponse(_ context.Context, resp interface{}) (interface{}, error)
 		return nil, utils.Str2Err(res.Error)
 	}
 
-	pbReply.Error = res.Error
-	pbReply.NoteId = res.NoteID
-	pbReply.Url = res.URL
-
-	return pbReply, nil
+	return grpccodec.ShareNoteResp2pbResp(res), nil
 }
 
 func GetNoteRequest(_ context.Context, request interface{}) ( interface{}, error)  {
@@ -57,6 +54,9 @@ func GetNoteResponse(_ context.Context, resp interface{}) (interface{}, error) {
 
 	pbReply.Content = res.Content
 	pbReply.Name = res.Name
+	pbReply.Etag = res.ETag
+	pbReply.LastModified = res.LastModified
+	pbReply.NotModified = res.NotModified
 
 	return pbReply, nil
 }
@@ -81,4 +81,611 @@ func PrivateNoteResponse(_ context.Context, resp interface{}) (interface{}, erro
 
 	pbReply.Error = res.Error
 	return pbReply, nil
-}
\ No newline at end of file
+}
+
+func GetNoteStreamRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.GetNoteStreamRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("GetNoteStream", utils.Request,utils.GRPC)
+	}
+	return &pb.GetNoteRequest{
+		Id: req.NoteID,
+	}, nil
+}
+
+// ShareNoteStreamRequest sends the request content in chunks of at most size
+// bytes, the first chunk carries the note name.
+func ShareNoteStreamRequest(_ context.Context, request interface{}, size int, send func(*pb.ShareNoteChunk) error) error  {
+	req, ok := request.(requests.ShareNoteStreamRequest)
+	if !ok {
+		return utils.ErrorCodecCasting("ShareNoteStream", utils.Request, utils.GRPC)
+	}
+
+	var name = req.Name
+	chunks, err := grpccodec.SendChunks(req.Content, size, func(content []byte) error {
+		chunk := &pb.ShareNoteChunk{
+			Name: name,
+			Content: content,
+		}
+		name = ""
+		return send(chunk)
+	})
+	if err != nil {
+		return err
+	}
+
+	if chunks == 0 {
+		return send(&pb.ShareNoteChunk{
+			Name: req.Name,
+		})
+	}
+	return nil
+}
+
+// GetNoteStreamResponse sends the response content in chunks of at most size
+// bytes and closes it, the first chunk carries the note name.
+func GetNoteStreamResponse(_ context.Context, resp interface{}, size int, send func(*pb.GetNoteChunk) error) error  {
+	res, ok := resp.(responses.GetNoteStreamResponse)
+	if !ok {
+		return utils.ErrorCodecCasting("GetNoteStream", utils.Response, utils.GRPC)
+	}
+
+	if res.Error != "" {
+		return utils.Str2Err(res.Error)
+	}
+	defer res.Content.Close()
+
+	var name = res.Name
+	chunks, err := grpccodec.SendChunks(res.Content, size, func(content []byte) error {
+		chunk := &pb.GetNoteChunk{
+			Name: name,
+			Content: content,
+		}
+		name = ""
+		return send(chunk)
+	})
+	if err != nil {
+		return err
+	}
+
+	if chunks == 0 {
+		return send(&pb.GetNoteChunk{
+			Name: res.Name,
+		})
+	}
+	return nil
+}
+
+func WatchNoteRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.WatchNoteRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("WatchNote", utils.Request,utils.GRPC)
+	}
+	return &pb.WatchNoteRequest{
+		Id: req.NoteID,
+	}, nil
+}
+
+// WatchNoteResponse sends the headers as soon as the note is being watched,
+// then every event of the response until its channel is closed.
+func WatchNoteResponse(_ context.Context, resp interface{}, stream pb.Share_WatchNoteServer) error  {
+	res, ok := resp.(responses.WatchNoteResponse)
+	if !ok {
+		return utils.ErrorCodecCasting("WatchNote", utils.Response, utils.GRPC)
+	}
+
+	if res.Error != "" {
+		return utils.Str2Err(res.Error)
+	}
+
+	return sendNoteEvents(res.Events, stream)
+}
+
+func WatchNotesRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	if _, ok := request.(requests.WatchNotesRequest); !ok {
+		return nil, utils.ErrorCodecCasting("WatchNotes", utils.Request,utils.GRPC)
+	}
+	return &pb.WatchNotesRequest{}, nil
+}
+
+// WatchNotesResponse sends the headers as soon as the notes are being
+// watched, then every event of the response until its channel is closed.
+func WatchNotesResponse(_ context.Context, resp interface{}, stream pb.Share_WatchNotesServer) error  {
+	res, ok := resp.(responses.WatchNotesResponse)
+	if !ok {
+		return utils.ErrorCodecCasting("WatchNotes", utils.Response, utils.GRPC)
+	}
+
+	if res.Error != "" {
+		return utils.Str2Err(res.Error)
+	}
+
+	return sendNoteEvents(res.Events, stream)
+}
+
+// noteEventStream is the server side of WatchNote and WatchNotes.
+type noteEventStream interface {
+	SendHeader(metadata.MD) error
+	Send(*pb.NoteEvent) error
+}
+
+func sendNoteEvents(events <-chan model.NoteEvent, stream noteEventStream) error {
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for event := range events {
+		if err := stream.Send(grpccodec.NoteEvent2pbNoteEvent(event)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EditNoteRequest encodes the join message opening an EditNote stream.
+func EditNoteRequest(_ context.Context, request interface{}) (*pb.EditMessage, error)  {
+	req, ok := request.(requests.EditNoteRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("EditNote", utils.Request, utils.GRPC)
+	}
+	return &pb.EditMessage{
+		Type: model.EditMessageJoin,
+		NoteId: req.NoteID,
+		User: req.User,
+	}, nil
+}
+
+// EditNoteResponse returns the session to relay, or sends the error message
+// when the note cannot be joined.
+func EditNoteResponse(_ context.Context, resp interface{}, send func(*pb.EditMessage) error) (model.EditSession, error)  {
+	res, ok := resp.(responses.EditNoteResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("EditNote", utils.Response, utils.GRPC)
+	}
+
+	if res.Error != "" {
+		send(&pb.EditMessage{
+			Type: model.EditMessageError,
+			Error: res.Error,
+		})
+		return nil, utils.Str2Err(res.Error)
+	}
+	return res.Session, nil
+}
+
+func SyncNotesRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.SyncNotesRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("SyncNotes", utils.Request,utils.GRPC)
+	}
+	return grpccodec.SyncNotesReq2pbReq(req), nil
+}
+
+func SyncNotesResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.SyncNotesResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("SyncNotes", utils.Response, utils.GRPC)
+	}
+
+	if res.Error != "" {
+		return nil, utils.Str2Err(res.Error)
+	}
+
+	return grpccodec.SyncNotesResp2pbResp(res), nil
+}
+
+func ForkNoteRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.ForkNoteRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("ForkNote", utils.Request,utils.GRPC)
+	}
+	return grpccodec.ForkNoteReq2pbReq(req), nil
+}
+
+func ForkNoteResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.ForkNoteResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("ForkNote", utils.Response, utils.GRPC)
+	}
+
+	if res.Error != "" {
+		return nil, utils.Str2Err(res.Error)
+	}
+
+	return grpccodec.ForkNoteResp2pbResp(res), nil
+}
+
+func ListForksRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.ListForksRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("ListForks", utils.Request,utils.GRPC)
+	}
+	return &pb.ListForksRequest{
+		Id: req.NoteID,
+	}, nil
+}
+
+func ListForksResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.ListForksResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("ListForks", utils.Response, utils.GRPC)
+	}
+
+	if res.Error != "" {
+		return nil, utils.Str2Err(res.Error)
+	}
+
+	return grpccodec.ListForksResp2pbResp(res), nil
+}
+
+func MarkTemplateRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.MarkTemplateRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("MarkTemplate", utils.Request,utils.GRPC)
+	}
+	return &pb.MarkTemplateRequest{
+		Id: req.NoteID,
+		Template: req.Template,
+	}, nil
+}
+
+func MarkTemplateResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.MarkTemplateResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("MarkTemplate", utils.Response, utils.GRPC)
+	}
+
+	if res.Error != "" {
+		return nil, utils.Str2Err(res.Error)
+	}
+
+	return grpccodec.MarkTemplateResp2pbResp(res), nil
+}
+
+func InstantiateTemplateRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.InstantiateTemplateRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("InstantiateTemplate", utils.Request,utils.GRPC)
+	}
+	return &pb.InstantiateTemplateRequest{
+		Id: req.NoteID,
+		Vars: req.Vars,
+	}, nil
+}
+
+func InstantiateTemplateResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.InstantiateTemplateResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("InstantiateTemplate", utils.Response, utils.GRPC)
+	}
+
+	if res.Error != "" {
+		return nil, utils.Str2Err(res.Error)
+	}
+
+	return grpccodec.InstantiateTemplateResp2pbResp(res), nil
+}
+
+func CreateCollectionRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.CreateCollectionRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("CreateCollection", utils.Request,utils.GRPC)
+	}
+	return &pb.CreateCollectionRequest{
+		Name: req.Name,
+		NoteIds: req.NoteIDs,
+	}, nil
+}
+
+func CreateCollectionResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.CreateCollectionResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("CreateCollection", utils.Response, utils.GRPC)
+	}
+
+	if res.Error != "" {
+		return nil, utils.Str2Err(res.Error)
+	}
+
+	return &pb.CreateCollectionResponse{
+		Url: res.URL,
+		CollectionId: res.CollectionID,
+		Error: res.Error,
+	}, nil
+}
+
+func AddCollectionNotesRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.AddCollectionNotesRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("AddCollectionNotes", utils.Request,utils.GRPC)
+	}
+	return &pb.AddCollectionNotesRequest{
+		Id: req.CollectionID,
+		NoteIds: req.NoteIDs,
+	}, nil
+}
+
+func AddCollectionNotesResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.AddCollectionNotesResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("AddCollectionNotes", utils.Response, utils.GRPC)
+	}
+
+	if res.Error != "" {
+		return nil, utils.Str2Err(res.Error)
+	}
+
+	return &pb.AddCollectionNotesResponse{
+		Error: res.Error,
+	}, nil
+}
+
+func RemoveCollectionNotesRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.RemoveCollectionNotesRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("RemoveCollectionNotes", utils.Request,utils.GRPC)
+	}
+	return &pb.RemoveCollectionNotesRequest{
+		Id: req.CollectionID,
+		NoteIds: req.NoteIDs,
+	}, nil
+}
+
+func RemoveCollectionNotesResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.RemoveCollectionNotesResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("RemoveCollectionNotes", utils.Response, utils.GRPC)
+	}
+
+	if res.Error != "" {
+		return nil, utils.Str2Err(res.Error)
+	}
+
+	return &pb.RemoveCollectionNotesResponse{
+		Error: res.Error,
+	}, nil
+}
+
+func ReorderCollectionRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.ReorderCollectionRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("ReorderCollection", utils.Request,utils.GRPC)
+	}
+	return &pb.ReorderCollectionRequest{
+		Id: req.CollectionID,
+		NoteIds: req.NoteIDs,
+	}, nil
+}
+
+func ReorderCollectionResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.ReorderCollectionResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("ReorderCollection", utils.Response, utils.GRPC)
+	}
+
+	if res.Error != "" {
+		return nil, utils.Str2Err(res.Error)
+	}
+
+	return &pb.ReorderCollectionResponse{
+		Error: res.Error,
+	}, nil
+}
+
+func GetCollectionRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.GetCollectionRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("GetCollection", utils.Request,utils.GRPC)
+	}
+	return &pb.GetCollectionRequest{
+		Id: req.CollectionID,
+	}, nil
+}
+
+func GetCollectionResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.GetCollectionResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("GetCollection", utils.Response, utils.GRPC)
+	}
+
+	if res.Error != "" {
+		return nil, utils.Str2Err(res.Error)
+	}
+
+	return grpccodec.GetCollectionResp2pbResp(res), nil
+}
+
+// UploadAttachmentRequest sends the request content in chunks of at most size
+// bytes, the first chunk carries the note id and the attachment name.
+func UploadAttachmentRequest(_ context.Context, request interface{}, size int, send func(*pb.AttachmentChunk) error) error  {
+	req, ok := request.(requests.UploadAttachmentRequest)
+	if !ok {
+		return utils.ErrorCodecCasting("UploadAttachment", utils.Request, utils.GRPC)
+	}
+
+	var attachment = &pb.Attachment{
+		NoteId: req.NoteID,
+		Name: req.Name,
+	}
+
+	first := attachment
+	chunks, err := grpccodec.SendChunks(req.Content, size, func(content []byte) error {
+		chunk := &pb.AttachmentChunk{
+			Attachment: first,
+			Content: content,
+		}
+		first = nil
+		return send(chunk)
+	})
+	if err != nil {
+		return err
+	}
+
+	if chunks == 0 {
+		return send(&pb.AttachmentChunk{
+			Attachment: attachment,
+		})
+	}
+	return nil
+}
+
+func UploadAttachmentResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.UploadAttachmentResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("UploadAttachment", utils.Response, utils.GRPC)
+	}
+
+	if res.Error != "" {
+		return nil, utils.Str2Err(res.Error)
+	}
+
+	return grpccodec.UploadAttachmentResp2pbResp(res), nil
+}
+
+func DownloadAttachmentRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.DownloadAttachmentRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("DownloadAttachment", utils.Request,utils.GRPC)
+	}
+	return &pb.DownloadAttachmentRequest{
+		NoteId: req.NoteID,
+		Id: req.AttachmentID,
+	}, nil
+}
+
+// DownloadAttachmentResponse sends the response content in chunks of at most
+// size bytes and closes it, the first chunk carries the attachment.
+func DownloadAttachmentResponse(_ context.Context, resp interface{}, size int, send func(*pb.AttachmentChunk) error) error  {
+	res, ok := resp.(responses.DownloadAttachmentResponse)
+	if !ok {
+		return utils.ErrorCodecCasting("DownloadAttachment", utils.Response, utils.GRPC)
+	}
+
+	if res.Error != "" {
+		return utils.Str2Err(res.Error)
+	}
+	defer res.Content.Close()
+
+	var attachment = grpccodec.Attachment2pbAttachment(res.Attachment)
+
+	first := attachment
+	chunks, err := grpccodec.SendChunks(res.Content, size, func(content []byte) error {
+		chunk := &pb.AttachmentChunk{
+			Attachment: first,
+			Content: content,
+		}
+		first = nil
+		return send(chunk)
+	})
+	if err != nil {
+		return err
+	}
+
+	if chunks == 0 {
+		return send(&pb.AttachmentChunk{
+			Attachment: attachment,
+		})
+	}
+	return nil
+}
+
+func AddCommentRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.AddCommentRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("AddComment", utils.Request,utils.GRPC)
+	}
+	return &pb.AddCommentRequest{
+		NoteId: req.NoteID,
+		Author: req.Author,
+		Body: req.Body,
+		StartLine: req.StartLine,
+		EndLine: req.EndLine,
+	}, nil
+}
+
+func AddCommentResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.AddCommentResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("AddComment", utils.Response, utils.GRPC)
+	}
+
+	if res.Error != "" {
+		return nil, utils.Str2Err(res.Error)
+	}
+
+	return grpccodec.AddCommentResp2pbResp(res), nil
+}
+
+func ListCommentsRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.ListCommentsRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("ListComments", utils.Request,utils.GRPC)
+	}
+	return &pb.ListCommentsRequest{
+		NoteId: req.NoteID,
+		IncludeResolved: req.IncludeResolved,
+	}, nil
+}
+
+func ListCommentsResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.ListCommentsResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("ListComments", utils.Response, utils.GRPC)
+	}
+
+	if res.Error != "" {
+		return nil, utils.Str2Err(res.Error)
+	}
+
+	return grpccodec.ListCommentsResp2pbResp(res), nil
+}
+
+func ResolveCommentRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.ResolveCommentRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("ResolveComment", utils.Request,utils.GRPC)
+	}
+	return &pb.ResolveCommentRequest{
+		NoteId: req.NoteID,
+		Id: req.CommentID,
+		User: req.User,
+	}, nil
+}
+
+func ResolveCommentResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.ResolveCommentResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("ResolveComment", utils.Response, utils.GRPC)
+	}
+
+	if res.Error != "" {
+		return nil, utils.Str2Err(res.Error)
+	}
+
+	return grpccodec.ResolveCommentResp2pbResp(res), nil
+}
+
+func GetNoteStatsRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.GetNoteStatsRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("GetNoteStats", utils.Request,utils.GRPC)
+	}
+	return &pb.GetNoteStatsRequest{
+		Id: req.NoteID,
+		OwnerToken: req.OwnerToken,
+		Days: int32(req.Days),
+	}, nil
+}
+
+func GetNoteStatsResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.GetNoteStatsResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("GetNoteStats", utils.Response, utils.GRPC)
+	}
+
+	if res.Error != "" {
+		return nil, utils.Str2Err(res.Error)
+	}
+
+	return grpccodec.GetNoteStatsResp2pbResp(res), nil
+}
@@ -2,13 +2,40 @@ package httpcodec
 
 import (
 	"context"
-	"encoding/json"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"net/http"
 )
 
+// ErrorEncoder writes the error with the codec negotiated for the request,
+// the protobuf errors are google.rpc.Status messages.
 func ErrorEncoder(ctx context.Context, err error, w http.ResponseWriter) {
-	w.WriteHeader(http.StatusInternalServerError)
-	json.NewEncoder(w).Encode(ErrorWrapper{Error: err.Error()})
+	var (
+		codec = FromContext(ctx)
+		code = http.StatusInternalServerError
+		body interface{} = ErrorWrapper{Error: err.Error()}
+	)
+
+	switch err {
+	case ErrUnsupportedMediaType:
+		code = http.StatusUnsupportedMediaType
+	case ErrNotAcceptable:
+		code = http.StatusNotAcceptable
+	}
+
+	if codec == Protobuf {
+		body = status.New(codes.Unknown, err.Error()).Proto()
+	}
+
+	data, merr := codec.Marshal(body)
+	if merr != nil {
+		codec = JSON
+		data, _ = JSON.Marshal(ErrorWrapper{Error: err.Error()})
+	}
+
+	w.Header().Set("Content-Type", codec.ContentType())
+	w.WriteHeader(code)
+	w.Write(data)
 }
 
 type ErrorWrapper struct {
@@ -1,17 +1,30 @@
 package httpdecode
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"github.com/al8n/shareable-notes/share-svc/internal/codec"
+	"github.com/al8n/shareable-notes/share-svc/internal/codec/httpcodec"
+	"github.com/al8n/shareable-notes/share-svc/model"
 	"github.com/al8n/shareable-notes/share-svc/model/requests"
 	"github.com/al8n/shareable-notes/share-svc/model/responses"
 	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"strconv"
 )
 
-var ErrBadRouting = errors.New("inconsistent mapping between route and handler (programmer error)")
+var (
+	ErrBadRouting = errors.New("inconsistent mapping between route and handler (programmer error)")
+	ErrNoAttachmentFile = errors.New("multipart form has no \"file\" part")
+)
 
 func GetNoteRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 
@@ -31,6 +44,12 @@ func GetNoteRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 	}
 
 	req.NoteID = string(id)
+	req.Condition.IfNoneMatch = r.Header.Get("If-None-Match")
+
+	// an invalid date is ignored, as RFC 7232 requires
+	if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil {
+		req.Condition.IfModifiedSince = since.Unix()
+	}
 	return req, nil
 }
 
@@ -38,7 +57,7 @@ func PrivateNoteRequest(ctx context.Context, r *http.Request) (interface{}, erro
 
 	var req requests.PrivateNoteRequest
 
-	err := json.NewDecoder(r.Body).Decode(&req)
+	err := httpcodec.DecodeRequest(r, &req)
 	if err != nil {
 		return nil, err
 	}
@@ -52,7 +71,7 @@ func ShareNoteRequest(ctx context.Context, r *http.Request) (interface{}, error)
 		req requests.ShareNoteRequest
 	)
 
-	err := json.NewDecoder(r.Body).Decode(&req)
+	err := httpcodec.DecodeRequest(r, &req)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +84,7 @@ func PrivateNoteResponse(_ context.Context, r *http.Response) (interface{}, erro
 		return nil, errors.New(r.Status)
 	}
 	var resp responses.PrivateNoteResponse
-	err := json.NewDecoder(r.Body).Decode(&resp)
+	err := httpcodec.DecodeResponse(r, &resp)
 	return resp, err
 }
 
@@ -74,15 +93,665 @@ func ShareNoteResponse(_ context.Context, r *http.Response) (interface{}, error)
 		return nil, errors.New(r.Status)
 	}
 	var resp responses.ShareNoteResponse
-	err := json.NewDecoder(r.Body).Decode(&resp)
+	err := httpcodec.DecodeResponse(r, &resp)
 	return resp, err
 }
 
 func GetNoteResponse(_ context.Context, r *http.Response) (interface{}, error)  {
+	var resp responses.GetNoteResponse
+
+	switch r.StatusCode {
+	case http.StatusOK:
+		err := httpcodec.DecodeResponse(r, &resp)
+		return resp, err
+	case http.StatusNotModified:
+		resp.NotModified = true
+		resp.ETag = r.Header.Get("ETag")
+		if modified, err := http.ParseTime(r.Header.Get("Last-Modified")); err == nil {
+			resp.LastModified = modified.Unix()
+		}
+		return resp, nil
+	default:
+		return nil, errors.New(r.Status)
+	}
+}
+
+func WatchNoteRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var (
+		req requests.WatchNoteRequest
+	)
+
+	bid, ok := mux.Vars(r)["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+
+	id, err := base64.URLEncoding.DecodeString(bid)
+	if err != nil {
+		return nil, err
+	}
+
+	req.NoteID = string(id)
+	return req, nil
+}
+
+// WatchNoteResponse decodes a server-sent events stream, the client must be
+// created with the BufferedStream option so the body stays open. The body is
+// closed when the stream ends or ctx is done.
+func WatchNoteResponse(ctx context.Context, r *http.Response) (interface{}, error)  {
 	if r.StatusCode != http.StatusOK {
+		r.Body.Close()
 		return nil, errors.New(r.Status)
 	}
-	var resp responses.GetNoteResponse
-	err := json.NewDecoder(r.Body).Decode(&resp)
-	return resp, err
-}
\ No newline at end of file
+
+	var events = make(chan model.NoteEvent)
+
+	go func() {
+		defer close(events)
+		defer r.Body.Close()
+
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if !bytes.HasPrefix(line, []byte("data:")) {
+				continue
+			}
+
+			var event model.NoteEvent
+			if err := json.Unmarshal(bytes.TrimSpace(line[len("data:"):]), &event); err != nil {
+				return
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return &responses.WatchNoteResponse{
+		Events: events,
+	}, nil
+}
+
+func EditNoteRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var (
+		req requests.EditNoteRequest
+	)
+
+	bid, ok := mux.Vars(r)["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+
+	id, err := base64.URLEncoding.DecodeString(bid)
+	if err != nil {
+		return nil, err
+	}
+
+	req.NoteID = string(id)
+	req.User = r.URL.Query().Get("user")
+	return req, nil
+}
+
+// EditNoteResponse reads the first message of an edit WebSocket connection,
+// the connection is closed with the session.
+func EditNoteResponse(conn *websocket.Conn) (interface{}, error)  {
+	var first model.EditMessage
+
+	if err := conn.ReadJSON(&first); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if first.Type == model.EditMessageError {
+		conn.Close()
+		return &responses.EditNoteResponse{
+			Error: first.Error,
+		}, nil
+	}
+
+	session := codec.NewEditSession(
+		first,
+		func(msg model.EditMessage) error {
+			return conn.WriteJSON(msg)
+		},
+		func() (msg model.EditMessage, err error) {
+			err = conn.ReadJSON(&msg)
+			return msg, err
+		},
+		conn.Close,
+	)
+
+	return &responses.EditNoteResponse{
+		Session: session,
+	}, nil
+}
+
+// EditNoteHandshakeResponse decodes the error written by the server instead
+// of upgrading the connection.
+func EditNoteHandshakeResponse(r *http.Response) (interface{}, error)  {
+	var resp responses.EditNoteResponse
+
+	defer r.Body.Close()
+	if err := httpcodec.DecodeResponse(r, &resp); err != nil || resp.Error == "" {
+		return nil, errors.New(r.Status)
+	}
+	return &resp, nil
+}
+
+func SyncNotesRequest(ctx context.Context, r *http.Request) (interface{}, error)  {
+	var (
+		req requests.SyncNotesRequest
+	)
+
+	err := httpcodec.DecodeRequest(r, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func SyncNotesResponse(_ context.Context, r *http.Response) (interface{}, error)  {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var resp responses.SyncNotesResponse
+	err := httpcodec.DecodeResponse(r, &resp)
+	return &resp, err
+}
+
+// ForkNoteRequest reads the note id from the path and the owner from the
+// optional JSON body.
+func ForkNoteRequest(ctx context.Context, r *http.Request) (interface{}, error)  {
+	var (
+		req requests.ForkNoteRequest
+	)
+
+	bid, ok := mux.Vars(r)["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+
+	id, err := base64.URLEncoding.DecodeString(bid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = httpcodec.DecodeRequest(r, &req)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	req.NoteID = string(id)
+	return req, nil
+}
+
+func ForkNoteResponse(_ context.Context, r *http.Response) (interface{}, error)  {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var resp responses.ForkNoteResponse
+	err := httpcodec.DecodeResponse(r, &resp)
+	return &resp, err
+}
+
+func ListForksRequest(ctx context.Context, r *http.Request) (interface{}, error)  {
+	var (
+		req requests.ListForksRequest
+	)
+
+	bid, ok := mux.Vars(r)["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+
+	id, err := base64.URLEncoding.DecodeString(bid)
+	if err != nil {
+		return nil, err
+	}
+
+	req.NoteID = string(id)
+	return req, nil
+}
+
+func ListForksResponse(_ context.Context, r *http.Response) (interface{}, error)  {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var resp responses.ListForksResponse
+	err := httpcodec.DecodeResponse(r, &resp)
+	return &resp, err
+}
+
+// MarkTemplateRequest reads the note id from the path and whether the note is
+// a template from the JSON body, an empty body marks the note.
+func MarkTemplateRequest(ctx context.Context, r *http.Request) (interface{}, error)  {
+	var (
+		req = requests.MarkTemplateRequest{Template: true}
+	)
+
+	id, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	err = httpcodec.DecodeRequest(r, &req)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	req.NoteID = id
+	return req, nil
+}
+
+func MarkTemplateResponse(_ context.Context, r *http.Response) (interface{}, error)  {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var resp responses.MarkTemplateResponse
+	err := httpcodec.DecodeResponse(r, &resp)
+	return &resp, err
+}
+
+func InstantiateTemplateRequest(ctx context.Context, r *http.Request) (interface{}, error)  {
+	var (
+		req requests.InstantiateTemplateRequest
+	)
+
+	id, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	err = httpcodec.DecodeRequest(r, &req)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	req.NoteID = id
+	return req, nil
+}
+
+func InstantiateTemplateResponse(_ context.Context, r *http.Response) (interface{}, error)  {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var resp responses.InstantiateTemplateResponse
+	err := httpcodec.DecodeResponse(r, &resp)
+	return &resp, err
+}
+
+func CreateCollectionRequest(ctx context.Context, r *http.Request) (interface{}, error)  {
+	var (
+		req requests.CreateCollectionRequest
+	)
+
+	err := httpcodec.DecodeRequest(r, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func AddCollectionNotesRequest(ctx context.Context, r *http.Request) (interface{}, error)  {
+	var (
+		req requests.AddCollectionNotesRequest
+	)
+
+	id, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	err = httpcodec.DecodeRequest(r, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	req.CollectionID = id
+	return req, nil
+}
+
+func RemoveCollectionNotesRequest(ctx context.Context, r *http.Request) (interface{}, error)  {
+	var (
+		req requests.RemoveCollectionNotesRequest
+	)
+
+	id, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	err = httpcodec.DecodeRequest(r, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	req.CollectionID = id
+	return req, nil
+}
+
+func ReorderCollectionRequest(ctx context.Context, r *http.Request) (interface{}, error)  {
+	var (
+		req requests.ReorderCollectionRequest
+	)
+
+	id, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	err = httpcodec.DecodeRequest(r, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	req.CollectionID = id
+	return req, nil
+}
+
+func GetCollectionRequest(ctx context.Context, r *http.Request) (interface{}, error)  {
+	var (
+		req requests.GetCollectionRequest
+	)
+
+	id, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req.CollectionID = id
+	return req, nil
+}
+
+func CreateCollectionResponse(_ context.Context, r *http.Response) (interface{}, error)  {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var resp responses.CreateCollectionResponse
+	err := httpcodec.DecodeResponse(r, &resp)
+	return &resp, err
+}
+
+func AddCollectionNotesResponse(_ context.Context, r *http.Response) (interface{}, error)  {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var resp responses.AddCollectionNotesResponse
+	err := httpcodec.DecodeResponse(r, &resp)
+	return &resp, err
+}
+
+func RemoveCollectionNotesResponse(_ context.Context, r *http.Response) (interface{}, error)  {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var resp responses.RemoveCollectionNotesResponse
+	err := httpcodec.DecodeResponse(r, &resp)
+	return &resp, err
+}
+
+func ReorderCollectionResponse(_ context.Context, r *http.Response) (interface{}, error)  {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var resp responses.ReorderCollectionResponse
+	err := httpcodec.DecodeResponse(r, &resp)
+	return &resp, err
+}
+
+func GetCollectionResponse(_ context.Context, r *http.Response) (interface{}, error)  {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var resp responses.GetCollectionResponse
+	err := httpcodec.DecodeResponse(r, &resp)
+	return &resp, err
+}
+
+// UploadAttachmentRequest streams the "file" part of a multipart form, the
+// parts before it are skipped and the parts after it are ignored.
+func UploadAttachmentRequest(ctx context.Context, r *http.Request) (interface{}, error)  {
+	var (
+		req requests.UploadAttachmentRequest
+		mr *multipart.Reader
+		part *multipart.Part
+	)
+
+	id, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	mr, err = r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		part, err = mr.NextPart()
+		if err == io.EOF {
+			return nil, ErrNoAttachmentFile
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if part.FormName() == "file" {
+			break
+		}
+		part.Close()
+	}
+
+	req.NoteID = id
+	req.Name = part.FileName()
+	req.Content = part
+	return req, nil
+}
+
+func UploadAttachmentResponse(_ context.Context, r *http.Response) (interface{}, error)  {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var resp responses.UploadAttachmentResponse
+	err := httpcodec.DecodeResponse(r, &resp)
+	return &resp, err
+}
+
+func DownloadAttachmentRequest(ctx context.Context, r *http.Request) (interface{}, error)  {
+	var (
+		req requests.DownloadAttachmentRequest
+	)
+
+	noteID, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := pathVar(r, "attachment")
+	if err != nil {
+		return nil, err
+	}
+
+	req.NoteID = noteID
+	req.AttachmentID = id
+	return req, nil
+}
+
+// DownloadAttachmentResponse describes the attachment from the response
+// headers, the caller reads the content from the response body and must close it.
+func DownloadAttachmentResponse(_ context.Context, r *http.Response) (interface{}, error)  {
+	if r.StatusCode != http.StatusOK {
+		r.Body.Close()
+		return nil, errors.New(r.Status)
+	}
+
+	var attachment = model.AttachmentInfo{
+		ContentType: r.Header.Get("Content-Type"),
+		Size: r.ContentLength,
+	}
+
+	if _, params, err := mime.ParseMediaType(r.Header.Get("Content-Disposition")); err == nil {
+		attachment.Name = params["filename"]
+	}
+
+	if modified, err := http.ParseTime(r.Header.Get("Last-Modified")); err == nil {
+		attachment.CreatedAt = modified.Unix()
+	}
+
+	return &responses.DownloadAttachmentResponse{
+		Attachment: attachment,
+		Content: r.Body,
+	}, nil
+}
+
+func AddCommentRequest(ctx context.Context, r *http.Request) (interface{}, error)  {
+	var (
+		req requests.AddCommentRequest
+	)
+
+	id, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	err = httpcodec.DecodeRequest(r, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	req.NoteID = id
+	return req, nil
+}
+
+// ListCommentsRequest reads the note id from the path, the resolved comments
+// are listed when the include_resolved query parameter is true.
+func ListCommentsRequest(ctx context.Context, r *http.Request) (interface{}, error)  {
+	var (
+		req requests.ListCommentsRequest
+	)
+
+	id, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if v := r.URL.Query().Get("include_resolved"); v != "" {
+		req.IncludeResolved, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req.NoteID = id
+	return req, nil
+}
+
+func ResolveCommentRequest(ctx context.Context, r *http.Request) (interface{}, error)  {
+	var (
+		req requests.ResolveCommentRequest
+	)
+
+	noteID, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := pathVar(r, "comment")
+	if err != nil {
+		return nil, err
+	}
+
+	err = httpcodec.DecodeRequest(r, &req)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	req.NoteID = noteID
+	req.CommentID = id
+	return req, nil
+}
+
+func AddCommentResponse(_ context.Context, r *http.Response) (interface{}, error)  {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var resp responses.AddCommentResponse
+	err := httpcodec.DecodeResponse(r, &resp)
+	return &resp, err
+}
+
+func ListCommentsResponse(_ context.Context, r *http.Response) (interface{}, error)  {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var resp responses.ListCommentsResponse
+	err := httpcodec.DecodeResponse(r, &resp)
+	return &resp, err
+}
+
+func ResolveCommentResponse(_ context.Context, r *http.Response) (interface{}, error)  {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var resp responses.ResolveCommentResponse
+	err := httpcodec.DecodeResponse(r, &resp)
+	return &resp, err
+}
+
+// GetNoteStatsRequest reads the note id from the path, and the owner token
+// and the number of days from the query.
+func GetNoteStatsRequest(ctx context.Context, r *http.Request) (interface{}, error)  {
+	var (
+		req requests.GetNoteStatsRequest
+		query = r.URL.Query()
+	)
+
+	id, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if v := query.Get("days"); v != "" {
+		req.Days, err = strconv.Atoi(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req.NoteID = id
+	req.OwnerToken = query.Get("owner_token")
+	return req, nil
+}
+
+func GetNoteStatsResponse(_ context.Context, r *http.Response) (interface{}, error)  {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var resp responses.GetNoteStatsResponse
+	err := httpcodec.DecodeResponse(r, &resp)
+	return &resp, err
+}
+
+// pathID returns the note or collection id encoded in the path.
+func pathID(r *http.Request) (string, error)  {
+	return pathVar(r, "id")
+}
+
+// pathVar returns the id encoded in the path variable with the given name.
+func pathVar(r *http.Request, name string) (string, error)  {
+	bid, ok := mux.Vars(r)[name]
+	if !ok {
+		return "", ErrBadRouting
+	}
+
+	id, err := base64.URLEncoding.DecodeString(bid)
+	if err != nil {
+		return "", err
+	}
+	return string(id), nil
+}
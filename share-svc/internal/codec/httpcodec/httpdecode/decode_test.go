@@ -0,0 +1,180 @@
+package httpdecode
+
+import (
+	"context"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/internal/codec/httpcodec/httpencode"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"github.com/al8n/shareable-notes/share-svc/model/requests"
+	"github.com/al8n/shareable-notes/share-svc/model/responses"
+	"github.com/gorilla/mux"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestWatchNoteResponse(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		events []model.NoteEvent
+	}{
+		{"no event", nil},
+		{"update", []model.NoteEvent{
+			{NoteID: "id", Type: model.NoteEventUpdate, Timestamp: 1},
+		}},
+		{"updates then delete", []model.NoteEvent{
+			{NoteID: "id", Type: model.NoteEventUpdate, Timestamp: 1},
+			{NoteID: "id", Type: model.NoteEventUpdate, Timestamp: 2},
+			{NoteID: "id", Type: model.NoteEventDelete, Timestamp: 3},
+		}},
+		{"privatize", []model.NoteEvent{
+			{NoteID: "id", Type: model.NoteEventPrivate, Timestamp: 4},
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				ctx    = context.Background()
+				events = make(chan model.NoteEvent, len(tc.events))
+				w      = httptest.NewRecorder()
+			)
+			for _, event := range tc.events {
+				events <- event
+			}
+			close(events)
+
+			if err := httpencode.WatchNoteResponse(ctx, w, responses.WatchNoteResponse{Events: events}); err != nil {
+				t.Fatal(err)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+				t.Fatalf("content type %q", ct)
+			}
+
+			response, err := WatchNoteResponse(ctx, w.Result())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []model.NoteEvent
+			for event := range response.(*responses.WatchNoteResponse).Events {
+				got = append(got, event)
+			}
+			if !reflect.DeepEqual(got, tc.events) {
+				t.Fatalf("got %+v, want %+v", got, tc.events)
+			}
+		})
+	}
+}
+
+func TestWatchNoteResponseError(t *testing.T) {
+	var (
+		ctx = context.Background()
+		w   = httptest.NewRecorder()
+	)
+
+	err := httpencode.WatchNoteResponse(ctx, w, responses.WatchNoteResponse{Error: common.ErrorNoteNotFound.Error()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Code == http.StatusOK {
+		t.Fatal("the error is written with 200 OK")
+	}
+
+	if _, err = WatchNoteResponse(ctx, w.Result()); err == nil {
+		t.Fatal("the error response is decoded")
+	}
+}
+
+func TestCommentRequests(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		path   string
+		req    interface{}
+		encode func(context.Context, *http.Request, interface{}) error
+		decode func(context.Context, *http.Request) (interface{}, error)
+	}{
+		{
+			"add", "/note/{id}/comments",
+			requests.AddCommentRequest{NoteID: "id", Author: "alice", Body: "body"},
+			httpencode.AddCommentRequest, AddCommentRequest,
+		},
+		{
+			"add anchored", "/note/{id}/comments",
+			requests.AddCommentRequest{NoteID: "id", Body: "body", StartLine: 2, EndLine: 4},
+			httpencode.AddCommentRequest, AddCommentRequest,
+		},
+		{
+			"list", "/note/{id}/comments",
+			requests.ListCommentsRequest{NoteID: "id"},
+			httpencode.ListCommentsRequest, ListCommentsRequest,
+		},
+		{
+			"list resolved", "/note/{id}/comments",
+			requests.ListCommentsRequest{NoteID: "id", IncludeResolved: true},
+			httpencode.ListCommentsRequest, ListCommentsRequest,
+		},
+		{
+			"resolve", "/note/{id}/comments/{comment}/resolve",
+			requests.ResolveCommentRequest{NoteID: "id", CommentID: "comment", User: "bob"},
+			httpencode.ResolveCommentRequest, ResolveCommentRequest,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := roundTrip(t, tc.path, tc.req, tc.encode, tc.decode)
+			if !reflect.DeepEqual(got, tc.req) {
+				t.Fatalf("got %+v, want %+v", got, tc.req)
+			}
+		})
+	}
+}
+
+// roundTrip encodes request to a request for the path template, then routes
+// it to decode.
+func roundTrip(
+	t *testing.T,
+	path string,
+	request interface{},
+	encode func(context.Context, *http.Request, interface{}) error,
+	decode func(context.Context, *http.Request) (interface{}, error),
+) (got interface{}) {
+	t.Helper()
+
+	r := httptest.NewRequest(http.MethodPost, path, nil)
+	if err := encode(context.Background(), r, request); err != nil {
+		t.Fatal(err)
+	}
+
+	var err error
+	router := mux.NewRouter()
+	router.HandleFunc(path, func(_ http.ResponseWriter, r *http.Request) {
+		got, err = decode(r.Context(), r)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("%s is not routed: %d", r.URL, w.Code)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func TestGetNoteStatsRequest(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		req  requests.GetNoteStatsRequest
+	}{
+		{"default days", requests.GetNoteStatsRequest{NoteID: "id", OwnerToken: "token"}},
+		{"days", requests.GetNoteStatsRequest{NoteID: "id", OwnerToken: "to+k/en=", Days: 7}},
+		{"no token", requests.GetNoteStatsRequest{NoteID: "id"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := roundTrip(t, "/note/{id}/stats", tc.req, httpencode.GetNoteStatsRequest, GetNoteStatsRequest)
+			if !reflect.DeepEqual(got, tc.req) {
+				t.Fatalf("got %+v, want %+v", got, tc.req)
+			}
+		})
+	}
+}
@@ -1,18 +1,29 @@
 package httpencode
 
 import (
-	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"github.com/al8n/shareable-notes/share-svc/internal/codec/httpcodec"
 	"github.com/al8n/shareable-notes/share-svc/internal/utils"
+	"github.com/al8n/shareable-notes/share-svc/model"
 	"github.com/al8n/shareable-notes/share-svc/model/requests"
 	"github.com/al8n/shareable-notes/share-svc/model/responses"
-	"io/ioutil"
+	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// sseKeepAlive is the interval of the comments sent on idle event streams, so
+// proxies do not close them.
+const sseKeepAlive = 15 * time.Second
+
 
 
 func GetNoteRequest(ctx context.Context, req *http.Request, request interface{}) error  {
@@ -20,20 +31,248 @@ func GetNoteRequest(ctx context.Context, req *http.Request, request interface{})
 	noteID := url.QueryEscape(r.NoteID)
 
 	req.URL.Path = "/note/" + noteID
+	if r.Condition.IfNoneMatch != "" {
+		req.Header.Set("If-None-Match", r.Condition.IfNoneMatch)
+	}
+
+	if r.Condition.IfModifiedSince > 0 {
+		req.Header.Set("If-Modified-Since", time.Unix(r.Condition.IfModifiedSince, 0).UTC().Format(http.TimeFormat))
+	}
 	return GenericRequest(ctx, req, request)
 }
 
-// GenericRequest is a transport/http.EncodeRequestFunc that
-// JSON-encodes any request to the request body. Primarily useful in a client.
-func GenericRequest(_ context.Context, r *http.Request, request interface{}) error {
-	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(request); err != nil {
-		return err
+// WatchNoteRequest fills the note id into the {id} placeholder of the
+// configured path.
+func WatchNoteRequest(_ context.Context, req *http.Request, request interface{}) error  {
+	r, ok := request.(requests.WatchNoteRequest)
+	if !ok {
+		return utils.ErrorCodecCasting("WatchNote", utils.Request, utils.HTTP)
+	}
+
+	req.URL.Path = strings.Replace(req.URL.Path, "{id}", base64.URLEncoding.EncodeToString([]byte(r.NoteID)), 1)
+	req.Header.Set("Accept", "text/event-stream")
+	return nil
+}
+
+// EditNoteRequest fills the note id into the {id} placeholder of the
+// configured path, and the user into the query.
+func EditNoteRequest(_ context.Context, req *http.Request, request interface{}) error  {
+	r, ok := request.(requests.EditNoteRequest)
+	if !ok {
+		return utils.ErrorCodecCasting("EditNote", utils.Request, utils.HTTP)
+	}
+
+	req.URL.Path = strings.Replace(req.URL.Path, "{id}", base64.URLEncoding.EncodeToString([]byte(r.NoteID)), 1)
+	if r.User != "" {
+		req.URL.RawQuery = url.Values{"user": {r.User}}.Encode()
+	}
+	return nil
+}
+
+// ForkNoteRequest fills the note id in the path and encodes the owner
+// to the request body.
+func ForkNoteRequest(ctx context.Context, req *http.Request, request interface{}) error  {
+	r, ok := request.(requests.ForkNoteRequest)
+	if !ok {
+		return utils.ErrorCodecCasting("ForkNote", utils.Request, utils.HTTP)
+	}
+
+	req.URL.Path = strings.Replace(req.URL.Path, "{id}", base64.URLEncoding.EncodeToString([]byte(r.NoteID)), 1)
+	return GenericRequest(ctx, req, r)
+}
+
+func ListForksRequest(_ context.Context, req *http.Request, request interface{}) error  {
+	r, ok := request.(requests.ListForksRequest)
+	if !ok {
+		return utils.ErrorCodecCasting("ListForks", utils.Request, utils.HTTP)
+	}
+
+	req.URL.Path = strings.Replace(req.URL.Path, "{id}", base64.URLEncoding.EncodeToString([]byte(r.NoteID)), 1)
+	return nil
+}
+
+// MarkTemplateRequest fills the note id in the path and encodes the
+// request to the request body.
+func MarkTemplateRequest(ctx context.Context, req *http.Request, request interface{}) error  {
+	r, ok := request.(requests.MarkTemplateRequest)
+	if !ok {
+		return utils.ErrorCodecCasting("MarkTemplate", utils.Request, utils.HTTP)
 	}
-	r.Body = ioutil.NopCloser(&buf)
+
+	req.URL.Path = strings.Replace(req.URL.Path, "{id}", base64.URLEncoding.EncodeToString([]byte(r.NoteID)), 1)
+	return GenericRequest(ctx, req, r)
+}
+
+// InstantiateTemplateRequest fills the note id in the path and encodes
+// the variables to the request body.
+func InstantiateTemplateRequest(ctx context.Context, req *http.Request, request interface{}) error  {
+	r, ok := request.(requests.InstantiateTemplateRequest)
+	if !ok {
+		return utils.ErrorCodecCasting("InstantiateTemplate", utils.Request, utils.HTTP)
+	}
+
+	req.URL.Path = strings.Replace(req.URL.Path, "{id}", base64.URLEncoding.EncodeToString([]byte(r.NoteID)), 1)
+	return GenericRequest(ctx, req, r)
+}
+
+// AddCollectionNotesRequest fills the collection id in the path and encodes the
+// note ids to the request body.
+func AddCollectionNotesRequest(ctx context.Context, req *http.Request, request interface{}) error  {
+	r, ok := request.(requests.AddCollectionNotesRequest)
+	if !ok {
+		return utils.ErrorCodecCasting("AddCollectionNotes", utils.Request, utils.HTTP)
+	}
+
+	req.URL.Path = strings.Replace(req.URL.Path, "{id}", base64.URLEncoding.EncodeToString([]byte(r.CollectionID)), 1)
+	return GenericRequest(ctx, req, r)
+}
+
+// RemoveCollectionNotesRequest fills the collection id in the path and encodes the
+// note ids to the request body.
+func RemoveCollectionNotesRequest(ctx context.Context, req *http.Request, request interface{}) error  {
+	r, ok := request.(requests.RemoveCollectionNotesRequest)
+	if !ok {
+		return utils.ErrorCodecCasting("RemoveCollectionNotes", utils.Request, utils.HTTP)
+	}
+
+	req.URL.Path = strings.Replace(req.URL.Path, "{id}", base64.URLEncoding.EncodeToString([]byte(r.CollectionID)), 1)
+	return GenericRequest(ctx, req, r)
+}
+
+// ReorderCollectionRequest fills the collection id in the path and encodes the
+// note ids to the request body.
+func ReorderCollectionRequest(ctx context.Context, req *http.Request, request interface{}) error  {
+	r, ok := request.(requests.ReorderCollectionRequest)
+	if !ok {
+		return utils.ErrorCodecCasting("ReorderCollection", utils.Request, utils.HTTP)
+	}
+
+	req.URL.Path = strings.Replace(req.URL.Path, "{id}", base64.URLEncoding.EncodeToString([]byte(r.CollectionID)), 1)
+	return GenericRequest(ctx, req, r)
+}
+
+func GetCollectionRequest(_ context.Context, req *http.Request, request interface{}) error  {
+	r, ok := request.(requests.GetCollectionRequest)
+	if !ok {
+		return utils.ErrorCodecCasting("GetCollection", utils.Request, utils.HTTP)
+	}
+
+	req.URL.Path = strings.Replace(req.URL.Path, "{id}", base64.URLEncoding.EncodeToString([]byte(r.CollectionID)), 1)
+	return nil
+}
+
+// UploadAttachmentRequest fills the note id into the {id} placeholder of the
+// configured path, and streams the content as the "file" part of a multipart form.
+func UploadAttachmentRequest(_ context.Context, req *http.Request, request interface{}) error  {
+	r, ok := request.(requests.UploadAttachmentRequest)
+	if !ok {
+		return utils.ErrorCodecCasting("UploadAttachment", utils.Request, utils.HTTP)
+	}
+
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	go func() {
+		part, err := mw.CreateFormFile("file", r.Name)
+		if err == nil {
+			_, err = io.Copy(part, r.Content)
+		}
+		if err == nil {
+			err = mw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	req.URL.Path = strings.Replace(req.URL.Path, "{id}", base64.URLEncoding.EncodeToString([]byte(r.NoteID)), 1)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Body = pr
 	return nil
 }
 
+// DownloadAttachmentRequest fills the note and attachment ids into the {id}
+// and {attachment} placeholders of the configured path.
+func DownloadAttachmentRequest(_ context.Context, req *http.Request, request interface{}) error  {
+	r, ok := request.(requests.DownloadAttachmentRequest)
+	if !ok {
+		return utils.ErrorCodecCasting("DownloadAttachment", utils.Request, utils.HTTP)
+	}
+
+	req.URL.Path = strings.NewReplacer(
+		"{id}", base64.URLEncoding.EncodeToString([]byte(r.NoteID)),
+		"{attachment}", base64.URLEncoding.EncodeToString([]byte(r.AttachmentID)),
+	).Replace(req.URL.Path)
+	return nil
+}
+
+// AddCommentRequest fills the note id in the path and encodes the
+// comment to the request body.
+func AddCommentRequest(ctx context.Context, req *http.Request, request interface{}) error  {
+	r, ok := request.(requests.AddCommentRequest)
+	if !ok {
+		return utils.ErrorCodecCasting("AddComment", utils.Request, utils.HTTP)
+	}
+
+	req.URL.Path = strings.Replace(req.URL.Path, "{id}", base64.URLEncoding.EncodeToString([]byte(r.NoteID)), 1)
+	return GenericRequest(ctx, req, r)
+}
+
+func ListCommentsRequest(_ context.Context, req *http.Request, request interface{}) error  {
+	r, ok := request.(requests.ListCommentsRequest)
+	if !ok {
+		return utils.ErrorCodecCasting("ListComments", utils.Request, utils.HTTP)
+	}
+
+	req.URL.Path = strings.Replace(req.URL.Path, "{id}", base64.URLEncoding.EncodeToString([]byte(r.NoteID)), 1)
+	if r.IncludeResolved {
+		req.URL.RawQuery = url.Values{"include_resolved": {"true"}}.Encode()
+	}
+	return nil
+}
+
+// ResolveCommentRequest fills the note and comment ids into the {id} and
+// {comment} placeholders of the configured path, and encodes the user
+// to the request body.
+func ResolveCommentRequest(ctx context.Context, req *http.Request, request interface{}) error  {
+	r, ok := request.(requests.ResolveCommentRequest)
+	if !ok {
+		return utils.ErrorCodecCasting("ResolveComment", utils.Request, utils.HTTP)
+	}
+
+	req.URL.Path = strings.NewReplacer(
+		"{id}", base64.URLEncoding.EncodeToString([]byte(r.NoteID)),
+		"{comment}", base64.URLEncoding.EncodeToString([]byte(r.CommentID)),
+	).Replace(req.URL.Path)
+	return GenericRequest(ctx, req, r)
+}
+
+// GetNoteStatsRequest fills the note id in the path, and the owner token and
+// the number of days in the query.
+func GetNoteStatsRequest(_ context.Context, req *http.Request, request interface{}) error  {
+	r, ok := request.(requests.GetNoteStatsRequest)
+	if !ok {
+		return utils.ErrorCodecCasting("GetNoteStats", utils.Request, utils.HTTP)
+	}
+
+	var query = url.Values{}
+	if r.OwnerToken != "" {
+		query.Set("owner_token", r.OwnerToken)
+	}
+	if r.Days > 0 {
+		query.Set("days", strconv.Itoa(r.Days))
+	}
+
+	req.URL.Path = strings.Replace(req.URL.Path, "{id}", base64.URLEncoding.EncodeToString([]byte(r.NoteID)), 1)
+	req.URL.RawQuery = query.Encode()
+	return nil
+}
+
+// GenericRequest is a transport/http.EncodeRequestFunc that encodes any
+// request to the request body, with the codec carried by ctx or JSON.
+// Primarily useful in a client.
+func GenericRequest(ctx context.Context, r *http.Request, request interface{}) error {
+	return httpcodec.EncodeRequest(ctx, r, request)
+}
+
 func ShareNoteResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) (err error)  {
 
 	response, ok := resp.(responses.ShareNoteResponse)
@@ -57,9 +296,7 @@ func ShareNoteResponse(ctx context.Context, w http.ResponseWriter, resp interfac
 		return nil
 	}
 
-	w.WriteHeader(http.StatusOK)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	return json.NewEncoder(w).Encode(resp)
+	return httpcodec.EncodeResponse(ctx, w, resp)
 }
 
 func PrivateNoteResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) error  {
@@ -83,9 +320,7 @@ func PrivateNoteResponse(ctx context.Context, w http.ResponseWriter, resp interf
 		return nil
 	}
 
-	w.WriteHeader(http.StatusOK)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	return json.NewEncoder(w).Encode(resp)
+	return httpcodec.EncodeResponse(ctx, w, resp)
 }
 
 func GetNoteResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) error  {
@@ -110,7 +345,532 @@ func GetNoteResponse(ctx context.Context, w http.ResponseWriter, resp interface{
 		return nil
 	}
 
+	// the clients revalidate the note on every read
+	w.Header().Set("Cache-Control", "no-cache")
+	if response.ETag != "" {
+		w.Header().Set("ETag", response.ETag)
+	}
+
+	if response.LastModified > 0 {
+		w.Header().Set("Last-Modified", time.Unix(response.LastModified, 0).UTC().Format(http.TimeFormat))
+	}
+
+	if response.NotModified {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	return httpcodec.EncodeResponse(ctx, w, resp)
+}
+
+// WatchNoteResponse writes the note events as server-sent events until the
+// events channel is closed.
+func WatchNoteResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) error  {
+
+	response, ok := resp.(responses.WatchNoteResponse)
+	if !ok {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.ErrorCodecCasting(
+				"WatchNote",
+				utils.Response,
+				utils.HTTP),
+			w)
+		return nil
+	}
+
+	if response.Error != "" {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.Str2Err(response.Error),
+			w)
+		return nil
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		flusher = nopFlusher{}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(sseKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-response.Events:
+			if !ok {
+				return nil
+			}
+
+			data, err := json.Marshal(event)
+			if err != nil {
+				return err
+			}
+
+			if _, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				return err
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+type nopFlusher struct{}
+
+func (nopFlusher) Flush() {}
+
+// EditNoteResponse returns the session to relay over the WebSocket
+// connection, or writes the error when the note cannot be joined.
+func EditNoteResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) model.EditSession  {
+	response, ok := resp.(responses.EditNoteResponse)
+	if !ok {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.ErrorCodecCasting(
+				"EditNote",
+				utils.Response,
+				utils.HTTP),
+			w)
+		return nil
+	}
+
+	if response.Error != "" {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.Str2Err(response.Error),
+			w)
+		return nil
+	}
+	return response.Session
+}
+
+func SyncNotesResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) error  {
+
+	response, ok := resp.(responses.SyncNotesResponse)
+	if !ok {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.ErrorCodecCasting(
+				"SyncNotes",
+				utils.Response,
+				utils.HTTP),
+			w)
+		return nil
+	}
+
+	if response.Error != "" {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.Str2Err(response.Error),
+			w)
+		return nil
+	}
+
+	return httpcodec.EncodeResponse(ctx, w, resp)
+}
+
+func ForkNoteResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) error  {
+
+	response, ok := resp.(responses.ForkNoteResponse)
+	if !ok {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.ErrorCodecCasting(
+				"ForkNote",
+				utils.Response,
+				utils.HTTP),
+			w)
+		return nil
+	}
+
+	if response.Error != "" {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.Str2Err(response.Error),
+			w)
+		return nil
+	}
+
+	return httpcodec.EncodeResponse(ctx, w, resp)
+}
+
+func ListForksResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) error  {
+
+	response, ok := resp.(responses.ListForksResponse)
+	if !ok {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.ErrorCodecCasting(
+				"ListForks",
+				utils.Response,
+				utils.HTTP),
+			w)
+		return nil
+	}
+
+	if response.Error != "" {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.Str2Err(response.Error),
+			w)
+		return nil
+	}
+
+	return httpcodec.EncodeResponse(ctx, w, resp)
+}
+
+func MarkTemplateResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) error  {
+
+	response, ok := resp.(responses.MarkTemplateResponse)
+	if !ok {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.ErrorCodecCasting(
+				"MarkTemplate",
+				utils.Response,
+				utils.HTTP),
+			w)
+		return nil
+	}
+
+	if response.Error != "" {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.Str2Err(response.Error),
+			w)
+		return nil
+	}
+
+	return httpcodec.EncodeResponse(ctx, w, resp)
+}
+
+func InstantiateTemplateResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) error  {
+
+	response, ok := resp.(responses.InstantiateTemplateResponse)
+	if !ok {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.ErrorCodecCasting(
+				"InstantiateTemplate",
+				utils.Response,
+				utils.HTTP),
+			w)
+		return nil
+	}
+
+	if response.Error != "" {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.Str2Err(response.Error),
+			w)
+		return nil
+	}
+
+	return httpcodec.EncodeResponse(ctx, w, resp)
+}
+
+func CreateCollectionResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) error  {
+
+	response, ok := resp.(responses.CreateCollectionResponse)
+	if !ok {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.ErrorCodecCasting(
+				"CreateCollection",
+				utils.Response,
+				utils.HTTP),
+			w)
+		return nil
+	}
+
+	if response.Error != "" {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.Str2Err(response.Error),
+			w)
+		return nil
+	}
+
+	return httpcodec.EncodeResponse(ctx, w, resp)
+}
+
+func AddCollectionNotesResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) error  {
+
+	response, ok := resp.(responses.AddCollectionNotesResponse)
+	if !ok {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.ErrorCodecCasting(
+				"AddCollectionNotes",
+				utils.Response,
+				utils.HTTP),
+			w)
+		return nil
+	}
+
+	if response.Error != "" {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.Str2Err(response.Error),
+			w)
+		return nil
+	}
+
+	return httpcodec.EncodeResponse(ctx, w, resp)
+}
+
+func RemoveCollectionNotesResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) error  {
+
+	response, ok := resp.(responses.RemoveCollectionNotesResponse)
+	if !ok {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.ErrorCodecCasting(
+				"RemoveCollectionNotes",
+				utils.Response,
+				utils.HTTP),
+			w)
+		return nil
+	}
+
+	if response.Error != "" {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.Str2Err(response.Error),
+			w)
+		return nil
+	}
+
+	return httpcodec.EncodeResponse(ctx, w, resp)
+}
+
+func ReorderCollectionResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) error  {
+
+	response, ok := resp.(responses.ReorderCollectionResponse)
+	if !ok {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.ErrorCodecCasting(
+				"ReorderCollection",
+				utils.Response,
+				utils.HTTP),
+			w)
+		return nil
+	}
+
+	if response.Error != "" {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.Str2Err(response.Error),
+			w)
+		return nil
+	}
+
+	return httpcodec.EncodeResponse(ctx, w, resp)
+}
+
+func GetCollectionResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) error  {
+
+	response, ok := resp.(responses.GetCollectionResponse)
+	if !ok {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.ErrorCodecCasting(
+				"GetCollection",
+				utils.Response,
+				utils.HTTP),
+			w)
+		return nil
+	}
+
+	if response.Error != "" {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.Str2Err(response.Error),
+			w)
+		return nil
+	}
+
+	return httpcodec.EncodeResponse(ctx, w, resp)
+}
+
+func UploadAttachmentResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) error  {
+
+	response, ok := resp.(responses.UploadAttachmentResponse)
+	if !ok {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.ErrorCodecCasting(
+				"UploadAttachment",
+				utils.Response,
+				utils.HTTP),
+			w)
+		return nil
+	}
+
+	if response.Error != "" {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.Str2Err(response.Error),
+			w)
+		return nil
+	}
+
+	return httpcodec.EncodeResponse(ctx, w, resp)
+}
+
+// DownloadAttachmentResponse writes the attachment content and closes it.
+// Only images and PDFs are displayed inline, the other attachments are
+// downloaded, and browsers must not sniff a different content type.
+func DownloadAttachmentResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) error  {
+
+	response, ok := resp.(responses.DownloadAttachmentResponse)
+	if !ok {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.ErrorCodecCasting(
+				"DownloadAttachment",
+				utils.Response,
+				utils.HTTP),
+			w)
+		return nil
+	}
+
+	if response.Error != "" {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.Str2Err(response.Error),
+			w)
+		return nil
+	}
+	defer response.Content.Close()
+
+	var (
+		attachment = response.Attachment
+		disposition = "attachment"
+	)
+
+	if strings.HasPrefix(attachment.ContentType, "image/") || strings.HasPrefix(attachment.ContentType, "application/pdf") {
+		disposition = "inline"
+	}
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Name}))
+	w.Header().Set("Last-Modified", time.Unix(attachment.CreatedAt, 0).UTC().Format(http.TimeFormat))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
 	w.WriteHeader(http.StatusOK)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	return json.NewEncoder(w).Encode(resp)
-}
\ No newline at end of file
+
+	_, err := io.Copy(w, response.Content)
+	return err
+}
+
+func AddCommentResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) error  {
+
+	response, ok := resp.(responses.AddCommentResponse)
+	if !ok {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.ErrorCodecCasting(
+				"AddComment",
+				utils.Response,
+				utils.HTTP),
+			w)
+		return nil
+	}
+
+	if response.Error != "" {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.Str2Err(response.Error),
+			w)
+		return nil
+	}
+
+	return httpcodec.EncodeResponse(ctx, w, resp)
+}
+
+func ListCommentsResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) error  {
+
+	response, ok := resp.(responses.ListCommentsResponse)
+	if !ok {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.ErrorCodecCasting(
+				"ListComments",
+				utils.Response,
+				utils.HTTP),
+			w)
+		return nil
+	}
+
+	if response.Error != "" {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.Str2Err(response.Error),
+			w)
+		return nil
+	}
+
+	return httpcodec.EncodeResponse(ctx, w, resp)
+}
+
+func ResolveCommentResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) error  {
+
+	response, ok := resp.(responses.ResolveCommentResponse)
+	if !ok {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.ErrorCodecCasting(
+				"ResolveComment",
+				utils.Response,
+				utils.HTTP),
+			w)
+		return nil
+	}
+
+	if response.Error != "" {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.Str2Err(response.Error),
+			w)
+		return nil
+	}
+
+	return httpcodec.EncodeResponse(ctx, w, resp)
+}
+
+func GetNoteStatsResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) error  {
+
+	response, ok := resp.(responses.GetNoteStatsResponse)
+	if !ok {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.ErrorCodecCasting(
+				"GetNoteStats",
+				utils.Response,
+				utils.HTTP),
+			w)
+		return nil
+	}
+
+	if response.Error != "" {
+		httpcodec.ErrorEncoder(
+			ctx,
+			utils.Str2Err(response.Error),
+			w)
+		return nil
+	}
+
+	return httpcodec.EncodeResponse(ctx, w, resp)
+}
@@ -0,0 +1,282 @@
+package httpcodec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/golang/protobuf/proto"
+	"github.com/vmihailenco/msgpack"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	ErrNotAcceptable = errors.New("none of the accepted media types is supported")
+)
+
+// Codec marshals the bodies of one media type.
+type Codec interface {
+	// ContentType is the Content-Type of the bodies written.
+	ContentType() string
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// The codecs of the HTTP routes, JSON is the default one.
+var (
+	JSON Codec = jsonCodec{}
+	Protobuf Codec = protobufCodec{}
+	MessagePack Codec = msgpackCodec{}
+)
+
+// codecs maps the media types accepted to their codec.
+var codecs = map[string]Codec{
+	"application/json": JSON,
+	"application/x-protobuf": Protobuf,
+	"application/protobuf": Protobuf,
+	"application/msgpack": MessagePack,
+	"application/x-msgpack": MessagePack,
+}
+
+type codecKey struct{}
+
+type notAcceptableKey struct{}
+
+// NewContext returns a context carrying the codec of the responses, or of the
+// requests on the client side.
+func NewContext(ctx context.Context, codec Codec) context.Context {
+	return context.WithValue(ctx, codecKey{}, codec)
+}
+
+// FromContext returns the codec carried by ctx, JSON when there is none.
+func FromContext(ctx context.Context) Codec {
+	if codec, ok := ctx.Value(codecKey{}).(Codec); ok {
+		return codec
+	}
+	return JSON
+}
+
+// NegotiateToContext returns an http RequestFunc storing the codec of the
+// response in the context, as chosen by the Accept header of the request.
+// The requests accepting none of the codecs are answered in JSON, or refused
+// by the decoders wrapped by Negotiated.
+func NegotiateToContext() func(ctx context.Context, r *http.Request) context.Context {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		codec, err := Negotiate(r.Header.Get("Accept"))
+		if err != nil {
+			ctx = context.WithValue(ctx, notAcceptableKey{}, err)
+		}
+		return NewContext(ctx, codec)
+	}
+}
+
+// Negotiated returns a request decoder failing with ErrNotAcceptable, before
+// decoding the request with dec, when the Accept header of the request
+// accepts none of the codecs. The routes whose responses are not written
+// with the codecs do not wrap their decoder.
+func Negotiated(dec func(context.Context, *http.Request) (interface{}, error)) func(context.Context, *http.Request) (interface{}, error) {
+	return func(ctx context.Context, r *http.Request) (interface{}, error) {
+		if err, ok := ctx.Value(notAcceptableKey{}).(error); ok {
+			return nil, err
+		}
+		return dec(ctx, r)
+	}
+}
+
+// Negotiate returns the codec of the media type preferred by accept, JSON
+// when accept is empty or accepts any media type. A media type is preferred
+// to a wildcard of the same quality. It fails with ErrNotAcceptable, along
+// with JSON, when accept lists media types and none is supported.
+func Negotiate(accept string) (Codec, error) {
+	var (
+		codec Codec
+		best = 0.0
+		wildcard bool
+	)
+
+	if strings.TrimSpace(accept) == "" {
+		return JSON, nil
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+
+		c, ok := codecs[mediaType]
+		wildcardType := mediaType == "*/*" || mediaType == "application/*"
+		if wildcardType {
+			c, ok = JSON, true
+		}
+
+		if !ok || q < best || q <= 0 || (q == best && (wildcardType || !wildcard)) {
+			continue
+		}
+		codec, best, wildcard = c, q, wildcardType
+	}
+
+	if codec == nil {
+		return JSON, ErrNotAcceptable
+	}
+	return codec, nil
+}
+
+// ContentTypeCodec returns the codec of a Content-Type header, JSON when it
+// is empty.
+func ContentTypeCodec(contentType string) (Codec, error) {
+	if contentType == "" {
+		return JSON, nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, ErrUnsupportedMediaType
+	}
+
+	codec, ok := codecs[mediaType]
+	if !ok {
+		return nil, ErrUnsupportedMediaType
+	}
+	return codec, nil
+}
+
+// DecodeRequest unmarshals the body of r into v with the codec of its
+// Content-Type, io.EOF is returned when the body is empty.
+func DecodeRequest(r *http.Request, v interface{}) error {
+	codec, err := ContentTypeCodec(r.Header.Get("Content-Type"))
+	if err != nil {
+		return err
+	}
+	return decode(codec, r.Body, v)
+}
+
+// DecodeResponse unmarshals the body of r into v with the codec of its
+// Content-Type. Primarily useful in a client.
+func DecodeResponse(r *http.Response, v interface{}) error {
+	codec, err := ContentTypeCodec(r.Header.Get("Content-Type"))
+	if err != nil {
+		return err
+	}
+	return decode(codec, r.Body, v)
+}
+
+// EncodeResponse writes v with the codec negotiated for the request.
+func EncodeResponse(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+	codec := FromContext(ctx)
+
+	data, err := codec.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", codec.ContentType())
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(data)
+	return err
+}
+
+// EncodeRequest writes v to the body of r with the codec carried by ctx, and
+// accepts the responses of the same media type.
+func EncodeRequest(ctx context.Context, r *http.Request, v interface{}) error {
+	codec := FromContext(ctx)
+
+	data, err := codec.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	r.Header.Set("Content-Type", codec.ContentType())
+	r.Header.Set("Accept", codec.ContentType())
+	r.Body = ioutil.NopCloser(bytes.NewReader(data))
+	r.ContentLength = int64(len(data))
+	return nil
+}
+
+func decode(codec Codec, body io.Reader, v interface{}) error {
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	if len(data) == 0 {
+		return io.EOF
+	}
+	return codec.Unmarshal(data, v)
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) ContentType() string {
+	return "application/json; charset=utf-8"
+}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// msgpackCodec names the fields as the JSON bodies do.
+type msgpackCodec struct{}
+
+func (msgpackCodec) ContentType() string {
+	return "application/msgpack"
+}
+
+func (msgpackCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := msgpack.NewEncoder(&buf).UseJSONTag(true).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (msgpackCodec) Unmarshal(data []byte, v interface{}) error {
+	return msgpack.NewDecoder(bytes.NewReader(data)).UseJSONTag(true).Decode(v)
+}
+
+// protobufCodec converts the models to their pb messages, see proto.go.
+type protobufCodec struct{}
+
+func (protobufCodec) ContentType() string {
+	return "application/x-protobuf"
+}
+
+func (protobufCodec) Marshal(v interface{}) ([]byte, error) {
+	if msg, ok := v.(proto.Message); ok {
+		return proto.Marshal(msg)
+	}
+
+	msg, err := toMessage(v)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(msg)
+}
+
+func (protobufCodec) Unmarshal(data []byte, v interface{}) error {
+	if msg, ok := v.(proto.Message); ok {
+		return proto.Unmarshal(data, msg)
+	}
+	return fromMessage(data, v)
+}
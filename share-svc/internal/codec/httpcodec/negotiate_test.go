@@ -0,0 +1,135 @@
+package httpcodec
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	for _, tc := range []struct {
+		accept string
+		want   Codec
+		err    error
+	}{
+		{"", JSON, nil},
+		{"application/json", JSON, nil},
+		{"application/x-protobuf", Protobuf, nil},
+		{"application/protobuf", Protobuf, nil},
+		{"application/msgpack", MessagePack, nil},
+		{"application/x-msgpack; charset=binary", MessagePack, nil},
+		{"application/msgpack, application/json", MessagePack, nil},
+		{"application/json;q=0.5, application/x-protobuf", Protobuf, nil},
+		{"application/x-protobuf;q=0.2, application/msgpack;q=0.9", MessagePack, nil},
+		{"application/msgpack;q=0, application/json;q=0.1", JSON, nil},
+		{"application/msgpack;q=abc, application/json;q=0.1", JSON, nil},
+		{"text/html, application/x-protobuf;q=0.1", Protobuf, nil},
+		{"*/*", JSON, nil},
+		{"application/*;q=0.5", JSON, nil},
+		{"*/*, application/msgpack", MessagePack, nil},
+		{"*/*;q=0.9, application/msgpack;q=0.8", JSON, nil},
+		{"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", JSON, nil},
+		{"text/html", JSON, ErrNotAcceptable},
+		{"application/msgpack;q=0", JSON, ErrNotAcceptable},
+		{"*/*;q=0", JSON, ErrNotAcceptable},
+	} {
+		t.Run(tc.accept, func(t *testing.T) {
+			if got, err := Negotiate(tc.accept); got != tc.want || err != tc.err {
+				t.Fatalf("got %v %v, want %v %v", got.ContentType(), err, tc.want.ContentType(), tc.err)
+			}
+		})
+	}
+}
+
+func TestContentTypeCodec(t *testing.T) {
+	for _, tc := range []struct {
+		contentType string
+		want        Codec
+		err         error
+	}{
+		{"", JSON, nil},
+		{"application/json; charset=utf-8", JSON, nil},
+		{"application/x-protobuf", Protobuf, nil},
+		{"application/x-msgpack", MessagePack, nil},
+		{"text/plain", nil, ErrUnsupportedMediaType},
+		{"application/", nil, ErrUnsupportedMediaType},
+	} {
+		t.Run(tc.contentType, func(t *testing.T) {
+			if got, err := ContentTypeCodec(tc.contentType); got != tc.want || err != tc.err {
+				t.Fatalf("got %v %v, want %v %v", got, err, tc.want, tc.err)
+			}
+		})
+	}
+}
+
+func TestNegotiated(t *testing.T) {
+	var decode = Negotiated(func(_ context.Context, r *http.Request) (interface{}, error) {
+		var v map[string]string
+		return v, DecodeRequest(r, &v)
+	})
+
+	for _, tc := range []struct {
+		name               string
+		accept             string
+		contentType        string
+		code               int
+		contentTypeWritten string
+	}{
+		{
+			name:               "not acceptable",
+			accept:             "text/html",
+			contentType:        "application/json",
+			code:               http.StatusNotAcceptable,
+			contentTypeWritten: JSON.ContentType(),
+		},
+		{
+			name:               "unsupported media type",
+			accept:             "application/x-protobuf",
+			contentType:        "text/plain",
+			code:               http.StatusUnsupportedMediaType,
+			contentTypeWritten: Protobuf.ContentType(),
+		},
+		{
+			name:               "not acceptable first",
+			accept:             "text/html",
+			contentType:        "text/plain",
+			code:               http.StatusNotAcceptable,
+			contentTypeWritten: JSON.ContentType(),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			r.Header.Set("Accept", tc.accept)
+			r.Header.Set("Content-Type", tc.contentType)
+
+			ctx := NegotiateToContext()(context.Background(), r)
+			_, err := decode(ctx, r)
+			if err == nil {
+				t.Fatal("request decoded")
+			}
+
+			w := httptest.NewRecorder()
+			ErrorEncoder(ctx, err, w)
+			if w.Code != tc.code || w.Header().Get("Content-Type") != tc.contentTypeWritten {
+				t.Fatalf("got %d %q", w.Code, w.Header().Get("Content-Type"))
+			}
+		})
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.Header.Set("Accept", "application/msgpack")
+	r.Header.Set("Content-Type", "application/json")
+	r.Body = http.NoBody
+
+	ctx := NegotiateToContext()(context.Background(), r)
+	if FromContext(ctx) != MessagePack {
+		t.Fatalf("negotiated %q", FromContext(ctx).ContentType())
+	}
+
+	// the acceptable requests are decoded
+	if _, err := decode(ctx, r); errors.Is(err, ErrNotAcceptable) {
+		t.Fatalf("got %v", err)
+	}
+}
@@ -0,0 +1,243 @@
+package httpcodec
+
+import (
+	"context"
+	"fmt"
+	"github.com/al8n/shareable-notes/share-svc/internal/codec/grpccodec/grpcdecode"
+	"github.com/al8n/shareable-notes/share-svc/internal/codec/grpccodec/grpcencode"
+	"github.com/al8n/shareable-notes/share-svc/model/requests"
+	"github.com/al8n/shareable-notes/share-svc/model/responses"
+	"github.com/al8n/shareable-notes/share-svc/pb"
+	"github.com/golang/protobuf/proto"
+	"reflect"
+)
+
+// protoMapping converts a model to its pb message and back, with the
+// functions of the gRPC codecs.
+type protoMapping struct {
+	message func() proto.Message
+	encode func(context.Context, interface{}) (interface{}, error)
+	decode func(context.Context, interface{}) (interface{}, error)
+}
+
+// protoMappings holds the models having a body on the HTTP routes.
+var protoMappings = map[reflect.Type]protoMapping{
+	reflect.TypeOf(requests.ShareNoteRequest{}): {
+		message: func() proto.Message { return &pb.ShareNoteRequest{} },
+		encode: grpcencode.ShareNoteRequest,
+		decode: grpcdecode.ShareNoteRequest,
+	},
+	reflect.TypeOf(requests.PrivateNoteRequest{}): {
+		message: func() proto.Message { return &pb.PrivateNoteRequest{} },
+		encode: grpcencode.PrivateNoteRequest,
+		decode: grpcdecode.PrivateNoteRequest,
+	},
+	reflect.TypeOf(requests.GetNoteRequest{}): {
+		message: func() proto.Message { return &pb.GetNoteRequest{} },
+		encode: grpcencode.GetNoteRequest,
+		decode: grpcdecode.GetNoteRequest,
+	},
+	reflect.TypeOf(requests.SyncNotesRequest{}): {
+		message: func() proto.Message { return &pb.SyncNotesRequest{} },
+		encode: grpcencode.SyncNotesRequest,
+		decode: grpcdecode.SyncNotesRequest,
+	},
+	reflect.TypeOf(requests.ForkNoteRequest{}): {
+		message: func() proto.Message { return &pb.ForkNoteRequest{} },
+		encode: grpcencode.ForkNoteRequest,
+		decode: grpcdecode.ForkNoteRequest,
+	},
+	reflect.TypeOf(requests.ListForksRequest{}): {
+		message: func() proto.Message { return &pb.ListForksRequest{} },
+		encode: grpcencode.ListForksRequest,
+		decode: grpcdecode.ListForksRequest,
+	},
+	reflect.TypeOf(requests.MarkTemplateRequest{}): {
+		message: func() proto.Message { return &pb.MarkTemplateRequest{} },
+		encode: grpcencode.MarkTemplateRequest,
+		decode: grpcdecode.MarkTemplateRequest,
+	},
+	reflect.TypeOf(requests.InstantiateTemplateRequest{}): {
+		message: func() proto.Message { return &pb.InstantiateTemplateRequest{} },
+		encode: grpcencode.InstantiateTemplateRequest,
+		decode: grpcdecode.InstantiateTemplateRequest,
+	},
+	reflect.TypeOf(requests.CreateCollectionRequest{}): {
+		message: func() proto.Message { return &pb.CreateCollectionRequest{} },
+		encode: grpcencode.CreateCollectionRequest,
+		decode: grpcdecode.CreateCollectionRequest,
+	},
+	reflect.TypeOf(requests.AddCollectionNotesRequest{}): {
+		message: func() proto.Message { return &pb.AddCollectionNotesRequest{} },
+		encode: grpcencode.AddCollectionNotesRequest,
+		decode: grpcdecode.AddCollectionNotesRequest,
+	},
+	reflect.TypeOf(requests.RemoveCollectionNotesRequest{}): {
+		message: func() proto.Message { return &pb.RemoveCollectionNotesRequest{} },
+		encode: grpcencode.RemoveCollectionNotesRequest,
+		decode: grpcdecode.RemoveCollectionNotesRequest,
+	},
+	reflect.TypeOf(requests.ReorderCollectionRequest{}): {
+		message: func() proto.Message { return &pb.ReorderCollectionRequest{} },
+		encode: grpcencode.ReorderCollectionRequest,
+		decode: grpcdecode.ReorderCollectionRequest,
+	},
+	reflect.TypeOf(requests.GetCollectionRequest{}): {
+		message: func() proto.Message { return &pb.GetCollectionRequest{} },
+		encode: grpcencode.GetCollectionRequest,
+		decode: grpcdecode.GetCollectionRequest,
+	},
+	reflect.TypeOf(requests.AddCommentRequest{}): {
+		message: func() proto.Message { return &pb.AddCommentRequest{} },
+		encode: grpcencode.AddCommentRequest,
+		decode: grpcdecode.AddCommentRequest,
+	},
+	reflect.TypeOf(requests.ListCommentsRequest{}): {
+		message: func() proto.Message { return &pb.ListCommentsRequest{} },
+		encode: grpcencode.ListCommentsRequest,
+		decode: grpcdecode.ListCommentsRequest,
+	},
+	reflect.TypeOf(requests.ResolveCommentRequest{}): {
+		message: func() proto.Message { return &pb.ResolveCommentRequest{} },
+		encode: grpcencode.ResolveCommentRequest,
+		decode: grpcdecode.ResolveCommentRequest,
+	},
+	reflect.TypeOf(requests.GetNoteStatsRequest{}): {
+		message: func() proto.Message { return &pb.GetNoteStatsRequest{} },
+		encode: grpcencode.GetNoteStatsRequest,
+		decode: grpcdecode.GetNoteStatsRequest,
+	},
+	reflect.TypeOf(responses.ShareNoteResponse{}): {
+		message: func() proto.Message { return &pb.ShareNoteResponse{} },
+		encode: grpcencode.ShareNoteResponse,
+		decode: grpcdecode.ShareNoteResponse,
+	},
+	reflect.TypeOf(responses.PrivateNoteResponse{}): {
+		message: func() proto.Message { return &pb.PrivateNoteResponse{} },
+		encode: grpcencode.PrivateNoteResponse,
+		decode: grpcdecode.PrivateNoteResponse,
+	},
+	reflect.TypeOf(responses.GetNoteResponse{}): {
+		message: func() proto.Message { return &pb.GetNoteResponse{} },
+		encode: grpcencode.GetNoteResponse,
+		decode: grpcdecode.GetNoteResponse,
+	},
+	reflect.TypeOf(responses.SyncNotesResponse{}): {
+		message: func() proto.Message { return &pb.SyncNotesResponse{} },
+		encode: grpcencode.SyncNotesResponse,
+		decode: grpcdecode.SyncNotesResponse,
+	},
+	reflect.TypeOf(responses.ForkNoteResponse{}): {
+		message: func() proto.Message { return &pb.ForkNoteResponse{} },
+		encode: grpcencode.ForkNoteResponse,
+		decode: grpcdecode.ForkNoteResponse,
+	},
+	reflect.TypeOf(responses.ListForksResponse{}): {
+		message: func() proto.Message { return &pb.ListForksResponse{} },
+		encode: grpcencode.ListForksResponse,
+		decode: grpcdecode.ListForksResponse,
+	},
+	reflect.TypeOf(responses.MarkTemplateResponse{}): {
+		message: func() proto.Message { return &pb.MarkTemplateResponse{} },
+		encode: grpcencode.MarkTemplateResponse,
+		decode: grpcdecode.MarkTemplateResponse,
+	},
+	reflect.TypeOf(responses.InstantiateTemplateResponse{}): {
+		message: func() proto.Message { return &pb.InstantiateTemplateResponse{} },
+		encode: grpcencode.InstantiateTemplateResponse,
+		decode: grpcdecode.InstantiateTemplateResponse,
+	},
+	reflect.TypeOf(responses.CreateCollectionResponse{}): {
+		message: func() proto.Message { return &pb.CreateCollectionResponse{} },
+		encode: grpcencode.CreateCollectionResponse,
+		decode: grpcdecode.CreateCollectionResponse,
+	},
+	reflect.TypeOf(responses.AddCollectionNotesResponse{}): {
+		message: func() proto.Message { return &pb.AddCollectionNotesResponse{} },
+		encode: grpcencode.AddCollectionNotesResponse,
+		decode: grpcdecode.AddCollectionNotesResponse,
+	},
+	reflect.TypeOf(responses.RemoveCollectionNotesResponse{}): {
+		message: func() proto.Message { return &pb.RemoveCollectionNotesResponse{} },
+		encode: grpcencode.RemoveCollectionNotesResponse,
+		decode: grpcdecode.RemoveCollectionNotesResponse,
+	},
+	reflect.TypeOf(responses.ReorderCollectionResponse{}): {
+		message: func() proto.Message { return &pb.ReorderCollectionResponse{} },
+		encode: grpcencode.ReorderCollectionResponse,
+		decode: grpcdecode.ReorderCollectionResponse,
+	},
+	reflect.TypeOf(responses.GetCollectionResponse{}): {
+		message: func() proto.Message { return &pb.GetCollectionResponse{} },
+		encode: grpcencode.GetCollectionResponse,
+		decode: grpcdecode.GetCollectionResponse,
+	},
+	reflect.TypeOf(responses.AddCommentResponse{}): {
+		message: func() proto.Message { return &pb.AddCommentResponse{} },
+		encode: grpcencode.AddCommentResponse,
+		decode: grpcdecode.AddCommentResponse,
+	},
+	reflect.TypeOf(responses.ListCommentsResponse{}): {
+		message: func() proto.Message { return &pb.ListCommentsResponse{} },
+		encode: grpcencode.ListCommentsResponse,
+		decode: grpcdecode.ListCommentsResponse,
+	},
+	reflect.TypeOf(responses.ResolveCommentResponse{}): {
+		message: func() proto.Message { return &pb.ResolveCommentResponse{} },
+		encode: grpcencode.ResolveCommentResponse,
+		decode: grpcdecode.ResolveCommentResponse,
+	},
+	reflect.TypeOf(responses.GetNoteStatsResponse{}): {
+		message: func() proto.Message { return &pb.GetNoteStatsResponse{} },
+		encode: grpcencode.GetNoteStatsResponse,
+		decode: grpcdecode.GetNoteStatsResponse,
+	},
+	reflect.TypeOf(responses.UploadAttachmentResponse{}): {
+		message: func() proto.Message { return &pb.UploadAttachmentResponse{} },
+		encode: grpcencode.UploadAttachmentResponse,
+		decode: grpcdecode.UploadAttachmentResponse,
+	},
+}
+
+// toMessage converts v, a model or a pointer to it, to its pb message.
+func toMessage(v interface{}) (proto.Message, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+
+	mapping, ok := protoMappings[rv.Type()]
+	if !ok {
+		return nil, fmt.Errorf("httpcodec: no protobuf message for %T", v)
+	}
+
+	msg, err := mapping.encode(context.Background(), rv.Interface())
+	if err != nil {
+		return nil, err
+	}
+	return msg.(proto.Message), nil
+}
+
+// fromMessage unmarshals the pb message of the model v points to, and stores
+// the converted model in v.
+func fromMessage(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("httpcodec: cannot unmarshal into %T", v)
+	}
+
+	mapping, ok := protoMappings[rv.Elem().Type()]
+	if !ok {
+		return fmt.Errorf("httpcodec: no protobuf message for %T", v)
+	}
+
+	msg := mapping.message()
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return err
+	}
+
+	out, err := mapping.decode(context.Background(), msg)
+	if err != nil {
+		return err
+	}
+
+	rv.Elem().Set(reflect.Indirect(reflect.ValueOf(out)))
+	return nil
+}
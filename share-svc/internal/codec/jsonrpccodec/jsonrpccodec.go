@@ -0,0 +1,114 @@
+package jsonrpccodec
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/internal/utils"
+	"github.com/al8n/shareable-notes/share-svc/model/requests"
+	"github.com/al8n/shareable-notes/share-svc/model/responses"
+	"github.com/go-kit/kit/transport/http/jsonrpc"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// NoteNotFoundError is returned when the note of a call cannot be found, the
+// code is in the range the JSON-RPC 2.0 specification reserves for servers.
+const NoteNotFoundError = -32001
+
+var errNoNoteID = errors.New("note_id is required")
+
+// Error returns the JSON-RPC error of an error returned by the service, the
+// errors without a code of their own are internal errors.
+func Error(err error) jsonrpc.Error {
+	code := jsonrpc.InternalError
+
+	switch {
+	case errors.Is(err, common.ErrorNoteNotFound), errors.Is(err, mongo.ErrNoDocuments):
+		code = NoteNotFoundError
+	case errors.Is(err, common.ErrorInvalidNoteID), errors.Is(err, primitive.ErrInvalidHex):
+		code = jsonrpc.InvalidParamsError
+	}
+
+	return jsonrpc.Error{
+		Code:    code,
+		Message: err.Error(),
+	}
+}
+
+// invalidParams wraps the errors of params which cannot be decoded.
+func invalidParams(err error) jsonrpc.Error {
+	return jsonrpc.Error{
+		Code:    jsonrpc.InvalidParamsError,
+		Message: err.Error(),
+	}
+}
+
+func ShareNoteRequest(_ context.Context, params json.RawMessage) (interface{}, error)  {
+	var req requests.ShareNoteRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, invalidParams(err)
+	}
+	return req, nil
+}
+
+func PrivateNoteRequest(_ context.Context, params json.RawMessage) (interface{}, error)  {
+	var req requests.PrivateNoteRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, invalidParams(err)
+	}
+
+	if req.NoteID == "" {
+		return nil, invalidParams(errNoNoteID)
+	}
+	return req, nil
+}
+
+func GetNoteRequest(_ context.Context, params json.RawMessage) (interface{}, error)  {
+	var req requests.GetNoteRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, invalidParams(err)
+	}
+
+	if req.NoteID == "" {
+		return nil, invalidParams(errNoNoteID)
+	}
+	return req, nil
+}
+
+func ShareNoteResponse(_ context.Context, resp interface{}) (json.RawMessage, error)  {
+	res, ok := resp.(responses.ShareNoteResponse)
+	if !ok {
+		return nil, Error(utils.ErrorCodecCasting("ShareNote", utils.Response, utils.JSONRPC))
+	}
+
+	if res.Error != "" {
+		return nil, Error(utils.Str2Err(res.Error))
+	}
+	return json.Marshal(res)
+}
+
+func PrivateNoteResponse(_ context.Context, resp interface{}) (json.RawMessage, error)  {
+	res, ok := resp.(responses.PrivateNoteResponse)
+	if !ok {
+		return nil, Error(utils.ErrorCodecCasting("PrivateNote", utils.Response, utils.JSONRPC))
+	}
+
+	if res.Error != "" {
+		return nil, Error(utils.Str2Err(res.Error))
+	}
+	return json.Marshal(res)
+}
+
+func GetNoteResponse(_ context.Context, resp interface{}) (json.RawMessage, error)  {
+	res, ok := resp.(responses.GetNoteResponse)
+	if !ok {
+		return nil, Error(utils.ErrorCodecCasting("GetNote", utils.Response, utils.JSONRPC))
+	}
+
+	if res.Error != "" {
+		return nil, Error(utils.Str2Err(res.Error))
+	}
+	return json.Marshal(res)
+}
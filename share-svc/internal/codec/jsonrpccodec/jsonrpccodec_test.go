@@ -0,0 +1,35 @@
+package jsonrpccodec
+
+import (
+	"errors"
+	"fmt"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/internal/utils"
+	"github.com/go-kit/kit/transport/http/jsonrpc"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"testing"
+)
+
+func TestError(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"not found", common.ErrorNoteNotFound, NoteNotFoundError},
+		{"not found from a response", utils.Str2Err(common.ErrorNoteNotFound.Error()), NoteNotFoundError},
+		{"no documents", mongo.ErrNoDocuments, NoteNotFoundError},
+		{"wrapped no documents", fmt.Errorf("find note: %w", mongo.ErrNoDocuments), NoteNotFoundError},
+		{"invalid id", common.ErrorInvalidNoteID, jsonrpc.InvalidParamsError},
+		{"invalid id from a response", utils.Str2Err(common.ErrorInvalidNoteID.Error()), jsonrpc.InvalidParamsError},
+		{"invalid hex", primitive.ErrInvalidHex, jsonrpc.InvalidParamsError},
+		{"other", errors.New("connection refused"), jsonrpc.InternalError},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Error(tc.err); got.Code != tc.code || got.Message != tc.err.Error() {
+				t.Fatalf("got %d %q, want %d %q", got.Code, got.Message, tc.code, tc.err.Error())
+			}
+		})
+	}
+}
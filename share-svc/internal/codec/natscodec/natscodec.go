@@ -0,0 +1,18 @@
+package natscodec
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/nats-io/nats.go"
+)
+
+// ErrorEncoder replies with the error in the error field the responses share,
+// so publishers decode it as a failed response.
+func ErrorEncoder(_ context.Context, err error, reply string, nc *nats.Conn) {
+	b, _ := json.Marshal(ErrorWrapper{Error: err.Error()})
+	nc.Publish(reply, b)
+}
+
+type ErrorWrapper struct {
+	Error string `json:"error"`
+}
@@ -0,0 +1,45 @@
+package natsdecode
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/al8n/shareable-notes/share-svc/model/requests"
+	"github.com/al8n/shareable-notes/share-svc/model/responses"
+	"github.com/nats-io/nats.go"
+)
+
+func ShareNoteRequest(_ context.Context, msg *nats.Msg) (interface{}, error)  {
+	var req requests.ShareNoteRequest
+	err := json.Unmarshal(msg.Data, &req)
+	return req, err
+}
+
+func PrivateNoteRequest(_ context.Context, msg *nats.Msg) (interface{}, error)  {
+	var req requests.PrivateNoteRequest
+	err := json.Unmarshal(msg.Data, &req)
+	return req, err
+}
+
+func GetNoteRequest(_ context.Context, msg *nats.Msg) (interface{}, error)  {
+	var req requests.GetNoteRequest
+	err := json.Unmarshal(msg.Data, &req)
+	return req, err
+}
+
+func ShareNoteResponse(_ context.Context, msg *nats.Msg) (interface{}, error)  {
+	var resp responses.ShareNoteResponse
+	err := json.Unmarshal(msg.Data, &resp)
+	return &resp, err
+}
+
+func PrivateNoteResponse(_ context.Context, msg *nats.Msg) (interface{}, error)  {
+	var resp responses.PrivateNoteResponse
+	err := json.Unmarshal(msg.Data, &resp)
+	return &resp, err
+}
+
+func GetNoteResponse(_ context.Context, msg *nats.Msg) (interface{}, error)  {
+	var resp responses.GetNoteResponse
+	err := json.Unmarshal(msg.Data, &resp)
+	return &resp, err
+}
@@ -0,0 +1,79 @@
+package natsencode
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/al8n/shareable-notes/share-svc/internal/utils"
+	"github.com/al8n/shareable-notes/share-svc/model/requests"
+	"github.com/al8n/shareable-notes/share-svc/model/responses"
+	"github.com/nats-io/nats.go"
+)
+
+func ShareNoteRequest(_ context.Context, msg *nats.Msg, request interface{}) (err error)  {
+	req, ok := request.(requests.ShareNoteRequest)
+	if !ok {
+		return utils.ErrorCodecCasting("ShareNote", utils.Request, utils.NATS)
+	}
+
+	msg.Data, err = json.Marshal(req)
+	return err
+}
+
+func PrivateNoteRequest(_ context.Context, msg *nats.Msg, request interface{}) (err error)  {
+	req, ok := request.(requests.PrivateNoteRequest)
+	if !ok {
+		return utils.ErrorCodecCasting("PrivateNote", utils.Request, utils.NATS)
+	}
+
+	msg.Data, err = json.Marshal(req)
+	return err
+}
+
+func GetNoteRequest(_ context.Context, msg *nats.Msg, request interface{}) (err error)  {
+	req, ok := request.(requests.GetNoteRequest)
+	if !ok {
+		return utils.ErrorCodecCasting("GetNote", utils.Request, utils.NATS)
+	}
+
+	msg.Data, err = json.Marshal(req)
+	return err
+}
+
+func ShareNoteResponse(_ context.Context, reply string, nc *nats.Conn, resp interface{}) error  {
+	res, ok := resp.(responses.ShareNoteResponse)
+	if !ok {
+		return utils.ErrorCodecCasting("ShareNote", utils.Response, utils.NATS)
+	}
+
+	b, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	return nc.Publish(reply, b)
+}
+
+func PrivateNoteResponse(_ context.Context, reply string, nc *nats.Conn, resp interface{}) error  {
+	res, ok := resp.(responses.PrivateNoteResponse)
+	if !ok {
+		return utils.ErrorCodecCasting("PrivateNote", utils.Response, utils.NATS)
+	}
+
+	b, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	return nc.Publish(reply, b)
+}
+
+func GetNoteResponse(_ context.Context, reply string, nc *nats.Conn, resp interface{}) error  {
+	res, ok := resp.(responses.GetNoteResponse)
+	if !ok {
+		return utils.ErrorCodecCasting("GetNote", utils.Response, utils.NATS)
+	}
+
+	b, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	return nc.Publish(reply, b)
+}
@@ -0,0 +1,765 @@
+package thriftcodec
+
+import (
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"github.com/al8n/shareable-notes/share-svc/model/requests"
+	"github.com/al8n/shareable-notes/share-svc/model/responses"
+	sharethrift "github.com/al8n/shareable-notes/share-svc/thrift/gen-go/share"
+)
+
+func ShareNoteReq2thriftReq(req requests.ShareNoteRequest) (thriftReq *sharethrift.ShareNoteRequest)  {
+	return &sharethrift.ShareNoteRequest{
+		Name:    req.Name,
+		Content: req.Content,
+	}
+}
+
+func ShareNotethriftReq2Req(thriftReq *sharethrift.ShareNoteRequest) (req requests.ShareNoteRequest)  {
+	return requests.ShareNoteRequest{
+		Name:    thriftReq.Name,
+		Content: thriftReq.Content,
+	}
+}
+
+func ShareNoteResp2thriftResp(resp responses.ShareNoteResponse) (thriftResp *sharethrift.ShareNoteResponse)  {
+	return &sharethrift.ShareNoteResponse{
+		URL:        resp.URL,
+		NoteID:     resp.NoteID,
+		OwnerToken: resp.OwnerToken,
+		Error:      resp.Error,
+	}
+}
+
+func ShareNotethriftResp2Resp(thriftResp *sharethrift.ShareNoteResponse) (resp *responses.ShareNoteResponse)  {
+	return &responses.ShareNoteResponse{
+		URL:        thriftResp.URL,
+		NoteID:     thriftResp.NoteID,
+		OwnerToken: thriftResp.OwnerToken,
+		Error:      thriftResp.Error,
+	}
+}
+
+func PrivateNoteReq2thriftReq(req requests.PrivateNoteRequest) (thriftReq *sharethrift.PrivateNoteRequest)  {
+	return &sharethrift.PrivateNoteRequest{NoteID: req.NoteID}
+}
+
+func PrivateNotethriftReq2Req(thriftReq *sharethrift.PrivateNoteRequest) (req requests.PrivateNoteRequest)  {
+	return requests.PrivateNoteRequest{NoteID: thriftReq.NoteID}
+}
+
+func PrivateNoteResp2thriftResp(resp responses.PrivateNoteResponse) (thriftResp *sharethrift.PrivateNoteResponse)  {
+	return &sharethrift.PrivateNoteResponse{Error: resp.Error}
+}
+
+func PrivateNotethriftResp2Resp(thriftResp *sharethrift.PrivateNoteResponse) (resp *responses.PrivateNoteResponse)  {
+	return &responses.PrivateNoteResponse{Error: thriftResp.Error}
+}
+
+func GetNoteReq2thriftReq(req requests.GetNoteRequest) (thriftReq *sharethrift.GetNoteRequest)  {
+	return &sharethrift.GetNoteRequest{
+		ID:              req.NoteID,
+		IfNoneMatch:     req.Condition.IfNoneMatch,
+		IfModifiedSince: req.Condition.IfModifiedSince,
+	}
+}
+
+func GetNotethriftReq2Req(thriftReq *sharethrift.GetNoteRequest) (req requests.GetNoteRequest)  {
+	return requests.GetNoteRequest{
+		NoteID: thriftReq.ID,
+		Condition: model.NoteCondition{
+			IfNoneMatch:     thriftReq.IfNoneMatch,
+			IfModifiedSince: thriftReq.IfModifiedSince,
+		},
+	}
+}
+
+func GetNoteResp2thriftResp(resp responses.GetNoteResponse) (thriftResp *sharethrift.GetNoteResponse)  {
+	return &sharethrift.GetNoteResponse{
+		Name:         resp.Name,
+		Content:      resp.Content,
+		Etag:         resp.ETag,
+		LastModified: resp.LastModified,
+		NotModified:  resp.NotModified,
+		Error:        resp.Error,
+	}
+}
+
+func GetNotethriftResp2Resp(thriftResp *sharethrift.GetNoteResponse) (resp *responses.GetNoteResponse)  {
+	return &responses.GetNoteResponse{
+		Name:         thriftResp.Name,
+		Content:      thriftResp.Content,
+		ETag:         thriftResp.Etag,
+		LastModified: thriftResp.LastModified,
+		NotModified:  thriftResp.NotModified,
+		Error:        thriftResp.Error,
+	}
+}
+
+func NoteChange2thriftNoteChange(change model.NoteChange) (thriftChange *sharethrift.NoteChange)  {
+	thriftChange = &sharethrift.NoteChange{
+		NoteID:      change.NoteID,
+		Name:        change.Name,
+		Content:     change.Content,
+		Versions:    map[string]int64{},
+		UpdatedAt:   change.UpdatedAt,
+		UpdatedBy:   change.UpdatedBy,
+		Deactivated: change.Deactivated,
+	}
+
+	for replica, version := range change.Versions {
+		thriftChange.Versions[replica] = int64(version)
+	}
+	return
+}
+
+func NoteChangethrift2NoteChange(thriftChange *sharethrift.NoteChange) (change model.NoteChange)  {
+	if thriftChange == nil {
+		return
+	}
+
+	change = model.NoteChange{
+		NoteID:      thriftChange.NoteID,
+		Name:        thriftChange.Name,
+		Content:     thriftChange.Content,
+		UpdatedAt:   thriftChange.UpdatedAt,
+		UpdatedBy:   thriftChange.UpdatedBy,
+		Deactivated: thriftChange.Deactivated,
+	}
+
+	if thriftChange.Versions != nil {
+		change.Versions = model.VersionVector{}
+		for replica, version := range thriftChange.Versions {
+			change.Versions[replica] = uint64(version)
+		}
+	}
+	return
+}
+
+func SyncNotesReq2thriftReq(req requests.SyncNotesRequest) (thriftReq *sharethrift.SyncNotesRequest)  {
+	thriftReq = &sharethrift.SyncNotesRequest{
+		ClientID: req.ClientID,
+		Since:    req.Since,
+		NoteIds:  req.NoteIDs,
+	}
+
+	for _, change := range req.Changes {
+		thriftReq.Changes = append(thriftReq.Changes, NoteChange2thriftNoteChange(change))
+	}
+	return
+}
+
+func SyncNotesthriftReq2Req(thriftReq *sharethrift.SyncNotesRequest) (req requests.SyncNotesRequest)  {
+	req = requests.SyncNotesRequest{
+		ClientID: thriftReq.ClientID,
+		Since:    thriftReq.Since,
+		NoteIDs:  thriftReq.NoteIds,
+	}
+
+	for _, change := range thriftReq.Changes {
+		req.Changes = append(req.Changes, NoteChangethrift2NoteChange(change))
+	}
+	return
+}
+
+func SyncNotesResp2thriftResp(resp responses.SyncNotesResponse) (thriftResp *sharethrift.SyncNotesResponse)  {
+	thriftResp = &sharethrift.SyncNotesResponse{
+		Version: resp.Version,
+		Error:   resp.Error,
+	}
+
+	for _, change := range resp.Changes {
+		thriftResp.Changes = append(thriftResp.Changes, NoteChange2thriftNoteChange(change))
+	}
+
+	for _, conflict := range resp.Conflicts {
+		thriftResp.Conflicts = append(thriftResp.Conflicts, &sharethrift.SyncConflict{
+			NoteID:     conflict.NoteID,
+			Local:      NoteChange2thriftNoteChange(conflict.Local),
+			Server:     NoteChange2thriftNoteChange(conflict.Server),
+			Resolution: conflict.Resolution,
+		})
+	}
+	return
+}
+
+func SyncNotesthriftResp2Resp(thriftResp *sharethrift.SyncNotesResponse) (resp *responses.SyncNotesResponse)  {
+	resp = &responses.SyncNotesResponse{
+		Version: thriftResp.Version,
+		Error:   thriftResp.Error,
+	}
+
+	for _, change := range thriftResp.Changes {
+		resp.Changes = append(resp.Changes, NoteChangethrift2NoteChange(change))
+	}
+
+	for _, conflict := range thriftResp.Conflicts {
+		resp.Conflicts = append(resp.Conflicts, model.SyncConflict{
+			NoteID:     conflict.NoteID,
+			Local:      NoteChangethrift2NoteChange(conflict.Local),
+			Server:     NoteChangethrift2NoteChange(conflict.Server),
+			Resolution: conflict.Resolution,
+		})
+	}
+	return
+}
+
+func ForkNoteReq2thriftReq(req requests.ForkNoteRequest) (thriftReq *sharethrift.ForkNoteRequest)  {
+	return &sharethrift.ForkNoteRequest{
+		ID:    req.NoteID,
+		Owner: req.Owner,
+	}
+}
+
+func ForkNotethriftReq2Req(thriftReq *sharethrift.ForkNoteRequest) (req requests.ForkNoteRequest)  {
+	return requests.ForkNoteRequest{
+		NoteID: thriftReq.ID,
+		Owner:  thriftReq.Owner,
+	}
+}
+
+func ForkNoteResp2thriftResp(resp responses.ForkNoteResponse) (thriftResp *sharethrift.ForkNoteResponse)  {
+	return &sharethrift.ForkNoteResponse{
+		URL:        resp.URL,
+		NoteID:     resp.NoteID,
+		OwnerToken: resp.OwnerToken,
+		Error:      resp.Error,
+	}
+}
+
+func ForkNotethriftResp2Resp(thriftResp *sharethrift.ForkNoteResponse) (resp *responses.ForkNoteResponse)  {
+	return &responses.ForkNoteResponse{
+		URL:        thriftResp.URL,
+		NoteID:     thriftResp.NoteID,
+		OwnerToken: thriftResp.OwnerToken,
+		Error:      thriftResp.Error,
+	}
+}
+
+func ListForksReq2thriftReq(req requests.ListForksRequest) (thriftReq *sharethrift.ListForksRequest)  {
+	return &sharethrift.ListForksRequest{ID: req.NoteID}
+}
+
+func ListForksthriftReq2Req(thriftReq *sharethrift.ListForksRequest) (req requests.ListForksRequest)  {
+	return requests.ListForksRequest{NoteID: thriftReq.ID}
+}
+
+func ListForksResp2thriftResp(resp responses.ListForksResponse) (thriftResp *sharethrift.ListForksResponse)  {
+	thriftResp = &sharethrift.ListForksResponse{
+		Forks: []*sharethrift.NoteFork{},
+		Error: resp.Error,
+	}
+
+	for _, fork := range resp.Forks {
+		thriftResp.Forks = append(thriftResp.Forks, &sharethrift.NoteFork{
+			NoteID:    fork.NoteID,
+			Name:      fork.Name,
+			Owner:     fork.Owner,
+			URL:       fork.URL,
+			CreatedAt: fork.CreatedAt,
+		})
+	}
+	return
+}
+
+func ListForksthriftResp2Resp(thriftResp *sharethrift.ListForksResponse) (resp *responses.ListForksResponse)  {
+	resp = &responses.ListForksResponse{
+		Forks: []model.NoteFork{},
+		Error: thriftResp.Error,
+	}
+
+	for _, fork := range thriftResp.Forks {
+		resp.Forks = append(resp.Forks, model.NoteFork{
+			NoteID:    fork.NoteID,
+			Name:      fork.Name,
+			Owner:     fork.Owner,
+			URL:       fork.URL,
+			CreatedAt: fork.CreatedAt,
+		})
+	}
+	return
+}
+
+func MarkTemplateReq2thriftReq(req requests.MarkTemplateRequest) (thriftReq *sharethrift.MarkTemplateRequest)  {
+	return &sharethrift.MarkTemplateRequest{
+		ID:       req.NoteID,
+		Template: req.Template,
+	}
+}
+
+func MarkTemplatethriftReq2Req(thriftReq *sharethrift.MarkTemplateRequest) (req requests.MarkTemplateRequest)  {
+	return requests.MarkTemplateRequest{
+		NoteID:   thriftReq.ID,
+		Template: thriftReq.Template,
+	}
+}
+
+func MarkTemplateResp2thriftResp(resp responses.MarkTemplateResponse) (thriftResp *sharethrift.MarkTemplateResponse)  {
+	thriftResp = &sharethrift.MarkTemplateResponse{
+		Variables: []*sharethrift.TemplateVariable{},
+		Error:     resp.Error,
+	}
+
+	for _, variable := range resp.Variables {
+		thriftResp.Variables = append(thriftResp.Variables, &sharethrift.TemplateVariable{
+			Name:         variable.Name,
+			Required:     variable.Required,
+			DefaultValue: variable.Default,
+		})
+	}
+	return
+}
+
+func MarkTemplatethriftResp2Resp(thriftResp *sharethrift.MarkTemplateResponse) (resp *responses.MarkTemplateResponse)  {
+	resp = &responses.MarkTemplateResponse{
+		Variables: []model.TemplateVariable{},
+		Error:     thriftResp.Error,
+	}
+
+	for _, variable := range thriftResp.Variables {
+		resp.Variables = append(resp.Variables, model.TemplateVariable{
+			Name:     variable.Name,
+			Required: variable.Required,
+			Default:  variable.DefaultValue,
+		})
+	}
+	return
+}
+
+func InstantiateTemplateReq2thriftReq(req requests.InstantiateTemplateRequest) (thriftReq *sharethrift.InstantiateTemplateRequest)  {
+	return &sharethrift.InstantiateTemplateRequest{
+		ID:   req.NoteID,
+		Vars: req.Vars,
+	}
+}
+
+func InstantiateTemplatethriftReq2Req(thriftReq *sharethrift.InstantiateTemplateRequest) (req requests.InstantiateTemplateRequest)  {
+	return requests.InstantiateTemplateRequest{
+		NoteID: thriftReq.ID,
+		Vars:   thriftReq.Vars,
+	}
+}
+
+func InstantiateTemplateResp2thriftResp(resp responses.InstantiateTemplateResponse) (thriftResp *sharethrift.InstantiateTemplateResponse)  {
+	return &sharethrift.InstantiateTemplateResponse{
+		URL:        resp.URL,
+		NoteID:     resp.NoteID,
+		OwnerToken: resp.OwnerToken,
+		Error:      resp.Error,
+	}
+}
+
+func InstantiateTemplatethriftResp2Resp(thriftResp *sharethrift.InstantiateTemplateResponse) (resp *responses.InstantiateTemplateResponse)  {
+	return &responses.InstantiateTemplateResponse{
+		URL:        thriftResp.URL,
+		NoteID:     thriftResp.NoteID,
+		OwnerToken: thriftResp.OwnerToken,
+		Error:      thriftResp.Error,
+	}
+}
+
+func CreateCollectionReq2thriftReq(req requests.CreateCollectionRequest) (thriftReq *sharethrift.CreateCollectionRequest)  {
+	return &sharethrift.CreateCollectionRequest{
+		Name:    req.Name,
+		NoteIds: req.NoteIDs,
+	}
+}
+
+func CreateCollectionthriftReq2Req(thriftReq *sharethrift.CreateCollectionRequest) (req requests.CreateCollectionRequest)  {
+	return requests.CreateCollectionRequest{
+		Name:    thriftReq.Name,
+		NoteIDs: thriftReq.NoteIds,
+	}
+}
+
+func CreateCollectionResp2thriftResp(resp responses.CreateCollectionResponse) (thriftResp *sharethrift.CreateCollectionResponse)  {
+	return &sharethrift.CreateCollectionResponse{
+		URL:          resp.URL,
+		CollectionID: resp.CollectionID,
+		Error:        resp.Error,
+	}
+}
+
+func CreateCollectionthriftResp2Resp(thriftResp *sharethrift.CreateCollectionResponse) (resp *responses.CreateCollectionResponse)  {
+	return &responses.CreateCollectionResponse{
+		URL:          thriftResp.URL,
+		CollectionID: thriftResp.CollectionID,
+		Error:        thriftResp.Error,
+	}
+}
+
+func AddCollectionNotesReq2thriftReq(req requests.AddCollectionNotesRequest) (thriftReq *sharethrift.AddCollectionNotesRequest)  {
+	return &sharethrift.AddCollectionNotesRequest{
+		ID:      req.CollectionID,
+		NoteIds: req.NoteIDs,
+	}
+}
+
+func AddCollectionNotesthriftReq2Req(thriftReq *sharethrift.AddCollectionNotesRequest) (req requests.AddCollectionNotesRequest)  {
+	return requests.AddCollectionNotesRequest{
+		CollectionID: thriftReq.ID,
+		NoteIDs:      thriftReq.NoteIds,
+	}
+}
+
+func AddCollectionNotesResp2thriftResp(resp responses.AddCollectionNotesResponse) (thriftResp *sharethrift.AddCollectionNotesResponse)  {
+	return &sharethrift.AddCollectionNotesResponse{Error: resp.Error}
+}
+
+func AddCollectionNotesthriftResp2Resp(thriftResp *sharethrift.AddCollectionNotesResponse) (resp *responses.AddCollectionNotesResponse)  {
+	return &responses.AddCollectionNotesResponse{Error: thriftResp.Error}
+}
+
+func RemoveCollectionNotesReq2thriftReq(req requests.RemoveCollectionNotesRequest) (thriftReq *sharethrift.RemoveCollectionNotesRequest)  {
+	return &sharethrift.RemoveCollectionNotesRequest{
+		ID:      req.CollectionID,
+		NoteIds: req.NoteIDs,
+	}
+}
+
+func RemoveCollectionNotesthriftReq2Req(thriftReq *sharethrift.RemoveCollectionNotesRequest) (req requests.RemoveCollectionNotesRequest)  {
+	return requests.RemoveCollectionNotesRequest{
+		CollectionID: thriftReq.ID,
+		NoteIDs:      thriftReq.NoteIds,
+	}
+}
+
+func RemoveCollectionNotesResp2thriftResp(resp responses.RemoveCollectionNotesResponse) (thriftResp *sharethrift.RemoveCollectionNotesResponse)  {
+	return &sharethrift.RemoveCollectionNotesResponse{Error: resp.Error}
+}
+
+func RemoveCollectionNotesthriftResp2Resp(thriftResp *sharethrift.RemoveCollectionNotesResponse) (resp *responses.RemoveCollectionNotesResponse)  {
+	return &responses.RemoveCollectionNotesResponse{Error: thriftResp.Error}
+}
+
+func ReorderCollectionReq2thriftReq(req requests.ReorderCollectionRequest) (thriftReq *sharethrift.ReorderCollectionRequest)  {
+	return &sharethrift.ReorderCollectionRequest{
+		ID:      req.CollectionID,
+		NoteIds: req.NoteIDs,
+	}
+}
+
+func ReorderCollectionthriftReq2Req(thriftReq *sharethrift.ReorderCollectionRequest) (req requests.ReorderCollectionRequest)  {
+	return requests.ReorderCollectionRequest{
+		CollectionID: thriftReq.ID,
+		NoteIDs:      thriftReq.NoteIds,
+	}
+}
+
+func ReorderCollectionResp2thriftResp(resp responses.ReorderCollectionResponse) (thriftResp *sharethrift.ReorderCollectionResponse)  {
+	return &sharethrift.ReorderCollectionResponse{Error: resp.Error}
+}
+
+func ReorderCollectionthriftResp2Resp(thriftResp *sharethrift.ReorderCollectionResponse) (resp *responses.ReorderCollectionResponse)  {
+	return &responses.ReorderCollectionResponse{Error: thriftResp.Error}
+}
+
+func GetCollectionReq2thriftReq(req requests.GetCollectionRequest) (thriftReq *sharethrift.GetCollectionRequest)  {
+	return &sharethrift.GetCollectionRequest{ID: req.CollectionID}
+}
+
+func GetCollectionthriftReq2Req(thriftReq *sharethrift.GetCollectionRequest) (req requests.GetCollectionRequest)  {
+	return requests.GetCollectionRequest{CollectionID: thriftReq.ID}
+}
+
+func GetCollectionResp2thriftResp(resp responses.GetCollectionResponse) (thriftResp *sharethrift.GetCollectionResponse)  {
+	thriftResp = &sharethrift.GetCollectionResponse{
+		Name:  resp.Name,
+		Notes: []*sharethrift.CollectionNote{},
+		Error: resp.Error,
+	}
+
+	for _, note := range resp.Notes {
+		thriftResp.Notes = append(thriftResp.Notes, &sharethrift.CollectionNote{
+			NoteID: note.NoteID,
+			Name:   note.Name,
+			URL:    note.URL,
+		})
+	}
+	return
+}
+
+func GetCollectionthriftResp2Resp(thriftResp *sharethrift.GetCollectionResponse) (resp *responses.GetCollectionResponse)  {
+	resp = &responses.GetCollectionResponse{
+		Name:  thriftResp.Name,
+		Notes: []model.CollectionNote{},
+		Error: thriftResp.Error,
+	}
+
+	for _, note := range thriftResp.Notes {
+		resp.Notes = append(resp.Notes, model.CollectionNote{
+			NoteID: note.NoteID,
+			Name:   note.Name,
+			URL:    note.URL,
+		})
+	}
+	return
+}
+
+func Attachment2thriftAttachment(attachment model.AttachmentInfo) (thriftAttachment *sharethrift.Attachment)  {
+	return &sharethrift.Attachment{
+		ID:          attachment.ID,
+		NoteID:      attachment.NoteID,
+		Name:        attachment.Name,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		URL:         attachment.URL,
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
+
+func Attachmentthrift2Attachment(thriftAttachment *sharethrift.Attachment) (attachment model.AttachmentInfo)  {
+	if thriftAttachment == nil {
+		return
+	}
+
+	return model.AttachmentInfo{
+		ID:          thriftAttachment.ID,
+		NoteID:      thriftAttachment.NoteID,
+		Name:        thriftAttachment.Name,
+		ContentType: thriftAttachment.ContentType,
+		Size:        thriftAttachment.Size,
+		URL:         thriftAttachment.URL,
+		CreatedAt:   thriftAttachment.CreatedAt,
+	}
+}
+
+func UploadAttachmentResp2thriftResp(resp responses.UploadAttachmentResponse) (thriftResp *sharethrift.UploadAttachmentResponse)  {
+	return &sharethrift.UploadAttachmentResponse{
+		Attachment: Attachment2thriftAttachment(resp.Attachment),
+		Error:      resp.Error,
+	}
+}
+
+func UploadAttachmentthriftResp2Resp(thriftResp *sharethrift.UploadAttachmentResponse) (resp *responses.UploadAttachmentResponse)  {
+	return &responses.UploadAttachmentResponse{
+		Attachment: Attachmentthrift2Attachment(thriftResp.Attachment),
+		Error:      thriftResp.Error,
+	}
+}
+
+func DownloadAttachmentReq2thriftReq(req requests.DownloadAttachmentRequest) (thriftReq *sharethrift.DownloadAttachmentRequest)  {
+	return &sharethrift.DownloadAttachmentRequest{
+		NoteID: req.NoteID,
+		ID:     req.AttachmentID,
+	}
+}
+
+func DownloadAttachmentthriftReq2Req(thriftReq *sharethrift.DownloadAttachmentRequest) (req requests.DownloadAttachmentRequest)  {
+	return requests.DownloadAttachmentRequest{
+		NoteID:       thriftReq.NoteID,
+		AttachmentID: thriftReq.ID,
+	}
+}
+
+func Comment2thriftComment(comment model.NoteComment) (thriftComment *sharethrift.Comment)  {
+	return &sharethrift.Comment{
+		ID:         comment.ID,
+		NoteID:     comment.NoteID,
+		Author:     comment.Author,
+		Body:       comment.Body,
+		StartLine:  comment.StartLine,
+		EndLine:    comment.EndLine,
+		Resolved:   comment.Resolved,
+		ResolvedBy: comment.ResolvedBy,
+		CreatedAt:  comment.CreatedAt,
+		ResolvedAt: comment.ResolvedAt,
+	}
+}
+
+func Commentthrift2Comment(thriftComment *sharethrift.Comment) (comment model.NoteComment)  {
+	if thriftComment == nil {
+		return
+	}
+
+	return model.NoteComment{
+		ID:         thriftComment.ID,
+		NoteID:     thriftComment.NoteID,
+		Author:     thriftComment.Author,
+		Body:       thriftComment.Body,
+		StartLine:  thriftComment.StartLine,
+		EndLine:    thriftComment.EndLine,
+		Resolved:   thriftComment.Resolved,
+		ResolvedBy: thriftComment.ResolvedBy,
+		CreatedAt:  thriftComment.CreatedAt,
+		ResolvedAt: thriftComment.ResolvedAt,
+	}
+}
+
+func AddCommentReq2thriftReq(req requests.AddCommentRequest) (thriftReq *sharethrift.AddCommentRequest)  {
+	return &sharethrift.AddCommentRequest{
+		NoteID:    req.NoteID,
+		Author:    req.Author,
+		Body:      req.Body,
+		StartLine: req.StartLine,
+		EndLine:   req.EndLine,
+	}
+}
+
+func AddCommentthriftReq2Req(thriftReq *sharethrift.AddCommentRequest) (req requests.AddCommentRequest)  {
+	return requests.AddCommentRequest{
+		NoteID:    thriftReq.NoteID,
+		Author:    thriftReq.Author,
+		Body:      thriftReq.Body,
+		StartLine: thriftReq.StartLine,
+		EndLine:   thriftReq.EndLine,
+	}
+}
+
+func AddCommentResp2thriftResp(resp responses.AddCommentResponse) (thriftResp *sharethrift.AddCommentResponse)  {
+	return &sharethrift.AddCommentResponse{
+		Comment: Comment2thriftComment(resp.Comment),
+		Error:   resp.Error,
+	}
+}
+
+func AddCommentthriftResp2Resp(thriftResp *sharethrift.AddCommentResponse) (resp *responses.AddCommentResponse)  {
+	return &responses.AddCommentResponse{
+		Comment: Commentthrift2Comment(thriftResp.Comment),
+		Error:   thriftResp.Error,
+	}
+}
+
+func ListCommentsReq2thriftReq(req requests.ListCommentsRequest) (thriftReq *sharethrift.ListCommentsRequest)  {
+	return &sharethrift.ListCommentsRequest{
+		NoteID:          req.NoteID,
+		IncludeResolved: req.IncludeResolved,
+	}
+}
+
+func ListCommentsthriftReq2Req(thriftReq *sharethrift.ListCommentsRequest) (req requests.ListCommentsRequest)  {
+	return requests.ListCommentsRequest{
+		NoteID:          thriftReq.NoteID,
+		IncludeResolved: thriftReq.IncludeResolved,
+	}
+}
+
+func ListCommentsResp2thriftResp(resp responses.ListCommentsResponse) (thriftResp *sharethrift.ListCommentsResponse)  {
+	thriftResp = &sharethrift.ListCommentsResponse{
+		Comments: []*sharethrift.Comment{},
+		Error:    resp.Error,
+	}
+
+	for _, comment := range resp.Comments {
+		thriftResp.Comments = append(thriftResp.Comments, Comment2thriftComment(comment))
+	}
+	return
+}
+
+func ListCommentsthriftResp2Resp(thriftResp *sharethrift.ListCommentsResponse) (resp *responses.ListCommentsResponse)  {
+	resp = &responses.ListCommentsResponse{
+		Comments: []model.NoteComment{},
+		Error:    thriftResp.Error,
+	}
+
+	for _, comment := range thriftResp.Comments {
+		resp.Comments = append(resp.Comments, Commentthrift2Comment(comment))
+	}
+	return
+}
+
+func ResolveCommentReq2thriftReq(req requests.ResolveCommentRequest) (thriftReq *sharethrift.ResolveCommentRequest)  {
+	return &sharethrift.ResolveCommentRequest{
+		NoteID: req.NoteID,
+		ID:     req.CommentID,
+		User:   req.User,
+	}
+}
+
+func ResolveCommentthriftReq2Req(thriftReq *sharethrift.ResolveCommentRequest) (req requests.ResolveCommentRequest)  {
+	return requests.ResolveCommentRequest{
+		NoteID:    thriftReq.NoteID,
+		CommentID: thriftReq.ID,
+		User:      thriftReq.User,
+	}
+}
+
+func ResolveCommentResp2thriftResp(resp responses.ResolveCommentResponse) (thriftResp *sharethrift.ResolveCommentResponse)  {
+	return &sharethrift.ResolveCommentResponse{
+		Comment: Comment2thriftComment(resp.Comment),
+		Error:   resp.Error,
+	}
+}
+
+func ResolveCommentthriftResp2Resp(thriftResp *sharethrift.ResolveCommentResponse) (resp *responses.ResolveCommentResponse)  {
+	return &responses.ResolveCommentResponse{
+		Comment: Commentthrift2Comment(thriftResp.Comment),
+		Error:   thriftResp.Error,
+	}
+}
+
+func GetNoteStatsReq2thriftReq(req requests.GetNoteStatsRequest) (thriftReq *sharethrift.GetNoteStatsRequest)  {
+	return &sharethrift.GetNoteStatsRequest{
+		ID:         req.NoteID,
+		OwnerToken: req.OwnerToken,
+		Days:       int32(req.Days),
+	}
+}
+
+func GetNoteStatsthriftReq2Req(thriftReq *sharethrift.GetNoteStatsRequest) (req requests.GetNoteStatsRequest)  {
+	return requests.GetNoteStatsRequest{
+		NoteID:     thriftReq.ID,
+		OwnerToken: thriftReq.OwnerToken,
+		Days:       int(thriftReq.Days),
+	}
+}
+
+func GetNoteStatsResp2thriftResp(resp responses.GetNoteStatsResponse) (thriftResp *sharethrift.GetNoteStatsResponse)  {
+	var stats = resp.Stats
+
+	thriftResp = &sharethrift.GetNoteStatsResponse{
+		Stats: &sharethrift.NoteStats{
+			NoteID:        stats.NoteID,
+			Views:         stats.Views,
+			Transports:    stats.Transports,
+			Referrers:     stats.Referrers,
+			Daily:         []*sharethrift.DailyViews{},
+			FirstViewedAt: stats.FirstViewedAt,
+			LastViewedAt:  stats.LastViewedAt,
+		},
+		Error: resp.Error,
+	}
+
+	for _, day := range stats.Daily {
+		thriftResp.Stats.Daily = append(thriftResp.Stats.Daily, &sharethrift.DailyViews{
+			Day:      day.Day,
+			Views:    day.Views,
+			Visitors: day.Visitors,
+		})
+	}
+	return
+}
+
+func GetNoteStatsthriftResp2Resp(thriftResp *sharethrift.GetNoteStatsResponse) (resp *responses.GetNoteStatsResponse)  {
+	resp = &responses.GetNoteStatsResponse{
+		Stats: model.NoteStats{
+			Transports: map[string]int64{},
+			Referrers:  map[string]int64{},
+			Daily:      []model.DailyViews{},
+		},
+		Error: thriftResp.Error,
+	}
+
+	if thriftStats := thriftResp.Stats; thriftStats != nil {
+		resp.Stats.NoteID = thriftStats.NoteID
+		resp.Stats.Views = thriftStats.Views
+		resp.Stats.FirstViewedAt = thriftStats.FirstViewedAt
+		resp.Stats.LastViewedAt = thriftStats.LastViewedAt
+
+		for transport, views := range thriftStats.Transports {
+			resp.Stats.Transports[transport] = views
+		}
+
+		for referrer, views := range thriftStats.Referrers {
+			resp.Stats.Referrers[referrer] = views
+		}
+
+		for _, day := range thriftStats.Daily {
+			resp.Stats.Daily = append(resp.Stats.Daily, model.DailyViews{
+				Day:      day.Day,
+				Views:    day.Views,
+				Visitors: day.Visitors,
+			})
+		}
+	}
+	return
+}
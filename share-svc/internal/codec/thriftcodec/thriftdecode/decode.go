@@ -0,0 +1,209 @@
+package thriftdecode
+
+import (
+	"bytes"
+	"context"
+	"github.com/al8n/shareable-notes/share-svc/internal/codec/thriftcodec"
+	"github.com/al8n/shareable-notes/share-svc/model/requests"
+	"github.com/al8n/shareable-notes/share-svc/model/responses"
+	sharethrift "github.com/al8n/shareable-notes/share-svc/thrift/gen-go/share"
+	"io/ioutil"
+)
+
+func ShareNoteRequest(_ context.Context, thriftReq interface{}) (interface{}, error)  {
+	req := thriftReq.(*sharethrift.ShareNoteRequest)
+	return thriftcodec.ShareNotethriftReq2Req(req), nil
+}
+
+func ShareNoteResponse(_ context.Context, thriftResp interface{}) (interface{}, error)  {
+	resp := thriftResp.(*sharethrift.ShareNoteResponse)
+	return thriftcodec.ShareNotethriftResp2Resp(resp), nil
+}
+
+func PrivateNoteRequest(_ context.Context, thriftReq interface{}) (interface{}, error)  {
+	req := thriftReq.(*sharethrift.PrivateNoteRequest)
+	return thriftcodec.PrivateNotethriftReq2Req(req), nil
+}
+
+func PrivateNoteResponse(_ context.Context, thriftResp interface{}) (interface{}, error)  {
+	resp := thriftResp.(*sharethrift.PrivateNoteResponse)
+	return thriftcodec.PrivateNotethriftResp2Resp(resp), nil
+}
+
+func GetNoteRequest(_ context.Context, thriftReq interface{}) (interface{}, error)  {
+	req := thriftReq.(*sharethrift.GetNoteRequest)
+	return thriftcodec.GetNotethriftReq2Req(req), nil
+}
+
+func GetNoteResponse(_ context.Context, thriftResp interface{}) (interface{}, error)  {
+	resp := thriftResp.(*sharethrift.GetNoteResponse)
+	return thriftcodec.GetNotethriftResp2Resp(resp), nil
+}
+
+func SyncNotesRequest(_ context.Context, thriftReq interface{}) (interface{}, error)  {
+	req := thriftReq.(*sharethrift.SyncNotesRequest)
+	return thriftcodec.SyncNotesthriftReq2Req(req), nil
+}
+
+func SyncNotesResponse(_ context.Context, thriftResp interface{}) (interface{}, error)  {
+	resp := thriftResp.(*sharethrift.SyncNotesResponse)
+	return thriftcodec.SyncNotesthriftResp2Resp(resp), nil
+}
+
+func ForkNoteRequest(_ context.Context, thriftReq interface{}) (interface{}, error)  {
+	req := thriftReq.(*sharethrift.ForkNoteRequest)
+	return thriftcodec.ForkNotethriftReq2Req(req), nil
+}
+
+func ForkNoteResponse(_ context.Context, thriftResp interface{}) (interface{}, error)  {
+	resp := thriftResp.(*sharethrift.ForkNoteResponse)
+	return thriftcodec.ForkNotethriftResp2Resp(resp), nil
+}
+
+func ListForksRequest(_ context.Context, thriftReq interface{}) (interface{}, error)  {
+	req := thriftReq.(*sharethrift.ListForksRequest)
+	return thriftcodec.ListForksthriftReq2Req(req), nil
+}
+
+func ListForksResponse(_ context.Context, thriftResp interface{}) (interface{}, error)  {
+	resp := thriftResp.(*sharethrift.ListForksResponse)
+	return thriftcodec.ListForksthriftResp2Resp(resp), nil
+}
+
+func MarkTemplateRequest(_ context.Context, thriftReq interface{}) (interface{}, error)  {
+	req := thriftReq.(*sharethrift.MarkTemplateRequest)
+	return thriftcodec.MarkTemplatethriftReq2Req(req), nil
+}
+
+func MarkTemplateResponse(_ context.Context, thriftResp interface{}) (interface{}, error)  {
+	resp := thriftResp.(*sharethrift.MarkTemplateResponse)
+	return thriftcodec.MarkTemplatethriftResp2Resp(resp), nil
+}
+
+func InstantiateTemplateRequest(_ context.Context, thriftReq interface{}) (interface{}, error)  {
+	req := thriftReq.(*sharethrift.InstantiateTemplateRequest)
+	return thriftcodec.InstantiateTemplatethriftReq2Req(req), nil
+}
+
+func InstantiateTemplateResponse(_ context.Context, thriftResp interface{}) (interface{}, error)  {
+	resp := thriftResp.(*sharethrift.InstantiateTemplateResponse)
+	return thriftcodec.InstantiateTemplatethriftResp2Resp(resp), nil
+}
+
+func CreateCollectionRequest(_ context.Context, thriftReq interface{}) (interface{}, error)  {
+	req := thriftReq.(*sharethrift.CreateCollectionRequest)
+	return thriftcodec.CreateCollectionthriftReq2Req(req), nil
+}
+
+func CreateCollectionResponse(_ context.Context, thriftResp interface{}) (interface{}, error)  {
+	resp := thriftResp.(*sharethrift.CreateCollectionResponse)
+	return thriftcodec.CreateCollectionthriftResp2Resp(resp), nil
+}
+
+func AddCollectionNotesRequest(_ context.Context, thriftReq interface{}) (interface{}, error)  {
+	req := thriftReq.(*sharethrift.AddCollectionNotesRequest)
+	return thriftcodec.AddCollectionNotesthriftReq2Req(req), nil
+}
+
+func AddCollectionNotesResponse(_ context.Context, thriftResp interface{}) (interface{}, error)  {
+	resp := thriftResp.(*sharethrift.AddCollectionNotesResponse)
+	return thriftcodec.AddCollectionNotesthriftResp2Resp(resp), nil
+}
+
+func RemoveCollectionNotesRequest(_ context.Context, thriftReq interface{}) (interface{}, error)  {
+	req := thriftReq.(*sharethrift.RemoveCollectionNotesRequest)
+	return thriftcodec.RemoveCollectionNotesthriftReq2Req(req), nil
+}
+
+func RemoveCollectionNotesResponse(_ context.Context, thriftResp interface{}) (interface{}, error)  {
+	resp := thriftResp.(*sharethrift.RemoveCollectionNotesResponse)
+	return thriftcodec.RemoveCollectionNotesthriftResp2Resp(resp), nil
+}
+
+func ReorderCollectionRequest(_ context.Context, thriftReq interface{}) (interface{}, error)  {
+	req := thriftReq.(*sharethrift.ReorderCollectionRequest)
+	return thriftcodec.ReorderCollectionthriftReq2Req(req), nil
+}
+
+func ReorderCollectionResponse(_ context.Context, thriftResp interface{}) (interface{}, error)  {
+	resp := thriftResp.(*sharethrift.ReorderCollectionResponse)
+	return thriftcodec.ReorderCollectionthriftResp2Resp(resp), nil
+}
+
+func GetCollectionRequest(_ context.Context, thriftReq interface{}) (interface{}, error)  {
+	req := thriftReq.(*sharethrift.GetCollectionRequest)
+	return thriftcodec.GetCollectionthriftReq2Req(req), nil
+}
+
+func GetCollectionResponse(_ context.Context, thriftResp interface{}) (interface{}, error)  {
+	resp := thriftResp.(*sharethrift.GetCollectionResponse)
+	return thriftcodec.GetCollectionthriftResp2Resp(resp), nil
+}
+
+func UploadAttachmentRequest(_ context.Context, thriftReq interface{}) (interface{}, error)  {
+	req := thriftReq.(*sharethrift.UploadAttachmentRequest)
+	return requests.UploadAttachmentRequest{
+		NoteID:  req.NoteID,
+		Name:    req.Name,
+		Content: bytes.NewReader(req.Content),
+	}, nil
+}
+
+func UploadAttachmentResponse(_ context.Context, thriftResp interface{}) (interface{}, error)  {
+	resp := thriftResp.(*sharethrift.UploadAttachmentResponse)
+	return thriftcodec.UploadAttachmentthriftResp2Resp(resp), nil
+}
+
+func DownloadAttachmentRequest(_ context.Context, thriftReq interface{}) (interface{}, error)  {
+	req := thriftReq.(*sharethrift.DownloadAttachmentRequest)
+	return thriftcodec.DownloadAttachmentthriftReq2Req(req), nil
+}
+
+func DownloadAttachmentResponse(_ context.Context, thriftResp interface{}) (interface{}, error)  {
+	resp := thriftResp.(*sharethrift.DownloadAttachmentResponse)
+	return &responses.DownloadAttachmentResponse{
+		Attachment: thriftcodec.Attachmentthrift2Attachment(resp.Attachment),
+		Content:    ioutil.NopCloser(bytes.NewReader(resp.Content)),
+		Error:      resp.Error,
+	}, nil
+}
+
+func AddCommentRequest(_ context.Context, thriftReq interface{}) (interface{}, error)  {
+	req := thriftReq.(*sharethrift.AddCommentRequest)
+	return thriftcodec.AddCommentthriftReq2Req(req), nil
+}
+
+func AddCommentResponse(_ context.Context, thriftResp interface{}) (interface{}, error)  {
+	resp := thriftResp.(*sharethrift.AddCommentResponse)
+	return thriftcodec.AddCommentthriftResp2Resp(resp), nil
+}
+
+func ListCommentsRequest(_ context.Context, thriftReq interface{}) (interface{}, error)  {
+	req := thriftReq.(*sharethrift.ListCommentsRequest)
+	return thriftcodec.ListCommentsthriftReq2Req(req), nil
+}
+
+func ListCommentsResponse(_ context.Context, thriftResp interface{}) (interface{}, error)  {
+	resp := thriftResp.(*sharethrift.ListCommentsResponse)
+	return thriftcodec.ListCommentsthriftResp2Resp(resp), nil
+}
+
+func ResolveCommentRequest(_ context.Context, thriftReq interface{}) (interface{}, error)  {
+	req := thriftReq.(*sharethrift.ResolveCommentRequest)
+	return thriftcodec.ResolveCommentthriftReq2Req(req), nil
+}
+
+func ResolveCommentResponse(_ context.Context, thriftResp interface{}) (interface{}, error)  {
+	resp := thriftResp.(*sharethrift.ResolveCommentResponse)
+	return thriftcodec.ResolveCommentthriftResp2Resp(resp), nil
+}
+
+func GetNoteStatsRequest(_ context.Context, thriftReq interface{}) (interface{}, error)  {
+	req := thriftReq.(*sharethrift.GetNoteStatsRequest)
+	return thriftcodec.GetNoteStatsthriftReq2Req(req), nil
+}
+
+func GetNoteStatsResponse(_ context.Context, thriftResp interface{}) (interface{}, error)  {
+	resp := thriftResp.(*sharethrift.GetNoteStatsResponse)
+	return thriftcodec.GetNoteStatsthriftResp2Resp(resp), nil
+}
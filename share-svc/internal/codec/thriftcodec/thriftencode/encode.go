@@ -0,0 +1,343 @@
+package thriftencode
+
+import (
+	"context"
+	"github.com/al8n/shareable-notes/share-svc/internal/codec/thriftcodec"
+	"github.com/al8n/shareable-notes/share-svc/internal/utils"
+	"github.com/al8n/shareable-notes/share-svc/model/requests"
+	"github.com/al8n/shareable-notes/share-svc/model/responses"
+	sharethrift "github.com/al8n/shareable-notes/share-svc/thrift/gen-go/share"
+	"io/ioutil"
+)
+
+func ShareNoteRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.ShareNoteRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("ShareNote", utils.Request, utils.Thrift)
+	}
+	return thriftcodec.ShareNoteReq2thriftReq(req), nil
+}
+
+func ShareNoteResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.ShareNoteResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("ShareNote", utils.Response, utils.Thrift)
+	}
+	return thriftcodec.ShareNoteResp2thriftResp(res), nil
+}
+
+func PrivateNoteRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.PrivateNoteRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("PrivateNote", utils.Request, utils.Thrift)
+	}
+	return thriftcodec.PrivateNoteReq2thriftReq(req), nil
+}
+
+func PrivateNoteResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.PrivateNoteResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("PrivateNote", utils.Response, utils.Thrift)
+	}
+	return thriftcodec.PrivateNoteResp2thriftResp(res), nil
+}
+
+func GetNoteRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.GetNoteRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("GetNote", utils.Request, utils.Thrift)
+	}
+	return thriftcodec.GetNoteReq2thriftReq(req), nil
+}
+
+func GetNoteResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.GetNoteResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("GetNote", utils.Response, utils.Thrift)
+	}
+	return thriftcodec.GetNoteResp2thriftResp(res), nil
+}
+
+func SyncNotesRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.SyncNotesRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("SyncNotes", utils.Request, utils.Thrift)
+	}
+	return thriftcodec.SyncNotesReq2thriftReq(req), nil
+}
+
+func SyncNotesResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.SyncNotesResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("SyncNotes", utils.Response, utils.Thrift)
+	}
+	return thriftcodec.SyncNotesResp2thriftResp(res), nil
+}
+
+func ForkNoteRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.ForkNoteRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("ForkNote", utils.Request, utils.Thrift)
+	}
+	return thriftcodec.ForkNoteReq2thriftReq(req), nil
+}
+
+func ForkNoteResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.ForkNoteResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("ForkNote", utils.Response, utils.Thrift)
+	}
+	return thriftcodec.ForkNoteResp2thriftResp(res), nil
+}
+
+func ListForksRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.ListForksRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("ListForks", utils.Request, utils.Thrift)
+	}
+	return thriftcodec.ListForksReq2thriftReq(req), nil
+}
+
+func ListForksResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.ListForksResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("ListForks", utils.Response, utils.Thrift)
+	}
+	return thriftcodec.ListForksResp2thriftResp(res), nil
+}
+
+func MarkTemplateRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.MarkTemplateRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("MarkTemplate", utils.Request, utils.Thrift)
+	}
+	return thriftcodec.MarkTemplateReq2thriftReq(req), nil
+}
+
+func MarkTemplateResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.MarkTemplateResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("MarkTemplate", utils.Response, utils.Thrift)
+	}
+	return thriftcodec.MarkTemplateResp2thriftResp(res), nil
+}
+
+func InstantiateTemplateRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.InstantiateTemplateRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("InstantiateTemplate", utils.Request, utils.Thrift)
+	}
+	return thriftcodec.InstantiateTemplateReq2thriftReq(req), nil
+}
+
+func InstantiateTemplateResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.InstantiateTemplateResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("InstantiateTemplate", utils.Response, utils.Thrift)
+	}
+	return thriftcodec.InstantiateTemplateResp2thriftResp(res), nil
+}
+
+func CreateCollectionRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.CreateCollectionRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("CreateCollection", utils.Request, utils.Thrift)
+	}
+	return thriftcodec.CreateCollectionReq2thriftReq(req), nil
+}
+
+func CreateCollectionResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.CreateCollectionResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("CreateCollection", utils.Response, utils.Thrift)
+	}
+	return thriftcodec.CreateCollectionResp2thriftResp(res), nil
+}
+
+func AddCollectionNotesRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.AddCollectionNotesRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("AddCollectionNotes", utils.Request, utils.Thrift)
+	}
+	return thriftcodec.AddCollectionNotesReq2thriftReq(req), nil
+}
+
+func AddCollectionNotesResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.AddCollectionNotesResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("AddCollectionNotes", utils.Response, utils.Thrift)
+	}
+	return thriftcodec.AddCollectionNotesResp2thriftResp(res), nil
+}
+
+func RemoveCollectionNotesRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.RemoveCollectionNotesRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("RemoveCollectionNotes", utils.Request, utils.Thrift)
+	}
+	return thriftcodec.RemoveCollectionNotesReq2thriftReq(req), nil
+}
+
+func RemoveCollectionNotesResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.RemoveCollectionNotesResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("RemoveCollectionNotes", utils.Response, utils.Thrift)
+	}
+	return thriftcodec.RemoveCollectionNotesResp2thriftResp(res), nil
+}
+
+func ReorderCollectionRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.ReorderCollectionRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("ReorderCollection", utils.Request, utils.Thrift)
+	}
+	return thriftcodec.ReorderCollectionReq2thriftReq(req), nil
+}
+
+func ReorderCollectionResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.ReorderCollectionResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("ReorderCollection", utils.Response, utils.Thrift)
+	}
+	return thriftcodec.ReorderCollectionResp2thriftResp(res), nil
+}
+
+func GetCollectionRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.GetCollectionRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("GetCollection", utils.Request, utils.Thrift)
+	}
+	return thriftcodec.GetCollectionReq2thriftReq(req), nil
+}
+
+func GetCollectionResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.GetCollectionResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("GetCollection", utils.Response, utils.Thrift)
+	}
+	return thriftcodec.GetCollectionResp2thriftResp(res), nil
+}
+
+// UploadAttachmentRequest reads the whole attachment content, Thrift has no
+// streams to send it in chunks.
+func UploadAttachmentRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.UploadAttachmentRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("UploadAttachment", utils.Request, utils.Thrift)
+	}
+
+	content, err := ioutil.ReadAll(req.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sharethrift.UploadAttachmentRequest{
+		NoteID:  req.NoteID,
+		Name:    req.Name,
+		Content: content,
+	}, nil
+}
+
+func UploadAttachmentResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.UploadAttachmentResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("UploadAttachment", utils.Response, utils.Thrift)
+	}
+	return thriftcodec.UploadAttachmentResp2thriftResp(res), nil
+}
+
+func DownloadAttachmentRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.DownloadAttachmentRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("DownloadAttachment", utils.Request, utils.Thrift)
+	}
+	return thriftcodec.DownloadAttachmentReq2thriftReq(req), nil
+}
+
+// DownloadAttachmentResponse reads the whole attachment content and closes it,
+// Thrift has no streams to send it in chunks.
+func DownloadAttachmentResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.DownloadAttachmentResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("DownloadAttachment", utils.Response, utils.Thrift)
+	}
+
+	if res.Error != "" {
+		return &sharethrift.DownloadAttachmentResponse{Error: res.Error}, nil
+	}
+	defer res.Content.Close()
+
+	content, err := ioutil.ReadAll(res.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sharethrift.DownloadAttachmentResponse{
+		Attachment: thriftcodec.Attachment2thriftAttachment(res.Attachment),
+		Content:    content,
+	}, nil
+}
+
+func AddCommentRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.AddCommentRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("AddComment", utils.Request, utils.Thrift)
+	}
+	return thriftcodec.AddCommentReq2thriftReq(req), nil
+}
+
+func AddCommentResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.AddCommentResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("AddComment", utils.Response, utils.Thrift)
+	}
+	return thriftcodec.AddCommentResp2thriftResp(res), nil
+}
+
+func ListCommentsRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.ListCommentsRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("ListComments", utils.Request, utils.Thrift)
+	}
+	return thriftcodec.ListCommentsReq2thriftReq(req), nil
+}
+
+func ListCommentsResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.ListCommentsResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("ListComments", utils.Response, utils.Thrift)
+	}
+	return thriftcodec.ListCommentsResp2thriftResp(res), nil
+}
+
+func ResolveCommentRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.ResolveCommentRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("ResolveComment", utils.Request, utils.Thrift)
+	}
+	return thriftcodec.ResolveCommentReq2thriftReq(req), nil
+}
+
+func ResolveCommentResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.ResolveCommentResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("ResolveComment", utils.Response, utils.Thrift)
+	}
+	return thriftcodec.ResolveCommentResp2thriftResp(res), nil
+}
+
+func GetNoteStatsRequest(_ context.Context, request interface{}) ( interface{}, error)  {
+	req, ok := request.(requests.GetNoteStatsRequest)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("GetNoteStats", utils.Request, utils.Thrift)
+	}
+	return thriftcodec.GetNoteStatsReq2thriftReq(req), nil
+}
+
+func GetNoteStatsResponse(_ context.Context, resp interface{}) (interface{}, error) {
+	res, ok := resp.(responses.GetNoteStatsResponse)
+	if !ok {
+		return nil, utils.ErrorCodecCasting("GetNoteStats", utils.Response, utils.Thrift)
+	}
+	return thriftcodec.GetNoteStatsResp2thriftResp(res), nil
+}
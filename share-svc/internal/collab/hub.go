@@ -0,0 +1,647 @@
+package collab
+
+import (
+	"context"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// sessionBuffer is the number of messages queued for a session, a session
+	// falling further behind is closed since it cannot skip revisions.
+	sessionBuffer = 256
+
+	// storeTimeout bounds loading and saving a snapshot, and logging an edit.
+	storeTimeout = 30 * time.Second
+
+	// appendRetries bounds the attempts to log an edit whose revision keeps
+	// being taken by the other instances.
+	appendRetries = 8
+
+	anonymousUser = "anonymous"
+)
+
+// Store loads and persists the snapshots of the notes being edited.
+type Store interface {
+	// NoteSnapshot returns the content of the note, its revision and the seq
+	// of its last write.
+	NoteSnapshot(ctx context.Context, id string) (content string, revision, seq int64, err error)
+
+	// SaveNoteSnapshot returns the seq of the write. It must fail with
+	// common.ErrorEditConflict when the note has been written since seq, and
+	// with common.ErrorNoteNotFound when the note is no longer visible.
+	SaveNoteSnapshot(ctx context.Context, id, content string, revision, seq int64) (next int64, err error)
+
+	// AppendNoteEdit logs the edit producing a revision, it must fail with
+	// common.ErrorEditRevisionTaken when the revision is logged already.
+	AppendNoteEdit(ctx context.Context, edit model.NoteEdit) error
+
+	// NoteEdits returns the logged edits after the given revision, in order.
+	NoteEdits(ctx context.Context, id string, after int64) (edits []model.NoteEdit, err error)
+
+	// DeleteNoteEdits deletes the logged edits from revision from to revision
+	// to, both included, to is negative to delete the edits after from.
+	DeleteNoteEdits(ctx context.Context, id string, from, to int64) error
+}
+
+// Hub merges the concurrent edits made to notes. Every note being edited has
+// a room holding its content, the operations of its recent revisions and its
+// sessions. The content is persisted periodically and when the last session
+// leaves.
+//
+// The rooms of a note on different instances share the edit log of the
+// store: an edit is applied once it is logged at the next revision, and the
+// edits logged by the other instances are applied every relay interval. The
+// gateway still routes the sessions of a note to one instance when it can.
+type Hub struct {
+	store    Store
+	interval time.Duration
+	relay    time.Duration
+	history  int
+
+	mu    sync.Mutex
+	rooms map[string]*room
+	// closed is set once Close is called, no session is opened past it.
+	closed bool
+}
+
+// NewHub returns a hub saving snapshots every interval, applying the edits
+// of the other instances every relay, and keeping the operations of the last
+// history revisions to transform late operations.
+func NewHub(store Store, interval, relay time.Duration, history int) *Hub {
+	return &Hub{
+		store:    store,
+		interval: interval,
+		relay:    relay,
+		history:  history,
+		rooms:    make(map[string]*room),
+	}
+}
+
+// Join opens a session on the note with the given id, its first message is
+// the join message carrying the content.
+func (h *Hub) Join(ctx context.Context, id, user string) (model.EditSession, error) {
+	if user == "" {
+		user = anonymousUser
+	}
+
+	for {
+		h.mu.Lock()
+		if h.closed {
+			h.mu.Unlock()
+			return nil, common.ErrorEditShutdown
+		}
+
+		r, ok := h.rooms[id]
+		if !ok {
+			r = newRoom(h, id)
+			h.rooms[id] = r
+			go r.load()
+		}
+		h.mu.Unlock()
+
+		select {
+		case <-r.ready:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		if r.err != nil {
+			return nil, r.err
+		}
+
+		if s := r.join(user); s != nil {
+			return s, nil
+		}
+
+		// the room is closing, join the next one once it is gone
+		select {
+		case <-r.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Close ends the sessions of every room with common.ErrorEditShutdown, and
+// returns once the rooms saved their content. The sessions joined afterwards
+// fail with common.ErrorEditShutdown.
+func (h *Hub) Close() error {
+	h.mu.Lock()
+	h.closed = true
+	rooms := make([]*room, 0, len(h.rooms))
+	for _, r := range h.rooms {
+		rooms = append(rooms, r)
+	}
+	h.mu.Unlock()
+
+	for _, r := range rooms {
+		<-r.ready
+		if r.err != nil {
+			// the room is already gone
+			continue
+		}
+
+		r.fail(common.ErrorEditShutdown)
+		<-r.done
+	}
+	return nil
+}
+
+func (h *Hub) remove(r *room) {
+	h.mu.Lock()
+	if h.rooms[r.id] == r {
+		delete(h.rooms, r.id)
+	}
+	h.mu.Unlock()
+	close(r.done)
+}
+
+type room struct {
+	hub *Hub
+	id  string
+
+	// ready is closed once the snapshot is loaded, or err is set.
+	ready chan struct{}
+	err   error
+
+	// stop is closed when the room starts closing, done once it is gone.
+	stop chan struct{}
+	done chan struct{}
+
+	mu       sync.Mutex
+	closing  bool
+	content  string
+	revision int64
+	saved    int64
+
+	// seq is the seq of the last write of the note, any other write made to
+	// the note conflicts with the room.
+	seq int64
+
+	// history holds the operations producing the revisions after base.
+	base    int64
+	history [][]model.EditOp
+
+	sessions map[*session]struct{}
+}
+
+func newRoom(h *Hub, id string) *room {
+	return &room{
+		hub:      h,
+		id:       id,
+		ready:    make(chan struct{}),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+		sessions: make(map[*session]struct{}),
+	}
+}
+
+func (r *room) load() {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	r.content, r.revision, r.seq, r.err = r.hub.store.NoteSnapshot(ctx, r.id)
+	if r.err == nil {
+		r.base, r.saved = r.revision, r.revision
+		r.err = r.loadEdits(ctx)
+	}
+
+	if r.err != nil {
+		r.hub.remove(r)
+		close(r.ready)
+		return
+	}
+
+	close(r.ready)
+	go r.run()
+}
+
+// loadEdits applies the edits the other instances logged since the snapshot
+// was saved. The edits logged on a content overwritten since cannot apply,
+// they are dropped.
+func (r *room) loadEdits(ctx context.Context) error {
+	edits, err := r.hub.store.NoteEdits(ctx, r.id, r.revision)
+	if err != nil {
+		return err
+	}
+
+	if err = r.applyEdits(edits); err != common.ErrorEditConflict {
+		return err
+	}
+
+	r.content, r.revision, r.seq, err = r.hub.store.NoteSnapshot(ctx, r.id)
+	if err != nil {
+		return err
+	}
+
+	r.base, r.saved, r.history = r.revision, r.revision, nil
+	return r.hub.store.DeleteNoteEdits(ctx, r.id, r.revision+1, -1)
+}
+
+// relayEdits applies the edits the other instances logged since the last
+// revision of the room.
+func (r *room) relayEdits() error {
+	r.mu.Lock()
+	after := r.revision
+	r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	edits, err := r.hub.store.NoteEdits(ctx, r.id, after)
+	if err != nil || len(edits) == 0 {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.applyEdits(edits)
+}
+
+// applyEdits applies the logged edits following the last revision of the
+// room, and broadcasts them. It fails with common.ErrorEditConflict when
+// the edits do not follow the content of the room.
+func (r *room) applyEdits(edits []model.NoteEdit) error {
+	for _, edit := range edits {
+		if edit.Revision <= r.revision {
+			// applied already
+			continue
+		}
+
+		if edit.Revision != r.revision+1 {
+			return common.ErrorEditConflict
+		}
+
+		content, err := Apply(r.content, edit.Operation)
+		if err != nil {
+			return common.ErrorEditConflict
+		}
+
+		r.commit(content, edit.Operation)
+		r.broadcast(model.EditMessage{
+			Type:      model.EditMessageOperation,
+			User:      edit.User,
+			Revision:  r.revision,
+			Operation: edit.Operation,
+		}, nil)
+	}
+	return nil
+}
+
+func (r *room) run() {
+	ticker := time.NewTicker(r.hub.interval)
+	defer ticker.Stop()
+
+	relay := time.NewTicker(r.hub.relay)
+	defer relay.Stop()
+
+	for {
+		select {
+		case <-relay.C:
+			if err := r.relayEdits(); err == common.ErrorEditConflict {
+				r.fail(err)
+			}
+		case <-ticker.C:
+			r.mu.Lock()
+			if len(r.sessions) == 0 {
+				// every joiner gave up before joining
+				r.close()
+			}
+			r.mu.Unlock()
+
+			if err := r.save(); err == common.ErrorEditConflict || err == common.ErrorNoteNotFound {
+				r.fail(err)
+			}
+		case <-r.stop:
+			r.save()
+			r.hub.remove(r)
+			return
+		}
+	}
+}
+
+// save persists the content if it changed since the last save, transient
+// errors are retried on the next tick.
+func (r *room) save() error {
+	r.mu.Lock()
+	if r.revision == r.saved {
+		r.mu.Unlock()
+		return nil
+	}
+	content, revision, saved := r.content, r.revision, r.saved
+	r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	// only the run goroutine saves, so seq is not shared
+	seq, err := r.hub.store.SaveNoteSnapshot(ctx, r.id, content, revision, r.seq)
+	if err == common.ErrorEditConflict {
+		seq, err = r.resave(ctx, content, revision, saved)
+	}
+
+	switch err {
+	case nil:
+	case common.ErrorEditConflict, common.ErrorNoteNotFound:
+		// the edits made on an overwritten content cannot apply to the new one
+		r.hub.store.DeleteNoteEdits(ctx, r.id, saved+1, -1)
+		return err
+	default:
+		return err
+	}
+
+	r.mu.Lock()
+	r.saved, r.seq = revision, seq
+	r.mu.Unlock()
+
+	// the rooms of the other instances apply the edits within a few relays
+	if trim := revision - int64(r.hub.history); trim > 0 {
+		r.hub.store.DeleteNoteEdits(ctx, r.id, 0, trim)
+	}
+	return nil
+}
+
+// resave saves the content again when the conflicting write is the save of
+// a room of another instance, which raised the revision.
+func (r *room) resave(ctx context.Context, content string, revision, saved int64) (seq int64, err error) {
+	_, stored, seq, err := r.hub.store.NoteSnapshot(ctx, r.id)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case stored <= saved:
+		// the note has been written by something else than a room
+		return 0, common.ErrorEditConflict
+	case stored >= revision:
+		return seq, nil
+	default:
+		return r.hub.store.SaveNoteSnapshot(ctx, r.id, content, revision, seq)
+	}
+}
+
+// fail ends every session with err and closes the room.
+func (r *room) fail(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failLocked(err)
+}
+
+func (r *room) failLocked(err error) {
+	for s := range r.sessions {
+		delete(r.sessions, s)
+		s.finish(err)
+	}
+	r.close()
+}
+
+func (r *room) join(user string) *session {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closing {
+		return nil
+	}
+
+	s := &session{
+		room: r,
+		user: user,
+		out:  make(chan model.EditMessage, sessionBuffer),
+		done: make(chan struct{}),
+	}
+	r.sessions[s] = struct{}{}
+
+	s.push(model.EditMessage{
+		Type:     model.EditMessageJoin,
+		NoteID:   r.id,
+		User:     user,
+		Revision: r.revision,
+		Content:  r.content,
+	})
+	r.membersChanged()
+	return s
+}
+
+// drop ends the session with err, io.EOF when the client leaves.
+func (r *room) drop(s *session, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.sessions[s]; !ok {
+		return
+	}
+	delete(r.sessions, s)
+	s.finish(err)
+	r.membersChanged()
+}
+
+// apply transforms the operation made by s at the given revision against the
+// operations applied since, logs it at the next revision, applies it and
+// broadcasts it. When another instance logged the revision first, its edits
+// are applied and the operation is transformed against them.
+func (r *room) apply(s *session, revision int64, ops []model.EditOp) (err error) {
+	var content string
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	for i := 0; ; i++ {
+		if _, ok := r.sessions[s]; !ok {
+			return common.ErrorEditSessionClosed
+		}
+
+		if revision < r.base || revision > r.revision {
+			return common.ErrorEditRevision
+		}
+
+		for _, concurrent := range r.history[revision-r.base:] {
+			ops, _, err = Transform(ops, concurrent)
+			if err != nil {
+				return err
+			}
+		}
+		revision = r.revision
+
+		content, err = Apply(r.content, ops)
+		if err != nil {
+			return err
+		}
+
+		err = r.hub.store.AppendNoteEdit(ctx, model.NoteEdit{
+			NoteID:    r.id,
+			Revision:  revision + 1,
+			User:      s.user,
+			Operation: ops,
+			CreatedAt: time.Now().Unix(),
+		})
+		if err != common.ErrorEditRevisionTaken || i == appendRetries {
+			break
+		}
+
+		edits, err := r.hub.store.NoteEdits(ctx, r.id, r.revision)
+		if err != nil {
+			return err
+		}
+
+		if err = r.applyEdits(edits); err != nil {
+			r.failLocked(err)
+			return err
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	r.commit(content, ops)
+	r.broadcast(model.EditMessage{
+		Type:      model.EditMessageOperation,
+		User:      s.user,
+		Revision:  r.revision,
+		Operation: ops,
+	}, s)
+
+	if !r.send(s, model.EditMessage{Type: model.EditMessageAck, Revision: r.revision}) {
+		r.membersChanged()
+	}
+	return nil
+}
+
+// commit makes content, produced by ops, the next revision.
+func (r *room) commit(content string, ops []model.EditOp) {
+	r.content = content
+	r.revision++
+	r.history = append(r.history, ops)
+	if len(r.history) > 2*r.hub.history {
+		trim := len(r.history) - r.hub.history
+		r.history = append([][]model.EditOp(nil), r.history[trim:]...)
+		r.base += int64(trim)
+	}
+}
+
+// broadcast queues msg for every session but except.
+func (r *room) broadcast(msg model.EditMessage, except *session) {
+	var dropped bool
+	for s := range r.sessions {
+		if s != except && !r.send(s, msg) {
+			dropped = true
+		}
+	}
+
+	if dropped {
+		r.membersChanged()
+	}
+}
+
+// send queues msg for s, or ends s if it cannot keep up.
+func (r *room) send(s *session, msg model.EditMessage) bool {
+	if s.push(msg) {
+		return true
+	}
+	delete(r.sessions, s)
+	s.finish(common.ErrorEditSessionLagging)
+	return false
+}
+
+// membersChanged broadcasts the presence, or closes the room once empty.
+func (r *room) membersChanged() {
+	if len(r.sessions) == 0 {
+		r.close()
+		return
+	}
+
+	users := make([]string, 0, len(r.sessions))
+	for s := range r.sessions {
+		users = append(users, s.user)
+	}
+	sort.Strings(users)
+
+	r.broadcast(model.EditMessage{
+		Type:     model.EditMessagePresence,
+		Revision: r.revision,
+		Users:    users,
+	}, nil)
+}
+
+func (r *room) close() {
+	if !r.closing {
+		r.closing = true
+		close(r.stop)
+	}
+}
+
+// session implements model.EditSession for a client of the room.
+type session struct {
+	room *room
+	user string
+	out  chan model.EditMessage
+
+	once sync.Once
+	done chan struct{}
+	err  error
+}
+
+func (s *session) Send(msg model.EditMessage) (err error) {
+	switch msg.Type {
+	case model.EditMessageOperation:
+		err = s.room.apply(s, msg.Revision, msg.Operation)
+	default:
+		err = common.ErrorUnknownEditMessage
+	}
+
+	if err != nil && err != common.ErrorEditSessionClosed {
+		s.room.drop(s, err)
+	}
+	return err
+}
+
+func (s *session) Recv() (model.EditMessage, error) {
+	select {
+	case msg := <-s.out:
+		return msg, nil
+	case <-s.done:
+		// deliver what was queued before the session ended
+		select {
+		case msg := <-s.out:
+			return msg, nil
+		default:
+			return model.EditMessage{}, s.err
+		}
+	}
+}
+
+func (s *session) Close() error {
+	s.room.drop(s, io.EOF)
+	return nil
+}
+
+func (s *session) push(msg model.EditMessage) bool {
+	select {
+	case s.out <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+// finish ends the session, queueing an error message unless the client left.
+func (s *session) finish(err error) {
+	s.once.Do(func() {
+		if err != io.EOF {
+			s.push(model.EditMessage{
+				Type:  model.EditMessageError,
+				Error: err.Error(),
+			})
+		}
+		s.err = err
+		close(s.done)
+	})
+}
@@ -0,0 +1,223 @@
+package collab
+
+import (
+	"context"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryStore is the store shared by the hubs of several instances.
+type memoryStore struct {
+	mu       sync.Mutex
+	content  string
+	revision int64
+	seq      int64
+	edits    []model.NoteEdit
+}
+
+func (m *memoryStore) NoteSnapshot(_ context.Context, _ string) (string, int64, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.content, m.revision, m.seq, nil
+}
+
+func (m *memoryStore) SaveNoteSnapshot(_ context.Context, _, content string, revision, seq int64) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if seq != m.seq {
+		return 0, common.ErrorEditConflict
+	}
+	m.content, m.revision = content, revision
+	m.seq++
+	return m.seq, nil
+}
+
+func (m *memoryStore) AppendNoteEdit(_ context.Context, edit model.NoteEdit) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, logged := range m.edits {
+		if logged.Revision == edit.Revision {
+			return common.ErrorEditRevisionTaken
+		}
+	}
+	m.edits = append(m.edits, edit)
+	return nil
+}
+
+func (m *memoryStore) NoteEdits(_ context.Context, _ string, after int64) (edits []model.NoteEdit, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, edit := range m.edits {
+		if edit.Revision > after {
+			edits = append(edits, edit)
+		}
+	}
+	return edits, nil
+}
+
+func (m *memoryStore) DeleteNoteEdits(_ context.Context, _ string, from, to int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	kept := m.edits[:0]
+	for _, edit := range m.edits {
+		if edit.Revision < from || (to >= 0 && edit.Revision > to) {
+			kept = append(kept, edit)
+		}
+	}
+	m.edits = kept
+	return nil
+}
+
+// client applies the messages of its session the way an ot.js client does.
+type client struct {
+	t        *testing.T
+	session  model.EditSession
+	doc      string
+	revision int64
+	pending  []model.EditOp
+}
+
+func join(t *testing.T, h *Hub, user string) *client {
+	session, err := h.Join(context.Background(), "note", user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := session.Recv()
+	if err != nil || msg.Type != model.EditMessageJoin {
+		t.Fatalf("join: %+v %v", msg, err)
+	}
+	return &client{t: t, session: session, doc: msg.Content, revision: msg.Revision}
+}
+
+func (c *client) insert(text string) {
+	ops := []model.EditOp{{Insert: text}}
+	if n := len([]rune(c.doc)); n > 0 {
+		ops = append(ops, model.EditOp{Retain: n})
+	}
+
+	doc, err := Apply(c.doc, ops)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	c.doc, c.pending = doc, ops
+
+	if err = c.session.Send(model.EditMessage{Type: model.EditMessageOperation, Revision: c.revision, Operation: ops}); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// sync receives until the client is at revision.
+func (c *client) sync(revision int64) {
+	for c.revision < revision {
+		msg, err := c.session.Recv()
+		if err != nil {
+			c.t.Fatal(err)
+		}
+
+		switch msg.Type {
+		case model.EditMessageAck:
+			c.revision, c.pending = msg.Revision, nil
+		case model.EditMessageOperation:
+			ops := msg.Operation
+			if c.pending != nil {
+				if c.pending, ops, err = Transform(c.pending, ops); err != nil {
+					c.t.Fatal(err)
+				}
+			}
+
+			if c.doc, err = Apply(c.doc, ops); err != nil {
+				c.t.Fatal(err)
+			}
+			c.revision = msg.Revision
+		case model.EditMessageError:
+			c.t.Fatal(msg.Error)
+		}
+	}
+}
+
+func TestHubAcrossInstances(t *testing.T) {
+	store := &memoryStore{content: "note", revision: 3}
+	a := NewHub(store, time.Hour, 5*time.Millisecond, 100)
+	b := NewHub(store, time.Hour, 5*time.Millisecond, 100)
+
+	alice, bob := join(t, a, "alice"), join(t, b, "bob")
+
+	// both edits are based on revision 3, one of them is logged at 4 and the
+	// other one is transformed and logged at 5
+	alice.insert("a")
+	bob.insert("b")
+
+	alice.sync(5)
+	bob.sync(5)
+
+	if alice.doc != bob.doc || len(alice.doc) != len("abnote") {
+		t.Fatalf("diverged: %q %q", alice.doc, bob.doc)
+	}
+
+	alice.session.Close()
+	bob.session.Close()
+	a.Close()
+	b.Close()
+
+	if store.content != alice.doc || store.revision != 5 {
+		t.Fatalf("saved %q at %d, want %q at 5", store.content, store.revision, alice.doc)
+	}
+}
+
+func TestHubDropsStaleEdits(t *testing.T) {
+	// the edit does not apply to the content, which was overwritten
+	store := &memoryStore{
+		content:  "new",
+		revision: 1,
+		edits:    []model.NoteEdit{{NoteID: "note", Revision: 2, Operation: []model.EditOp{{Retain: 10}}}},
+	}
+	h := NewHub(store, time.Hour, time.Hour, 100)
+
+	c := join(t, h, "alice")
+	if c.doc != "new" || c.revision != 1 {
+		t.Fatalf("joined %q at %d", c.doc, c.revision)
+	}
+
+	if edits, _ := store.NoteEdits(context.Background(), "note", 1); len(edits) != 0 {
+		t.Fatalf("stale edits kept: %+v", edits)
+	}
+
+	c.session.Close()
+	h.Close()
+}
+
+func TestHubClose(t *testing.T) {
+	store := &memoryStore{content: "note", revision: 3}
+	h := NewHub(store, time.Hour, time.Hour, 100)
+
+	alice := join(t, h, "alice")
+	alice.insert("a")
+	alice.sync(4)
+
+	h.Close()
+
+	// the session ends with an error message, then the error
+	msg, err := alice.session.Recv()
+	if err != nil || msg.Type != model.EditMessageError || msg.Error != common.ErrorEditShutdown.Error() {
+		t.Fatalf("got %+v %v", msg, err)
+	}
+	if _, err = alice.session.Recv(); err != common.ErrorEditShutdown {
+		t.Fatalf("got %v, want %v", err, common.ErrorEditShutdown)
+	}
+
+	if store.content != alice.doc || store.revision != 4 {
+		t.Fatalf("saved %q at %d, want %q at 4", store.content, store.revision, alice.doc)
+	}
+
+	if _, err = h.Join(context.Background(), "note", "bob"); err != common.ErrorEditShutdown {
+		t.Fatalf("joined after Close: %v", err)
+	}
+}
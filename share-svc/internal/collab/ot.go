@@ -0,0 +1,220 @@
+package collab
+
+import (
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"strings"
+	"unicode/utf8"
+)
+
+// The operations follow the ot.js model: a sequence of retain, insert and
+// delete components spanning the whole document.
+
+func isRetain(op model.EditOp) bool {
+	return op.Retain > 0 && op.Insert == "" && op.Delete == 0
+}
+
+func isInsert(op model.EditOp) bool {
+	return op.Insert != "" && op.Retain == 0 && op.Delete == 0
+}
+
+func isDelete(op model.EditOp) bool {
+	return op.Delete > 0 && op.Retain == 0 && op.Insert == ""
+}
+
+// validate checks every component of the operation sets exactly one field.
+func validate(ops []model.EditOp) error {
+	for _, op := range ops {
+		if !isRetain(op) && !isInsert(op) && !isDelete(op) {
+			return common.ErrorInvalidEditOperation
+		}
+	}
+	return nil
+}
+
+// baseLen returns the length of the documents the operation applies to.
+func baseLen(ops []model.EditOp) (n int) {
+	for _, op := range ops {
+		n += op.Retain + op.Delete
+	}
+	return
+}
+
+// Apply returns the document produced by applying the operation to doc.
+func Apply(doc string, ops []model.EditOp) (string, error) {
+	if err := validate(ops); err != nil {
+		return "", err
+	}
+
+	runes := []rune(doc)
+	if baseLen(ops) != len(runes) {
+		return "", common.ErrorInvalidEditOperation
+	}
+
+	var (
+		sb  strings.Builder
+		pos int
+	)
+	sb.Grow(len(doc))
+	for _, op := range ops {
+		switch {
+		case isRetain(op):
+			sb.WriteString(string(runes[pos : pos+op.Retain]))
+			pos += op.Retain
+		case isInsert(op):
+			sb.WriteString(op.Insert)
+		case isDelete(op):
+			pos += op.Delete
+		}
+	}
+	return sb.String(), nil
+}
+
+// Transform returns a' and b' such that applying a then b' gives the same
+// document as applying b then a', for operations a and b made concurrently on
+// the same document. When both insert at the same position, the insert of a
+// is placed first.
+func Transform(a, b []model.EditOp) (a1, b1 []model.EditOp, err error) {
+	if err = validate(a); err != nil {
+		return nil, nil, err
+	}
+	if err = validate(b); err != nil {
+		return nil, nil, err
+	}
+	if baseLen(a) != baseLen(b) {
+		return nil, nil, common.ErrorInvalidEditOperation
+	}
+
+	var (
+		ca, cb = newCursor(a), newCursor(b)
+		ba, bb builder
+	)
+	for ca.ok || cb.ok {
+		switch {
+		case ca.ok && isInsert(ca.op):
+			ba.insert(ca.op.Insert)
+			bb.retain(utf8.RuneCountInString(ca.op.Insert))
+			ca.next()
+		case cb.ok && isInsert(cb.op):
+			ba.retain(utf8.RuneCountInString(cb.op.Insert))
+			bb.insert(cb.op.Insert)
+			cb.next()
+		case !ca.ok || !cb.ok:
+			return nil, nil, common.ErrorInvalidEditOperation
+		case isRetain(ca.op) && isRetain(cb.op):
+			n := min(ca.op.Retain, cb.op.Retain)
+			ba.retain(n)
+			bb.retain(n)
+			ca.consume(n)
+			cb.consume(n)
+		case isDelete(ca.op) && isDelete(cb.op):
+			// both deleted the same text
+			n := min(ca.op.Delete, cb.op.Delete)
+			ca.consume(n)
+			cb.consume(n)
+		case isDelete(ca.op) && isRetain(cb.op):
+			n := min(ca.op.Delete, cb.op.Retain)
+			ba.delete(n)
+			ca.consume(n)
+			cb.consume(n)
+		case isRetain(ca.op) && isDelete(cb.op):
+			n := min(ca.op.Retain, cb.op.Delete)
+			bb.delete(n)
+			ca.consume(n)
+			cb.consume(n)
+		}
+	}
+	return ba.ops, bb.ops, nil
+}
+
+// cursor walks the components of an operation, retains and deletes can be
+// consumed partially.
+type cursor struct {
+	ops []model.EditOp
+	i   int
+	op  model.EditOp
+	ok  bool
+}
+
+func newCursor(ops []model.EditOp) *cursor {
+	c := &cursor{ops: ops}
+	c.next()
+	return c
+}
+
+func (c *cursor) next() {
+	if c.i < len(c.ops) {
+		c.op, c.ok = c.ops[c.i], true
+		c.i++
+		return
+	}
+	c.op, c.ok = model.EditOp{}, false
+}
+
+func (c *cursor) consume(n int) {
+	if c.op.Retain > 0 {
+		c.op.Retain -= n
+	} else {
+		c.op.Delete -= n
+	}
+
+	if c.op.Retain == 0 && c.op.Delete == 0 {
+		c.next()
+	}
+}
+
+// builder appends components to an operation, merging adjacent components
+// of the same kind and keeping inserts ahead of deletes.
+type builder struct {
+	ops []model.EditOp
+}
+
+func (b *builder) retain(n int) {
+	if n == 0 {
+		return
+	}
+	if l := len(b.ops); l > 0 && isRetain(b.ops[l-1]) {
+		b.ops[l-1].Retain += n
+		return
+	}
+	b.ops = append(b.ops, model.EditOp{Retain: n})
+}
+
+func (b *builder) insert(s string) {
+	if s == "" {
+		return
+	}
+
+	l := len(b.ops)
+	switch {
+	case l > 0 && isInsert(b.ops[l-1]):
+		b.ops[l-1].Insert += s
+	case l > 0 && isDelete(b.ops[l-1]):
+		if l > 1 && isInsert(b.ops[l-2]) {
+			b.ops[l-2].Insert += s
+			return
+		}
+		b.ops = append(b.ops, b.ops[l-1])
+		b.ops[l-1] = model.EditOp{Insert: s}
+	default:
+		b.ops = append(b.ops, model.EditOp{Insert: s})
+	}
+}
+
+func (b *builder) delete(n int) {
+	if n == 0 {
+		return
+	}
+	if l := len(b.ops); l > 0 && isDelete(b.ops[l-1]) {
+		b.ops[l-1].Delete += n
+		return
+	}
+	b.ops = append(b.ops, model.EditOp{Delete: n})
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
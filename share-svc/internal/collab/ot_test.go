@@ -0,0 +1,124 @@
+package collab
+
+import (
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"testing"
+)
+
+func TestTransformConverges(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		doc  string
+		a, b []model.EditOp
+		want string
+	}{
+		{
+			name: "inserts at the same position",
+			doc:  "abc",
+			a:    []model.EditOp{{Retain: 1}, {Insert: "x"}, {Retain: 2}},
+			b:    []model.EditOp{{Retain: 1}, {Insert: "y"}, {Retain: 2}},
+			want: "axybc",
+		},
+		{
+			name: "inserts into an empty note",
+			doc:  "",
+			a:    []model.EditOp{{Insert: "a"}},
+			b:    []model.EditOp{{Insert: "b"}},
+			want: "ab",
+		},
+		{
+			name: "insert into deleted text",
+			doc:  "abcdef",
+			a:    []model.EditOp{{Retain: 2}, {Insert: "X"}, {Retain: 4}},
+			b:    []model.EditOp{{Retain: 1}, {Delete: 3}, {Retain: 2}},
+			want: "aXef",
+		},
+		{
+			name: "overlapping deletes",
+			doc:  "abcdef",
+			a:    []model.EditOp{{Retain: 1}, {Delete: 3}, {Retain: 2}},
+			b:    []model.EditOp{{Retain: 2}, {Delete: 3}, {Retain: 1}},
+			want: "af",
+		},
+		{
+			name: "insert at the end of deleted note",
+			doc:  "ab",
+			a:    []model.EditOp{{Retain: 2}, {Insert: "c"}},
+			b:    []model.EditOp{{Delete: 2}},
+			want: "c",
+		},
+		{
+			name: "runes",
+			doc:  "héllo",
+			a:    []model.EditOp{{Retain: 1}, {Insert: "e"}, {Delete: 1}, {Retain: 3}},
+			b:    []model.EditOp{{Retain: 5}, {Insert: "ü"}},
+			want: "helloü",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			a1, b1, err := Transform(tc.a, tc.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, order := range []struct {
+				first, then []model.EditOp
+			}{
+				{tc.a, b1},
+				{tc.b, a1},
+			} {
+				doc, err := Apply(tc.doc, order.first)
+				if err == nil {
+					doc, err = Apply(doc, order.then)
+				}
+				if err != nil || doc != tc.want {
+					t.Fatalf("applying %v then %v: got %q %v, want %q", order.first, order.then, doc, err, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestInvalidOperation(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		doc  string
+		a, b []model.EditOp
+	}{
+		{
+			name: "component setting two fields",
+			doc:  "ab",
+			a:    []model.EditOp{{Retain: 1, Insert: "x"}, {Retain: 1}},
+			b:    []model.EditOp{{Retain: 2}},
+		},
+		{
+			name: "empty component",
+			doc:  "ab",
+			a:    []model.EditOp{{}, {Retain: 2}},
+			b:    []model.EditOp{{Retain: 2}},
+		},
+		{
+			name: "shorter than the note",
+			doc:  "ab",
+			a:    []model.EditOp{{Retain: 1}},
+			b:    []model.EditOp{{Retain: 2}},
+		},
+		{
+			name: "longer than the note",
+			doc:  "ab",
+			a:    []model.EditOp{{Retain: 1}, {Delete: 2}},
+			b:    []model.EditOp{{Delete: 2}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Apply(tc.doc, tc.a); err != common.ErrorInvalidEditOperation {
+				t.Fatalf("apply: got %v", err)
+			}
+
+			if _, _, err := Transform(tc.a, tc.b); err != common.ErrorInvalidEditOperation {
+				t.Fatalf("transform: got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"github.com/klauspost/compress/zstd"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+)
+
+// The codecs, named as the HTTP content codings. The content compressed with
+// None is stored as is.
+const (
+	None = "none"
+	Gzip = "gzip"
+	Zstd = "zstd"
+)
+
+// ErrUnknownCodec is returned for the content compressed with a codec this
+// version does not know.
+var ErrUnknownCodec = errors.New("compression codec is not supported")
+
+// NewWriter returns a writer compressing to w with codec, the writer must be
+// closed to flush the compressed data, w is not closed.
+func NewWriter(codec string, w io.Writer) (io.WriteCloser, error) {
+	switch codec {
+	case Gzip:
+		return gzip.NewWriter(w), nil
+	case Zstd:
+		return zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+	default:
+		return nil, ErrUnknownCodec
+	}
+}
+
+// NewReader returns a reader decompressing r with codec, closing it closes
+// r when r is an io.Closer.
+func NewReader(codec string, r io.Reader) (io.ReadCloser, error) {
+	var (
+		rc io.ReadCloser
+		err error
+	)
+
+	switch codec {
+	case Gzip:
+		rc, err = gzip.NewReader(r)
+	case Zstd:
+		var dec *zstd.Decoder
+		if dec, err = zstd.NewReader(r, zstd.WithDecoderConcurrency(1)); err == nil {
+			rc = dec.IOReadCloser()
+		}
+	default:
+		err = ErrUnknownCodec
+	}
+
+	if err != nil {
+		if c, ok := r.(io.Closer); ok {
+			c.Close()
+		}
+		return nil, err
+	}
+	return &reader{ReadCloser: rc, r: r}, nil
+}
+
+// Compress returns data compressed with codec.
+func Compress(codec string, data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w, err := NewWriter(codec, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = w.Write(data); err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Negotiate returns the codec preferred by an Accept-Encoding header, None
+// when the client accepts none of them. "*" stands for the codecs the header
+// does not list, so a codec refused with q=0 is never chosen through it.
+func Negotiate(acceptEncoding string) string {
+	type coding struct {
+		name string
+		q float64
+	}
+
+	var (
+		codings []coding
+		listed = make(map[string]bool)
+	)
+
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+
+		codings = append(codings, coding{name: name, q: q})
+		listed[name] = true
+	}
+
+	var (
+		codec = None
+		best = 0.0
+	)
+
+	for _, c := range codings {
+		names := []string{c.name}
+		if c.name == "*" {
+			names = nil
+			for _, name := range []string{Gzip, Zstd} {
+				if !listed[name] {
+					names = append(names, name)
+				}
+			}
+		}
+
+		for _, name := range names {
+			if (name != Gzip && name != Zstd) || c.q <= best {
+				continue
+			}
+			codec, best = name, c.q
+		}
+	}
+	return codec
+}
+
+type reader struct {
+	io.ReadCloser
+	r io.Reader
+}
+
+func (r *reader) Close() error {
+	err := r.ReadCloser.Close()
+	if c, ok := r.r.(io.Closer); ok {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
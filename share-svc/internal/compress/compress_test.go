@@ -0,0 +1,77 @@
+package compress
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	for _, tc := range []struct {
+		acceptEncoding string
+		want           string
+	}{
+		{"", None},
+		{"identity", None},
+		{"br, deflate", None},
+		{"gzip", Gzip},
+		{"GZIP", Gzip},
+		{"zstd", Zstd},
+		{"zstd, gzip", Zstd},
+		{"gzip, zstd", Gzip},
+		{"gzip;q=0.5, zstd", Zstd},
+		{"gzip, zstd;q=0.5", Gzip},
+		{"zstd;q=0", None},
+		{"zstd;q=0, gzip;q=0.1", Gzip},
+		{"gzip;q=abc", None},
+		{"*", Gzip},
+		{"zstd;q=0.8, *;q=0.9", Gzip},
+		{"gzip;q=0, *", Zstd},
+		{"*, gzip;q=0", Zstd},
+		{"gzip;q=0, zstd;q=0, *", None},
+		{"gzip;q=0.5, *", Zstd},
+		{"*;q=0", None},
+		{"br;q=1, zstd;q=0.2", Zstd},
+	} {
+		t.Run(tc.acceptEncoding, func(t *testing.T) {
+			if got := Negotiate(tc.acceptEncoding); got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCompress(t *testing.T) {
+	data := bytes.Repeat([]byte("shareable notes "), 100)
+
+	for _, codec := range []string{Gzip, Zstd} {
+		t.Run(codec, func(t *testing.T) {
+			compressed, err := Compress(codec, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(compressed) >= len(data) {
+				t.Fatalf("%d bytes compressed to %d", len(data), len(compressed))
+			}
+
+			r, err := NewReader(codec, bytes.NewReader(compressed))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+
+			if got, err := ioutil.ReadAll(r); err != nil || !bytes.Equal(got, data) {
+				t.Fatalf("got %d bytes, %v", len(got), err)
+			}
+		})
+	}
+
+	if _, err := Compress(None, data); err != ErrUnknownCodec {
+		t.Fatalf("got %v", err)
+	}
+
+	if _, err := NewReader("br", bytes.NewReader(data)); err != ErrUnknownCodec {
+		t.Fatalf("got %v", err)
+	}
+}
@@ -0,0 +1,169 @@
+package envelope
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"io"
+	"sync"
+)
+
+// KeySize is the size of the data keys and of the master keys, they are
+// AES-256 keys.
+const KeySize = 32
+
+// maxCachedKeys bounds the data keys kept unwrapped, the cache is emptied
+// once it is full.
+const maxCachedKeys = 4096
+
+var (
+	ErrUnknownMasterKey = errors.New("master key cannot be found")
+	ErrCiphertext = errors.New("ciphertext cannot be decrypted")
+)
+
+// KeyProvider holds the master keys wrapping the data keys, e.g. a keyfile
+// or a KMS. The master keys never leave the provider.
+type KeyProvider interface {
+	// PrimaryKeyID is the id of the master key wrapping the new data keys.
+	PrimaryKeyID() string
+
+	// Wrap encrypts key with the primary master key.
+	Wrap(ctx context.Context, key []byte) (keyID string, wrapped []byte, err error)
+
+	// Unwrap decrypts a key wrapped by the master key keyID.
+	Unwrap(ctx context.Context, keyID string, wrapped []byte) (key []byte, err error)
+}
+
+// Envelope encrypts the data with data keys, which are stored along with the
+// data once wrapped by a master key of the provider.
+type Envelope struct {
+	provider KeyProvider
+
+	mu sync.Mutex
+	keys map[string][]byte
+}
+
+func New(provider KeyProvider) *Envelope {
+	return &Envelope{
+		provider: provider,
+		keys: map[string][]byte{},
+	}
+}
+
+// NewKey returns a new data key along with its wrapped form.
+func (e *Envelope) NewKey(ctx context.Context) (key []byte, wrapped model.WrappedKey, err error) {
+	key = make([]byte, KeySize)
+	if _, err = rand.Read(key); err != nil {
+		return nil, wrapped, err
+	}
+
+	wrapped.KeyID, wrapped.Key, err = e.provider.Wrap(ctx, key)
+	if err != nil {
+		return nil, wrapped, err
+	}
+
+	e.cache(wrapped, key)
+	return key, wrapped, nil
+}
+
+// Key returns the data key wrapped in wrapped, the keys unwrapped recently are
+// not unwrapped again.
+func (e *Envelope) Key(ctx context.Context, wrapped model.WrappedKey) (key []byte, err error) {
+	e.mu.Lock()
+	key, ok := e.keys[cacheKey(wrapped)]
+	e.mu.Unlock()
+
+	if ok {
+		return key, nil
+	}
+
+	key, err = e.provider.Unwrap(ctx, wrapped.KeyID, wrapped.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	e.cache(wrapped, key)
+	return key, nil
+}
+
+// Rewrap wraps the data key of wrapped with the primary master key, ok is
+// false when it is wrapped by it already.
+func (e *Envelope) Rewrap(ctx context.Context, wrapped model.WrappedKey) (rewrapped model.WrappedKey, ok bool, err error) {
+	if wrapped.KeyID == e.provider.PrimaryKeyID() {
+		return wrapped, false, nil
+	}
+
+	key, err := e.Key(ctx, wrapped)
+	if err != nil {
+		return wrapped, false, err
+	}
+
+	rewrapped.KeyID, rewrapped.Key, err = e.provider.Wrap(ctx, key)
+	if err != nil {
+		return wrapped, false, err
+	}
+	return rewrapped, true, nil
+}
+
+// PrimaryKeyID is the id of the master key wrapping the new data keys.
+func (e *Envelope) PrimaryKeyID() string {
+	return e.provider.PrimaryKeyID()
+}
+
+func (e *Envelope) cache(wrapped model.WrappedKey, key []byte) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(e.keys) >= maxCachedKeys {
+		e.keys = map[string][]byte{}
+	}
+	e.keys[cacheKey(wrapped)] = key
+}
+
+func cacheKey(wrapped model.WrappedKey) string {
+	return wrapped.KeyID + "\x00" + string(wrapped.Key)
+}
+
+// Seal encrypts plaintext with key using AES-256-GCM, the nonce is prepended
+// to the ciphertext.
+func Seal(key, plaintext []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize() + len(plaintext) + aead.Overhead())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Open decrypts a ciphertext sealed with key.
+func Open(key, ciphertext []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, ErrCiphertext
+	}
+
+	plaintext, err := aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, ErrCiphertext
+	}
+	return plaintext, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,219 @@
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSealOpen(t *testing.T) {
+	var (
+		key    = newTestKey(t)
+		sealed = func(plaintext string) []byte {
+			ciphertext, err := Seal(key, []byte(plaintext))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return ciphertext
+		}
+	)
+
+	for _, tc := range []struct {
+		name       string
+		key        []byte
+		ciphertext []byte
+		want       string
+		err        error
+	}{
+		{
+			name:       "sealed",
+			ciphertext: sealed("secret"),
+			want:       "secret",
+		},
+		{
+			name:       "empty",
+			ciphertext: sealed(""),
+		},
+		{
+			name:       "wrong key",
+			key:        newTestKey(t),
+			ciphertext: sealed("secret"),
+			err:        ErrCiphertext,
+		},
+		{
+			name: "byte flipped",
+			ciphertext: func() []byte {
+				ciphertext := sealed("secret")
+				ciphertext[len(ciphertext)-1] ^= 1
+				return ciphertext
+			}(),
+			err: ErrCiphertext,
+		},
+		{
+			name:       "truncated",
+			ciphertext: sealed("secret")[:20],
+			err:        ErrCiphertext,
+		},
+		{
+			name:       "shorter than the nonce",
+			ciphertext: sealed("secret")[:4],
+			err:        ErrCiphertext,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.key == nil {
+				tc.key = key
+			}
+
+			plaintext, err := Open(tc.key, tc.ciphertext)
+			if err != tc.err || string(plaintext) != tc.want {
+				t.Fatalf("got %q %v, want %q %v", plaintext, err, tc.want, tc.err)
+			}
+		})
+	}
+
+	// the nonces are random, the same plaintext is not sealed twice the same
+	if bytes.Equal(sealed("secret"), sealed("secret")) {
+		t.Fatal("same ciphertext")
+	}
+}
+
+// writeKeyfile writes a keyfile of the given master keys and returns it.
+func writeKeyfile(t *testing.T, primary string, keys map[string][]byte) *Keyfile {
+	t.Helper()
+
+	data := "primary: " + primary + "\nkeys:\n"
+	for id, key := range keys {
+		data += "  " + id + ": " + base64.StdEncoding.EncodeToString(key) + "\n"
+	}
+
+	path := filepath.Join(t.TempDir(), "keyfile.yml")
+	if err := ioutil.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	k, err := NewKeyfile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func TestRewrap(t *testing.T) {
+	var (
+		ctx               = context.Background()
+		former            = newTestKey(t)
+		primary           = newTestKey(t)
+		before            = New(writeKeyfile(t, "former", map[string][]byte{"former": former}))
+		rotated           = New(writeKeyfile(t, "primary", map[string][]byte{"former": former, "primary": primary}))
+		retired           = New(writeKeyfile(t, "primary", map[string][]byte{"primary": primary}))
+		key, wrapped, err = before.NewKey(ctx)
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the keys wrapped by the former master key are read after the rotation
+	if got, err := rotated.Key(ctx, wrapped); err != nil || !bytes.Equal(got, key) {
+		t.Fatalf("got %v", err)
+	}
+
+	if _, err = retired.Key(ctx, wrapped); err != ErrUnknownMasterKey {
+		t.Fatalf("got %v", err)
+	}
+
+	rewrapped, ok, err := rotated.Rewrap(ctx, wrapped)
+	if err != nil || !ok || rewrapped.KeyID != "primary" {
+		t.Fatalf("got %+v %v %v", rewrapped, ok, err)
+	}
+
+	// once rewrapped, the former master key can be removed
+	if got, err := retired.Key(ctx, rewrapped); err != nil || !bytes.Equal(got, key) {
+		t.Fatalf("got %v", err)
+	}
+
+	if again, ok, err := rotated.Rewrap(ctx, rewrapped); err != nil || ok || !bytes.Equal(again.Key, rewrapped.Key) {
+		t.Fatalf("got %+v %v %v", again, ok, err)
+	}
+}
+
+func TestNewKeyfile(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		data string
+		err  error
+	}{
+		{
+			name: "no primary key",
+			data: "primary: missing\nkeys:\n  key: " + base64.StdEncoding.EncodeToString(make([]byte, KeySize)) + "\n",
+			err:  ErrNoPrimaryKey,
+		},
+		{
+			name: "short key",
+			data: "primary: key\nkeys:\n  key: " + base64.StdEncoding.EncodeToString(make([]byte, 16)) + "\n",
+		},
+		{
+			name: "not base64",
+			data: "primary: key\nkeys:\n  key: \"!\"\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "keyfile.yml")
+			if err := ioutil.WriteFile(path, []byte(tc.data), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := NewKeyfile(path); err == nil || tc.err != nil && err != tc.err {
+				t.Fatalf("got %v", err)
+			}
+		})
+	}
+}
+
+// store has keys left to rewrap, which it rewraps by batch.
+type store struct {
+	mu    sync.Mutex
+	left  int
+	calls int
+}
+
+func (s *store) RewrapKeys(_ context.Context, batch int) (n int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+	if n = batch; s.left < batch {
+		n = s.left
+	}
+	s.left -= n
+	return n, nil
+}
+
+func TestRewrapper(t *testing.T) {
+	s := &store{left: 25}
+	r := NewRewrapper(s, 10, time.Hour)
+
+	// the batches are rewrapped until one is not full
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		s.mu.Lock()
+		left, calls := s.left, s.calls
+		s.mu.Unlock()
+
+		if left == 0 && calls == 3 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d keys left after %d batches", left, calls)
+		}
+	}
+
+	r.Close()
+	if s.calls != 3 {
+		t.Fatalf("%d batches", s.calls)
+	}
+}
@@ -0,0 +1,83 @@
+package envelope
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io/ioutil"
+)
+
+var ErrNoPrimaryKey = errors.New("keyfile has no primary master key")
+
+// Keyfile is a KeyProvider holding the master keys in a local YAML file:
+//
+//	primary: "2026-10"
+//	keys:
+//	  "2026-10": <base64 of 32 random bytes>
+//	  "2026-04": <base64 of 32 random bytes>
+//
+// The master keys are rotated by adding a key and making it the primary one.
+// The former keys are kept until the data keys they wrap are rewrapped.
+type Keyfile struct {
+	primary string
+	keys map[string][]byte
+}
+
+type keyfile struct {
+	Primary string `yaml:"primary"`
+	Keys map[string]string `yaml:"keys"`
+}
+
+func NewKeyfile(path string) (*Keyfile, error) {
+	var kf keyfile
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = yaml.Unmarshal(data, &kf); err != nil {
+		return nil, err
+	}
+
+	var k = &Keyfile{
+		primary: kf.Primary,
+		keys: make(map[string][]byte, len(kf.Keys)),
+	}
+
+	for id, encoded := range kf.Keys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("keyfile: master key %q: %w", id, err)
+		}
+
+		if len(key) != KeySize {
+			return nil, fmt.Errorf("keyfile: master key %q has %d bytes, %d are expected", id, len(key), KeySize)
+		}
+		k.keys[id] = key
+	}
+
+	if _, ok := k.keys[k.primary]; !ok {
+		return nil, ErrNoPrimaryKey
+	}
+	return k, nil
+}
+
+func (k *Keyfile) PrimaryKeyID() string {
+	return k.primary
+}
+
+func (k *Keyfile) Wrap(_ context.Context, key []byte) (keyID string, wrapped []byte, err error) {
+	wrapped, err = Seal(k.keys[k.primary], key)
+	return k.primary, wrapped, err
+}
+
+func (k *Keyfile) Unwrap(_ context.Context, keyID string, wrapped []byte) (key []byte, err error) {
+	master, ok := k.keys[keyID]
+	if !ok {
+		return nil, ErrUnknownMasterKey
+	}
+	return Open(master, wrapped)
+}
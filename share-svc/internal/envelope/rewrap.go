@@ -0,0 +1,93 @@
+package envelope
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rewrapTimeout bounds rewrapping a batch of data keys.
+const rewrapTimeout = 30 * time.Second
+
+// Store rewraps the data keys it holds which are not wrapped by the primary
+// master key, and encrypts what it holds written before it was encrypted.
+type Store interface {
+	// RewrapKeys rewraps up to batch data keys of each kind, encrypts up to
+	// batch plaintexts of each kind and returns how many were rewrapped or
+	// encrypted.
+	RewrapKeys(ctx context.Context, batch int) (n int, err error)
+}
+
+// Rewrapper rewraps the data keys in the background after the master key is
+// rotated, until none is wrapped by a former master key, so that the former
+// master keys can be removed.
+type Rewrapper struct {
+	store    Store
+	batch    int
+	interval time.Duration
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// NewRewrapper returns a rewrapper rewrapping the data keys of store every
+// interval, batch by batch.
+func NewRewrapper(store Store, batch int, interval time.Duration) *Rewrapper {
+	r := &Rewrapper{
+		store:    store,
+		batch:    batch,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	go r.run()
+	return r
+}
+
+// Close stops the rewrapper once the batch in progress is rewrapped.
+func (r *Rewrapper) Close() error {
+	r.once.Do(func() {
+		close(r.stop)
+	})
+	<-r.done
+	return nil
+}
+
+func (r *Rewrapper) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.rewrap()
+
+		select {
+		case <-ticker.C:
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+// rewrap rewraps batches until one is not full, or until it fails, the keys
+// and plaintexts left are done at the next tick.
+func (r *Rewrapper) rewrap() {
+	for {
+		select {
+		case <-r.stop:
+			return
+		default:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), rewrapTimeout)
+		n, err := r.store.RewrapKeys(ctx, r.batch)
+		cancel()
+
+		if err != nil || n < r.batch {
+			return
+		}
+	}
+}
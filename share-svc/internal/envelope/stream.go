@@ -0,0 +1,174 @@
+package envelope
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+)
+
+const (
+	// segmentSize is the plaintext size of the segments the streams are
+	// sealed in, only the last segment may be shorter.
+	segmentSize = 64 << 10
+
+	// the nonce of a segment is the random prefix of the stream, the index of
+	// the segment and a byte marking the last segment, so that the segments
+	// can be neither reordered nor truncated
+	noncePrefixSize = 7
+	nonceSize = noncePrefixSize + 4 + 1
+)
+
+// NewWriter returns a writer encrypting to w with key, the stream is sealed
+// by Close, which does not close w.
+func NewWriter(key []byte, w io.Writer) (io.WriteCloser, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	sw := &streamWriter{
+		w: w,
+		aead: aead,
+		nonce: make([]byte, nonceSize),
+	}
+
+	if _, err = io.ReadFull(rand.Reader, sw.nonce[:noncePrefixSize]); err != nil {
+		return nil, err
+	}
+
+	if _, err = w.Write(sw.nonce[:noncePrefixSize]); err != nil {
+		return nil, err
+	}
+	return sw, nil
+}
+
+type streamWriter struct {
+	w io.Writer
+	aead cipher.AEAD
+	nonce []byte
+	index uint32
+
+	// buf holds the plaintext of the segment being written, a full segment is
+	// sealed only once more bytes follow, the last segment is sealed by Close
+	buf []byte
+	closed bool
+}
+
+func (sw *streamWriter) Write(p []byte) (n int, err error) {
+	if sw.closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	sw.buf = append(sw.buf, p...)
+	for len(sw.buf) > segmentSize {
+		if err = sw.seal(sw.buf[:segmentSize], false); err != nil {
+			return 0, err
+		}
+		sw.buf = append(sw.buf[:0], sw.buf[segmentSize:]...)
+	}
+	return len(p), nil
+}
+
+func (sw *streamWriter) Close() error {
+	if sw.closed {
+		return nil
+	}
+	sw.closed = true
+	return sw.seal(sw.buf, true)
+}
+
+func (sw *streamWriter) seal(plaintext []byte, last bool) error {
+	setNonce(sw.nonce, sw.index, last)
+	sw.index++
+
+	_, err := sw.w.Write(sw.aead.Seal(nil, sw.nonce, plaintext, nil))
+	return err
+}
+
+// NewReader returns a reader decrypting r with key. Reading fails with
+// ErrCiphertext when the stream was altered or truncated.
+func NewReader(key []byte, r io.Reader) (io.Reader, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	sr := &streamReader{
+		r: bufio.NewReader(r),
+		aead: aead,
+		nonce: make([]byte, nonceSize),
+		segment: make([]byte, segmentSize + aead.Overhead()),
+	}
+
+	if _, err = io.ReadFull(sr.r, sr.nonce[:noncePrefixSize]); err != nil {
+		return nil, ErrCiphertext
+	}
+	return sr, nil
+}
+
+type streamReader struct {
+	r *bufio.Reader
+	aead cipher.AEAD
+	nonce []byte
+	index uint32
+
+	segment []byte
+	buf []byte
+	done bool
+}
+
+func (sr *streamReader) Read(p []byte) (n int, err error) {
+	for len(sr.buf) == 0 {
+		if sr.done {
+			return 0, io.EOF
+		}
+
+		if err = sr.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	n = copy(p, sr.buf)
+	sr.buf = sr.buf[n:]
+	return n, nil
+}
+
+// open decrypts the next segment, which is the last one when it is short or
+// nothing follows it.
+func (sr *streamReader) open() error {
+	n, err := io.ReadFull(sr.r, sr.segment)
+	switch err {
+	case nil:
+		_, err = sr.r.Peek(1)
+		sr.done = err == io.EOF
+		if err != nil && err != io.EOF {
+			return err
+		}
+	case io.ErrUnexpectedEOF:
+		sr.done = true
+	case io.EOF:
+		return ErrCiphertext
+	default:
+		return err
+	}
+
+	setNonce(sr.nonce, sr.index, sr.done)
+	sr.index++
+
+	sr.buf, err = sr.aead.Open(sr.segment[:0], sr.nonce, sr.segment[:n], nil)
+	if err != nil {
+		return ErrCiphertext
+	}
+	return nil
+}
+
+func setNonce(nonce []byte, index uint32, last bool) {
+	binary.BigEndian.PutUint32(nonce[noncePrefixSize:], index)
+
+	nonce[nonceSize - 1] = 0
+	if last {
+		nonce[nonceSize - 1] = 1
+	}
+}
@@ -0,0 +1,159 @@
+package envelope
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io/ioutil"
+	"testing"
+)
+
+// seal returns plaintext encrypted as a stream with key.
+func seal(t *testing.T, key, plaintext []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := NewWriter(key, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// written in pieces which do not match the segments
+	for p := plaintext; len(p) > 0; {
+		n := len(p)
+		if n > 1000 {
+			n = 1000
+		}
+		if _, err = w.Write(p[:n]); err != nil {
+			t.Fatal(err)
+		}
+		p = p[n:]
+	}
+
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func open(key, stream []byte) ([]byte, error) {
+	r, err := NewReader(key, bytes.NewReader(stream))
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(r)
+}
+
+func newTestKey(t *testing.T) []byte {
+	t.Helper()
+
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestStream(t *testing.T) {
+	key := newTestKey(t)
+
+	for _, size := range []int{0, 1, segmentSize - 1, segmentSize, segmentSize + 1, 3 * segmentSize} {
+		plaintext := make([]byte, size)
+		rand.Read(plaintext)
+
+		got, err := open(key, seal(t, key, plaintext))
+		if err != nil || !bytes.Equal(got, plaintext) {
+			t.Fatalf("%d bytes: got %d bytes, %v", size, len(got), err)
+		}
+	}
+}
+
+func TestStreamAltered(t *testing.T) {
+	const sealedSegment = segmentSize + 16
+
+	var (
+		key       = newTestKey(t)
+		plaintext = make([]byte, 2*segmentSize+segmentSize/2)
+	)
+	rand.Read(plaintext)
+
+	// the stream is the nonce prefix, two full segments and a short one
+	stream := seal(t, key, plaintext)
+	segment := func(i int) []byte {
+		start := noncePrefixSize + i*sealedSegment
+		if end := start + sealedSegment; end < len(stream) {
+			return stream[start:end]
+		}
+		return stream[start:]
+	}
+
+	for _, tc := range []struct {
+		name   string
+		key    []byte
+		stream []byte
+	}{
+		{
+			name:   "wrong key",
+			key:    newTestKey(t),
+			stream: stream,
+		},
+		{
+			name:   "short prefix",
+			stream: stream[:noncePrefixSize-1],
+		},
+		{
+			name:   "no segment",
+			stream: stream[:noncePrefixSize],
+		},
+		{
+			name:   "last segment dropped",
+			stream: stream[:noncePrefixSize+2*sealedSegment],
+		},
+		{
+			name:   "last segment cut",
+			stream: stream[:len(stream)-1],
+		},
+		{
+			name:   "segment appended",
+			stream: join(stream, segment(2)),
+		},
+		{
+			name:   "segments reordered",
+			stream: join(stream[:noncePrefixSize], segment(1), segment(0), segment(2)),
+		},
+		{
+			name:   "segment replayed",
+			stream: join(stream[:noncePrefixSize], segment(0), segment(0), segment(2)),
+		},
+		{
+			name: "byte flipped",
+			stream: func() []byte {
+				altered := join(stream)
+				altered[noncePrefixSize+sealedSegment+10] ^= 1
+				return altered
+			}(),
+		},
+		{
+			name: "prefix changed",
+			stream: func() []byte {
+				altered := join(stream)
+				altered[0] ^= 1
+				return altered
+			}(),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.key == nil {
+				tc.key = key
+			}
+
+			if got, err := open(tc.key, tc.stream); err != ErrCiphertext {
+				t.Fatalf("got %d bytes, %v", len(got), err)
+			}
+		})
+	}
+}
+
+// join returns a new slice concatenating parts.
+func join(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
@@ -0,0 +1,325 @@
+package repositories
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/config"
+	"github.com/al8n/shareable-notes/share-svc/internal/analytics"
+	"github.com/al8n/shareable-notes/share-svc/internal/utils"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const (
+	// noteStatsCollection holds the view counters of every note,
+	// noteDailyStatsCollection their views per day and
+	// noteReferrerStatsCollection their views per referrer.
+	noteStatsCollection = "note_stats"
+	noteDailyStatsCollection = "note_stats_daily"
+	noteReferrerStatsCollection = "note_stats_referrers"
+
+	dayLayout = "2006-01-02"
+	defaultStatsDays = 30
+	maxStatsDays = 366
+
+	// maxStatsReferrers is the number of top referrers returned with the stats.
+	maxStatsReferrers = 100
+
+	otherTransport = "other"
+)
+
+// noteCounters is the document of a note in noteStatsCollection.
+type noteCounters struct {
+	Views         int64            `bson:"views"`
+	Transports    map[string]int64 `bson:"transports"`
+	FirstViewedAt int64            `bson:"first_viewed_at"`
+	LastViewedAt  int64            `bson:"last_viewed_at"`
+}
+
+// RecordViews adds the views to the counters of their notes, to the buckets
+// of their days and of their referrers.
+func (repo Repo) RecordViews(ctx context.Context, views []model.NoteView) (err error)  {
+	type dayKey struct {
+		note primitive.ObjectID
+		day string
+	}
+
+	type referrerKey struct {
+		note primitive.ObjectID
+		referrer string
+	}
+
+	var (
+		notes = make(map[primitive.ObjectID]*noteCounters)
+		days = make(map[dayKey]int64)
+		visitors = make(map[dayKey]map[string]struct{})
+		referrers = make(map[referrerKey]int64)
+		noteWrites, dayWrites, referrerWrites []mongo.WriteModel
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
+	for _, view := range views {
+		oid, err := primitive.ObjectIDFromHex(view.NoteID)
+		if err != nil {
+			continue
+		}
+
+		counters, ok := notes[oid]
+		if !ok {
+			counters = &noteCounters{
+				Transports:    make(map[string]int64),
+				FirstViewedAt: view.Timestamp,
+			}
+			notes[oid] = counters
+		}
+		counters.Views++
+		counters.Transports[transportField(view.Transport)]++
+		if view.Timestamp < counters.FirstViewedAt {
+			counters.FirstViewedAt = view.Timestamp
+		}
+		if view.Timestamp > counters.LastViewedAt {
+			counters.LastViewedAt = view.Timestamp
+		}
+
+		day := dayKey{note: oid, day: time.Unix(view.Timestamp, 0).UTC().Format(dayLayout)}
+		days[day]++
+		if view.ClientHash != "" {
+			if visitors[day] == nil {
+				visitors[day] = make(map[string]struct{})
+			}
+			visitors[day][view.ClientHash] = struct{}{}
+		}
+
+		referrers[referrerKey{note: oid, referrer: view.Referrer}]++
+	}
+
+	for oid, counters := range notes {
+		inc := bson.D{{Key: "views", Value: counters.Views}}
+		for transport, n := range counters.Transports {
+			inc = append(inc, bson.E{Key: "transports." + transport, Value: n})
+		}
+
+		noteWrites = append(noteWrites, mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{Key: "_id", Value: oid}}).
+			SetUpdate(bson.D{
+				{Key: "$inc", Value: inc},
+				{Key: "$min", Value: bson.D{{Key: "first_viewed_at", Value: counters.FirstViewedAt}}},
+				{Key: "$max", Value: bson.D{{Key: "last_viewed_at", Value: counters.LastViewedAt}}},
+			}).
+			SetUpsert(true))
+	}
+
+	for day, n := range days {
+		update := bson.D{{Key: "$inc", Value: bson.D{{Key: "views", Value: n}}}}
+		if len(visitors[day]) > 0 {
+			hashes := make(bson.A, 0, len(visitors[day]))
+			for hash := range visitors[day] {
+				hashes = append(hashes, hash)
+			}
+			update = append(update, bson.E{Key: "$addToSet", Value: bson.D{{Key: "visitors", Value: bson.D{{Key: "$each", Value: hashes}}}}})
+		}
+
+		dayWrites = append(dayWrites, mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{Key: "note_id", Value: day.note}, {Key: "day", Value: day.day}}).
+			SetUpdate(update).
+			SetUpsert(true))
+	}
+
+	for referrer, n := range referrers {
+		referrerWrites = append(referrerWrites, mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{Key: "note_id", Value: referrer.note}, {Key: "referrer", Value: referrer.referrer}}).
+			SetUpdate(bson.D{{Key: "$inc", Value: bson.D{{Key: "views", Value: n}}}}).
+			SetUpsert(true))
+	}
+
+	span.LogKV("operation",  "record views", "db.bulkWrite", len(views), "notes", len(notes))
+
+	for collection, writes := range map[string][]mongo.WriteModel{
+		noteStatsCollection: noteWrites,
+		noteDailyStatsCollection: dayWrites,
+		noteReferrerStatsCollection: referrerWrites,
+	} {
+		if len(writes) == 0 {
+			continue
+		}
+
+		_, err = repo.stats(collection).BulkWrite(spanCtx, writes, options.BulkWrite().SetOrdered(false))
+		if err != nil {
+			utils.SetTracerSpanError(span, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// GetNoteStats returns the views of a visible note, with its daily views over
+// the last days, today included. The stats are only returned for the owner
+// token of the note, the notes written before the owner tokens have none and
+// their stats are not returned.
+func (repo Repo) GetNoteStats(ctx context.Context, id, ownerToken string, days int) (stats model.NoteStats, err error)  {
+	var (
+		note model.Note
+		counters noteCounters
+		cursor *mongo.Cursor
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
+	note, err = repo.findNote(spanCtx, id)
+	if err == nil && !ownerTokenMatches(note.OwnerTokenHash, ownerToken) {
+		err = common.ErrorNotNoteOwner
+	}
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return stats, err
+	}
+
+	if days <= 0 {
+		days = defaultStatsDays
+	}
+	if days > maxStatsDays {
+		days = maxStatsDays
+	}
+
+	span.LogKV("operation",  "get note stats", "db.findOne", id, "days", days)
+
+	err = repo.stats(noteStatsCollection).FindOne(spanCtx, bson.D{{Key: "_id", Value: note.ID}}).Decode(&counters)
+	if err != nil && err != mongo.ErrNoDocuments {
+		utils.SetTracerSpanError(span, err)
+		return stats, err
+	}
+
+	stats = model.NoteStats{
+		NoteID:        id,
+		Views:         counters.Views,
+		Transports:    counters.Transports,
+		Referrers:     make(map[string]int64),
+		Daily:         []model.DailyViews{},
+		FirstViewedAt: counters.FirstViewedAt,
+		LastViewedAt:  counters.LastViewedAt,
+	}
+	if stats.Transports == nil {
+		stats.Transports = make(map[string]int64)
+	}
+
+	cursor, err = repo.stats(noteReferrerStatsCollection).Find(
+		spanCtx,
+		bson.D{{Key: "note_id", Value: note.ID}},
+		options.Find().
+			SetSort(bson.D{{Key: "views", Value: -1}}).
+			SetLimit(maxStatsReferrers),
+	)
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return stats, err
+	}
+
+	var referrers []struct {
+		Referrer string `bson:"referrer"`
+		Views    int64  `bson:"views"`
+	}
+	if err = cursor.All(spanCtx, &referrers); err != nil {
+		utils.SetTracerSpanError(span, err)
+		return stats, err
+	}
+
+	for _, referrer := range referrers {
+		stats.Referrers[referrer.Referrer] = referrer.Views
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, 1-days).Format(dayLayout)
+	cursor, err = repo.stats(noteDailyStatsCollection).Aggregate(spanCtx, mongo.Pipeline{
+		bson.D{{Key: "$match", Value: bson.D{
+			{Key: "note_id", Value: note.ID},
+			{Key: "day", Value: bson.D{{Key: "$gte", Value: since}}},
+		}}},
+		bson.D{{Key: "$project", Value: bson.D{
+			{Key: "_id", Value: 0},
+			{Key: "day", Value: 1},
+			{Key: "views", Value: 1},
+			{Key: "visitors", Value: bson.D{{Key: "$size", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$visitors", bson.A{}}}}}}},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "day", Value: 1}}}},
+	})
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return stats, err
+	}
+
+	var daily []struct {
+		Day      string `bson:"day"`
+		Views    int64  `bson:"views"`
+		Visitors int64  `bson:"visitors"`
+	}
+	if err = cursor.All(spanCtx, &daily); err != nil {
+		utils.SetTracerSpanError(span, err)
+		return stats, err
+	}
+
+	for _, day := range daily {
+		stats.Daily = append(stats.Daily, model.DailyViews{
+			Day:      day.Day,
+			Views:    day.Views,
+			Visitors: day.Visitors,
+		})
+	}
+	return stats, nil
+}
+
+func (repo Repo) stats(collection string) *mongo.Collection {
+	return repo.MongoDB.Database(config.GetConfig().Mongo.DB).Collection(collection)
+}
+
+// transportField returns the field counting the views of transport, which
+// must not inject a path into the update.
+func transportField(transport string) string {
+	switch transport {
+	case analytics.TransportHTTP, analytics.TransportGRPC, analytics.TransportNATS, analytics.TransportThrift:
+		return transport
+	default:
+		return otherTransport
+	}
+}
+
+// newOwnerToken returns a random owner token and the hash stored in its note.
+func newOwnerToken() (token, hash string, err error) {
+	var b = make([]byte, 32)
+
+	if _, err = rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, ownerTokenHash(token), nil
+}
+
+func ownerTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ownerTokenMatches reports whether token is the owner token of the hash, no
+// token matches a note without a hash.
+func ownerTokenMatches(hash, token string) bool {
+	if hash == "" || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(ownerTokenHash(token))) == 1
+}
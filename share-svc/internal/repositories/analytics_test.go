@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"github.com/al8n/shareable-notes/share-svc/internal/analytics"
+	"testing"
+)
+
+func TestOwnerToken(t *testing.T) {
+	token, hash, err := newOwnerToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, _, err := newOwnerToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other == token {
+		t.Fatalf("owner tokens repeat: %q", token)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		hash  string
+		token string
+		want  bool
+	}{
+		{"owner", hash, token, true},
+		{"other token", hash, other, false},
+		{"hash as token", hash, hash, false},
+		{"no token", hash, "", false},
+		{"note without hash", "", token, false},
+		{"nothing", "", "", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ownerTokenMatches(tc.hash, tc.token); got != tc.want {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTransportField(t *testing.T) {
+	for _, tc := range []struct {
+		transport string
+		want      string
+	}{
+		{analytics.TransportHTTP, analytics.TransportHTTP},
+		{analytics.TransportGRPC, analytics.TransportGRPC},
+		{analytics.TransportNATS, analytics.TransportNATS},
+		{analytics.TransportThrift, analytics.TransportThrift},
+		{"", otherTransport},
+		{"websocket", otherTransport},
+		{"http.$inc", otherTransport},
+	} {
+		t.Run(tc.transport, func(t *testing.T) {
+			if got := transportField(tc.transport); got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
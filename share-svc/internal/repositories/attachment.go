@@ -0,0 +1,209 @@
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/config"
+	"github.com/al8n/shareable-notes/share-svc/internal/blobstore"
+	"github.com/al8n/shareable-notes/share-svc/internal/utils"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// attachmentsCollection holds the attachments metadata, their content is
+	// kept in the blob store.
+	attachmentsCollection = "attachments"
+
+	// sniffLen is the number of leading bytes the content type is detected from.
+	sniffLen = 512
+
+	// maxAttachmentName is the maximum length in bytes of an attachment name.
+	maxAttachmentName = 255
+	defaultAttachmentName = "attachment"
+)
+
+// UploadAttachment attaches the content read from content to a visible note.
+// The content type is sniffed from the content, and the upload fails with
+// common.ErrorAttachmentTooLarge above the configured maximum size.
+func (repo Repo) UploadAttachment(ctx context.Context, noteID, name string, content io.Reader) (attachment model.AttachmentInfo, err error)  {
+	var (
+		note model.Note
+		head = make([]byte, sniffLen)
+		n int
+		stored model.Attachment
+		rst *mongo.InsertOneResult
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
+	note, err = repo.findNote(spanCtx, noteID)
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return attachment, err
+	}
+
+	n, err = io.ReadFull(content, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		utils.SetTracerSpanError(span, err)
+		return attachment, err
+	}
+	head = head[:n]
+
+	stored = model.Attachment{
+		NoteID:      note.ID,
+		Name:        attachmentName(name),
+		ContentType: http.DetectContentType(head),
+		CreatedAt:   time.Now().Unix(),
+	}
+
+	span.LogKV("operation",  "upload attachment", "blob.put", stored.Name, "content_type", stored.ContentType)
+
+	stored.Key, stored.Size, err = repo.blobs.Put(spanCtx, stored.Name, &limitedReader{
+		r: io.MultiReader(bytes.NewReader(head), content),
+		n: config.GetConfig().Service.Attachments.MaxSize,
+	})
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return attachment, err
+	}
+
+	span.LogKV("operation",  "upload attachment", "db.insertOne", stored.Key, "size", stored.Size)
+
+	rst, err = repo.attachments().InsertOne(spanCtx, stored)
+	if err != nil {
+		// the blob is unreachable without its metadata
+		_ = repo.blobs.Delete(spanCtx, stored.Key)
+		utils.SetTracerSpanError(span, err)
+		return attachment, err
+	}
+
+	stored.ID = rst.InsertedID.(primitive.ObjectID)
+	return attachmentInfo(stored), nil
+}
+
+// DownloadAttachment returns an attachment of a visible note and a reader
+// over its content, the caller must close the reader.
+func (repo Repo) DownloadAttachment(ctx context.Context, noteID, attachmentID string) (attachment model.AttachmentInfo, content io.ReadCloser, err error)  {
+	var (
+		note model.Note
+		oid primitive.ObjectID
+		stored model.Attachment
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
+	span.LogKV("operation",  "download attachment", "db.findOne", attachmentID)
+
+	note, err = repo.findNote(spanCtx, noteID)
+	if err == nil {
+		oid, err = primitive.ObjectIDFromHex(attachmentID)
+		if err != nil {
+			err = common.ErrorAttachmentNotFound
+		}
+	}
+	if err == nil {
+		err = repo.attachments().FindOne(spanCtx, bson.D{
+			{Key: "_id", Value: oid},
+			{Key: "note_id", Value: note.ID},
+		}).Decode(&stored)
+		if err == mongo.ErrNoDocuments {
+			err = common.ErrorAttachmentNotFound
+		}
+	}
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return attachment, nil, err
+	}
+
+	span.LogKV("operation",  "download attachment", "blob.open", stored.Key)
+
+	content, err = repo.blobs.Open(spanCtx, stored.Key)
+	if err == blobstore.ErrNotFound {
+		err = common.ErrorAttachmentNotFound
+	}
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return attachment, nil, err
+	}
+
+	return attachmentInfo(stored), content, nil
+}
+
+func (repo Repo) attachments() *mongo.Collection {
+	return repo.MongoDB.Database(config.GetConfig().Mongo.DB).Collection(attachmentsCollection)
+}
+
+func attachmentInfo(attachment model.Attachment) model.AttachmentInfo {
+	return model.AttachmentInfo{
+		ID:          attachment.ID.Hex(),
+		NoteID:      attachment.NoteID.Hex(),
+		Name:        attachment.Name,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		URL:         attachmentURL(attachment.NoteID.Hex(), attachment.ID.Hex()),
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
+
+// attachmentName keeps the base name of an uploaded file, clients may send
+// their local path.
+func attachmentName(name string) string {
+	name = strings.TrimSpace(path.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "." || name == "/" || name == "" {
+		return defaultAttachmentName
+	}
+
+	for len(name) > maxAttachmentName {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
+
+// attachmentURL returns the URL the attachment can be downloaded at.
+func attachmentURL(noteID, attachmentID string) string {
+	return shareURL(noteID) + "/attachments/" + base64.URLEncoding.EncodeToString([]byte(attachmentID))
+}
+
+// limitedReader fails with common.ErrorAttachmentTooLarge as soon as more than
+// n bytes are read, so oversized uploads are aborted rather than stored.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (n int, err error) {
+	if l.n < 0 {
+		return 0, common.ErrorAttachmentTooLarge
+	}
+
+	// read one byte past the limit to tell a full attachment from an oversized one
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+
+	n, err = l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		return n, common.ErrorAttachmentTooLarge
+	}
+	return n, err
+}
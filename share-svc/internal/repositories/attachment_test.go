@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"bytes"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestAttachmentName(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "file.txt", "file.txt"},
+		{"spaces", "  file.txt ", "file.txt"},
+		{"unix path", "/home/user/file.txt", "file.txt"},
+		{"windows path", `C:\Users\user\file.txt`, "file.txt"},
+		{"traversal", "../../etc/passwd", "passwd"},
+		{"empty", "", defaultAttachmentName},
+		{"dot", ".", defaultAttachmentName},
+		{"root", "/", defaultAttachmentName},
+		{"too long", strings.Repeat("a", maxAttachmentName+10), strings.Repeat("a", maxAttachmentName)},
+		{"too long multibyte", strings.Repeat("é", maxAttachmentName), strings.Repeat("é", maxAttachmentName/2)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := attachmentName(tc.in); got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLimitedReader(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		size  int
+		limit int64
+		err   error
+	}{
+		{"empty", 0, 4, nil},
+		{"under the limit", 3, 4, nil},
+		{"at the limit", 4, 4, nil},
+		{"over the limit", 5, 4, common.ErrorAttachmentTooLarge},
+		{"far over the limit", 4096, 4, common.ErrorAttachmentTooLarge},
+		{"no room", 1, 0, common.ErrorAttachmentTooLarge},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			content := bytes.Repeat([]byte("a"), tc.size)
+
+			got, err := ioutil.ReadAll(&limitedReader{r: bytes.NewReader(content), n: tc.limit})
+			if err != tc.err {
+				t.Fatalf("got %v, want %v", err, tc.err)
+			}
+			if int64(len(got)) > tc.limit+1 {
+				t.Fatalf("read %d bytes past a limit of %d", len(got), tc.limit)
+			}
+			if err == nil && !bytes.Equal(got, content) {
+				t.Fatalf("got %q, want %q", got, content)
+			}
+		})
+	}
+}
@@ -0,0 +1,354 @@
+package repositories
+
+import (
+	"context"
+	"encoding/base64"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/config"
+	"github.com/al8n/shareable-notes/share-svc/internal/utils"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// collectionsCollection holds the note collections.
+const collectionsCollection = "collections"
+
+// CreateCollection shares a new collection of the given visible notes, in order.
+func (repo Repo) CreateCollection(ctx context.Context, name string, noteIDs []string) (url, collectionID string, err error)  {
+	var (
+		oids []primitive.ObjectID
+		rst *mongo.InsertOneResult
+		now = time.Now().Unix()
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
+	oids, err = repo.visibleNoteIDs(spanCtx, noteIDs)
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return "", "", err
+	}
+
+	span.LogKV("operation",  "create collection", "db.insertOne", name)
+
+	rst, err = repo.collections().InsertOne(spanCtx, model.Collection{
+		Name:      name,
+		NoteIDs:   oids,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return "", "", err
+	}
+
+	collectionID = rst.InsertedID.(primitive.ObjectID).Hex()
+	return collectionURL(collectionID), collectionID, nil
+}
+
+// AddCollectionNotes appends the given visible notes to a collection, the
+// notes already in the collection keep their place.
+func (repo Repo) AddCollectionNotes(ctx context.Context, id string, noteIDs []string) (err error)  {
+	var (
+		oid primitive.ObjectID
+		oids []primitive.ObjectID
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
+	oid, err = collectionObjectID(id)
+	if err == nil {
+		oids, err = repo.visibleNoteIDs(spanCtx, noteIDs)
+	}
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return err
+	}
+
+	span.LogKV("operation",  "add collection notes", "db.updateOne", id, "notes", len(oids))
+
+	// append the new notes in a single update, so concurrent additions of the
+	// same note cannot duplicate it
+	err = repo.updateCollection(spanCtx, bson.D{{Key: "_id", Value: oid}}, mongo.Pipeline{
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: "note_ids", Value: bson.D{{Key: "$concatArrays", Value: bson.A{
+				"$note_ids",
+				bson.D{{Key: "$filter", Value: bson.D{
+					{Key: "input", Value: oids},
+					{Key: "cond", Value: bson.D{{Key: "$not", Value: bson.A{
+						bson.D{{Key: "$in", Value: bson.A{"$$this", "$note_ids"}}},
+					}}}},
+				}}},
+			}}}},
+			{Key: "updated_at", Value: time.Now().Unix()},
+		}}},
+	})
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// RemoveCollectionNotes removes the given notes from a collection.
+func (repo Repo) RemoveCollectionNotes(ctx context.Context, id string, noteIDs []string) (err error)  {
+	var (
+		oid primitive.ObjectID
+		oids []primitive.ObjectID
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
+	oid, err = collectionObjectID(id)
+	if err == nil {
+		oids, err = objectIDs(noteIDs)
+	}
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return err
+	}
+
+	span.LogKV("operation",  "remove collection notes", "db.updateOne", id, "notes", len(oids))
+
+	err = repo.updateCollection(spanCtx, bson.D{{Key: "_id", Value: oid}}, bson.D{
+		{Key: "$pull", Value: bson.D{{Key: "note_ids", Value: bson.D{{Key: "$in", Value: oids}}}}},
+		{Key: "$set", Value: bson.D{{Key: "updated_at", Value: time.Now().Unix()}}},
+	})
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// ReorderCollection orders the notes of a collection as noteIDs, which must
+// list every note of the collection once.
+func (repo Repo) ReorderCollection(ctx context.Context, id string, noteIDs []string) (err error)  {
+	var (
+		oid primitive.ObjectID
+		oids []primitive.ObjectID
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
+	oid, err = collectionObjectID(id)
+	if err == nil {
+		oids, err = objectIDs(noteIDs)
+	}
+	if err == nil && len(oids) != len(noteIDs) {
+		err = common.ErrorInvalidCollectionOrder
+	}
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return err
+	}
+
+	span.LogKV("operation",  "reorder collection", "db.updateOne", id, "notes", len(oids))
+
+	// the order applies only to the notes it was made for
+	match := bson.D{{Key: "$size", Value: len(oids)}}
+	if len(oids) > 0 {
+		match = append(match, bson.E{Key: "$all", Value: oids})
+	}
+
+	err = repo.updateCollection(spanCtx, bson.D{{Key: "_id", Value: oid}, {Key: "note_ids", Value: match}}, bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "note_ids", Value: oids},
+			{Key: "updated_at", Value: time.Now().Unix()},
+		}},
+	})
+	if err == common.ErrorCollectionNotFound {
+		if _, err = repo.findCollection(spanCtx, oid); err == nil {
+			err = common.ErrorInvalidCollectionOrder
+		}
+	}
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+// GetCollection returns the name of a collection and the summaries of its
+// visible notes, in order.
+func (repo Repo) GetCollection(ctx context.Context, id string) (name string, notes []model.CollectionNote, err error)  {
+	var (
+		cfg = config.GetConfig()
+		oid primitive.ObjectID
+		collection model.Collection
+		cursor *mongo.Cursor
+		visible = make(map[primitive.ObjectID]model.Note)
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
+	oid, err = collectionObjectID(id)
+	if err == nil {
+		collection, err = repo.findCollection(spanCtx, oid)
+	}
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return "", nil, err
+	}
+
+	notes = []model.CollectionNote{}
+	if len(collection.NoteIDs) == 0 {
+		return collection.Name, notes, nil
+	}
+
+	span.LogKV("operation",  "get collection", "db.find", id, "notes", len(collection.NoteIDs))
+
+	cursor, err = repo.MongoDB.Database(cfg.Mongo.DB).Collection(cfg.Mongo.Collection).Find(
+		spanCtx,
+		bson.D{
+			{Key: "_id", Value: bson.D{{Key: "$in", Value: collection.NoteIDs}}},
+			{Key: "deactivated", Value: false},
+		},
+		options.Find().SetProjection(bson.D{
+			{Key: "name", Value: 1},
+			{Key: "encrypted_name", Value: 1},
+			{Key: "data_key", Value: 1},
+		}),
+	)
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return "", nil, err
+	}
+	defer cursor.Close(spanCtx)
+
+	for cursor.Next(spanCtx) {
+		var note model.Note
+		if err = cursor.Decode(&note); err == nil {
+			err = repo.openName(spanCtx, &note)
+		}
+		if err != nil {
+			utils.SetTracerSpanError(span, err)
+			return "", nil, err
+		}
+		visible[note.ID] = note
+	}
+
+	if err = cursor.Err(); err != nil {
+		utils.SetTracerSpanError(span, err)
+		return "", nil, err
+	}
+
+	// privatized notes stay in the collection, hidden until shared again
+	for _, noteID := range collection.NoteIDs {
+		if note, ok := visible[noteID]; ok {
+			notes = append(notes, model.CollectionNote{
+				NoteID: note.ID.Hex(),
+				Name:   note.Name,
+				URL:    shareURL(note.ID.Hex()),
+			})
+		}
+	}
+	return collection.Name, notes, nil
+}
+
+func (repo Repo) collections() *mongo.Collection {
+	return repo.MongoDB.Database(config.GetConfig().Mongo.DB).Collection(collectionsCollection)
+}
+
+func (repo Repo) findCollection(ctx context.Context, oid primitive.ObjectID) (collection model.Collection, err error)  {
+	err = repo.collections().FindOne(ctx, bson.D{{Key: "_id", Value: oid}}).Decode(&collection)
+	if err == mongo.ErrNoDocuments {
+		err = common.ErrorCollectionNotFound
+	}
+	return collection, err
+}
+
+// updateCollection fails with common.ErrorCollectionNotFound when no
+// collection matches filter.
+func (repo Repo) updateCollection(ctx context.Context, filter bson.D, update interface{}) error  {
+	rst, err := repo.collections().UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if rst.MatchedCount == 0 {
+		return common.ErrorCollectionNotFound
+	}
+	return nil
+}
+
+// visibleNoteIDs parses the note ids, and checks the notes are all visible.
+func (repo Repo) visibleNoteIDs(ctx context.Context, noteIDs []string) (oids []primitive.ObjectID, err error)  {
+	var (
+		cfg = config.GetConfig()
+		count int64
+	)
+
+	oids, err = objectIDs(noteIDs)
+	if err != nil || len(oids) == 0 {
+		return oids, err
+	}
+
+	count, err = repo.MongoDB.Database(cfg.Mongo.DB).Collection(cfg.Mongo.Collection).CountDocuments(
+		ctx,
+		bson.D{
+			{Key: "_id", Value: bson.D{{Key: "$in", Value: oids}}},
+			{Key: "deactivated", Value: false},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if count != int64(len(oids)) {
+		return nil, common.ErrorNoteNotFound
+	}
+	return oids, nil
+}
+
+// objectIDs parses ids, dropping the duplicates.
+func objectIDs(ids []string) (oids []primitive.ObjectID, err error)  {
+	var seen = make(map[primitive.ObjectID]struct{}, len(ids))
+
+	oids = make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		oid, err := noteObjectID(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := seen[oid]; !ok {
+			seen[oid] = struct{}{}
+			oids = append(oids, oid)
+		}
+	}
+	return oids, nil
+}
+
+func collectionObjectID(id string) (primitive.ObjectID, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return oid, common.ErrorCollectionNotFound
+	}
+	return oid, nil
+}
+
+// collectionURL returns the URL the collection with the given id is shared at.
+func collectionURL(collectionID string) string {
+	return config.GetConfig().Address + "/share/v1/collection/" + base64.URLEncoding.EncodeToString([]byte(collectionID))
+}
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"reflect"
+	"testing"
+)
+
+func TestObjectIDs(t *testing.T) {
+	var (
+		a = primitive.NewObjectID()
+		b = primitive.NewObjectID()
+	)
+
+	for _, tc := range []struct {
+		name string
+		ids  []string
+		want []primitive.ObjectID
+		err  error
+	}{
+		{"empty", nil, []primitive.ObjectID{}, nil},
+		{"in order", []string{a.Hex(), b.Hex()}, []primitive.ObjectID{a, b}, nil},
+		{"reversed", []string{b.Hex(), a.Hex()}, []primitive.ObjectID{b, a}, nil},
+		{"duplicates", []string{a.Hex(), b.Hex(), a.Hex()}, []primitive.ObjectID{a, b}, nil},
+		{"invalid id", []string{a.Hex(), "note"}, nil, common.ErrorInvalidNoteID},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := objectIDs(tc.ids)
+			if err != tc.err {
+				t.Fatalf("got %v, want %v", err, tc.err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCollectionObjectID(t *testing.T) {
+	oid := primitive.NewObjectID()
+
+	for _, tc := range []struct {
+		name string
+		id   string
+		err  error
+	}{
+		{"valid", oid.Hex(), nil},
+		{"empty", "", common.ErrorCollectionNotFound},
+		{"invalid", "collection", common.ErrorCollectionNotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := collectionObjectID(tc.id)
+			if err != tc.err {
+				t.Fatalf("got %v, want %v", err, tc.err)
+			}
+			if err == nil && got != oid {
+				t.Fatalf("got %v, want %v", got, oid)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/config"
+	"github.com/al8n/shareable-notes/share-svc/internal/utils"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"io"
+	"strings"
+	"time"
+)
+
+// commentsCollection holds the comments of the notes.
+const commentsCollection = "comments"
+
+// AddComment leaves a comment on a visible note. The comment is anchored to
+// the lines startLine to endLine of the content unless startLine is 0, a 0
+// endLine anchors it to startLine alone.
+func (repo Repo) AddComment(ctx context.Context, noteID, author, body string, startLine, endLine int64) (comment model.NoteComment, err error)  {
+	var (
+		note model.Note
+		lines int64
+		stored model.Comment
+		rst *mongo.InsertOneResult
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		err = common.ErrorEmptyComment
+		utils.SetTracerSpanError(span, err)
+		return comment, err
+	}
+
+	if startLine > 0 && endLine == 0 {
+		endLine = startLine
+	}
+
+	note, err = repo.findNote(spanCtx, noteID)
+	if err == nil && (startLine != 0 || endLine != 0) {
+		lines, err = repo.countLines(spanCtx, note)
+		if err == nil && !validCommentRange(startLine, endLine, lines) {
+			err = common.ErrorInvalidCommentAnchor
+		}
+	}
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return comment, err
+	}
+
+	stored = model.Comment{
+		NoteID:    note.ID,
+		Author:    author,
+		Body:      body,
+		StartLine: startLine,
+		EndLine:   endLine,
+		CreatedAt: time.Now().Unix(),
+	}
+
+	span.LogKV("operation",  "add comment", "db.insertOne", noteID)
+
+	rst, err = repo.comments().InsertOne(spanCtx, stored)
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return comment, err
+	}
+
+	stored.ID = rst.InsertedID.(primitive.ObjectID)
+	return noteComment(stored), nil
+}
+
+// ListComments returns the comments of a visible note, oldest first. The
+// resolved comments are left out unless includeResolved is set.
+func (repo Repo) ListComments(ctx context.Context, noteID string, includeResolved bool) (comments []model.NoteComment, err error)  {
+	var (
+		note model.Note
+		filter bson.D
+		cursor *mongo.Cursor
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
+	note, err = repo.findNote(spanCtx, noteID)
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return nil, err
+	}
+
+	filter = bson.D{{Key: "note_id", Value: note.ID}}
+	if !includeResolved {
+		filter = append(filter, bson.E{Key: "resolved", Value: false})
+	}
+
+	span.LogKV("operation",  "list comments", "db.find", noteID)
+
+	cursor, err = repo.comments().Find(
+		spanCtx,
+		filter,
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return nil, err
+	}
+	defer cursor.Close(spanCtx)
+
+	comments = []model.NoteComment{}
+	for cursor.Next(spanCtx) {
+		var comment model.Comment
+		if err = cursor.Decode(&comment); err != nil {
+			utils.SetTracerSpanError(span, err)
+			return nil, err
+		}
+		comments = append(comments, noteComment(comment))
+	}
+
+	if err = cursor.Err(); err != nil {
+		utils.SetTracerSpanError(span, err)
+		return nil, err
+	}
+	return comments, nil
+}
+
+// ResolveComment marks a comment of a visible note as resolved by user, a
+// comment already resolved is returned unchanged.
+func (repo Repo) ResolveComment(ctx context.Context, noteID, commentID, user string) (comment model.NoteComment, err error)  {
+	var (
+		note model.Note
+		oid primitive.ObjectID
+		stored model.Comment
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
+	note, err = repo.findNote(spanCtx, noteID)
+	if err == nil {
+		oid, err = primitive.ObjectIDFromHex(commentID)
+		if err != nil {
+			err = common.ErrorCommentNotFound
+		}
+	}
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return comment, err
+	}
+
+	span.LogKV("operation",  "resolve comment", "db.findOneAndUpdate", commentID)
+
+	err = repo.comments().FindOneAndUpdate(
+		spanCtx,
+		bson.D{
+			{Key: "_id", Value: oid},
+			{Key: "note_id", Value: note.ID},
+			{Key: "resolved", Value: false},
+		},
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: "resolved", Value: true},
+			{Key: "resolved_by", Value: user},
+			{Key: "resolved_at", Value: time.Now().Unix()},
+		}}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&stored)
+	if err == mongo.ErrNoDocuments {
+		err = repo.comments().FindOne(spanCtx, bson.D{
+			{Key: "_id", Value: oid},
+			{Key: "note_id", Value: note.ID},
+		}).Decode(&stored)
+		if err == mongo.ErrNoDocuments {
+			err = common.ErrorCommentNotFound
+		}
+	}
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return comment, err
+	}
+	return noteComment(stored), nil
+}
+
+func (repo Repo) comments() *mongo.Collection {
+	return repo.MongoDB.Database(config.GetConfig().Mongo.DB).Collection(commentsCollection)
+}
+
+// countLines returns the number of lines of the note content, a trailing
+// newline does not start a new line.
+func (repo Repo) countLines(ctx context.Context, note model.Note) (lines int64, err error)  {
+	var (
+		content io.ReadCloser
+		buf = make([]byte, 32 << 10)
+		last byte = '\n'
+		n int
+	)
+
+	content, err = repo.openContent(ctx, &note)
+	if err != nil {
+		return 0, err
+	}
+	defer content.Close()
+
+	for {
+		n, err = content.Read(buf)
+		if n > 0 {
+			lines += int64(bytes.Count(buf[:n], []byte{'\n'}))
+			last = buf[n-1]
+		}
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if last != '\n' {
+		lines++
+	}
+	return lines, nil
+}
+
+// validCommentRange reports whether the lines startLine to endLine are within
+// the lines of a note.
+func validCommentRange(startLine, endLine, lines int64) bool {
+	return startLine >= 1 && endLine >= startLine && endLine <= lines
+}
+
+func noteComment(comment model.Comment) model.NoteComment {
+	return model.NoteComment{
+		ID:         comment.ID.Hex(),
+		NoteID:     comment.NoteID.Hex(),
+		Author:     comment.Author,
+		Body:       comment.Body,
+		StartLine:  comment.StartLine,
+		EndLine:    comment.EndLine,
+		Resolved:   comment.Resolved,
+		ResolvedBy: comment.ResolvedBy,
+		CreatedAt:  comment.CreatedAt,
+		ResolvedAt: comment.ResolvedAt,
+	}
+}
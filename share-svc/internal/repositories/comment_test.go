@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"github.com/al8n/shareable-notes/share-svc/internal/compress"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"testing"
+)
+
+func TestValidCommentRange(t *testing.T) {
+	for _, tc := range []struct {
+		name              string
+		start, end, lines int64
+		want              bool
+	}{
+		{"single line", 2, 2, 3, true},
+		{"whole note", 1, 3, 3, true},
+		{"last line", 3, 3, 3, true},
+		{"zero start", 0, 2, 3, false},
+		{"negative start", -1, 2, 3, false},
+		{"reversed", 3, 2, 3, false},
+		{"past the end", 2, 4, 3, false},
+		{"empty note", 1, 1, 0, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := validCommentRange(tc.start, tc.end, tc.lines); got != tc.want {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCountLines(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		want    int64
+	}{
+		{"empty", "", 0},
+		{"one line", "line", 1},
+		{"trailing newline", "line\n", 1},
+		{"lines", "one\ntwo\nthree", 3},
+		{"blank lines", "one\n\n\nfour\n", 4},
+		{"only newlines", "\n\n", 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			compressed, err := compress.Compress(compress.Gzip, []byte(tc.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, note := range []model.Note{
+				{Content: tc.content},
+				{Encoding: compress.Gzip, CompressedContent: compressed},
+			} {
+				got, err := Repo{}.countLines(context.Background(), note)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != tc.want {
+					t.Fatalf("got %d lines, want %d (encoding %q)", got, tc.want, note.Encoding)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/config"
+	"github.com/al8n/shareable-notes/share-svc/internal/envelope"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"io"
+	"io/ioutil"
+	"time"
+)
+
+const (
+	noteContentsCollection = "note_contents"
+
+	// contentRetries bounds the attempts to store a content deleted by its
+	// last note meanwhile.
+	contentRetries = 3
+)
+
+// errContentReleased is returned when the content of a note was deleted by a
+// concurrent write of the note, after the note was read.
+var errContentReleased = errors.New("note content has been released")
+
+// putContent stores the content read from content, unless a note has the
+// same content already, and returns its hash. The content is referred to
+// once more, until releaseContent. The content is encrypted with its own data
+// key when the notes are encrypted.
+func (repo Repo) putContent(ctx context.Context, name string, content io.Reader) (hash string, size int64, err error)  {
+	var (
+		cfg = config.GetConfig()
+		body model.NoteContent
+		head, key []byte
+		found, stored bool
+	)
+
+	h, err := repo.contentHash(ctx)
+	if err != nil {
+		return "", 0, err
+	}
+
+	if key, body.DataKey, err = repo.newDataKey(ctx); err != nil {
+		return "", 0, err
+	}
+
+	// read one byte more than the threshold to find out whether the content fits in the document
+	head, err = ioutil.ReadAll(io.LimitReader(content, int64(cfg.Service.Stream.Threshold) + 1))
+	if err != nil {
+		return "", 0, err
+	}
+
+	if len(head) > cfg.Service.Stream.Threshold {
+		body.Encoding = contentCodec()
+		body.ContentFileID, body.Size, err = repo.uploadContent(ctx, name, io.TeeReader(io.MultiReader(bytes.NewReader(head), content), h), body.Encoding, key)
+		if err != nil {
+			return "", 0, err
+		}
+	} else {
+		h.Write(head)
+		body.Size = int64(len(head))
+
+		body.Encoding, body.CompressedContent, err = compressContent(head)
+		if err != nil {
+			return "", 0, err
+		}
+
+		switch {
+		case key != nil && body.Encoding != "":
+			body.EncryptedContent, err = envelope.Seal(key, body.CompressedContent)
+			body.CompressedContent = nil
+		case key != nil:
+			body.EncryptedContent, err = envelope.Seal(key, head)
+		case body.Encoding == "":
+			body.Content = string(head)
+		}
+
+		if err != nil {
+			return "", 0, err
+		}
+	}
+
+	body.Hash = hex.EncodeToString(h.Sum(nil))
+	body.Refs = 1
+	body.CreatedAt = time.Now().Unix()
+
+	for i := 0; i < contentRetries && !found && !stored; i++ {
+		found, err = repo.referContent(ctx, body.Hash)
+		if err != nil || found {
+			break
+		}
+
+		// another note may store the same content meanwhile
+		_, err = repo.contents().InsertOne(ctx, body)
+		if mongo.IsDuplicateKeyError(err) {
+			err = nil
+			continue
+		}
+		stored = err == nil
+		break
+	}
+
+	if !stored && !body.ContentFileID.IsZero() {
+		repo.deleteContent(ctx, body.ContentFileID)
+	}
+
+	if err != nil {
+		return "", 0, err
+	}
+
+	if !found && !stored {
+		return "", 0, common.ErrorContentContention
+	}
+	return body.Hash, body.Size, nil
+}
+
+// referContent refers to the content of hash once more, found is false when
+// no note has this content.
+func (repo Repo) referContent(ctx context.Context, hash string) (found bool, err error)  {
+	rst, err := repo.contents().UpdateOne(
+		ctx,
+		bson.D{{Key: "_id", Value: hash}},
+		bson.D{{Key: "$inc", Value: bson.D{{Key: "refs", Value: 1}}}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return rst.MatchedCount > 0, nil
+}
+
+// releaseContent drops a reference to the content of hash, the content is
+// deleted once no note refers to it.
+func (repo Repo) releaseContent(ctx context.Context, hash string) (err error)  {
+	return repo.releaseContentRefs(ctx, hash, 1)
+}
+
+// releaseContentRefs drops refs references to the content of hash.
+func (repo Repo) releaseContentRefs(ctx context.Context, hash string, refs int64) (err error)  {
+	var body model.NoteContent
+
+	if hash == "" || refs <= 0 {
+		return nil
+	}
+
+	err = repo.contents().FindOneAndUpdate(
+		ctx,
+		bson.D{{Key: "_id", Value: hash}},
+		bson.D{{Key: "$inc", Value: bson.D{{Key: "refs", Value: -refs}}}},
+		options.FindOneAndUpdate().
+			SetReturnDocument(options.After).
+			SetProjection(bson.D{{Key: "refs", Value: 1}}),
+	).Decode(&body)
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+
+	if err != nil || body.Refs > 0 {
+		return err
+	}
+
+	// the content is kept when a note referred to it meanwhile
+	err = repo.contents().FindOneAndDelete(
+		ctx,
+		bson.D{
+			{Key: "_id", Value: hash},
+			{Key: "refs", Value: bson.D{{Key: "$lte", Value: 0}}},
+		},
+		options.FindOneAndDelete().SetProjection(bson.D{{Key: "content_file_id", Value: 1}}),
+	).Decode(&body)
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !body.ContentFileID.IsZero() {
+		return repo.deleteContent(ctx, body.ContentFileID)
+	}
+	return nil
+}
+
+func (repo Repo) findContent(ctx context.Context, hash string) (body model.NoteContent, err error)  {
+	err = repo.contents().FindOne(ctx, bson.D{{Key: "_id", Value: hash}}).Decode(&body)
+	return body, err
+}
+
+func (repo Repo) contents() *mongo.Collection {
+	return repo.MongoDB.Database(config.GetConfig().Mongo.DB).Collection(noteContentsCollection)
+}
@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"github.com/al8n/shareable-notes/share-svc/internal/compress"
+	"github.com/al8n/shareable-notes/share-svc/internal/envelope"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"io/ioutil"
+	"testing"
+)
+
+// plainProvider wraps the data keys as they are.
+type plainProvider struct{}
+
+func (plainProvider) PrimaryKeyID() string { return "plain" }
+
+func (plainProvider) Wrap(_ context.Context, key []byte) (string, []byte, error) {
+	return "plain", append([]byte(nil), key...), nil
+}
+
+func (plainProvider) Unwrap(_ context.Context, _ string, wrapped []byte) ([]byte, error) {
+	return append([]byte(nil), wrapped...), nil
+}
+
+func sum(t *testing.T, repo Repo, content string) []byte {
+	h, err := repo.contentHash(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Write([]byte(content))
+	return h.Sum(nil)
+}
+
+func TestContentHash(t *testing.T) {
+	var (
+		key   = bytes.Repeat([]byte{1}, envelope.KeySize)
+		other = bytes.Repeat([]byte{2}, envelope.KeySize)
+		keyed = func(key []byte) Repo {
+			return Repo{envelope: envelope.New(plainProvider{}), hashKey: &hashKey{key: key}}
+		}
+		sha = sha256.Sum256([]byte("content"))
+		mac = hmac.New(sha256.New, key)
+	)
+	mac.Write([]byte("content"))
+
+	for _, tc := range []struct {
+		name string
+		repo Repo
+		want []byte
+	}{
+		{"not encrypted", Repo{}, sha[:]},
+		{"encrypted", keyed(key), mac.Sum(nil)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := sum(t, tc.repo, "content"); !bytes.Equal(got, tc.want) {
+				t.Fatalf("got %x, want %x", got, tc.want)
+			}
+			if got, want := sum(t, tc.repo, "content"), sum(t, tc.repo, "content"); !bytes.Equal(got, want) {
+				t.Fatalf("same content: got %x, want %x", got, want)
+			}
+			if got, other := sum(t, tc.repo, "content"), sum(t, tc.repo, "other"); bytes.Equal(got, other) {
+				t.Fatalf("other content: got %x, want another hash", got)
+			}
+		})
+	}
+
+	if got, want := sum(t, keyed(key), "content"), sum(t, keyed(other), "content"); bytes.Equal(got, want) {
+		t.Fatalf("other key: got %x, want another hash", got)
+	}
+}
+
+func TestOpenNoteContent(t *testing.T) {
+	compressed, err := compress.Compress(compress.Gzip, []byte("line\nline"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		note model.Note
+		want string
+	}{
+		{
+			name: "plain",
+			note: model.Note{Content: "content"},
+			want: "content",
+		},
+		{
+			name: "empty",
+			note: model.Note{},
+			want: "",
+		},
+		{
+			name: "compressed",
+			note: model.Note{Encoding: compress.Gzip, CompressedContent: compressed},
+			want: "line\nline",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// the notes written before the contents were shared hold their content
+			content, err := Repo{}.openNoteContent(context.Background(), tc.note)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer content.Close()
+
+			got, err := ioutil.ReadAll(content)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/config"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const noteEditsCollection = "note_edits"
+
+// noteEdit is a logged edit, its id orders the edits of a note by revision
+// so that they are ranged over with the _id index.
+type noteEdit struct {
+	ID             string `bson:"_id"`
+	model.NoteEdit `bson:",inline"`
+}
+
+// AppendNoteEdit logs the edit producing a revision of the note, it fails
+// with common.ErrorEditRevisionTaken when another instance logged it first.
+func (repo Repo) AppendNoteEdit(ctx context.Context, edit model.NoteEdit) (err error)  {
+	_, err = repo.noteEdits().InsertOne(ctx, noteEdit{
+		ID:       noteEditID(edit.NoteID, edit.Revision),
+		NoteEdit: edit,
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return common.ErrorEditRevisionTaken
+	}
+	return err
+}
+
+// NoteEdits returns the logged edits of the note after the given revision, in
+// order.
+func (repo Repo) NoteEdits(ctx context.Context, id string, after int64) (edits []model.NoteEdit, err error)  {
+	var cursor *mongo.Cursor
+
+	cursor, err = repo.noteEdits().Find(
+		ctx,
+		bson.D{{Key: "_id", Value: bson.D{
+			{Key: "$gt", Value: noteEditID(id, after)},
+			{Key: "$lt", Value: noteEditsEnd(id)},
+		}}},
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var edit noteEdit
+		if err = cursor.Decode(&edit); err != nil {
+			return nil, err
+		}
+		edits = append(edits, edit.NoteEdit)
+	}
+	return edits, cursor.Err()
+}
+
+// DeleteNoteEdits deletes the logged edits of the note from revision from to
+// revision to, both included.
+func (repo Repo) DeleteNoteEdits(ctx context.Context, id string, from, to int64) (err error)  {
+	var rng = bson.D{{Key: "$gte", Value: noteEditID(id, from)}}
+
+	if to < 0 {
+		rng = append(rng, bson.E{Key: "$lt", Value: noteEditsEnd(id)})
+	} else {
+		rng = append(rng, bson.E{Key: "$lte", Value: noteEditID(id, to)})
+	}
+
+	_, err = repo.noteEdits().DeleteMany(ctx, bson.D{{Key: "_id", Value: rng}})
+	return err
+}
+
+// noteEditID sorts the edits of a note by revision, the revisions are not
+// negative.
+func noteEditID(id string, revision int64) string {
+	if revision < 0 {
+		revision = 0
+	}
+	return fmt.Sprintf("%s:%016x", id, revision)
+}
+
+// noteEditsEnd sorts after every edit of the note.
+func noteEditsEnd(id string) string {
+	return id + ";"
+}
+
+func (repo Repo) noteEdits() *mongo.Collection {
+	return repo.MongoDB.Database(config.GetConfig().Mongo.DB).Collection(noteEditsCollection)
+}
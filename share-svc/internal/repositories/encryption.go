@@ -0,0 +1,545 @@
+package repositories
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/config"
+	"github.com/al8n/shareable-notes/share-svc/internal/envelope"
+	"github.com/al8n/shareable-notes/share-svc/internal/utils"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"hash"
+	"io"
+	"sync"
+)
+
+const (
+	encryptionKeysCollection = "encryption_keys"
+
+	// contentHashKeyID is the id of the data key the content hashes are keyed by.
+	contentHashKeyID = "content_hash"
+)
+
+// encryptionKey is a data key which is not the one of a note or of a content.
+type encryptionKey struct {
+	ID      string           `bson:"_id"`
+	DataKey model.WrappedKey `bson:"data_key"`
+}
+
+// hashKey holds the key of the content hashes once loaded.
+type hashKey struct {
+	mu  sync.Mutex
+	key []byte
+}
+
+// contentHash returns the hash the contents are keyed by: a SHA-256 when the
+// notes are not encrypted, an HMAC-SHA256 otherwise, so that the hash of a
+// guessed content cannot be looked up.
+func (repo Repo) contentHash(ctx context.Context) (h hash.Hash, err error)  {
+	if repo.envelope == nil {
+		return sha256.New(), nil
+	}
+
+	repo.hashKey.mu.Lock()
+	defer repo.hashKey.mu.Unlock()
+
+	if repo.hashKey.key == nil {
+		if repo.hashKey.key, err = repo.loadHashKey(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return hmac.New(sha256.New, repo.hashKey.key), nil
+}
+
+// loadHashKey returns the key of the content hashes, which is created by the
+// first instance needing it.
+func (repo Repo) loadHashKey(ctx context.Context) (key []byte, err error)  {
+	var stored encryptionKey
+
+	err = repo.encryptionKeys().FindOne(ctx, bson.D{{Key: "_id", Value: contentHashKeyID}}).Decode(&stored)
+	if err == mongo.ErrNoDocuments {
+		stored.ID = contentHashKeyID
+		key, stored.DataKey, err = repo.envelope.NewKey(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = repo.encryptionKeys().InsertOne(ctx, stored)
+		if err == nil {
+			return key, nil
+		}
+
+		// another instance created it meanwhile
+		if mongo.IsDuplicateKeyError(err) {
+			err = repo.encryptionKeys().FindOne(ctx, bson.D{{Key: "_id", Value: contentHashKeyID}}).Decode(&stored)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return repo.envelope.Key(ctx, stored.DataKey)
+}
+
+// newDataKey returns a new data key along with its wrapped form, both are nil
+// when the notes are not encrypted.
+func (repo Repo) newDataKey(ctx context.Context) (key []byte, wrapped *model.WrappedKey, err error)  {
+	if repo.envelope == nil {
+		return nil, nil, nil
+	}
+
+	key, dataKey, err := repo.envelope.NewKey(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, &dataKey, nil
+}
+
+// dataKey unwraps the data key of a note or of a content.
+func (repo Repo) dataKey(ctx context.Context, wrapped model.WrappedKey) (key []byte, err error)  {
+	if repo.envelope == nil {
+		return nil, common.ErrorNoteEncrypted
+	}
+	return repo.envelope.Key(ctx, wrapped)
+}
+
+// sealName encrypts the name of a new note with a new data key of the note,
+// when the notes are encrypted.
+func (repo Repo) sealName(ctx context.Context, note *model.Note) (err error)  {
+	var key []byte
+
+	key, note.DataKey, err = repo.newDataKey(ctx)
+	if err != nil || key == nil {
+		return err
+	}
+
+	if note.EncryptedName, err = envelope.Seal(key, []byte(note.Name)); err != nil {
+		return err
+	}
+	note.Name = ""
+	return nil
+}
+
+// openName decrypts the name of note, when it is encrypted.
+func (repo Repo) openName(ctx context.Context, note *model.Note) (err error)  {
+	var key, name []byte
+
+	if note.DataKey == nil || len(note.EncryptedName) == 0 {
+		return nil
+	}
+
+	if key, err = repo.dataKey(ctx, *note.DataKey); err != nil {
+		return err
+	}
+
+	if name, err = envelope.Open(key, note.EncryptedName); err != nil {
+		return err
+	}
+
+	note.Name = string(name)
+	note.EncryptedName = nil
+	return nil
+}
+
+// nameFields returns the fields setting the name of note to name, encrypted
+// with the data key of the note, which is created for the notes written
+// before the notes were encrypted.
+func (repo Repo) nameFields(ctx context.Context, note model.Note, name string) (set bson.D, err error)  {
+	var key []byte
+
+	if note.DataKey == nil {
+		if key, note.DataKey, err = repo.newDataKey(ctx); err != nil {
+			return nil, err
+		}
+
+		if key == nil {
+			return bson.D{{Key: "name", Value: name}}, nil
+		}
+		set = bson.D{{Key: "data_key", Value: note.DataKey}}
+	} else if key, err = repo.dataKey(ctx, *note.DataKey); err != nil {
+		return nil, err
+	}
+
+	encrypted, err := envelope.Seal(key, []byte(name))
+	if err != nil {
+		return nil, err
+	}
+	return append(set, bson.E{Key: "name", Value: ""}, bson.E{Key: "encrypted_name", Value: encrypted}), nil
+}
+
+// RewrapKeys rewraps up to batch data keys of the notes, of the contents and
+// of the content hashes which are not wrapped by the primary master key. It
+// then encrypts up to batch names, note contents and contents written before
+// the notes were encrypted.
+func (repo Repo) RewrapKeys(ctx context.Context, batch int) (n int, err error)  {
+	var (
+		cfg = config.GetConfig()
+		span stdopentracing.Span
+		spanCtx context.Context
+		collection *mongo.Collection
+		rewrapped int
+	)
+
+	if repo.envelope == nil {
+		return 0, nil
+	}
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
+	collection = repo.MongoDB.Database(cfg.Mongo.DB).Collection(cfg.Mongo.Collection)
+
+	for _, keys := range []*mongo.Collection{collection, repo.contents(), repo.encryptionKeys()} {
+		span.LogKV("operation",  "rewrap keys", "db.find", keys.Name(), "primary", repo.envelope.PrimaryKeyID())
+
+		rewrapped, err = repo.rewrapCollection(spanCtx, keys, batch)
+		n += rewrapped
+		if err != nil {
+			utils.SetTracerSpanError(span, err)
+			return n, err
+		}
+	}
+
+	for _, encrypt := range []struct{
+		operation string
+		fn func(context.Context, *mongo.Collection, int) (int, error)
+	}{
+		{"encrypt names", repo.encryptNames},
+		{"encrypt note contents", repo.encryptNoteContents},
+		{"encrypt contents", repo.encryptContents},
+	} {
+		span.LogKV("operation",  encrypt.operation, "db.find", collection.Name())
+
+		rewrapped, err = encrypt.fn(spanCtx, collection, batch)
+		n += rewrapped
+		if err != nil {
+			utils.SetTracerSpanError(span, err)
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func (repo Repo) rewrapCollection(ctx context.Context, collection *mongo.Collection, batch int) (n int, err error)  {
+	var (
+		cursor *mongo.Cursor
+		rst *mongo.UpdateResult
+	)
+
+	cursor, err = collection.Find(
+		ctx,
+		bson.D{
+			{Key: "data_key", Value: bson.D{{Key: "$exists", Value: true}}},
+			{Key: "data_key.key_id", Value: bson.D{{Key: "$ne", Value: repo.envelope.PrimaryKeyID()}}},
+		},
+		options.Find().
+			SetLimit(int64(batch)).
+			SetProjection(bson.D{{Key: "data_key", Value: 1}}),
+	)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID      interface{}      `bson:"_id"`
+			DataKey model.WrappedKey `bson:"data_key"`
+		}
+
+		if err = cursor.Decode(&doc); err != nil {
+			return n, err
+		}
+
+		rewrapped, ok, err := repo.envelope.Rewrap(ctx, doc.DataKey)
+		if err != nil {
+			return n, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		// the key is left as is when it was rewrapped meanwhile
+		rst, err = collection.UpdateOne(
+			ctx,
+			bson.D{
+				{Key: "_id", Value: doc.ID},
+				{Key: "data_key.key_id", Value: doc.DataKey.KeyID},
+				{Key: "data_key.key", Value: doc.DataKey.Key},
+			},
+			bson.D{{Key: "$set", Value: bson.D{{Key: "data_key", Value: rewrapped}}}},
+		)
+		if err != nil {
+			return n, err
+		}
+		n += int(rst.ModifiedCount)
+	}
+	return n, cursor.Err()
+}
+
+// encryptNames encrypts up to batch names of the notes written before the
+// notes were encrypted, with a new data key of each note.
+func (repo Repo) encryptNames(ctx context.Context, collection *mongo.Collection, batch int) (n int, err error)  {
+	var (
+		cursor *mongo.Cursor
+		rst *mongo.UpdateResult
+		set bson.D
+	)
+
+	cursor, err = collection.Find(
+		ctx,
+		bson.D{{Key: "data_key", Value: bson.D{{Key: "$exists", Value: false}}}},
+		options.Find().
+			SetLimit(int64(batch)).
+			SetProjection(bson.D{{Key: "name", Value: 1}}),
+	)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var note model.Note
+
+		if err = cursor.Decode(&note); err != nil {
+			return n, err
+		}
+
+		if set, err = repo.nameFields(ctx, note, note.Name); err != nil {
+			return n, err
+		}
+
+		// the name is left as is when it was renamed meanwhile, with a data key
+		rst, err = collection.UpdateOne(
+			ctx,
+			bson.D{
+				{Key: "_id", Value: note.ID},
+				{Key: "data_key", Value: bson.D{{Key: "$exists", Value: false}}},
+				{Key: "name", Value: note.Name},
+			},
+			bson.D{{Key: "$set", Value: set}},
+		)
+		if err != nil {
+			return n, err
+		}
+		n += int(rst.ModifiedCount)
+	}
+	return n, cursor.Err()
+}
+
+// encryptNoteContents moves up to batch contents held by the notes written
+// before the contents were shared to encrypted contents.
+func (repo Repo) encryptNoteContents(ctx context.Context, collection *mongo.Collection, batch int) (n int, err error)  {
+	var (
+		cursor *mongo.Cursor
+		moved bool
+	)
+
+	cursor, err = collection.Find(
+		ctx,
+		bson.D{
+			{Key: "content_hash", Value: bson.D{{Key: "$exists", Value: false}}},
+			{Key: "$or", Value: bson.A{
+				bson.D{{Key: "content", Value: bson.D{{Key: "$gt", Value: ""}}}},
+				bson.D{{Key: "compressed_content", Value: bson.D{{Key: "$exists", Value: true}}}},
+				bson.D{{Key: "content_file_id", Value: bson.D{{Key: "$exists", Value: true}}}},
+			}},
+		},
+		options.Find().SetLimit(int64(batch)),
+	)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var note model.Note
+
+		if err = cursor.Decode(&note); err != nil {
+			return n, err
+		}
+
+		if moved, err = repo.encryptNoteContent(ctx, collection, note); err != nil {
+			return n, err
+		}
+
+		if moved {
+			n++
+		}
+	}
+	return n, cursor.Err()
+}
+
+// encryptNoteContent moves the content held by note to an encrypted content,
+// unless the note has been written since. The content of a privatized note is
+// dropped, as it is not read anymore.
+func (repo Repo) encryptNoteContent(ctx context.Context, collection *mongo.Collection, note model.Note) (moved bool, err error)  {
+	var (
+		rc io.ReadCloser
+		rst *mongo.UpdateResult
+		hash string
+		size int64
+		update bson.D
+	)
+
+	if !note.Deactivated {
+		if rc, err = repo.openNoteContent(ctx, note); err != nil {
+			return false, err
+		}
+
+		hash, size, err = repo.putContent(ctx, note.ID.Hex(), rc)
+		rc.Close()
+		if err != nil {
+			return false, err
+		}
+
+		update = bson.D{{Key: "$set", Value: bson.D{
+			{Key: "content_hash", Value: hash},
+			{Key: "size", Value: size},
+		}}}
+	}
+
+	update = append(update, bson.E{
+		Key: "$unset",
+		Value: bson.D{
+			{Key: "content", Value: ""},
+			{Key: "compressed_content", Value: ""},
+			{Key: "encoding", Value: ""},
+			{Key: "content_file_id", Value: ""},
+		},
+	})
+
+	rst, err = collection.UpdateOne(
+		ctx,
+		bson.D{
+			{Key: "_id", Value: note.ID},
+			{Key: "content_hash", Value: bson.D{{Key: "$exists", Value: false}}},
+			seqFilter(note.Seq),
+		},
+		update,
+	)
+	if err != nil || rst.ModifiedCount == 0 {
+		repo.releaseContent(ctx, hash)
+		return false, err
+	}
+
+	if !note.ContentFileID.IsZero() {
+		repo.deleteContent(ctx, note.ContentFileID)
+	}
+	return true, nil
+}
+
+// encryptContents moves the notes referring to up to batch contents stored
+// before the notes were encrypted to encrypted copies of them, which are
+// keyed by their HMAC, then deletes the contents.
+func (repo Repo) encryptContents(ctx context.Context, collection *mongo.Collection, batch int) (n int, err error)  {
+	var (
+		cursor *mongo.Cursor
+		deleted bool
+	)
+
+	cursor, err = repo.contents().Find(
+		ctx,
+		bson.D{{Key: "data_key", Value: bson.D{{Key: "$exists", Value: false}}}},
+		options.Find().SetLimit(int64(batch)),
+	)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var body model.NoteContent
+
+		if err = cursor.Decode(&body); err != nil {
+			return n, err
+		}
+
+		if deleted, err = repo.encryptContent(ctx, collection, body); err != nil {
+			return n, err
+		}
+
+		if deleted {
+			n++
+		}
+	}
+	return n, cursor.Err()
+}
+
+// encryptContent moves the notes referring to body to an encrypted copy of
+// it. The contents stored before the notes were encrypted are not referred
+// to anymore once the notes are encrypted, as the hashes of the contents
+// written are HMACs, so body is deleted once no note refers to it.
+func (repo Repo) encryptContent(ctx context.Context, collection *mongo.Collection, body model.NoteContent) (deleted bool, err error)  {
+	var (
+		filter = bson.D{{Key: "content_hash", Value: body.Hash}}
+		rc io.ReadCloser
+		rst *mongo.UpdateResult
+		refs int64
+		hash string
+	)
+
+	if refs, err = collection.CountDocuments(ctx, filter); err != nil {
+		return false, err
+	}
+
+	if refs > 0 {
+		if rc, err = repo.openBody(ctx, body); err != nil {
+			return false, err
+		}
+
+		// the name of the GridFS file is empty as the names are encrypted
+		hash, _, err = repo.putContent(ctx, "", rc)
+		rc.Close()
+		if err != nil {
+			return false, err
+		}
+
+		// the copy is referred to by every note before they refer to it, so
+		// that a note written meanwhile does not delete it when releasing it
+		_, err = repo.contents().UpdateOne(
+			ctx,
+			bson.D{{Key: "_id", Value: hash}},
+			bson.D{{Key: "$inc", Value: bson.D{{Key: "refs", Value: refs - 1}}}},
+		)
+		if err != nil {
+			repo.releaseContent(ctx, hash)
+			return false, err
+		}
+
+		rst, err = collection.UpdateMany(ctx, filter, bson.D{{Key: "$set", Value: bson.D{{Key: "content_hash", Value: hash}}}})
+		if err != nil {
+			repo.releaseContentRefs(ctx, hash, refs)
+			return false, err
+		}
+
+		// the notes written meanwhile refer to another content already
+		if err = repo.releaseContentRefs(ctx, hash, refs - rst.ModifiedCount); err != nil {
+			return false, err
+		}
+	}
+
+	// the content is kept while a note written by an instance not encrypting the notes refers to it
+	if refs, err = collection.CountDocuments(ctx, filter); err != nil || refs > 0 {
+		return false, err
+	}
+
+	if _, err = repo.contents().DeleteOne(ctx, bson.D{{Key: "_id", Value: body.Hash}}); err != nil {
+		return false, err
+	}
+
+	if !body.ContentFileID.IsZero() {
+		return true, repo.deleteContent(ctx, body.ContentFileID)
+	}
+	return true, nil
+}
+
+func (repo Repo) encryptionKeys() *mongo.Collection {
+	return repo.MongoDB.Database(config.GetConfig().Mongo.DB).Collection(encryptionKeysCollection)
+}
@@ -1,17 +1,27 @@
 package repositories
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
-	"errors"
+	"fmt"
+	"github.com/al8n/shareable-notes/share-svc/common"
 	"github.com/al8n/shareable-notes/share-svc/config"
+	"github.com/al8n/shareable-notes/share-svc/internal/blobstore"
+	"github.com/al8n/shareable-notes/share-svc/internal/broker"
+	"github.com/al8n/shareable-notes/share-svc/internal/compress"
+	"github.com/al8n/shareable-notes/share-svc/internal/envelope"
 	"github.com/al8n/shareable-notes/share-svc/internal/utils"
 	"github.com/al8n/shareable-notes/share-svc/model"
 	stdopentracing "github.com/opentracing/opentracing-go"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"io"
+	"io/ioutil"
+	"strings"
 	"time"
 )
 
@@ -19,35 +29,154 @@ const mongoOPName = "MongoDB"
 
 type Repo struct {
 	MongoDB *mongo.Client
+
+	// broker delivers note events when MongoDB change streams are not available
+	broker *broker.Broker
+
+	// blobs holds the content of the note attachments
+	blobs blobstore.Store
+
+	// envelope encrypts the note names and contents, it is nil when the notes
+	// are not encrypted
+	envelope *envelope.Envelope
+	hashKey *hashKey
 }
 
 func NewRepo() (repo *Repo, err error ) {
 
 	var (
+		cfg = config.GetConfig()
 		client *mongo.Client
 		opt *options.ClientOptions
+		blobs blobstore.Store
+		keyfile *envelope.Keyfile
+		env *envelope.Envelope
 	)
 
-	opt, err = config.GetConfig().Mongo.Standardize()
+	opt, err = cfg.Mongo.Standardize()
 	if err != nil {
 		return nil, err
 	}
 
+	if cfg.Service.Encryption.Keyfile != "" {
+		if keyfile, err = envelope.NewKeyfile(cfg.Service.Encryption.Keyfile); err != nil {
+			return nil, err
+		}
+		env = envelope.New(keyfile)
+	}
+
 	if client, err = mongo.Connect(context.TODO(), opt); err != nil {
 		return
 	}
 
+	attachments := cfg.Service.Attachments
+	switch attachments.Store {
+	case config.AttachmentStoreLocal:
+		if blobs, err = blobstore.NewLocal(attachments.Dir); err != nil {
+			return nil, err
+		}
+	default:
+		blobs = blobstore.NewGridFS(client.Database(cfg.Mongo.DB), attachments.Bucket)
+	}
+
 	return &Repo{
 		MongoDB: client,
+		broker: broker.New(),
+		blobs: blobs,
+		envelope: env,
+		hashKey: &hashKey{},
 	}, nil
 }
 
-func (repo Repo) ShareNote(ctx context.Context, name, content string) (url, shareID string, err error)  {
+// Close disconnects from MongoDB once the operations in progress are done.
+func (repo Repo) Close(ctx context.Context) error  {
+	return repo.MongoDB.Disconnect(ctx)
+}
+
+func (repo Repo) ShareNote(ctx context.Context, name, content string) (url, shareID, ownerToken string, err error)  {
+	return repo.ShareNoteStream(ctx, name, strings.NewReader(content))
+}
+
+// ShareNoteStream stores the content read from content as a new note. Content
+// above the configured stream threshold is stored in GridFS instead of the note document.
+// The owner token returned is the only way to read the stats of the note.
+func (repo Repo) ShareNoteStream(ctx context.Context, name string, content io.Reader) (url, shareID, ownerToken string, err error)  {
+	var (
+		note = model.Note{Name: name}
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
+	span.LogKV("operation",  "share note", "db.insertOne", name)
+
+	ownerToken, note.OwnerTokenHash, err = newOwnerToken()
+	if err == nil {
+		shareID, err = repo.insertNote(spanCtx, &note, content)
+	}
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return "", "", "", err
+	}
+	return shareURL(shareID), shareID, ownerToken, nil
+}
+
+// ForkNote copies a visible note into a new note owned by owner, which
+// records the note it was forked from. The owner token returned is the only
+// way to read the stats of the fork.
+func (repo Repo) ForkNote(ctx context.Context, id, owner string) (url, shareID, ownerToken string, err error)  {
+	var (
+		source model.Note
+		fork model.Note
+		rc io.ReadCloser
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
+	source, err = repo.findNote(spanCtx, id)
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return "", "", "", err
+	}
+
+	rc, err = repo.openContent(spanCtx, &source)
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return "", "", "", err
+	}
+	defer rc.Close()
+
+	span.LogKV("operation",  "fork note", "db.insertOne", id, "owner", owner)
+
+	fork = model.Note{
+		Name:       source.Name,
+		Owner:      owner,
+		ForkedFrom: source.ID,
+	}
+
+	ownerToken, fork.OwnerTokenHash, err = newOwnerToken()
+	if err == nil {
+		shareID, err = repo.insertNote(spanCtx, &fork, rc)
+	}
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return "", "", "", err
+	}
+	return shareURL(shareID), shareID, ownerToken, nil
+}
+
+// ListForks returns the visible notes forked from a visible note, oldest first.
+func (repo Repo) ListForks(ctx context.Context, id string) (forks []model.NoteFork, err error)  {
 	var (
 		cfg = config.GetConfig()
-		rst *mongo.InsertOneResult
 		collection *mongo.Collection
-		note *model.Note
+		cursor *mongo.Cursor
+		source model.Note
 		span stdopentracing.Span
 		spanCtx context.Context
 	)
@@ -55,34 +184,117 @@ func (repo Repo) ShareNote(ctx context.Context, name, content string) (url, shar
 	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
 	defer span.Finish()
 
+	source, err = repo.findNote(spanCtx, id)
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return nil, err
+	}
+
+	collection = repo.MongoDB.Database(cfg.Mongo.DB).Collection(cfg.Mongo.Collection)
+
+	span.LogKV("operation",  "list forks", "db.find", id)
+
+	cursor, err = collection.Find(
+		spanCtx,
+		bson.D{
+			{Key: "forked_from", Value: source.ID},
+			{Key: "deactivated", Value: false},
+		},
+		options.Find().
+			SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+			SetProjection(bson.D{
+				{Key: "name", Value: 1},
+				{Key: "encrypted_name", Value: 1},
+				{Key: "data_key", Value: 1},
+				{Key: "owner", Value: 1},
+				{Key: "created_at", Value: 1},
+			}),
+	)
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return nil, err
+	}
+	defer cursor.Close(spanCtx)
+
+	forks = []model.NoteFork{}
+	for cursor.Next(spanCtx) {
+		var note model.Note
+		if err = cursor.Decode(&note); err == nil {
+			err = repo.openName(spanCtx, &note)
+		}
+		if err != nil {
+			utils.SetTracerSpanError(span, err)
+			return nil, err
+		}
+
+		forks = append(forks, model.NoteFork{
+			NoteID:    note.ID.Hex(),
+			Name:      note.Name,
+			Owner:     note.Owner,
+			URL:       shareURL(note.ID.Hex()),
+			CreatedAt: note.CreatedAt,
+		})
+	}
+
+	if err = cursor.Err(); err != nil {
+		utils.SetTracerSpanError(span, err)
+		return nil, err
+	}
+	return forks, nil
+}
+
+// insertNote stores note as a new visible note with the content read from
+// content, and returns its id.
+func (repo Repo) insertNote(ctx context.Context, note *model.Note, content io.Reader) (shareID string, err error)  {
+	var (
+		cfg = config.GetConfig()
+		rst *mongo.InsertOneResult
+		collection *mongo.Collection
+	)
+
 	collection = repo.MongoDB.Database(cfg.Mongo.DB).Collection(cfg.Mongo.Collection)
 
 	now := time.Now().Unix()
-	note = &model.Note{
-		Name:          name,
-		Content:       content,
-		Deactivated:   false,
-		CreatedAt:     now,
-		UpdatedAt:     now,
+	note.Deactivated = false
+	note.UpdatedBy = model.ServerReplica
+	note.CreatedAt = now
+	note.UpdatedAt = now
+
+	// the name of the GridFS file is empty when the name is encrypted
+	if err = repo.sealName(ctx, note); err != nil {
+		return "", err
 	}
 
-	rst, err = collection.InsertOne(spanCtx, note)
-	span.LogKV("operation",  "share note", "db.insertOne", name)
+	note.ContentHash, note.Size, err = repo.putContent(ctx, note.Name, content)
 	if err != nil {
-		utils.SetTracerSpanError(span, err)
-		return "", "", err
+		return "", err
 	}
 
-	shareID = rst.InsertedID.(primitive.ObjectID).Hex()
-	url = cfg.Address + "/share/v1/note/" + base64.URLEncoding.EncodeToString([]byte(shareID))
-	return
+	note.Seq, err = repo.nextSeq(ctx)
+	if err == nil {
+		rst, err = collection.InsertOne(ctx, note)
+	}
+
+	if err != nil {
+		repo.releaseContent(ctx, note.ContentHash)
+		return "", err
+	}
+	return rst.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+// shareURL returns the URL the note with the given id is shared at.
+func shareURL(shareID string) string {
+	return config.GetConfig().Address + "/share/v1/note/" + base64.URLEncoding.EncodeToString([]byte(shareID))
 }
 
 func (repo Repo) PrivateNote(ctx context.Context, id string) (err error)  {
 	var (
 		cfg = config.GetConfig()
 		collection *mongo.Collection
+		previous model.Note
 		oid primitive.ObjectID
+		seq int64
+		now = time.Now().Unix()
 		span stdopentracing.Span
 		spanCtx context.Context
 	)
@@ -92,37 +304,131 @@ func (repo Repo) PrivateNote(ctx context.Context, id string) (err error)  {
 
 	collection = repo.MongoDB.Database(cfg.Mongo.DB).Collection(cfg.Mongo.Collection)
 
-	span.LogKV("operation",  "private note", "db.updateOne", id)
+	span.LogKV("operation",  "private note", "db.findOneAndUpdate", id)
 
-	oid, err = primitive.ObjectIDFromHex(id)
+	oid, err = noteObjectID(id)
 	if err != nil {
 		utils.SetTracerSpanError(span, err)
 		return err
 	}
 
-	_, err = collection.UpdateOne(spanCtx, bson.M{"_id": oid}, bson.D{
-		{
-			"$set",
-			bson.D{
-				{Key: "deactivated", Value: true},
-				{Key: "deactivated_at", Value: time.Now().Unix()},
+	// sync clients learn about the privatization through the seq
+	seq, err = repo.nextSeq(spanCtx)
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return err
+	}
+
+	// the privatized notes are not read anymore, their content is released,
+	// along with the content held by the note when it was written before the
+	// contents were shared
+	err = collection.FindOneAndUpdate(
+		spanCtx,
+		bson.M{"_id": oid},
+		bson.D{
+			{
+				Key: "$set",
+				Value: bson.D{
+					{Key: "deactivated", Value: true},
+					{Key: "deactivated_at", Value: now},
+					{Key: "seq", Value: seq},
+				},
+			},
+			{
+				Key: "$unset",
+				Value: bson.D{
+					{Key: "content_hash", Value: ""},
+					{Key: "content", Value: ""},
+					{Key: "compressed_content", Value: ""},
+					{Key: "encoding", Value: ""},
+					{Key: "content_file_id", Value: ""},
+				},
 			},
 		},
-	})
+		options.FindOneAndUpdate().SetProjection(bson.D{
+			{Key: "content_hash", Value: 1},
+			{Key: "content_file_id", Value: 1},
+		}),
+	).Decode(&previous)
+
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
 
 	if err != nil {
 		utils.SetTracerSpanError(span, err)
 		return err
 	}
 
-	return
+	if err = repo.releaseContent(spanCtx, previous.ContentHash); err != nil {
+		span.LogKV("operation", "private note", "release content", previous.ContentHash, "error", err)
+	}
+
+	if !previous.ContentFileID.IsZero() {
+		if err = repo.deleteContent(spanCtx, previous.ContentFileID); err != nil {
+			span.LogKV("operation", "private note", "gridfs.delete", previous.ContentFileID.Hex(), "error", err)
+		}
+	}
+
+	repo.broker.Publish(model.NoteEvent{
+		NoteID:    id,
+		Type:      model.NoteEventPrivate,
+		Timestamp: now,
+	})
+	return nil
 }
 
 
-func (repo Repo) GetNote(ctx context.Context, id string) (name, content string, err error)  {
+// GetNote returns the note with its whole content, it fails with
+// common.ErrorNoteTooLarge when the content does not fit in a unary response.
+func (repo Repo) GetNote(ctx context.Context, id string) (name, content string, version model.NoteVersion, err error)  {
+	var (
+		note model.Note
+		rc io.ReadCloser
+		buf []byte
+		maxSize = config.GetConfig().Service.Stream.MaxUnarySize
+	)
+
+	note, rc, err = repo.noteStream(ctx, id)
+	if err != nil {
+		return "", "", version, err
+	}
+	defer rc.Close()
+
+	if note.Size > int64(maxSize) {
+		return "", "", version, common.ErrorNoteTooLarge
+	}
+
+	// the size of the notes written before it was recorded is unknown
+	buf, err = ioutil.ReadAll(io.LimitReader(rc, int64(maxSize) + 1))
+	if err != nil {
+		return "", "", version, err
+	}
+
+	if len(buf) > maxSize {
+		return "", "", version, common.ErrorNoteTooLarge
+	}
+
+	return note.Name, string(buf), noteVersion(note), nil
+}
+
+// GetNoteStream returns the note name and a reader over the note content,
+// the caller must close the reader.
+func (repo Repo) GetNoteStream(ctx context.Context, id string) (name string, content io.ReadCloser, err error)  {
+	var note model.Note
+
+	note, content, err = repo.noteStream(ctx, id)
+	if err != nil {
+		return "", nil, err
+	}
+	return note.Name, content, nil
+}
+
+// NoteVersion returns the version of a visible note, only the fields it is
+// derived from are read.
+func (repo Repo) NoteVersion(ctx context.Context, id string) (version model.NoteVersion, err error)  {
 	var (
 		cfg = config.GetConfig()
-		collection *mongo.Collection
 		note model.Note
 		oid primitive.ObjectID
 		span stdopentracing.Span
@@ -132,36 +438,546 @@ func (repo Repo) GetNote(ctx context.Context, id string) (name, content string,
 	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
 	defer span.Finish()
 
+	span.LogKV("operation",  "note version", "db.findOne", id)
+
+	oid, err = noteObjectID(id)
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return version, err
+	}
+
+	err = repo.MongoDB.Database(cfg.Mongo.DB).Collection(cfg.Mongo.Collection).FindOne(
+		spanCtx,
+		bson.D{{Key: "_id", Value: oid}},
+		options.FindOne().SetProjection(bson.D{
+			{Key: "deactivated", Value: 1},
+			{Key: "revision", Value: 1},
+			{Key: "seq", Value: 1},
+			{Key: "created_at", Value: 1},
+			{Key: "updated_at", Value: 1},
+		}),
+	).Decode(&note)
+	if err == mongo.ErrNoDocuments {
+		err = common.ErrorNoteNotFound
+	}
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return version, err
+	}
+
+	if note.Deactivated {
+		return version, common.ErrorNoteNotFound
+	}
+	return noteVersion(note), nil
+}
+
+func (repo Repo) noteStream(ctx context.Context, id string) (note model.Note, content io.ReadCloser, err error)  {
+	var (
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
 	span.LogKV("operation",  "get note", "db.findOne", id)
 
-	collection = repo.MongoDB.Database(cfg.Mongo.DB).Collection(cfg.Mongo.Collection)
+	note, err = repo.findNote(spanCtx, id)
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return note, nil, err
+	}
+
+	if note.ContentHash != "" {
+		span.LogKV("operation",  "get note", "db.findOne", note.ContentHash)
+	} else if !note.ContentFileID.IsZero() {
+		span.LogKV("operation",  "get note", "gridfs.openDownloadStream", note.ContentFileID.Hex())
+	}
+
+	content, err = repo.openContent(spanCtx, &note)
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return note, nil, err
+	}
+
+	return note, content, nil
+}
+
+// noteVersion derives the version of the content of a note from the seq of
+// its last write, its revision and its modification time.
+func noteVersion(note model.Note) model.NoteVersion {
+	var modified = note.UpdatedAt
+	if modified == 0 {
+		modified = note.CreatedAt
+	}
+
+	return model.NoteVersion{
+		ETag: fmt.Sprintf(`"%x-%x-%x"`, note.Seq, note.Revision, modified),
+		LastModified: modified,
+	}
+}
+
+// NoteSnapshot returns the content of a visible note along with its revision,
+// and the seq of its last write.
+func (repo Repo) NoteSnapshot(ctx context.Context, id string) (content string, revision, seq int64, err error)  {
+	var (
+		note model.Note
+		rc io.ReadCloser
+		buf []byte
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
+	span.LogKV("operation",  "note snapshot", "db.findOne", id)
 
-	oid, err = primitive.ObjectIDFromHex(id)
+	note, err = repo.findNote(spanCtx, id)
 	if err != nil {
 		utils.SetTracerSpanError(span, err)
-		return "", "", err
+		return "", 0, 0, err
 	}
 
-	err = collection.FindOne(spanCtx,
+	rc, err = repo.openContent(spanCtx, &note)
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return "", 0, 0, err
+	}
+	defer rc.Close()
+
+	buf, err = ioutil.ReadAll(rc)
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return "", 0, 0, err
+	}
+
+	return string(buf), note.Revision, note.Seq, nil
+}
+
+// SaveNoteSnapshot replaces the content of a visible note with the content
+// at the given revision, provided the note has not been written since seq.
+// It returns the seq of the write.
+func (repo Repo) SaveNoteSnapshot(ctx context.Context, id, content string, revision, seq int64) (next int64, err error)  {
+	var (
+		oid primitive.ObjectID
+		now = time.Now().Unix()
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
+	oid, err = noteObjectID(id)
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return 0, err
+	}
+
+	span.LogKV("operation",  "save note snapshot", "db.findOneAndUpdate", id, "revision", revision)
+
+	next, err = repo.updateContent(
+		spanCtx,
+		oid,
+		seq,
+		content,
 		bson.D{
-			{
-				Key: "_id",
-				Value: oid,
-			},
+			{Key: "revision", Value: revision},
+			{Key: "updated_at", Value: now},
+			{Key: "updated_by", Value: model.ServerReplica},
 		},
-	).Decode(&note)
+		bson.D{{Key: "versions." + model.ServerReplica, Value: 1}},
+	)
+
+	if err == mongo.ErrNoDocuments {
+		err = repo.editConflict(spanCtx, oid)
+	}
+
 	if err != nil {
 		utils.SetTracerSpanError(span, err)
-		return "", "", err
+		return 0, err
+	}
+
+	repo.broker.Publish(model.NoteEvent{
+		NoteID:    id,
+		Type:      model.NoteEventUpdate,
+		Timestamp: now,
+	})
+	return next, nil
+}
+
+// updateContent replaces the content of the visible note last written at
+// seq, sets the fields of set and increments the fields of inc. It returns
+// the seq of the write, which is taken once the content is stored so the
+// write commits right after it. It fails with mongo.ErrNoDocuments when the
+// note has been written since seq.
+func (repo Repo) updateContent(ctx context.Context, oid primitive.ObjectID, seq int64, content string, set, inc bson.D) (next int64, err error)  {
+	var (
+		cfg = config.GetConfig()
+		collection *mongo.Collection
+		previous model.Note
+		hash string
+		size int64
+		update bson.D
+	)
+
+	collection = repo.MongoDB.Database(cfg.Mongo.DB).Collection(cfg.Mongo.Collection)
+
+	hash, size, err = repo.putContent(ctx, oid.Hex(), strings.NewReader(content))
+	if err != nil {
+		return 0, err
+	}
+
+	next, err = repo.nextSeq(ctx)
+	if err != nil {
+		repo.releaseContent(ctx, hash)
+		return 0, err
+	}
+
+	// the content held by the note, when it was written before the contents were shared, is dropped
+	set = append(set, bson.E{Key: "seq", Value: next}, bson.E{Key: "content_hash", Value: hash}, bson.E{Key: "size", Value: size})
+	update = bson.D{
+		{Key: "$set", Value: set},
+		{
+			Key: "$unset",
+			Value: bson.D{
+				{Key: "content", Value: ""},
+				{Key: "compressed_content", Value: ""},
+				{Key: "encoding", Value: ""},
+				{Key: "content_file_id", Value: ""},
+			},
+		},
+	}
+
+	if len(inc) > 0 {
+		update = append(update, bson.E{Key: "$inc", Value: inc})
+	}
+
+	err = collection.FindOneAndUpdate(
+		ctx,
+		bson.D{
+			{Key: "_id", Value: oid},
+			{Key: "deactivated", Value: false},
+			seqFilter(seq),
+		},
+		update,
+		options.FindOneAndUpdate().SetProjection(bson.D{
+			{Key: "content_hash", Value: 1},
+			{Key: "content_file_id", Value: 1},
+		}),
+	).Decode(&previous)
+
+	if err != nil {
+		repo.releaseContent(ctx, hash)
+		return 0, err
+	}
+
+	repo.releaseContent(ctx, previous.ContentHash)
+	if !previous.ContentFileID.IsZero() {
+		repo.deleteContent(ctx, previous.ContentFileID)
+	}
+	return next, nil
+}
+
+// editConflict tells why a snapshot of the note could not be saved.
+func (repo Repo) editConflict(ctx context.Context, oid primitive.ObjectID) error {
+	if _, err := repo.findNote(ctx, oid.Hex()); err != nil {
+		return err
+	}
+	return common.ErrorEditConflict
+}
+
+// noteObjectID returns the ObjectID of a note id, common.ErrorInvalidNoteID
+// when the id is not one.
+func noteObjectID(id string) (oid primitive.ObjectID, err error) {
+	if oid, err = primitive.ObjectIDFromHex(id); err != nil {
+		return oid, common.ErrorInvalidNoteID
+	}
+	return oid, nil
+}
+
+// findNote returns the note with the given id unless it has been privatized.
+func (repo Repo) findNote(ctx context.Context, id string) (note model.Note, err error)  {
+	var (
+		cfg = config.GetConfig()
+		collection *mongo.Collection
+		oid primitive.ObjectID
+	)
+
+	collection = repo.MongoDB.Database(cfg.Mongo.DB).Collection(cfg.Mongo.Collection)
+
+	oid, err = noteObjectID(id)
+	if err != nil {
+		return note, err
+	}
+
+	err = collection.FindOne(ctx, bson.D{{Key: "_id", Value: oid}}).Decode(&note)
+	if err == mongo.ErrNoDocuments {
+		return note, common.ErrorNoteNotFound
+	}
+	if err != nil {
+		return note, err
 	}
 
 	if note.Deactivated {
-		return "", "", errors.New("note cannot be found")
+		return note, common.ErrorNoteNotFound
+	}
+	return note, repo.openName(ctx, &note)
+}
+
+// openContent returns a reader over the note content, decrypted and
+// decompressed. A content released by a concurrent write of the note is read
+// again from the note, which is updated.
+func (repo Repo) openContent(ctx context.Context, note *model.Note) (content io.ReadCloser, err error)  {
+	content, err = repo.openNoteContent(ctx, *note)
+	if err != errContentReleased {
+		return content, err
 	}
 
-	name = note.Name
-	content = note.Content
-	return
+	// the note refers to its new content now, unless it was privatized
+	if *note, err = repo.findNote(ctx, note.ID.Hex()); err != nil {
+		return nil, err
+	}
+
+	content, err = repo.openNoteContent(ctx, *note)
+	if err == errContentReleased {
+		err = common.ErrorContentContention
+	}
+	return content, err
+}
+
+// openNoteContent fails with errContentReleased when the content of note has
+// been released meanwhile.
+func (repo Repo) openNoteContent(ctx context.Context, note model.Note) (content io.ReadCloser, err error)  {
+	// the notes written before the contents were shared hold their content
+	var body = model.NoteContent{
+		Content: note.Content,
+		Encoding: note.Encoding,
+		CompressedContent: note.CompressedContent,
+		ContentFileID: note.ContentFileID,
+	}
+
+	if note.ContentHash != "" {
+		body, err = repo.findContent(ctx, note.ContentHash)
+		if err == mongo.ErrNoDocuments {
+			return nil, errContentReleased
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	content, err = repo.openBody(ctx, body)
+	if err == gridfs.ErrFileNotFound && note.ContentHash != "" {
+		return nil, errContentReleased
+	}
+	return content, err
 }
 
+// openBody returns a reader over a content, wherever it is stored,
+// decrypted and decompressed.
+func (repo Repo) openBody(ctx context.Context, body model.NoteContent) (content io.ReadCloser, err error)  {
+	var key []byte
+
+	if body.DataKey != nil {
+		if key, err = repo.dataKey(ctx, *body.DataKey); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case !body.ContentFileID.IsZero():
+		content, err = repo.downloadContent(ctx, body.ContentFileID)
+		if err != nil {
+			return nil, err
+		}
+
+		if key != nil {
+			content, err = decryptReader(key, content)
+			if err != nil {
+				return nil, err
+			}
+		}
+	case key != nil:
+		plaintext, err := envelope.Open(key, body.EncryptedContent)
+		if err != nil {
+			return nil, err
+		}
+		content = ioutil.NopCloser(bytes.NewReader(plaintext))
+	case body.Encoding != "":
+		content = ioutil.NopCloser(bytes.NewReader(body.CompressedContent))
+	default:
+		return ioutil.NopCloser(strings.NewReader(body.Content)), nil
+	}
+
+	if body.Encoding == "" {
+		return content, nil
+	}
+	return compress.NewReader(body.Encoding, content)
+}
+
+// contentCodec returns the codec the content written is compressed with,
+// empty when it is not compressed.
+func contentCodec() string {
+	if codec := config.GetConfig().Service.Compression.Codec; codec != compress.None {
+		return codec
+	}
+	return ""
+}
+
+// compressContent compresses the content embedded in a note document when it
+// reaches the threshold and shrinks, codec is empty otherwise.
+func compressContent(content []byte) (codec string, compressed []byte, err error) {
+	codec = contentCodec()
+	if codec == "" || len(content) < config.GetConfig().Service.Compression.Threshold {
+		return "", nil, nil
+	}
+
+	compressed, err = compress.Compress(codec, content)
+	if err != nil || len(compressed) >= len(content) {
+		return "", nil, err
+	}
+	return codec, compressed, nil
+}
+
+func (repo Repo) bucket() (*gridfs.Bucket, error) {
+	var cfg = config.GetConfig()
+
+	return gridfs.NewBucket(
+		repo.MongoDB.Database(cfg.Mongo.DB),
+		options.GridFSBucket().SetName(cfg.Service.Stream.Bucket),
+	)
+}
+
+// uploadContent stores content in GridFS, compressed with codec unless it is
+// empty, then encrypted with key unless it is nil. The size returned is the
+// one of content.
+func (repo Repo) uploadContent(ctx context.Context, name string, content io.Reader, codec string, key []byte) (fileID primitive.ObjectID, size int64, err error) {
+	var (
+		bucket *gridfs.Bucket
+		stream *gridfs.UploadStream
+		w, ew io.WriteCloser
+	)
+
+	bucket, err = repo.bucket()
+	if err != nil {
+		return primitive.NilObjectID, 0, err
+	}
+
+	stream, err = bucket.OpenUploadStream(name)
+	if err != nil {
+		return primitive.NilObjectID, 0, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		stream.SetWriteDeadline(deadline)
+	}
+
+	w = stream
+	if key != nil {
+		if ew, err = envelope.NewWriter(key, stream); err != nil {
+			stream.Abort()
+			return primitive.NilObjectID, 0, err
+		}
+		w = ew
+	}
+
+	if codec != "" {
+		if w, err = compress.NewWriter(codec, w); err != nil {
+			stream.Abort()
+			return primitive.NilObjectID, 0, err
+		}
+	}
+
+	size, err = io.Copy(w, content)
+	if err == nil && w != stream {
+		err = w.Close()
+	}
 
+	// the encryption is sealed once the compression is flushed into it
+	if err == nil && ew != nil && w != ew {
+		err = ew.Close()
+	}
+
+	if err != nil {
+		stream.Abort()
+		return primitive.NilObjectID, 0, err
+	}
+
+	err = stream.Close()
+	if err != nil {
+		return primitive.NilObjectID, 0, err
+	}
+
+	return stream.FileID.(primitive.ObjectID), size, nil
+}
+
+// downloadContent returns a reader over a GridFS file, which fails once ctx
+// is done.
+func (repo Repo) downloadContent(ctx context.Context, fileID primitive.ObjectID) (content io.ReadCloser, err error) {
+	var (
+		bucket *gridfs.Bucket
+		stream *gridfs.DownloadStream
+	)
+
+	bucket, err = repo.bucket()
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		bucket.SetReadDeadline(deadline)
+	}
+
+	stream, err = bucket.OpenDownloadStream(fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		stream.SetReadDeadline(deadline)
+	}
+	return contextReader{ctx: ctx, ReadCloser: stream}, nil
+}
+
+// contextReader stops reading once ctx is done.
+type contextReader struct {
+	ctx context.Context
+	io.ReadCloser
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.ReadCloser.Read(p)
+}
+
+// decryptReader decrypts the content read from rc, closing rc once closed.
+func decryptReader(key []byte, rc io.ReadCloser) (io.ReadCloser, error) {
+	r, err := envelope.NewReader(key, rc)
+	if err != nil {
+		rc.Close()
+		return nil, err
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{r, rc}, nil
+}
+
+func (repo Repo) deleteContent(ctx context.Context, fileID primitive.ObjectID) (err error) {
+	var bucket *gridfs.Bucket
+
+	bucket, err = repo.bucket()
+	if err != nil {
+		return err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		bucket.SetWriteDeadline(deadline)
+	}
+	return bucket.Delete(fileID)
+}
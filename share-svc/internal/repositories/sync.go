@@ -0,0 +1,343 @@
+package repositories
+
+import (
+	"context"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/config"
+	"github.com/al8n/shareable-notes/share-svc/internal/utils"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"io/ioutil"
+	"time"
+)
+
+const (
+	// countersCollection holds the seq counter of the notes.
+	countersCollection = "counters"
+	notesCounter = "notes"
+
+	// syncRetries is the number of times a change is applied again after the
+	// note has been written concurrently.
+	syncRetries = 5
+
+	// syncSeqWindow is the number of seqs below the version of a client which
+	// are scanned again. A write commits after the writes which took their seq
+	// after its own when it is slower than them, it is still returned once
+	// the client has seen a greater seq as long as it is in the window.
+	syncSeqWindow = 1024
+)
+
+// SyncNotes applies the local changes of a sync client, then returns the
+// server changes made to the changed notes and the notes in noteIDs since
+// the known version.
+func (repo Repo) SyncNotes(ctx context.Context, clientID string, since int64, noteIDs []string, changes []model.NoteChange) (result model.SyncResult, err error)  {
+	var (
+		conflict *model.SyncConflict
+		ids = make([]primitive.ObjectID, 0, len(noteIDs) + len(changes))
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
+	if clientID == "" || clientID == model.ServerReplica {
+		utils.SetTracerSpanError(span, common.ErrorInvalidSyncClient)
+		return result, common.ErrorInvalidSyncClient
+	}
+
+	for _, id := range noteIDs {
+		oid, err := noteObjectID(id)
+		if err != nil {
+			utils.SetTracerSpanError(span, err)
+			return result, err
+		}
+		ids = append(ids, oid)
+	}
+
+	for _, change := range changes {
+		span.LogKV("operation",  "sync notes", "db.findOneAndUpdate", change.NoteID, "client", clientID)
+
+		oid, err := noteObjectID(change.NoteID)
+		if err != nil {
+			utils.SetTracerSpanError(span, err)
+			return result, err
+		}
+		ids = append(ids, oid)
+
+		conflict, err = repo.applyNoteChange(spanCtx, clientID, oid, change)
+		if err == common.ErrorNoteNotFound || err == mongo.ErrNoDocuments {
+			// the client learns about it from the server changes
+			continue
+		}
+		if err != nil {
+			utils.SetTracerSpanError(span, err)
+			return result, err
+		}
+
+		if conflict != nil {
+			result.Conflicts = append(result.Conflicts, *conflict)
+		}
+	}
+
+	span.LogKV("operation",  "sync notes", "db.find", len(ids), "since", since)
+
+	result.Version, result.Changes, err = repo.notesChangedSince(spanCtx, ids, since)
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return result, err
+	}
+	return result, nil
+}
+
+// applyNoteChange writes a local change unless the server has seen it already.
+// A change made concurrently with the server version is resolved in favour of
+// the latest edit, and reported as a conflict.
+func (repo Repo) applyNoteChange(ctx context.Context, clientID string, oid primitive.ObjectID, change model.NoteChange) (conflict *model.SyncConflict, err error)  {
+	var (
+		note model.Note
+		server model.NoteChange
+		set bson.D
+	)
+
+	if change.UpdatedAt == 0 {
+		change.UpdatedAt = time.Now().Unix()
+	}
+	change.UpdatedBy = clientID
+
+	for i := 0; i < syncRetries; i++ {
+		note, err = repo.findNote(ctx, oid.Hex())
+		if err != nil {
+			return nil, err
+		}
+
+		if note.Versions.Descends(change.Versions) {
+			// the change has been applied already, or the client has no new edit
+			return nil, nil
+		}
+
+		set = bson.D{{Key: "versions", Value: note.Versions.Merge(change.Versions)}}
+
+		conflict = nil
+		if !change.Versions.Descends(note.Versions) {
+			server, err = repo.noteChange(ctx, note)
+			if err != nil {
+				return nil, err
+			}
+
+			conflict = &model.SyncConflict{
+				NoteID: oid.Hex(),
+				Local: change,
+				Server: server,
+				Resolution: model.SyncResolutionLocal,
+			}
+
+			if !newerEdit(change, server) {
+				// keep the content, the merged versions tell the client it is outdated
+				conflict.Resolution = model.SyncResolutionServer
+				err = repo.updateVersions(ctx, oid, note.Seq, set)
+				if err == mongo.ErrNoDocuments {
+					continue
+				}
+				return conflict, err
+			}
+		}
+
+		if change.Name != "" {
+			name, err := repo.nameFields(ctx, note, change.Name)
+			if err != nil {
+				return nil, err
+			}
+			set = append(set, name...)
+		}
+		set = append(set,
+			bson.E{Key: "updated_at", Value: change.UpdatedAt},
+			bson.E{Key: "updated_by", Value: change.UpdatedBy},
+		)
+
+		_, err = repo.updateContent(ctx, oid, note.Seq, change.Content, set, nil)
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		repo.broker.Publish(model.NoteEvent{
+			NoteID:    oid.Hex(),
+			Type:      model.NoteEventUpdate,
+			Timestamp: time.Now().Unix(),
+		})
+		return conflict, nil
+	}
+	return nil, common.ErrorSyncContention
+}
+
+// newerEdit orders concurrent edits by time, then by replica, the same way
+// on every replica.
+func newerEdit(a, b model.NoteChange) bool {
+	if a.UpdatedAt != b.UpdatedAt {
+		return a.UpdatedAt > b.UpdatedAt
+	}
+	return a.UpdatedBy > b.UpdatedBy
+}
+
+// updateVersions sets the fields of set on the visible note last written at
+// seq, along with the seq of the write, it fails with mongo.ErrNoDocuments
+// when the note has been written since.
+func (repo Repo) updateVersions(ctx context.Context, oid primitive.ObjectID, seq int64, set bson.D) error  {
+	var (
+		cfg = config.GetConfig()
+		collection *mongo.Collection
+		rst *mongo.UpdateResult
+		next int64
+		err error
+	)
+
+	collection = repo.MongoDB.Database(cfg.Mongo.DB).Collection(cfg.Mongo.Collection)
+
+	next, err = repo.nextSeq(ctx)
+	if err != nil {
+		return err
+	}
+	set = append(set, bson.E{Key: "seq", Value: next})
+
+	rst, err = collection.UpdateOne(
+		ctx,
+		bson.D{
+			{Key: "_id", Value: oid},
+			{Key: "deactivated", Value: false},
+			seqFilter(seq),
+		},
+		bson.D{{Key: "$set", Value: set}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if rst.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// notesChangedSince returns the notes among ids written after since, privatized
+// ones included, along with the greatest seq among them. The seqs are taken
+// before the writes commit, so the notes of the last syncSeqWindow seqs up to
+// since are returned again: a write may commit after a greater seq has been
+// returned. Returning a note the client knows already is harmless, its
+// versions tell the client it has nothing new.
+func (repo Repo) notesChangedSince(ctx context.Context, ids []primitive.ObjectID, since int64) (version int64, changes []model.NoteChange, err error)  {
+	var (
+		cfg = config.GetConfig()
+		collection *mongo.Collection
+		cursor *mongo.Cursor
+		change model.NoteChange
+	)
+
+	version = since
+	if len(ids) == 0 {
+		return version, nil, nil
+	}
+
+	collection = repo.MongoDB.Database(cfg.Mongo.DB).Collection(cfg.Mongo.Collection)
+
+	cursor, err = collection.Find(
+		ctx,
+		bson.D{
+			{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}},
+			{Key: "seq", Value: bson.D{{Key: "$gt", Value: since - syncSeqWindow}}},
+		},
+		options.Find().SetSort(bson.D{{Key: "seq", Value: 1}}),
+	)
+	if err != nil {
+		return since, nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var note model.Note
+		if err = cursor.Decode(&note); err != nil {
+			return since, nil, err
+		}
+
+		change, err = repo.noteChange(ctx, note)
+		if err != nil {
+			return since, nil, err
+		}
+
+		changes = append(changes, change)
+		if note.Seq > version {
+			version = note.Seq
+		}
+	}
+	return version, changes, cursor.Err()
+}
+
+// noteChange returns the sync state of the note, without the content of a
+// privatized note.
+func (repo Repo) noteChange(ctx context.Context, note model.Note) (change model.NoteChange, err error)  {
+	change = model.NoteChange{
+		NoteID:      note.ID.Hex(),
+		Name:        note.Name,
+		Versions:    note.Versions,
+		UpdatedAt:   note.UpdatedAt,
+		UpdatedBy:   note.UpdatedBy,
+		Deactivated: note.Deactivated,
+	}
+
+	if note.Deactivated {
+		change.Name = ""
+		return change, nil
+	}
+
+	if err = repo.openName(ctx, &note); err != nil {
+		return change, err
+	}
+	change.Name = note.Name
+
+	rc, err := repo.openContent(ctx, &note)
+	if err != nil {
+		return change, err
+	}
+	defer rc.Close()
+
+	buf, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return change, err
+	}
+
+	change.Content = string(buf)
+	return change, nil
+}
+
+// nextSeq allocates the seq of a note write.
+func (repo Repo) nextSeq(ctx context.Context) (seq int64, err error)  {
+	var (
+		cfg = config.GetConfig()
+		counter struct {
+			Seq int64 `bson:"seq"`
+		}
+	)
+
+	err = repo.MongoDB.Database(cfg.Mongo.DB).Collection(countersCollection).FindOneAndUpdate(
+		ctx,
+		bson.D{{Key: "_id", Value: notesCounter}},
+		bson.D{{Key: "$inc", Value: bson.D{{Key: "seq", Value: int64(1)}}}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&counter)
+	return counter.Seq, err
+}
+
+// seqFilter matches the notes last written at seq, the notes written before
+// sync existed have no seq.
+func seqFilter(seq int64) bson.E {
+	if seq == 0 {
+		return bson.E{Key: "seq", Value: bson.D{{Key: "$in", Value: bson.A{int64(0), nil}}}}
+	}
+	return bson.E{Key: "seq", Value: seq}
+}
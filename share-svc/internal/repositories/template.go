@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"context"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/config"
+	"github.com/al8n/shareable-notes/share-svc/internal/templates"
+	"github.com/al8n/shareable-notes/share-svc/internal/utils"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"io/ioutil"
+	"strings"
+)
+
+// MarkTemplate marks a visible note as a template, or unmarks it, and returns
+// the variables of its name and content.
+func (repo Repo) MarkTemplate(ctx context.Context, id string, template bool) (variables []model.TemplateVariable, err error)  {
+	var (
+		cfg = config.GetConfig()
+		collection *mongo.Collection
+		note model.Note
+		content string
+		rst *mongo.UpdateResult
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
+	note, content, err = repo.noteContent(spanCtx, id)
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return nil, err
+	}
+
+	collection = repo.MongoDB.Database(cfg.Mongo.DB).Collection(cfg.Mongo.Collection)
+
+	span.LogKV("operation",  "mark template", "db.updateOne", id, "template", template)
+
+	rst, err = collection.UpdateOne(
+		spanCtx,
+		bson.D{
+			{Key: "_id", Value: note.ID},
+			{Key: "deactivated", Value: false},
+		},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "template", Value: template}}}},
+	)
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return nil, err
+	}
+
+	if rst.MatchedCount == 0 {
+		utils.SetTracerSpanError(span, common.ErrorNoteNotFound)
+		return nil, common.ErrorNoteNotFound
+	}
+
+	variables = templates.Variables(note.Name, content)
+	if variables == nil {
+		variables = []model.TemplateVariable{}
+	}
+	return variables, nil
+}
+
+// InstantiateTemplate shares a new note made of the name and content of a
+// visible template, with its placeholders filled from vars. The owner token
+// returned is the only way to read the stats of the new note.
+func (repo Repo) InstantiateTemplate(ctx context.Context, id string, vars map[string]string) (url, shareID, ownerToken string, err error)  {
+	var (
+		note model.Note
+		instance model.Note
+		content string
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
+	note, content, err = repo.noteContent(spanCtx, id)
+	if err == nil && !note.Template {
+		err = common.ErrorNotTemplate
+	}
+	if err == nil {
+		err = templates.Validate(templates.Variables(note.Name, content), vars)
+	}
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return "", "", "", err
+	}
+
+	span.LogKV("operation",  "instantiate template", "db.insertOne", id)
+
+	instance = model.Note{
+		Name:       templates.Render(note.Name, vars),
+		TemplateID: note.ID,
+	}
+
+	ownerToken, instance.OwnerTokenHash, err = newOwnerToken()
+	if err == nil {
+		shareID, err = repo.insertNote(spanCtx, &instance, strings.NewReader(templates.Render(content, vars)))
+	}
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return "", "", "", err
+	}
+	return shareURL(shareID), shareID, ownerToken, nil
+}
+
+// noteContent returns a visible note along with its content.
+func (repo Repo) noteContent(ctx context.Context, id string) (note model.Note, content string, err error)  {
+	note, err = repo.findNote(ctx, id)
+	if err != nil {
+		return note, "", err
+	}
+
+	rc, err := repo.openContent(ctx, &note)
+	if err != nil {
+		return note, "", err
+	}
+	defer rc.Close()
+
+	buf, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return note, "", err
+	}
+	return note, string(buf), nil
+}
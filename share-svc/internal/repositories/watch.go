@@ -0,0 +1,264 @@
+package repositories
+
+import (
+	"context"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/config"
+	"github.com/al8n/shareable-notes/share-svc/internal/utils"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// changeEvent is the subset of a MongoDB change stream event used to derive note events.
+type changeEvent struct {
+	OperationType string `bson:"operationType"`
+	FullDocument *model.Note `bson:"fullDocument"`
+	DocumentKey struct {
+		ID primitive.ObjectID `bson:"_id"`
+	} `bson:"documentKey"`
+	UpdateDescription struct {
+		UpdatedFields bson.Raw `bson:"updatedFields"`
+	} `bson:"updateDescription"`
+}
+
+// WatchNote returns a channel receiving the changes made to a visible note.
+// It uses a MongoDB change stream where available (replica sets and sharded
+// clusters), and the in-process broker otherwise. The channel is closed when
+// ctx is done, or after the note is privatized or deleted.
+func (repo Repo) WatchNote(ctx context.Context, id string) (events <-chan model.NoteEvent, err error)  {
+	var (
+		cfg = config.GetConfig()
+		collection *mongo.Collection
+		note model.Note
+		oid primitive.ObjectID
+		stream *mongo.ChangeStream
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
+	span.LogKV("operation",  "watch note", "db.findOne", id)
+
+	collection = repo.MongoDB.Database(cfg.Mongo.DB).Collection(cfg.Mongo.Collection)
+
+	oid, err = noteObjectID(id)
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return nil, err
+	}
+
+	err = collection.FindOne(
+		spanCtx,
+		bson.D{{Key: "_id", Value: oid}},
+		options.FindOne().SetProjection(bson.D{{Key: "deactivated", Value: 1}}),
+	).Decode(&note)
+	if err == mongo.ErrNoDocuments {
+		err = common.ErrorNoteNotFound
+	}
+	if err != nil {
+		utils.SetTracerSpanError(span, err)
+		return nil, err
+	}
+
+	if note.Deactivated {
+		return nil, common.ErrorNoteNotFound
+	}
+
+	// the stream lives as long as the watcher, so it must not use the span context
+	stream, err = collection.Watch(
+		ctx,
+		mongo.Pipeline{
+			{{Key: "$match", Value: bson.D{{Key: "documentKey._id", Value: oid}}}},
+		},
+		options.ChangeStream().SetFullDocument(options.UpdateLookup),
+	)
+	if err != nil {
+		span.LogKV("operation",  "watch note", "db.watch", id, "fallback", "broker", "reason", err)
+		return repo.watchBroker(ctx, id), nil
+	}
+
+	span.LogKV("operation",  "watch note", "db.watch", id)
+	return repo.watchChangeStream(ctx, id, stream), nil
+}
+
+func (repo Repo) watchChangeStream(ctx context.Context, id string, stream *mongo.ChangeStream) <-chan model.NoteEvent {
+	var ch = make(chan model.NoteEvent)
+
+	go func() {
+		defer close(ch)
+		defer stream.Close(context.Background())
+
+		for stream.Next(ctx) {
+			var change changeEvent
+			if err := stream.Decode(&change); err != nil {
+				return
+			}
+
+			event := model.NoteEvent{
+				NoteID:    id,
+				Timestamp: time.Now().Unix(),
+			}
+
+			switch change.OperationType {
+			case "delete":
+				event.Type = model.NoteEventDelete
+			case "update", "replace":
+				if change.FullDocument == nil || change.FullDocument.Deactivated {
+					event.Type = model.NoteEventPrivate
+				} else {
+					event.Type = model.NoteEventUpdate
+				}
+			case "invalidate":
+				return
+			default:
+				continue
+			}
+
+			select {
+			case ch <- event:
+			case <-ctx.Done():
+				return
+			}
+
+			if event.Final() {
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// watchBroker relays the events of the note with the given id published to
+// the broker, or of every note when id is empty.
+func (repo Repo) watchBroker(ctx context.Context, id string) <-chan model.NoteEvent {
+	var (
+		ch = make(chan model.NoteEvent)
+		sub, cancel = repo.broker.Subscribe(id)
+	)
+
+	go func() {
+		defer close(ch)
+		defer cancel()
+
+		for {
+			select {
+			case event := <-sub:
+				select {
+				case ch <- event:
+				case <-ctx.Done():
+					return
+				}
+
+				if id != "" && event.Final() {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// WatchNotes returns a channel receiving the changes made to every note, the
+// notes created excepted. It uses a MongoDB change stream where available,
+// and the in-process broker otherwise, which only sees the changes made by
+// this instance. The channel is closed when ctx is done, or when the change
+// stream fails.
+func (repo Repo) WatchNotes(ctx context.Context) (events <-chan model.NoteEvent, err error)  {
+	var (
+		cfg = config.GetConfig()
+		collection *mongo.Collection
+		stream *mongo.ChangeStream
+		span stdopentracing.Span
+	)
+
+	span, _ = stdopentracing.StartSpanFromContext(ctx, mongoOPName)
+	defer span.Finish()
+
+	collection = repo.MongoDB.Database(cfg.Mongo.DB).Collection(cfg.Mongo.Collection)
+
+	// the updated fields carry the content, only deactivated is kept
+	stream, err = collection.Watch(
+		ctx,
+		mongo.Pipeline{
+			{{Key: "$match", Value: bson.D{{Key: "operationType", Value: bson.D{{Key: "$in", Value: bson.A{"update", "replace", "delete"}}}}}}},
+			{{Key: "$project", Value: bson.D{
+				{Key: "operationType", Value: 1},
+				{Key: "documentKey", Value: 1},
+				{Key: "fullDocument.deactivated", Value: 1},
+				{Key: "updateDescription.updatedFields.deactivated", Value: 1},
+			}}},
+		},
+	)
+	if err != nil {
+		span.LogKV("operation",  "watch notes", "db.watch", cfg.Mongo.Collection, "fallback", "broker", "reason", err)
+		return repo.watchBroker(ctx, ""), nil
+	}
+
+	span.LogKV("operation",  "watch notes", "db.watch", cfg.Mongo.Collection)
+	return repo.watchNotesChangeStream(ctx, stream), nil
+}
+
+func (repo Repo) watchNotesChangeStream(ctx context.Context, stream *mongo.ChangeStream) <-chan model.NoteEvent {
+	var ch = make(chan model.NoteEvent)
+
+	go func() {
+		defer close(ch)
+		defer stream.Close(context.Background())
+
+		for stream.Next(ctx) {
+			var change changeEvent
+			if err := stream.Decode(&change); err != nil {
+				return
+			}
+
+			event := model.NoteEvent{
+				NoteID:    change.DocumentKey.ID.Hex(),
+				Type:      changeEventType(change),
+				Timestamp: time.Now().Unix(),
+			}
+			if event.Type == "" {
+				// the collection is gone
+				return
+			}
+
+			select {
+			case ch <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// changeEventType returns the type of the note event of a change made to a
+// note, empty when the change stream is invalidated.
+func changeEventType(change changeEvent) string {
+	switch change.OperationType {
+	case "delete":
+		return model.NoteEventDelete
+	case "replace":
+		if change.FullDocument == nil || change.FullDocument.Deactivated {
+			return model.NoteEventPrivate
+		}
+		return model.NoteEventUpdate
+	case "update":
+		if deactivated, ok := change.UpdateDescription.UpdatedFields.Lookup("deactivated").BooleanOK(); ok && deactivated {
+			return model.NoteEventPrivate
+		}
+		return model.NoteEventUpdate
+	}
+	return ""
+}
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"testing"
+)
+
+func TestChangeEventType(t *testing.T) {
+	updated := func(fields bson.D) changeEvent {
+		raw, err := bson.Marshal(fields)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var change = changeEvent{OperationType: "update"}
+		change.UpdateDescription.UpdatedFields = raw
+		return change
+	}
+
+	for _, tc := range []struct {
+		name   string
+		change changeEvent
+		want   string
+	}{
+		{"delete", changeEvent{OperationType: "delete"}, model.NoteEventDelete},
+		{"replace", changeEvent{OperationType: "replace", FullDocument: &model.Note{}}, model.NoteEventUpdate},
+		{"replace deactivated", changeEvent{OperationType: "replace", FullDocument: &model.Note{Deactivated: true}}, model.NoteEventPrivate},
+		{"replace without document", changeEvent{OperationType: "replace"}, model.NoteEventPrivate},
+		{"update", updated(bson.D{{Key: "seq", Value: int64(2)}}), model.NoteEventUpdate},
+		{"update without fields", changeEvent{OperationType: "update"}, model.NoteEventUpdate},
+		{"update deactivated", updated(bson.D{{Key: "deactivated", Value: true}}), model.NoteEventPrivate},
+		{"update reactivated", updated(bson.D{{Key: "deactivated", Value: false}}), model.NoteEventUpdate},
+		{"invalidate", changeEvent{OperationType: "invalidate"}, ""},
+		{"drop", changeEvent{OperationType: "drop"}, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := changeEventType(tc.change); got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
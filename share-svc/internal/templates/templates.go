@@ -0,0 +1,91 @@
+package templates
+
+import (
+	"fmt"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// placeholder matches {{name}} and {{name|default}}, any other text between
+// braces is left as it is.
+var placeholder = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*(?:\|([^{}]*))?\}\}`)
+
+// Variables returns the variables of the texts in order of appearance. A
+// variable is required unless every placeholder of it has a default, the
+// first default is reported while each placeholder falls back to its own.
+func Variables(texts ...string) (variables []model.TemplateVariable) {
+	var index = make(map[string]int)
+
+	for _, text := range texts {
+		for _, m := range placeholder.FindAllStringSubmatchIndex(text, -1) {
+			name := text[m[2]:m[3]]
+			hasDefault := m[4] >= 0
+
+			i, ok := index[name]
+			if !ok {
+				index[name] = len(variables)
+				variable := model.TemplateVariable{Name: name, Required: !hasDefault}
+				if hasDefault {
+					variable.Default = text[m[4]:m[5]]
+				}
+				variables = append(variables, variable)
+				continue
+			}
+
+			if !hasDefault {
+				variables[i].Required = true
+				variables[i].Default = ""
+			} else if !variables[i].Required && variables[i].Default == "" {
+				variables[i].Default = text[m[4]:m[5]]
+			}
+		}
+	}
+	return variables
+}
+
+// Validate checks vars against the variables, every required variable must be
+// given and every given variable must be known.
+func Validate(variables []model.TemplateVariable, vars map[string]string) error {
+	var (
+		known = make(map[string]struct{}, len(variables))
+		missing, unknown []string
+	)
+
+	for _, variable := range variables {
+		known[variable.Name] = struct{}{}
+		if _, ok := vars[variable.Name]; variable.Required && !ok {
+			missing = append(missing, variable.Name)
+		}
+	}
+
+	for name := range vars {
+		if _, ok := known[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", common.ErrorMissingTemplateVariables, strings.Join(missing, ", "))
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%w: %s", common.ErrorUnknownTemplateVariables, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// Render replaces the placeholders of text with the values of vars, or their
+// defaults. The vars must have been validated against the variables of text.
+func Render(text string, vars map[string]string) string {
+	return placeholder.ReplaceAllStringFunc(text, func(s string) string {
+		m := placeholder.FindStringSubmatch(s)
+		if value, ok := vars[m[1]]; ok {
+			return value
+		}
+		return m[2]
+	})
+}
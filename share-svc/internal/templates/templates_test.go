@@ -0,0 +1,147 @@
+package templates
+
+import (
+	"errors"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"reflect"
+	"testing"
+)
+
+func TestVariables(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		texts []string
+		want  []model.TemplateVariable
+	}{
+		{
+			name:  "no placeholder",
+			texts: []string{"plain {text} {{ }} {{1x}}"},
+		},
+		{
+			name:  "required and default",
+			texts: []string{"Dear {{ name }}, see you {{when|tomorrow}}"},
+			want: []model.TemplateVariable{
+				{Name: "name", Required: true},
+				{Name: "when", Default: "tomorrow"},
+			},
+		},
+		{
+			name:  "across the name and the content",
+			texts: []string{"{{title}}", "{{body|empty}} {{title}}"},
+			want: []model.TemplateVariable{
+				{Name: "title", Required: true},
+				{Name: "body", Default: "empty"},
+			},
+		},
+		{
+			name:  "required once without default",
+			texts: []string{"{{who|you}} and {{who}}"},
+			want:  []model.TemplateVariable{{Name: "who", Required: true}},
+		},
+		{
+			name:  "first default reported",
+			texts: []string{"{{who|you}} and {{who|me}}"},
+			want:  []model.TemplateVariable{{Name: "who", Default: "you"}},
+		},
+		{
+			name:  "empty default",
+			texts: []string{"{{who|}} and {{who|me}}"},
+			want:  []model.TemplateVariable{{Name: "who", Default: "me"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Variables(tc.texts...); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	var variables = []model.TemplateVariable{
+		{Name: "name", Required: true},
+		{Name: "when", Default: "tomorrow"},
+	}
+
+	for _, tc := range []struct {
+		name string
+		vars map[string]string
+		err  error
+	}{
+		{name: "required given", vars: map[string]string{"name": "Ann"}},
+		{name: "all given", vars: map[string]string{"name": "Ann", "when": "today"}},
+		{name: "required empty", vars: map[string]string{"name": ""}},
+		{name: "required missing", vars: map[string]string{"when": "today"}, err: common.ErrorMissingTemplateVariables},
+		{name: "unknown", vars: map[string]string{"name": "Ann", "where": "home"}, err: common.ErrorUnknownTemplateVariables},
+		{name: "missing and unknown", vars: map[string]string{"where": "home"}, err: common.ErrorMissingTemplateVariables},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := Validate(variables, tc.vars); !errors.Is(err, tc.err) || (err == nil) != (tc.err == nil) {
+				t.Fatalf("got %v, want %v", err, tc.err)
+			}
+		})
+	}
+
+	err := Validate(variables, map[string]string{"name": "Ann", "b": "", "a": ""})
+	if err == nil || err.Error() != common.ErrorUnknownTemplateVariables.Error()+": a, b" {
+		t.Fatalf("got %v", err)
+	}
+}
+
+func TestRender(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		text string
+		vars map[string]string
+		want string
+	}{
+		{
+			name: "values",
+			text: "Dear {{ name }}, see you {{when|tomorrow}}.",
+			vars: map[string]string{"name": "Ann", "when": "today"},
+			want: "Dear Ann, see you today.",
+		},
+		{
+			name: "default",
+			text: "Dear {{name}}, see you {{when|tomorrow}}.",
+			vars: map[string]string{"name": "Ann"},
+			want: "Dear Ann, see you tomorrow.",
+		},
+		{
+			name: "empty value over default",
+			text: "[{{when|tomorrow}}]",
+			vars: map[string]string{"when": ""},
+			want: "[]",
+		},
+		{
+			name: "each placeholder its default",
+			text: "{{who|you}} and {{who|me}}",
+			want: "you and me",
+		},
+		{
+			name: "values not rendered",
+			text: "{{a}} {{b}}",
+			vars: map[string]string{"a": "{{b}}", "b": "$1"},
+			want: "{{b}} $1",
+		},
+		{
+			name: "other braces kept",
+			text: "{x} {{ }} {{1x}} {{a}}",
+			vars: map[string]string{"a": "A"},
+			want: "{x} {{ }} {{1x}} A",
+		},
+		{
+			name: "runes",
+			text: "¡{{saludo|hola}}, {{nombre}}!",
+			vars: map[string]string{"nombre": "José"},
+			want: "¡hola, José!",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Render(tc.text, tc.vars); got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"errors"
 	"fmt"
+	"github.com/al8n/shareable-notes/share-svc/common"
 	"github.com/opentracing/opentracing-go"
 	otlog "github.com/opentracing/opentracing-go/log"
 )
@@ -21,6 +22,8 @@ const (
 	GRPC Type =  iota
 	Thrift
 	HTTP
+	JSONRPC
+	NATS
 )
 
 func errorCodecCasting(name string, method Methods) string {
@@ -42,6 +45,10 @@ func ErrorCodecCasting(name string, method Methods, typ Type) error  {
 		return errors.New(fmt.Sprintf("Thrift: %s", errorCodecCasting(name, method)))
 	case HTTP:
 		return errors.New(fmt.Sprintf("HTTP: %s", errorCodecCasting(name, method)))
+	case JSONRPC:
+		return errors.New(fmt.Sprintf("JSON-RPC: %s", errorCodecCasting(name, method)))
+	case NATS:
+		return errors.New(fmt.Sprintf("NATS: %s", errorCodecCasting(name, method)))
 	}
 
 	return errors.New(fmt.Sprintf("Unkown: %s", errorCodecCasting(name, method)))
@@ -50,10 +57,16 @@ func ErrorCodecCasting(name string, method Methods, typ Type) error  {
 // These annoying helper functions are required to translate Go error types to
 // and from strings, which is the type we use in our IDLs to represent errors.
 // There is special casing to treat empty strings as nil errors.
+// Str2Err returns the error of a response, the errors of the service are
+// returned as is.
 func Str2Err(s string) error {
 	if s == "" {
 		return nil
 	}
+
+	if err, ok := common.ServiceError(s); ok {
+		return err
+	}
 	return errors.New(s)
 }
 
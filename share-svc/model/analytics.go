@@ -0,0 +1,30 @@
+package model
+
+// NoteView is a read of a note, the referrer is reduced to its host and the
+// client IP to a salted hash.
+type NoteView struct {
+	NoteID     string `json:"note_id"`
+	Timestamp  int64  `json:"timestamp"`
+	Transport  string `json:"transport"`
+	Referrer   string `json:"referrer"`
+	ClientHash string `json:"client_hash,omitempty"`
+}
+
+// NoteStats aggregates the views of a note.
+type NoteStats struct {
+	NoteID        string           `json:"note_id"`
+	Views         int64            `json:"views"`
+	Transports    map[string]int64 `json:"transports"`
+	Referrers     map[string]int64 `json:"referrers"`
+	Daily         []DailyViews     `json:"daily"`
+	FirstViewedAt int64            `json:"first_viewed_at,omitempty"`
+	LastViewedAt  int64            `json:"last_viewed_at,omitempty"`
+}
+
+// DailyViews counts the views of a note during a UTC day, formatted as
+// 2006-01-02, and the distinct clients they came from.
+type DailyViews struct {
+	Day      string `json:"day"`
+	Views    int64  `json:"views"`
+	Visitors int64  `json:"visitors"`
+}
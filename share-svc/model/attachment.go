@@ -0,0 +1,26 @@
+package model
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Attachment is a file attached to a note, its content is kept in the
+// configured blob store under Key.
+type Attachment struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	NoteID      primitive.ObjectID `bson:"note_id" json:"note_id"`
+	Name        string             `bson:"name" json:"name"`
+	ContentType string             `bson:"content_type" json:"content_type"`
+	Size        int64              `bson:"size" json:"size"`
+	Key         string             `bson:"key" json:"-"`
+	CreatedAt   int64              `bson:"created_at,omitempty" json:"created_at,omitempty"`
+}
+
+// AttachmentInfo describes an attachment of a visible note.
+type AttachmentInfo struct {
+	ID          string `json:"id"`
+	NoteID      string `json:"note_id"`
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	URL         string `json:"url"`
+	CreatedAt   int64  `json:"created_at"`
+}
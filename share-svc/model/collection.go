@@ -0,0 +1,19 @@
+package model
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Collection groups notes in order behind a single link.
+type Collection struct {
+	ID          primitive.ObjectID   `bson:"_id,omitempty" json:"_id,omitempty"`
+	Name        string               `bson:"name" json:"name"`
+	NoteIDs     []primitive.ObjectID `bson:"note_ids" json:"note_ids"`
+	CreatedAt   int64                `bson:"created_at,omitempty" json:"created_at,omitempty"`
+	UpdatedAt   int64                `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
+}
+
+// CollectionNote summarizes a visible note of a collection.
+type CollectionNote struct {
+	NoteID string `json:"note_id"`
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+}
@@ -0,0 +1,33 @@
+package model
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Comment is a comment left on a note, it is anchored to the lines
+// StartLine to EndLine of the note content, both 1-based and inclusive,
+// unless StartLine is 0.
+type Comment struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	NoteID     primitive.ObjectID `bson:"note_id" json:"note_id"`
+	Author     string             `bson:"author" json:"author"`
+	Body       string             `bson:"body" json:"body"`
+	StartLine  int64              `bson:"start_line,omitempty" json:"start_line,omitempty"`
+	EndLine    int64              `bson:"end_line,omitempty" json:"end_line,omitempty"`
+	Resolved   bool               `bson:"resolved" json:"resolved"`
+	ResolvedBy string             `bson:"resolved_by,omitempty" json:"resolved_by,omitempty"`
+	CreatedAt  int64              `bson:"created_at,omitempty" json:"created_at,omitempty"`
+	ResolvedAt int64              `bson:"resolved_at,omitempty" json:"resolved_at,omitempty"`
+}
+
+// NoteComment describes a comment of a visible note.
+type NoteComment struct {
+	ID         string `json:"id"`
+	NoteID     string `json:"note_id"`
+	Author     string `json:"author"`
+	Body       string `json:"body"`
+	StartLine  int64  `json:"start_line,omitempty"`
+	EndLine    int64  `json:"end_line,omitempty"`
+	Resolved   bool   `json:"resolved"`
+	ResolvedBy string `json:"resolved_by,omitempty"`
+	CreatedAt  int64  `json:"created_at"`
+	ResolvedAt int64  `json:"resolved_at,omitempty"`
+}
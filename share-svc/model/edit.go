@@ -0,0 +1,60 @@
+package model
+
+// Edit messages exchanged with the collaborative editing of a note.
+//
+// A client joins a note and receives a join message carrying the content at
+// a revision. It then sends operations based on the latest revision it has
+// seen, which are acknowledged to it and broadcast to the other editors as
+// new revisions. Presence messages list the users editing the note, and an
+// error message ends the session.
+const (
+	EditMessageJoin = "join"
+	EditMessageOperation = "operation"
+	EditMessageAck = "ack"
+	EditMessagePresence = "presence"
+	EditMessageError = "error"
+)
+
+// EditOp is one component of a text operation, exactly one of its fields is
+// set. Lengths are counted in unicode code points, and an operation retains
+// or deletes the whole document it applies to.
+type EditOp struct {
+	Retain int    `bson:"retain,omitempty" json:"retain,omitempty"`
+	Insert string `bson:"insert,omitempty" json:"insert,omitempty"`
+	Delete int    `bson:"delete,omitempty" json:"delete,omitempty"`
+}
+
+// NoteEdit is the operation producing a revision of a note. The edits are
+// logged, so that every instance editing the note applies the same
+// operations in the same order.
+type NoteEdit struct {
+	NoteID    string   `bson:"note_id" json:"note_id"`
+	Revision  int64    `bson:"revision" json:"revision"`
+	User      string   `bson:"user" json:"user"`
+	Operation []EditOp `bson:"operation" json:"operation"`
+	CreatedAt int64    `bson:"created_at" json:"created_at"`
+}
+
+type EditMessage struct {
+	Type      string   `json:"type"`
+	NoteID    string   `json:"note_id,omitempty"`
+	User      string   `json:"user,omitempty"`
+	Revision  int64    `json:"revision"`
+	Operation []EditOp `json:"operation,omitempty"`
+	Content   string   `json:"content,omitempty"`
+	Users     []string `json:"users,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// EditSession is a client's connection to the collaborative editing of a note.
+type EditSession interface {
+	// Send submits a message from the client, only operations are accepted.
+	Send(msg EditMessage) error
+
+	// Recv blocks until the next message for the client, it returns io.EOF
+	// once the session is closed.
+	Recv() (EditMessage, error)
+
+	// Close leaves the note.
+	Close() error
+}
@@ -0,0 +1,19 @@
+package model
+
+const (
+	NoteEventUpdate = "update"
+	NoteEventPrivate = "privatize"
+	NoteEventDelete = "delete"
+)
+
+// NoteEvent describes a change made to a note.
+type NoteEvent struct {
+	NoteID    string `json:"note_id"`
+	Type      string `json:"type"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// Final reports whether the note is no longer visible after the event.
+func (e NoteEvent) Final() bool {
+	return e.Type == NoteEventPrivate || e.Type == NoteEventDelete
+}
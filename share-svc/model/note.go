@@ -1,6 +1,9 @@
 package model
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"strings"
+)
 
 type Note struct {
 	ID primitive.ObjectID   `bson:"_id,omitempty" json:"_id,omitempty"`
@@ -8,7 +11,123 @@ type Note struct {
 	Content   string `bson:"content" json:"content"`
 	Deactivated bool `bson:"deactivated" json:"deactivated"`
 
+	// EncryptedName is the name encrypted with the data key of the note when
+	// the notes are encrypted at rest, Name is empty then.
+	EncryptedName []byte      `bson:"encrypted_name,omitempty" json:"-"`
+	DataKey       *WrappedKey `bson:"data_key,omitempty" json:"-"`
+
+	// ContentFileID refers to the GridFS file holding the content when the
+	// content is too large to be embedded in the note document.
+	ContentFileID primitive.ObjectID `bson:"content_file_id,omitempty" json:"content_file_id,omitempty"`
+	Size          int64              `bson:"size" json:"size"`
+
+	// ContentHash is the SHA-256 of the content, which is held by the
+	// NoteContent of this hash. The notes written before the contents were
+	// shared hold their content themselves.
+	ContentHash   string             `bson:"content_hash,omitempty" json:"content_hash,omitempty"`
+
+	// Encoding is the codec the content is compressed with, in
+	// CompressedContent or in GridFS. The content is not compressed when
+	// it is empty.
+	Encoding          string `bson:"encoding,omitempty" json:"encoding,omitempty"`
+	CompressedContent []byte `bson:"compressed_content,omitempty" json:"-"`
+
+	// Revision counts the collaborative edits persisted in the content.
+	Revision      int64              `bson:"revision" json:"revision"`
+
+	// Versions is the version vector of the content, and Seq orders the
+	// writes made to all the notes, both are used by sync.
+	Versions      VersionVector      `bson:"versions,omitempty" json:"versions,omitempty"`
+	Seq           int64              `bson:"seq" json:"seq"`
+	UpdatedBy     string             `bson:"updated_by,omitempty" json:"updated_by,omitempty"`
+
+	// Owner is the user who forked the note, ForkedFrom the note it was
+	// forked from.
+	Owner         string             `bson:"owner,omitempty" json:"owner,omitempty"`
+	ForkedFrom    primitive.ObjectID `bson:"forked_from,omitempty" json:"forked_from,omitempty"`
+
+	// OwnerTokenHash is the SHA-256 of the owner token returned when the note
+	// was shared, forked or instantiated from a template, which is required to
+	// read the stats of the note.
+	OwnerTokenHash string            `bson:"owner_token_hash,omitempty" json:"-"`
+
+	// Template marks a note whose {{placeholders}} are filled to create new
+	// notes, TemplateID is the template a note was instantiated from.
+	Template      bool               `bson:"template,omitempty" json:"template,omitempty"`
+	TemplateID    primitive.ObjectID `bson:"template_id,omitempty" json:"template_id,omitempty"`
+
 	CreatedAt           int64              `bson:"created_at,omitempty" json:"created_at,omitempty"`
 	UpdatedAt           int64              `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
 	DeactivatedAt       int64              `bson:"deactivated_at,omitempty" json:"deactivated_at,omitempty"`
 }
+
+// NoteContent is a note content shared by the notes of the same content,
+// keyed by its SHA-256. Refs counts the notes referring to it, the content is
+// deleted once there is none.
+type NoteContent struct {
+	Hash    string `bson:"_id" json:"hash"`
+	Content string `bson:"content,omitempty" json:"content,omitempty"`
+
+	// Encoding is the codec the content is compressed with, in
+	// CompressedContent or in GridFS.
+	Encoding          string             `bson:"encoding,omitempty" json:"encoding,omitempty"`
+	CompressedContent []byte             `bson:"compressed_content,omitempty" json:"-"`
+	ContentFileID     primitive.ObjectID `bson:"content_file_id,omitempty" json:"content_file_id,omitempty"`
+	Size              int64              `bson:"size" json:"size"`
+
+	// EncryptedContent is the content, compressed or not, encrypted with the
+	// data key of the content when the notes are encrypted at rest. The
+	// content in GridFS is encrypted too, the hash is then an HMAC keyed by a
+	// secret key, so that it does not reveal the content.
+	EncryptedContent []byte      `bson:"encrypted_content,omitempty" json:"-"`
+	DataKey          *WrappedKey `bson:"data_key,omitempty" json:"-"`
+
+	Refs      int64 `bson:"refs" json:"refs"`
+	CreatedAt int64 `bson:"created_at,omitempty" json:"created_at,omitempty"`
+}
+
+// WrappedKey is a data key encrypted by the master key KeyID.
+type WrappedKey struct {
+	KeyID string `bson:"key_id" json:"key_id"`
+	Key   []byte `bson:"key" json:"-"`
+}
+
+// NoteFork describes a visible note forked from another note.
+type NoteFork struct {
+	NoteID    string `json:"note_id"`
+	Name      string `json:"name"`
+	Owner     string `json:"owner,omitempty"`
+	URL       string `json:"url"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// NoteVersion identifies the content of a note for the conditional reads,
+// LastModified is in Unix seconds.
+type NoteVersion struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified int64  `json:"last_modified,omitempty"`
+}
+
+// NoteCondition makes a read conditional, as the If-None-Match and
+// If-Modified-Since headers do. IfModifiedSince is in Unix seconds.
+type NoteCondition struct {
+	IfNoneMatch     string `json:"if_none_match,omitempty"`
+	IfModifiedSince int64  `json:"if_modified_since,omitempty"`
+}
+
+// Unchanged reports whether the client already holds the version v of the
+// note. IfNoneMatch takes precedence over IfModifiedSince, and the ETags are
+// compared weakly.
+func (c NoteCondition) Unchanged(v NoteVersion) bool {
+	if c.IfNoneMatch != "" {
+		for _, tag := range strings.Split(c.IfNoneMatch, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || (tag != "" && tag == strings.TrimPrefix(v.ETag, "W/")) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return c.IfModifiedSince > 0 && v.LastModified > 0 && v.LastModified <= c.IfModifiedSince
+}
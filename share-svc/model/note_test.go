@@ -0,0 +1,44 @@
+package model
+
+import "testing"
+
+func TestNoteConditionUnchanged(t *testing.T) {
+	var version = NoteVersion{ETag: `"3-1-2"`, LastModified: 100}
+
+	for _, tc := range []struct {
+		name    string
+		cond    NoteCondition
+		version NoteVersion
+		want    bool
+	}{
+		{name: "no condition", cond: NoteCondition{}, version: version},
+		{name: "etag matches", cond: NoteCondition{IfNoneMatch: `"3-1-2"`}, version: version, want: true},
+		{name: "etag differs", cond: NoteCondition{IfNoneMatch: `"3-1-1"`}, version: version},
+		{name: "weak etag", cond: NoteCondition{IfNoneMatch: `W/"3-1-2"`}, version: version, want: true},
+		{name: "weak version", cond: NoteCondition{IfNoneMatch: `"3-1-2"`}, version: NoteVersion{ETag: `W/"3-1-2"`}, want: true},
+		{name: "etag listed", cond: NoteCondition{IfNoneMatch: `"1-0-0", "3-1-2"`}, version: version, want: true},
+		{name: "etags listed differ", cond: NoteCondition{IfNoneMatch: `"1-0-0", "2-0-0"`}, version: version},
+		{name: "any etag", cond: NoteCondition{IfNoneMatch: "*"}, version: version, want: true},
+		{name: "empty etag listed", cond: NoteCondition{IfNoneMatch: ","}, version: NoteVersion{}},
+		{name: "not modified since", cond: NoteCondition{IfModifiedSince: 100}, version: version, want: true},
+		{name: "modified since", cond: NoteCondition{IfModifiedSince: 99}, version: version},
+		{name: "unknown modification", cond: NoteCondition{IfModifiedSince: 100}, version: NoteVersion{ETag: `"3-1-2"`}},
+		{
+			name:    "etag matches, modified since",
+			cond:    NoteCondition{IfNoneMatch: `"3-1-2"`, IfModifiedSince: 99},
+			version: version,
+			want:    true,
+		},
+		{
+			name:    "etag differs, not modified since",
+			cond:    NoteCondition{IfNoneMatch: `"3-1-1"`, IfModifiedSince: 100},
+			version: version,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.cond.Unchanged(tc.version); got != tc.want {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
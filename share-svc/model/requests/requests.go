@@ -1,5 +1,10 @@
 package requests
 
+import (
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"io"
+)
+
 type ShareNoteRequest struct {
 	Name      string `json:"name"`
 	Content   string `json:"content"`
@@ -11,4 +16,113 @@ type PrivateNoteRequest struct {
 
 type GetNoteRequest struct {
 	NoteID string `json:"note_id"`
+
+	// Condition is read from the If-None-Match and If-Modified-Since headers.
+	Condition model.NoteCondition `json:"-"`
+}
+
+type ShareNoteStreamRequest struct {
+	Name      string `json:"name"`
+	Content   io.Reader `json:"-"`
+}
+
+type GetNoteStreamRequest struct {
+	NoteID string `json:"note_id"`
+}
+
+type WatchNoteRequest struct {
+	NoteID string `json:"note_id"`
+}
+
+type WatchNotesRequest struct {}
+
+type EditNoteRequest struct {
+	NoteID string `json:"note_id"`
+	User   string `json:"user"`
+}
+
+type SyncNotesRequest struct {
+	ClientID string             `json:"client_id"`
+	Since    int64              `json:"since"`
+	NoteIDs  []string           `json:"note_ids"`
+	Changes  []model.NoteChange `json:"changes"`
+}
+
+type ForkNoteRequest struct {
+	NoteID string `json:"note_id"`
+	Owner  string `json:"owner"`
+}
+
+type ListForksRequest struct {
+	NoteID string `json:"note_id"`
+}
+
+type MarkTemplateRequest struct {
+	NoteID   string `json:"note_id"`
+	Template bool   `json:"template"`
+}
+
+type InstantiateTemplateRequest struct {
+	NoteID string            `json:"note_id"`
+	Vars   map[string]string `json:"vars"`
+}
+
+type CreateCollectionRequest struct {
+	Name    string   `json:"name"`
+	NoteIDs []string `json:"note_ids"`
+}
+
+type AddCollectionNotesRequest struct {
+	CollectionID string   `json:"collection_id"`
+	NoteIDs      []string `json:"note_ids"`
+}
+
+type RemoveCollectionNotesRequest struct {
+	CollectionID string   `json:"collection_id"`
+	NoteIDs      []string `json:"note_ids"`
+}
+
+type ReorderCollectionRequest struct {
+	CollectionID string   `json:"collection_id"`
+	NoteIDs      []string `json:"note_ids"`
+}
+
+type GetCollectionRequest struct {
+	CollectionID string `json:"collection_id"`
+}
+
+type UploadAttachmentRequest struct {
+	NoteID  string    `json:"note_id"`
+	Name    string    `json:"name"`
+	Content io.Reader `json:"-"`
+}
+
+type DownloadAttachmentRequest struct {
+	NoteID       string `json:"note_id"`
+	AttachmentID string `json:"attachment_id"`
+}
+
+type AddCommentRequest struct {
+	NoteID    string `json:"note_id"`
+	Author    string `json:"author"`
+	Body      string `json:"body"`
+	StartLine int64  `json:"start_line,omitempty"`
+	EndLine   int64  `json:"end_line,omitempty"`
+}
+
+type ListCommentsRequest struct {
+	NoteID          string `json:"note_id"`
+	IncludeResolved bool   `json:"include_resolved"`
+}
+
+type ResolveCommentRequest struct {
+	NoteID    string `json:"note_id"`
+	CommentID string `json:"comment_id"`
+	User      string `json:"user"`
+}
+
+type GetNoteStatsRequest struct {
+	NoteID     string `json:"note_id"`
+	OwnerToken string `json:"owner_token"`
+	Days       int    `json:"days"`
 }
@@ -1,17 +1,138 @@
 package responses
 
+import (
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"io"
+)
+
+// ShareNoteResponse carries the owner token of the new note, which is the
+// only way to read its stats.
 type ShareNoteResponse struct {
 	URL string `json:"url"`
 	NoteID string `json:"note_id"`
+	OwnerToken string `json:"owner_token,omitempty"`
 	Error  string `json:"error,omitempty"`
 }
 
 type GetNoteResponse struct {
 	Name      string `json:"name"`
 	Content   string `json:"content"`
+	ETag      string `json:"etag,omitempty"`
+	LastModified int64 `json:"last_modified,omitempty"`
+
+	// NotModified is set, without the name and content, when the condition
+	// of the request held.
+	NotModified bool `json:"-"`
 	Error     string `json:"error,omitempty"`
 }
 
 type PrivateNoteResponse struct {
 	Error string `json:"error,omitempty"`
-}
\ No newline at end of file
+}
+
+type GetNoteStreamResponse struct {
+	Name      string `json:"name"`
+	Content   io.ReadCloser `json:"-"`
+	Error     string `json:"error,omitempty"`
+}
+
+type WatchNoteResponse struct {
+	Events    <-chan model.NoteEvent `json:"-"`
+	Error     string `json:"error,omitempty"`
+}
+
+type WatchNotesResponse struct {
+	Events    <-chan model.NoteEvent `json:"-"`
+	Error     string `json:"error,omitempty"`
+}
+
+type EditNoteResponse struct {
+	Session   model.EditSession `json:"-"`
+	Error     string `json:"error,omitempty"`
+}
+
+type SyncNotesResponse struct {
+	Version   int64                `json:"version"`
+	Changes   []model.NoteChange   `json:"changes"`
+	Conflicts []model.SyncConflict `json:"conflicts"`
+	Error     string               `json:"error,omitempty"`
+}
+
+type ForkNoteResponse struct {
+	URL        string `json:"url"`
+	NoteID     string `json:"note_id"`
+	OwnerToken string `json:"owner_token,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+type ListForksResponse struct {
+	Forks []model.NoteFork `json:"forks"`
+	Error string           `json:"error,omitempty"`
+}
+
+type MarkTemplateResponse struct {
+	Variables []model.TemplateVariable `json:"variables"`
+	Error     string                   `json:"error,omitempty"`
+}
+
+type InstantiateTemplateResponse struct {
+	URL        string `json:"url"`
+	NoteID     string `json:"note_id"`
+	OwnerToken string `json:"owner_token,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+type CreateCollectionResponse struct {
+	URL          string `json:"url"`
+	CollectionID string `json:"collection_id"`
+	Error        string `json:"error,omitempty"`
+}
+
+type AddCollectionNotesResponse struct {
+	Error string `json:"error,omitempty"`
+}
+
+type RemoveCollectionNotesResponse struct {
+	Error string `json:"error,omitempty"`
+}
+
+type ReorderCollectionResponse struct {
+	Error string `json:"error,omitempty"`
+}
+
+type GetCollectionResponse struct {
+	Name  string                 `json:"name"`
+	Notes []model.CollectionNote `json:"notes"`
+	Error string                 `json:"error,omitempty"`
+}
+
+type UploadAttachmentResponse struct {
+	Attachment model.AttachmentInfo `json:"attachment"`
+	Error      string               `json:"error,omitempty"`
+}
+
+type DownloadAttachmentResponse struct {
+	Attachment model.AttachmentInfo `json:"attachment"`
+	Content    io.ReadCloser        `json:"-"`
+	Error      string               `json:"error,omitempty"`
+}
+
+type AddCommentResponse struct {
+	Comment model.NoteComment `json:"comment"`
+	Error   string            `json:"error,omitempty"`
+}
+
+type ListCommentsResponse struct {
+	Comments []model.NoteComment `json:"comments"`
+	Error    string              `json:"error,omitempty"`
+}
+
+type ResolveCommentResponse struct {
+	Comment model.NoteComment `json:"comment"`
+	Error   string            `json:"error,omitempty"`
+}
+
+type GetNoteStatsResponse struct {
+	Stats model.NoteStats `json:"stats"`
+	Error string          `json:"error,omitempty"`
+}
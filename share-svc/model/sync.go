@@ -0,0 +1,68 @@
+package model
+
+// ServerReplica is the replica making the changes that do not come from a
+// sync client, such as collaborative editing.
+const ServerReplica = "server"
+
+const (
+	SyncResolutionLocal = "local"
+	SyncResolutionServer = "server"
+)
+
+// VersionVector maps a replica, a sync client or the server, to the number of
+// changes it made to a note.
+type VersionVector map[string]uint64
+
+// Descends reports whether v has seen every change seen by o.
+func (v VersionVector) Descends(o VersionVector) bool {
+	for replica, n := range o {
+		if v[replica] < n {
+			return false
+		}
+	}
+	return true
+}
+
+// Merge returns the version vector which has seen the changes of v and o.
+func (v VersionVector) Merge(o VersionVector) VersionVector {
+	merged := make(VersionVector, len(v))
+	for replica, n := range v {
+		merged[replica] = n
+	}
+	for replica, n := range o {
+		if merged[replica] < n {
+			merged[replica] = n
+		}
+	}
+	return merged
+}
+
+// NoteChange is the state of a note exchanged by sync. Clients increment
+// their own entry of the version vector with every local edit.
+type NoteChange struct {
+	NoteID      string        `json:"note_id"`
+	Name        string        `json:"name"`
+	Content     string        `json:"content"`
+	Versions    VersionVector `json:"versions"`
+	UpdatedAt   int64         `json:"updated_at"`
+	UpdatedBy   string        `json:"updated_by,omitempty"`
+	Deactivated bool          `json:"deactivated,omitempty"`
+}
+
+// SyncConflict reports a local change made concurrently with the server
+// version of the note. The winner is the latest edit, ties are broken by
+// the greatest replica, so every replica resolves the conflict the same way.
+type SyncConflict struct {
+	NoteID     string     `json:"note_id"`
+	Local      NoteChange `json:"local"`
+	Server     NoteChange `json:"server"`
+	Resolution string     `json:"resolution"`
+}
+
+// SyncResult holds the server changes made to the synced notes after the
+// known version, and the conflicts met applying the local changes.
+type SyncResult struct {
+	Version   int64          `json:"version"`
+	Changes   []NoteChange   `json:"changes"`
+	Conflicts []SyncConflict `json:"conflicts"`
+}
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVersionVector(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		v, o      VersionVector
+		vDescends bool
+		oDescends bool
+		merged    VersionVector
+	}{
+		{
+			name:      "empty",
+			vDescends: true,
+			oDescends: true,
+			merged:    VersionVector{},
+		},
+		{
+			name:      "equal",
+			v:         VersionVector{"a": 1, "b": 2},
+			o:         VersionVector{"a": 1, "b": 2},
+			vDescends: true,
+			oDescends: true,
+			merged:    VersionVector{"a": 1, "b": 2},
+		},
+		{
+			name:      "missing replica is zero",
+			v:         VersionVector{"a": 1, "b": 0},
+			o:         VersionVector{"a": 1},
+			vDescends: true,
+			oDescends: true,
+			merged:    VersionVector{"a": 1, "b": 0},
+		},
+		{
+			name:      "newer entry",
+			v:         VersionVector{"a": 2, "b": 2},
+			o:         VersionVector{"a": 1, "b": 2},
+			vDescends: true,
+			merged:    VersionVector{"a": 2, "b": 2},
+		},
+		{
+			name:      "new replica",
+			v:         VersionVector{"a": 1},
+			o:         VersionVector{"a": 1, "c": 1},
+			oDescends: true,
+			merged:    VersionVector{"a": 1, "c": 1},
+		},
+		{
+			name:   "concurrent",
+			v:      VersionVector{"a": 2, "b": 1},
+			o:      VersionVector{"a": 1, "b": 2},
+			merged: VersionVector{"a": 2, "b": 2},
+		},
+		{
+			name:   "concurrent replicas",
+			v:      VersionVector{"a": 1},
+			o:      VersionVector{"b": 1},
+			merged: VersionVector{"a": 1, "b": 1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.v.Descends(tc.o); got != tc.vDescends {
+				t.Fatalf("v descends o: got %v", got)
+			}
+
+			if got := tc.o.Descends(tc.v); got != tc.oDescends {
+				t.Fatalf("o descends v: got %v", got)
+			}
+
+			// the merge is commutative and descends both, the entries at zero
+			// are the same as the missing ones
+			for _, merged := range []VersionVector{tc.v.Merge(tc.o), tc.o.Merge(tc.v)} {
+				if !merged.Descends(tc.merged) || !tc.merged.Descends(merged) {
+					t.Fatalf("got %v, want %v", merged, tc.merged)
+				}
+
+				if !merged.Descends(tc.v) || !merged.Descends(tc.o) {
+					t.Fatalf("%v does not descend %v and %v", merged, tc.v, tc.o)
+				}
+			}
+		})
+	}
+}
+
+func TestVersionVectorMergeCopies(t *testing.T) {
+	v := VersionVector{"a": 1}
+	v.Merge(VersionVector{"a": 2, "b": 1})
+
+	if !reflect.DeepEqual(v, VersionVector{"a": 1}) {
+		t.Fatalf("merge changed %v", v)
+	}
+}
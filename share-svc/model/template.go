@@ -0,0 +1,9 @@
+package model
+
+// TemplateVariable is a placeholder of a template note, written {{name}} when
+// it is required, or {{name|default}} when it falls back to default.
+type TemplateVariable struct {
+	Name     string `json:"name"`
+	Required bool   `json:"required"`
+	Default  string `json:"default,omitempty"`
+}
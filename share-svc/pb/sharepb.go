@@ -2,3 +2,8 @@ package pb
 
 
 //go:generate protoc --gofast_out=plugins=grpc:. share.proto
+//go:generate protoc --grpc-gateway_out=logtostderr=true:. share.proto
+//go:generate protoc --swagger_out=logtostderr=true:. share.proto
+
+// ShareServiceName is the full name of the Share service, as registered on the gRPC servers.
+const ShareServiceName = "pb.Share"
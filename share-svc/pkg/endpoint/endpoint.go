@@ -2,8 +2,10 @@ package endpoint
 
 import (
 	"context"
+	"github.com/al8n/shareable-notes/share-svc/common"
 	"github.com/al8n/shareable-notes/share-svc/config"
 	"github.com/al8n/shareable-notes/share-svc/internal/utils"
+	"github.com/al8n/shareable-notes/share-svc/model"
 	"github.com/al8n/shareable-notes/share-svc/model/requests"
 	"github.com/al8n/shareable-notes/share-svc/model/responses"
 	shareservice "github.com/al8n/shareable-notes/share-svc/pkg/service"
@@ -16,6 +18,7 @@ import (
 	stdopentracing "github.com/opentracing/opentracing-go"
 	"github.com/sony/gobreaker"
 	"golang.org/x/time/rate"
+	"io"
 )
 
 type MakeEndpointFunc = func(shareservice.Service) endpoint.Endpoint
@@ -24,9 +27,30 @@ type Set struct {
 	ShareNoteEndpoint endpoint.Endpoint
 	PrivateNoteEndpoint endpoint.Endpoint
 	GetNoteEndpoint endpoint.Endpoint
+	ShareNoteStreamEndpoint endpoint.Endpoint
+	GetNoteStreamEndpoint endpoint.Endpoint
+	WatchNoteEndpoint endpoint.Endpoint
+	WatchNotesEndpoint endpoint.Endpoint
+	EditNoteEndpoint endpoint.Endpoint
+	SyncNotesEndpoint endpoint.Endpoint
+	ForkNoteEndpoint endpoint.Endpoint
+	ListForksEndpoint endpoint.Endpoint
+	MarkTemplateEndpoint endpoint.Endpoint
+	InstantiateTemplateEndpoint endpoint.Endpoint
+	CreateCollectionEndpoint endpoint.Endpoint
+	AddCollectionNotesEndpoint endpoint.Endpoint
+	RemoveCollectionNotesEndpoint endpoint.Endpoint
+	ReorderCollectionEndpoint endpoint.Endpoint
+	GetCollectionEndpoint endpoint.Endpoint
+	UploadAttachmentEndpoint endpoint.Endpoint
+	DownloadAttachmentEndpoint endpoint.Endpoint
+	AddCommentEndpoint endpoint.Endpoint
+	ListCommentsEndpoint endpoint.Endpoint
+	ResolveCommentEndpoint endpoint.Endpoint
+	GetNoteStatsEndpoint endpoint.Endpoint
 }
 
-func (s Set) GetNote(ctx context.Context, id string) (name, content string, err error)  {
+func (s Set) GetNote(ctx context.Context, id string, cond model.NoteCondition) (name, content string, version model.NoteVersion, err error)  {
 	var (
 		resp interface{}
 		response *responses.GetNoteResponse
@@ -34,178 +58,1343 @@ func (s Set) GetNote(ctx context.Context, id string) (name, content string, err
 
 	resp, err = s.GetNoteEndpoint(ctx, requests.GetNoteRequest{
 		NoteID: id,
+		Condition: cond,
+	})
+
+	if err != nil {
+		return "", "", version, err
+	}
+
+	response = resp.(*responses.GetNoteResponse)
+	version = model.NoteVersion{ETag: response.ETag, LastModified: response.LastModified}
+	if response.NotModified {
+		return "", "", version, common.ErrorNoteNotModified
+	}
+	return response.Name, response.Content, version, utils.Str2Err(response.Error)
+}
+
+func (s Set) ShareNote(ctx context.Context, name, content string) (url, noteID, ownerToken string, err error)  {
+	var (
+		resp interface{}
+		response *responses.ShareNoteResponse
+	)
+
+	resp, err = s.ShareNoteEndpoint(ctx, requests.ShareNoteRequest{
+		Name: name,
+		Content: content,
+	})
+
+	if err != nil {
+		return "", "", "", err
+	}
+
+	response = resp.(*responses.ShareNoteResponse)
+	return response.URL, response.NoteID, response.OwnerToken, utils.Str2Err(response.Error)
+}
+
+func (s Set) PrivateNote(ctx context.Context, id string) (err error)  {
+	var (
+		resp interface{}
+		response *responses.PrivateNoteResponse
+	)
+
+	resp, err = s.PrivateNoteEndpoint(ctx, requests.PrivateNoteRequest{
+		NoteID: id,
+	})
+	if err != nil {
+		return  err
+	}
+	response = resp.(*responses.PrivateNoteResponse)
+	return utils.Str2Err(response.Error)
+}
+
+func (s Set) ShareNoteStream(ctx context.Context, name string, content io.Reader) (url, noteID, ownerToken string, err error)  {
+	var (
+		resp interface{}
+		response *responses.ShareNoteResponse
+	)
+
+	resp, err = s.ShareNoteStreamEndpoint(ctx, requests.ShareNoteStreamRequest{
+		Name: name,
+		Content: content,
+	})
+
+	if err != nil {
+		return "", "", "", err
+	}
+
+	response = resp.(*responses.ShareNoteResponse)
+	return response.URL, response.NoteID, response.OwnerToken, utils.Str2Err(response.Error)
+}
+
+func (s Set) GetNoteStream(ctx context.Context, id string) (name string, content io.ReadCloser, err error)  {
+	var (
+		resp interface{}
+		response *responses.GetNoteStreamResponse
+	)
+
+	resp, err = s.GetNoteStreamEndpoint(ctx, requests.GetNoteStreamRequest{
+		NoteID: id,
+	})
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	response = resp.(*responses.GetNoteStreamResponse)
+	return response.Name, response.Content, utils.Str2Err(response.Error)
+}
+
+func (s Set) WatchNote(ctx context.Context, id string) (events <-chan model.NoteEvent, err error)  {
+	var (
+		resp interface{}
+		response *responses.WatchNoteResponse
+	)
+
+	resp, err = s.WatchNoteEndpoint(ctx, requests.WatchNoteRequest{
+		NoteID: id,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	response = resp.(*responses.WatchNoteResponse)
+	return response.Events, utils.Str2Err(response.Error)
+}
+
+func (s Set) WatchNotes(ctx context.Context) (events <-chan model.NoteEvent, err error)  {
+	var (
+		resp interface{}
+		response *responses.WatchNotesResponse
+	)
+
+	resp, err = s.WatchNotesEndpoint(ctx, requests.WatchNotesRequest{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	response = resp.(*responses.WatchNotesResponse)
+	return response.Events, utils.Str2Err(response.Error)
+}
+
+func (s Set) EditNote(ctx context.Context, id, user string) (session model.EditSession, err error)  {
+	var (
+		resp interface{}
+		response *responses.EditNoteResponse
+	)
+
+	resp, err = s.EditNoteEndpoint(ctx, requests.EditNoteRequest{
+		NoteID: id,
+		User: user,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	response = resp.(*responses.EditNoteResponse)
+	return response.Session, utils.Str2Err(response.Error)
+}
+
+func (s Set) SyncNotes(ctx context.Context, clientID string, since int64, noteIDs []string, changes []model.NoteChange) (result model.SyncResult, err error)  {
+	var (
+		resp interface{}
+		response *responses.SyncNotesResponse
+	)
+
+	resp, err = s.SyncNotesEndpoint(ctx, requests.SyncNotesRequest{
+		ClientID: clientID,
+		Since: since,
+		NoteIDs: noteIDs,
+		Changes: changes,
+	})
+
+	if err != nil {
+		return result, err
+	}
+
+	response = resp.(*responses.SyncNotesResponse)
+	return model.SyncResult{
+		Version: response.Version,
+		Changes: response.Changes,
+		Conflicts: response.Conflicts,
+	}, utils.Str2Err(response.Error)
+}
+
+func (s Set) ForkNote(ctx context.Context, id, owner string) (url, sharedID, ownerToken string, err error)  {
+	var (
+		resp interface{}
+		response *responses.ForkNoteResponse
+	)
+
+	resp, err = s.ForkNoteEndpoint(ctx, requests.ForkNoteRequest{
+		NoteID: id,
+		Owner: owner,
+	})
+
+	if err != nil {
+		return "", "", "", err
+	}
+
+	response = resp.(*responses.ForkNoteResponse)
+	return response.URL, response.NoteID, response.OwnerToken, utils.Str2Err(response.Error)
+}
+
+func (s Set) ListForks(ctx context.Context, id string) (forks []model.NoteFork, err error)  {
+	var (
+		resp interface{}
+		response *responses.ListForksResponse
+	)
+
+	resp, err = s.ListForksEndpoint(ctx, requests.ListForksRequest{
+		NoteID: id,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	response = resp.(*responses.ListForksResponse)
+	return response.Forks, utils.Str2Err(response.Error)
+}
+
+func (s Set) MarkTemplate(ctx context.Context, id string, template bool) (variables []model.TemplateVariable, err error)  {
+	var (
+		resp interface{}
+		response *responses.MarkTemplateResponse
+	)
+
+	resp, err = s.MarkTemplateEndpoint(ctx, requests.MarkTemplateRequest{
+		NoteID: id,
+		Template: template,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	response = resp.(*responses.MarkTemplateResponse)
+	return response.Variables, utils.Str2Err(response.Error)
+}
+
+func (s Set) InstantiateTemplate(ctx context.Context, id string, vars map[string]string) (url, sharedID, ownerToken string, err error)  {
+	var (
+		resp interface{}
+		response *responses.InstantiateTemplateResponse
+	)
+
+	resp, err = s.InstantiateTemplateEndpoint(ctx, requests.InstantiateTemplateRequest{
+		NoteID: id,
+		Vars: vars,
+	})
+
+	if err != nil {
+		return "", "", "", err
+	}
+
+	response = resp.(*responses.InstantiateTemplateResponse)
+	return response.URL, response.NoteID, response.OwnerToken, utils.Str2Err(response.Error)
+}
+
+func (s Set) CreateCollection(ctx context.Context, name string, noteIDs []string) (url, collectionID string, err error)  {
+	var (
+		resp interface{}
+		response *responses.CreateCollectionResponse
+	)
+
+	resp, err = s.CreateCollectionEndpoint(ctx, requests.CreateCollectionRequest{
+		Name: name,
+		NoteIDs: noteIDs,
 	})
 
 	if err != nil {
 		return "", "", err
 	}
 
-	response = resp.(*responses.GetNoteResponse)
-	return response.Name, response.Content, utils.Str2Err(response.Error)
+	response = resp.(*responses.CreateCollectionResponse)
+	return response.URL, response.CollectionID, utils.Str2Err(response.Error)
+}
+
+func (s Set) AddCollectionNotes(ctx context.Context, id string, noteIDs []string) (err error)  {
+	var (
+		resp interface{}
+		response *responses.AddCollectionNotesResponse
+	)
+
+	resp, err = s.AddCollectionNotesEndpoint(ctx, requests.AddCollectionNotesRequest{
+		CollectionID: id,
+		NoteIDs: noteIDs,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	response = resp.(*responses.AddCollectionNotesResponse)
+	return utils.Str2Err(response.Error)
+}
+
+func (s Set) RemoveCollectionNotes(ctx context.Context, id string, noteIDs []string) (err error)  {
+	var (
+		resp interface{}
+		response *responses.RemoveCollectionNotesResponse
+	)
+
+	resp, err = s.RemoveCollectionNotesEndpoint(ctx, requests.RemoveCollectionNotesRequest{
+		CollectionID: id,
+		NoteIDs: noteIDs,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	response = resp.(*responses.RemoveCollectionNotesResponse)
+	return utils.Str2Err(response.Error)
+}
+
+func (s Set) ReorderCollection(ctx context.Context, id string, noteIDs []string) (err error)  {
+	var (
+		resp interface{}
+		response *responses.ReorderCollectionResponse
+	)
+
+	resp, err = s.ReorderCollectionEndpoint(ctx, requests.ReorderCollectionRequest{
+		CollectionID: id,
+		NoteIDs: noteIDs,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	response = resp.(*responses.ReorderCollectionResponse)
+	return utils.Str2Err(response.Error)
+}
+
+func (s Set) GetCollection(ctx context.Context, id string) (name string, notes []model.CollectionNote, err error)  {
+	var (
+		resp interface{}
+		response *responses.GetCollectionResponse
+	)
+
+	resp, err = s.GetCollectionEndpoint(ctx, requests.GetCollectionRequest{
+		CollectionID: id,
+	})
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	response = resp.(*responses.GetCollectionResponse)
+	return response.Name, response.Notes, utils.Str2Err(response.Error)
+}
+
+func (s Set) UploadAttachment(ctx context.Context, noteID, name string, content io.Reader) (attachment model.AttachmentInfo, err error)  {
+	var (
+		resp interface{}
+		response *responses.UploadAttachmentResponse
+	)
+
+	resp, err = s.UploadAttachmentEndpoint(ctx, requests.UploadAttachmentRequest{
+		NoteID: noteID,
+		Name: name,
+		Content: content,
+	})
+
+	if err != nil {
+		return attachment, err
+	}
+
+	response = resp.(*responses.UploadAttachmentResponse)
+	return response.Attachment, utils.Str2Err(response.Error)
+}
+
+func (s Set) DownloadAttachment(ctx context.Context, noteID, attachmentID string) (attachment model.AttachmentInfo, content io.ReadCloser, err error)  {
+	var (
+		resp interface{}
+		response *responses.DownloadAttachmentResponse
+	)
+
+	resp, err = s.DownloadAttachmentEndpoint(ctx, requests.DownloadAttachmentRequest{
+		NoteID: noteID,
+		AttachmentID: attachmentID,
+	})
+
+	if err != nil {
+		return attachment, nil, err
+	}
+
+	response = resp.(*responses.DownloadAttachmentResponse)
+	return response.Attachment, response.Content, utils.Str2Err(response.Error)
+}
+
+func (s Set) AddComment(ctx context.Context, noteID, author, body string, startLine, endLine int64) (comment model.NoteComment, err error)  {
+	var (
+		resp interface{}
+		response *responses.AddCommentResponse
+	)
+
+	resp, err = s.AddCommentEndpoint(ctx, requests.AddCommentRequest{
+		NoteID: noteID,
+		Author: author,
+		Body: body,
+		StartLine: startLine,
+		EndLine: endLine,
+	})
+
+	if err != nil {
+		return comment, err
+	}
+
+	response = resp.(*responses.AddCommentResponse)
+	return response.Comment, utils.Str2Err(response.Error)
+}
+
+func (s Set) ListComments(ctx context.Context, noteID string, includeResolved bool) (comments []model.NoteComment, err error)  {
+	var (
+		resp interface{}
+		response *responses.ListCommentsResponse
+	)
+
+	resp, err = s.ListCommentsEndpoint(ctx, requests.ListCommentsRequest{
+		NoteID: noteID,
+		IncludeResolved: includeResolved,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	response = resp.(*responses.ListCommentsResponse)
+	return response.Comments, utils.Str2Err(response.Error)
+}
+
+func (s Set) ResolveComment(ctx context.Context, noteID, commentID, user string) (comment model.NoteComment, err error)  {
+	var (
+		resp interface{}
+		response *responses.ResolveCommentResponse
+	)
+
+	resp, err = s.ResolveCommentEndpoint(ctx, requests.ResolveCommentRequest{
+		NoteID: noteID,
+		CommentID: commentID,
+		User: user,
+	})
+
+	if err != nil {
+		return comment, err
+	}
+
+	response = resp.(*responses.ResolveCommentResponse)
+	return response.Comment, utils.Str2Err(response.Error)
+}
+
+func (s Set) GetNoteStats(ctx context.Context, id, ownerToken string, days int) (stats model.NoteStats, err error)  {
+	var (
+		resp interface{}
+		response *responses.GetNoteStatsResponse
+	)
+
+	resp, err = s.GetNoteStatsEndpoint(ctx, requests.GetNoteStatsRequest{
+		NoteID: id,
+		OwnerToken: ownerToken,
+		Days: days,
+	})
+
+	if err != nil {
+		return stats, err
+	}
+
+	response = resp.(*responses.GetNoteStatsResponse)
+	return response.Stats, utils.Str2Err(response.Error)
+}
+
+func New(svc shareservice.Service, logger log.Logger, duration map[string]metrics.Histogram, tracer stdopentracing.Tracer) (set *Set, err error) {
+	apis := config.GetConfig().Service.APIs
+
+	set = &Set{
+		ShareNoteEndpoint: MakeEndpoint(
+			svc,
+			apis[shareservice.ShareNoteServiceName],
+			logger,
+			duration[shareservice.ShareNoteServiceName],
+			tracer,
+			MakeShareNoteEndpoint),
+
+		PrivateNoteEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.PrivateNoteServiceName],
+			logger,
+			duration[shareservice.PrivateNoteServiceName],
+			tracer,
+			MakePrivateNoteEndpoint),
+
+		GetNoteEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.GetNoteServiceName],
+			logger,
+			duration[shareservice.GetNoteServiceName],
+			tracer,
+			MakeGetNoteEndpoint),
+
+		ShareNoteStreamEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.ShareNoteStreamServiceName],
+			logger,
+			duration[shareservice.ShareNoteStreamServiceName],
+			tracer,
+			MakeShareNoteStreamEndpoint),
+
+		GetNoteStreamEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.GetNoteStreamServiceName],
+			logger,
+			duration[shareservice.GetNoteStreamServiceName],
+			tracer,
+			MakeGetNoteStreamEndpoint),
+
+		WatchNoteEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.WatchNoteServiceName],
+			logger,
+			duration[shareservice.WatchNoteServiceName],
+			tracer,
+			MakeWatchNoteEndpoint),
+
+		WatchNotesEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.WatchNotesServiceName],
+			logger,
+			duration[shareservice.WatchNotesServiceName],
+			tracer,
+			MakeWatchNotesEndpoint),
+
+		EditNoteEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.EditNoteServiceName],
+			logger,
+			duration[shareservice.EditNoteServiceName],
+			tracer,
+			MakeEditNoteEndpoint),
+
+		SyncNotesEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.SyncNotesServiceName],
+			logger,
+			duration[shareservice.SyncNotesServiceName],
+			tracer,
+			MakeSyncNotesEndpoint),
+
+		ForkNoteEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.ForkNoteServiceName],
+			logger,
+			duration[shareservice.ForkNoteServiceName],
+			tracer,
+			MakeForkNoteEndpoint),
+
+		ListForksEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.ListForksServiceName],
+			logger,
+			duration[shareservice.ListForksServiceName],
+			tracer,
+			MakeListForksEndpoint),
+
+		MarkTemplateEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.MarkTemplateServiceName],
+			logger,
+			duration[shareservice.MarkTemplateServiceName],
+			tracer,
+			MakeMarkTemplateEndpoint),
+
+		InstantiateTemplateEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.InstantiateTemplateServiceName],
+			logger,
+			duration[shareservice.InstantiateTemplateServiceName],
+			tracer,
+			MakeInstantiateTemplateEndpoint),
+
+		CreateCollectionEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.CreateCollectionServiceName],
+			logger,
+			duration[shareservice.CreateCollectionServiceName],
+			tracer,
+			MakeCreateCollectionEndpoint),
+
+		AddCollectionNotesEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.AddCollectionNotesServiceName],
+			logger,
+			duration[shareservice.AddCollectionNotesServiceName],
+			tracer,
+			MakeAddCollectionNotesEndpoint),
+
+		RemoveCollectionNotesEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.RemoveCollectionNotesServiceName],
+			logger,
+			duration[shareservice.RemoveCollectionNotesServiceName],
+			tracer,
+			MakeRemoveCollectionNotesEndpoint),
+
+		ReorderCollectionEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.ReorderCollectionServiceName],
+			logger,
+			duration[shareservice.ReorderCollectionServiceName],
+			tracer,
+			MakeReorderCollectionEndpoint),
+
+		GetCollectionEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.GetCollectionServiceName],
+			logger,
+			duration[shareservice.GetCollectionServiceName],
+			tracer,
+			MakeGetCollectionEndpoint),
+
+		UploadAttachmentEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.UploadAttachmentServiceName],
+			logger,
+			duration[shareservice.UploadAttachmentServiceName],
+			tracer,
+			MakeUploadAttachmentEndpoint),
+
+		DownloadAttachmentEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.DownloadAttachmentServiceName],
+			logger,
+			duration[shareservice.DownloadAttachmentServiceName],
+			tracer,
+			MakeDownloadAttachmentEndpoint),
+
+		AddCommentEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.AddCommentServiceName],
+			logger,
+			duration[shareservice.AddCommentServiceName],
+			tracer,
+			MakeAddCommentEndpoint),
+
+		ListCommentsEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.ListCommentsServiceName],
+			logger,
+			duration[shareservice.ListCommentsServiceName],
+			tracer,
+			MakeListCommentsEndpoint),
+
+		ResolveCommentEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.ResolveCommentServiceName],
+			logger,
+			duration[shareservice.ResolveCommentServiceName],
+			tracer,
+			MakeResolveCommentEndpoint),
+
+		GetNoteStatsEndpoint:    MakeEndpoint(
+			svc,
+			apis[shareservice.GetNoteStatsServiceName],
+			logger,
+			duration[shareservice.GetNoteStatsServiceName],
+			tracer,
+			MakeGetNoteStatsEndpoint),
+	}
+
+	return
+}
+
+func MakeEndpoint(svc shareservice.Service, api bootapi.API, logger log.Logger, duration metrics.Histogram, tracer stdopentracing.Tracer, makeFN MakeEndpointFunc) endpoint.Endpoint {
+	var ep endpoint.Endpoint
+	{
+		ep = makeFN(svc)
+		// RegisterByEmail is limited to 1000 requests per second with burst of 1 request.
+		// Note, rate is defined as a time interval between requests.
+		ep = ratelimit.NewErroringLimiter(rate.NewLimiter(rate.Every(api.RateLimit.Duration), api.RateLimit.Delta))(ep)
+
+		ep = circuitbreaker.Gobreaker(gobreaker.NewCircuitBreaker(api.Breaker.Standardize()))(ep)
+
+		ep = LoggingMiddleware(log.With(logger, api.GetGoKitLoggerKVs()))(ep)
+		ep = InstrumentingMiddleware(duration.With(api.Instrument...))(ep)
+
+	}
+	return ep
+}
+
+func MakeShareNoteEndpoint(svc shareservice.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		var (
+			req requests.ShareNoteRequest
+			url, noteid, token string
+			span stdopentracing.Span
+		)
+
+		span = stdopentracing.SpanFromContext(ctx)
+		span.SetTag("Endpoint", shareservice.ShareNoteServiceName)
+		defer span.Finish()
+
+		req = request.(requests.ShareNoteRequest)
+		url, noteid, token, err = svc.ShareNote(ctx, req.Name, req.Content)
+
+		if err != nil {
+			return responses.ShareNoteResponse{
+				Error: err.Error(),
+			}, nil
+		}
+
+		return responses.ShareNoteResponse{
+			URL:    url,
+			NoteID: noteid,
+			OwnerToken: token,
+			Error:    "",
+		}, nil
+	}
+}
+
+func MakeGetNoteEndpoint(svc shareservice.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		var (
+			req requests.GetNoteRequest
+			name, content string
+			version model.NoteVersion
+			span stdopentracing.Span
+		)
+
+		span = stdopentracing.SpanFromContext(ctx)
+		span.SetTag("Endpoint", shareservice.GetNoteServiceName)
+		defer span.Finish()
+
+		req = request.(requests.GetNoteRequest)
+		name, content, version, err = svc.GetNote(ctx, req.NoteID, req.Condition)
+		if err == common.ErrorNoteNotModified {
+			return responses.GetNoteResponse{
+				ETag: version.ETag,
+				LastModified: version.LastModified,
+				NotModified: true,
+			}, nil
+		}
+
+		if err != nil {
+			return responses.GetNoteResponse{
+				Error: err.Error(),
+			}, nil
+		}
+
+		return responses.GetNoteResponse{
+			Content:    content,
+			Name: name,
+			ETag: version.ETag,
+			LastModified: version.LastModified,
+			Error:    "",
+		}, nil
+	}
+}
+
+func MakePrivateNoteEndpoint(svc shareservice.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		var (
+			req requests.PrivateNoteRequest
+			span stdopentracing.Span
+		)
+
+		span = stdopentracing.SpanFromContext(ctx)
+		span.SetTag("Endpoint", shareservice.PrivateNoteServiceName)
+		defer span.Finish()
+
+		req = request.(requests.PrivateNoteRequest)
+		err = svc.PrivateNote(ctx, req.NoteID)
+		if err != nil {
+			return responses.PrivateNoteResponse{
+				Error: err.Error(),
+			}, nil
+		}
+
+		return responses.PrivateNoteResponse{
+			Error:    "",
+		}, nil
+	}
+}
+
+func MakeShareNoteStreamEndpoint(svc shareservice.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		var (
+			req requests.ShareNoteStreamRequest
+			url, noteid, token string
+			span stdopentracing.Span
+		)
+
+		span = stdopentracing.SpanFromContext(ctx)
+		span.SetTag("Endpoint", shareservice.ShareNoteStreamServiceName)
+		defer span.Finish()
+
+		req = request.(requests.ShareNoteStreamRequest)
+		url, noteid, token, err = svc.ShareNoteStream(ctx, req.Name, req.Content)
+
+		if err != nil {
+			return responses.ShareNoteResponse{
+				Error: err.Error(),
+			}, nil
+		}
+
+		return responses.ShareNoteResponse{
+			URL:    url,
+			NoteID: noteid,
+			OwnerToken: token,
+			Error:    "",
+		}, nil
+	}
+}
+
+func MakeGetNoteStreamEndpoint(svc shareservice.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		var (
+			req requests.GetNoteStreamRequest
+			name string
+			content io.ReadCloser
+			span stdopentracing.Span
+		)
+
+		span = stdopentracing.SpanFromContext(ctx)
+		span.SetTag("Endpoint", shareservice.GetNoteStreamServiceName)
+		defer span.Finish()
+
+		req = request.(requests.GetNoteStreamRequest)
+		name, content, err = svc.GetNoteStream(ctx, req.NoteID)
+		if err != nil {
+			return responses.GetNoteStreamResponse{
+				Error: err.Error(),
+			}, nil
+		}
+
+		return responses.GetNoteStreamResponse{
+			Name: name,
+			Content:    content,
+			Error:    "",
+		}, nil
+	}
+}
+
+func MakeWatchNoteEndpoint(svc shareservice.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		var (
+			req requests.WatchNoteRequest
+			events <-chan model.NoteEvent
+			span stdopentracing.Span
+		)
+
+		span = stdopentracing.SpanFromContext(ctx)
+		span.SetTag("Endpoint", shareservice.WatchNoteServiceName)
+		defer span.Finish()
+
+		req = request.(requests.WatchNoteRequest)
+		events, err = svc.WatchNote(ctx, req.NoteID)
+		if err != nil {
+			return responses.WatchNoteResponse{
+				Error: err.Error(),
+			}, nil
+		}
+
+		return responses.WatchNoteResponse{
+			Events:    events,
+			Error:    "",
+		}, nil
+	}
+}
+
+func MakeWatchNotesEndpoint(svc shareservice.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		var (
+			events <-chan model.NoteEvent
+			span stdopentracing.Span
+		)
+
+		span = stdopentracing.SpanFromContext(ctx)
+		span.SetTag("Endpoint", shareservice.WatchNotesServiceName)
+		defer span.Finish()
+
+		events, err = svc.WatchNotes(ctx)
+		if err != nil {
+			return responses.WatchNotesResponse{
+				Error: err.Error(),
+			}, nil
+		}
+
+		return responses.WatchNotesResponse{
+			Events:    events,
+			Error:    "",
+		}, nil
+	}
+}
+
+
+func MakeEditNoteEndpoint(svc shareservice.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		var (
+			req requests.EditNoteRequest
+			session model.EditSession
+			span stdopentracing.Span
+		)
+
+		span = stdopentracing.SpanFromContext(ctx)
+		span.SetTag("Endpoint", shareservice.EditNoteServiceName)
+		defer span.Finish()
+
+		req = request.(requests.EditNoteRequest)
+		session, err = svc.EditNote(ctx, req.NoteID, req.User)
+		if err != nil {
+			return responses.EditNoteResponse{
+				Error: err.Error(),
+			}, nil
+		}
+
+		return responses.EditNoteResponse{
+			Session:    session,
+			Error:    "",
+		}, nil
+	}
+}
+
+func MakeSyncNotesEndpoint(svc shareservice.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		var (
+			req requests.SyncNotesRequest
+			result model.SyncResult
+			span stdopentracing.Span
+		)
+
+		span = stdopentracing.SpanFromContext(ctx)
+		span.SetTag("Endpoint", shareservice.SyncNotesServiceName)
+		defer span.Finish()
+
+		req = request.(requests.SyncNotesRequest)
+		result, err = svc.SyncNotes(ctx, req.ClientID, req.Since, req.NoteIDs, req.Changes)
+		if err != nil {
+			return responses.SyncNotesResponse{
+				Error: err.Error(),
+			}, nil
+		}
+
+		return responses.SyncNotesResponse{
+			Version:    result.Version,
+			Changes:    result.Changes,
+			Conflicts:    result.Conflicts,
+			Error:    "",
+		}, nil
+	}
+}
+
+func MakeForkNoteEndpoint(svc shareservice.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		var (
+			req requests.ForkNoteRequest
+			url, id, token string
+			span stdopentracing.Span
+		)
+
+		span = stdopentracing.SpanFromContext(ctx)
+		span.SetTag("Endpoint", shareservice.ForkNoteServiceName)
+		defer span.Finish()
+
+		req = request.(requests.ForkNoteRequest)
+		url, id, token, err = svc.ForkNote(ctx, req.NoteID, req.Owner)
+		if err != nil {
+			return responses.ForkNoteResponse{
+				Error: err.Error(),
+			}, nil
+		}
+
+		return responses.ForkNoteResponse{
+			URL:    url,
+			NoteID:    id,
+			OwnerToken: token,
+			Error:    "",
+		}, nil
+	}
+}
+
+func MakeListForksEndpoint(svc shareservice.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		var (
+			req requests.ListForksRequest
+			forks []model.NoteFork
+			span stdopentracing.Span
+		)
+
+		span = stdopentracing.SpanFromContext(ctx)
+		span.SetTag("Endpoint", shareservice.ListForksServiceName)
+		defer span.Finish()
+
+		req = request.(requests.ListForksRequest)
+		forks, err = svc.ListForks(ctx, req.NoteID)
+		if err != nil {
+			return responses.ListForksResponse{
+				Error: err.Error(),
+			}, nil
+		}
+
+		return responses.ListForksResponse{
+			Forks:    forks,
+			Error:    "",
+		}, nil
+	}
 }
 
-func (s Set) ShareNote(ctx context.Context, name, content string) (url, noteID string, err error)  {
-	var (
-		resp interface{}
-		response *responses.ShareNoteResponse
-	)
+func MakeMarkTemplateEndpoint(svc shareservice.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		var (
+			req requests.MarkTemplateRequest
+			variables []model.TemplateVariable
+			span stdopentracing.Span
+		)
 
-	resp, err = s.ShareNoteEndpoint(ctx, requests.ShareNoteRequest{
-		Name: name,
-		Content: content,
-	})
+		span = stdopentracing.SpanFromContext(ctx)
+		span.SetTag("Endpoint", shareservice.MarkTemplateServiceName)
+		defer span.Finish()
 
-	if err != nil {
-		return "", "", err
+		req = request.(requests.MarkTemplateRequest)
+		variables, err = svc.MarkTemplate(ctx, req.NoteID, req.Template)
+		if err != nil {
+			return responses.MarkTemplateResponse{
+				Error: err.Error(),
+			}, nil
+		}
+
+		return responses.MarkTemplateResponse{
+			Variables:    variables,
+			Error:    "",
+		}, nil
 	}
+}
 
-	response = resp.(*responses.ShareNoteResponse)
-	return response.URL, response.NoteID, utils.Str2Err(response.Error)
+func MakeInstantiateTemplateEndpoint(svc shareservice.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		var (
+			req requests.InstantiateTemplateRequest
+			url, id, token string
+			span stdopentracing.Span
+		)
+
+		span = stdopentracing.SpanFromContext(ctx)
+		span.SetTag("Endpoint", shareservice.InstantiateTemplateServiceName)
+		defer span.Finish()
+
+		req = request.(requests.InstantiateTemplateRequest)
+		url, id, token, err = svc.InstantiateTemplate(ctx, req.NoteID, req.Vars)
+		if err != nil {
+			return responses.InstantiateTemplateResponse{
+				Error: err.Error(),
+			}, nil
+		}
+
+		return responses.InstantiateTemplateResponse{
+			URL:    url,
+			NoteID:    id,
+			OwnerToken: token,
+			Error:    "",
+		}, nil
+	}
 }
 
-func (s Set) PrivateNote(ctx context.Context, id string) (err error)  {
-	var (
-		resp interface{}
-		response *responses.PrivateNoteResponse
-	)
+func MakeCreateCollectionEndpoint(svc shareservice.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		var (
+			req requests.CreateCollectionRequest
+			url, id string
+			span stdopentracing.Span
+		)
 
-	resp, err = s.PrivateNoteEndpoint(ctx, requests.PrivateNoteRequest{
-		NoteID: id,
-	})
-	if err != nil {
-		return  err
+		span = stdopentracing.SpanFromContext(ctx)
+		span.SetTag("Endpoint", shareservice.CreateCollectionServiceName)
+		defer span.Finish()
+
+		req = request.(requests.CreateCollectionRequest)
+		url, id, err = svc.CreateCollection(ctx, req.Name, req.NoteIDs)
+		if err != nil {
+			return responses.CreateCollectionResponse{
+				Error: err.Error(),
+			}, nil
+		}
+
+		return responses.CreateCollectionResponse{
+			URL:    url,
+			CollectionID:    id,
+			Error:    "",
+		}, nil
 	}
-	response = resp.(*responses.PrivateNoteResponse)
-	return utils.Str2Err(response.Error)
 }
 
-func New(svc shareservice.Service, logger log.Logger, duration map[string]metrics.Histogram, tracer stdopentracing.Tracer) (set *Set, err error) {
-	apis := config.GetConfig().Service.APIs
+func MakeAddCollectionNotesEndpoint(svc shareservice.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		var (
+			req requests.AddCollectionNotesRequest
+			span stdopentracing.Span
+		)
 
-	set = &Set{
-		ShareNoteEndpoint: MakeEndpoint(
-			svc,
-			apis[shareservice.ShareNoteServiceName],
-			logger,
-			duration[shareservice.ShareNoteServiceName],
-			tracer,
-			MakeShareNoteEndpoint),
+		span = stdopentracing.SpanFromContext(ctx)
+		span.SetTag("Endpoint", shareservice.AddCollectionNotesServiceName)
+		defer span.Finish()
 
-		PrivateNoteEndpoint:    MakeEndpoint(
-			svc,
-			apis[shareservice.PrivateNoteServiceName],
-			logger,
-			duration[shareservice.PrivateNoteServiceName],
-			tracer,
-			MakePrivateNoteEndpoint),
+		req = request.(requests.AddCollectionNotesRequest)
+		err = svc.AddCollectionNotes(ctx, req.CollectionID, req.NoteIDs)
+		if err != nil {
+			return responses.AddCollectionNotesResponse{
+				Error: err.Error(),
+			}, nil
+		}
 
-		GetNoteEndpoint:    MakeEndpoint(
-			svc,
-			apis[shareservice.GetNoteServiceName],
-			logger,
-			duration[shareservice.GetNoteServiceName],
-			tracer,
-			MakeGetNoteEndpoint),
+		return responses.AddCollectionNotesResponse{
+			Error:    "",
+		}, nil
 	}
+}
 
-	return
+func MakeRemoveCollectionNotesEndpoint(svc shareservice.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		var (
+			req requests.RemoveCollectionNotesRequest
+			span stdopentracing.Span
+		)
+
+		span = stdopentracing.SpanFromContext(ctx)
+		span.SetTag("Endpoint", shareservice.RemoveCollectionNotesServiceName)
+		defer span.Finish()
+
+		req = request.(requests.RemoveCollectionNotesRequest)
+		err = svc.RemoveCollectionNotes(ctx, req.CollectionID, req.NoteIDs)
+		if err != nil {
+			return responses.RemoveCollectionNotesResponse{
+				Error: err.Error(),
+			}, nil
+		}
+
+		return responses.RemoveCollectionNotesResponse{
+			Error:    "",
+		}, nil
+	}
 }
 
-func MakeEndpoint(svc shareservice.Service, api bootapi.API, logger log.Logger, duration metrics.Histogram, tracer stdopentracing.Tracer, makeFN MakeEndpointFunc) endpoint.Endpoint {
-	var ep endpoint.Endpoint
-	{
-		ep = makeFN(svc)
-		// RegisterByEmail is limited to 1000 requests per second with burst of 1 request.
-		// Note, rate is defined as a time interval between requests.
-		ep = ratelimit.NewErroringLimiter(rate.NewLimiter(rate.Every(api.RateLimit.Duration), api.RateLimit.Delta))(ep)
+func MakeReorderCollectionEndpoint(svc shareservice.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		var (
+			req requests.ReorderCollectionRequest
+			span stdopentracing.Span
+		)
 
-		ep = circuitbreaker.Gobreaker(gobreaker.NewCircuitBreaker(api.Breaker.Standardize()))(ep)
+		span = stdopentracing.SpanFromContext(ctx)
+		span.SetTag("Endpoint", shareservice.ReorderCollectionServiceName)
+		defer span.Finish()
 
-		ep = LoggingMiddleware(log.With(logger, api.GetGoKitLoggerKVs()))(ep)
-		ep = InstrumentingMiddleware(duration.With(api.Instrument...))(ep)
+		req = request.(requests.ReorderCollectionRequest)
+		err = svc.ReorderCollection(ctx, req.CollectionID, req.NoteIDs)
+		if err != nil {
+			return responses.ReorderCollectionResponse{
+				Error: err.Error(),
+			}, nil
+		}
 
+		return responses.ReorderCollectionResponse{
+			Error:    "",
+		}, nil
 	}
-	return ep
 }
 
-func MakeShareNoteEndpoint(svc shareservice.Service) endpoint.Endpoint {
+func MakeGetCollectionEndpoint(svc shareservice.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		var (
-			req requests.ShareNoteRequest
-			url, noteid string
+			req requests.GetCollectionRequest
+			name string
+			notes []model.CollectionNote
 			span stdopentracing.Span
 		)
 
 		span = stdopentracing.SpanFromContext(ctx)
-		span.SetTag("Endpoint", shareservice.ShareNoteServiceName)
+		span.SetTag("Endpoint", shareservice.GetCollectionServiceName)
 		defer span.Finish()
 
-		req = request.(requests.ShareNoteRequest)
-		url, noteid, err = svc.ShareNote(ctx, req.Name, req.Content)
+		req = request.(requests.GetCollectionRequest)
+		name, notes, err = svc.GetCollection(ctx, req.CollectionID)
+		if err != nil {
+			return responses.GetCollectionResponse{
+				Error: err.Error(),
+			}, nil
+		}
+
+		return responses.GetCollectionResponse{
+			Name:    name,
+			Notes:    notes,
+			Error:    "",
+		}, nil
+	}
+}
+
+func MakeUploadAttachmentEndpoint(svc shareservice.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		var (
+			req requests.UploadAttachmentRequest
+			attachment model.AttachmentInfo
+			span stdopentracing.Span
+		)
+
+		span = stdopentracing.SpanFromContext(ctx)
+		span.SetTag("Endpoint", shareservice.UploadAttachmentServiceName)
+		defer span.Finish()
 
+		req = request.(requests.UploadAttachmentRequest)
+		attachment, err = svc.UploadAttachment(ctx, req.NoteID, req.Name, req.Content)
 		if err != nil {
-			return responses.ShareNoteResponse{
+			return responses.UploadAttachmentResponse{
 				Error: err.Error(),
 			}, nil
 		}
 
-		return responses.ShareNoteResponse{
-			URL:    url,
-			NoteID: noteid,
+		return responses.UploadAttachmentResponse{
+			Attachment: attachment,
 			Error:    "",
 		}, nil
 	}
 }
 
-func MakeGetNoteEndpoint(svc shareservice.Service) endpoint.Endpoint {
+func MakeDownloadAttachmentEndpoint(svc shareservice.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		var (
-			req requests.GetNoteRequest
-			name, content string
+			req requests.DownloadAttachmentRequest
+			attachment model.AttachmentInfo
+			content io.ReadCloser
 			span stdopentracing.Span
 		)
 
 		span = stdopentracing.SpanFromContext(ctx)
-		span.SetTag("Endpoint", shareservice.GetNoteServiceName)
+		span.SetTag("Endpoint", shareservice.DownloadAttachmentServiceName)
 		defer span.Finish()
 
-		req = request.(requests.GetNoteRequest)
-		name, content, err = svc.GetNote(ctx, req.NoteID)
+		req = request.(requests.DownloadAttachmentRequest)
+		attachment, content, err = svc.DownloadAttachment(ctx, req.NoteID, req.AttachmentID)
 		if err != nil {
-			return responses.GetNoteResponse{
+			return responses.DownloadAttachmentResponse{
 				Error: err.Error(),
 			}, nil
 		}
 
-		return responses.GetNoteResponse{
+		return responses.DownloadAttachmentResponse{
+			Attachment: attachment,
 			Content:    content,
-			Name: name,
 			Error:    "",
 		}, nil
 	}
 }
 
-func MakePrivateNoteEndpoint(svc shareservice.Service) endpoint.Endpoint {
+func MakeAddCommentEndpoint(svc shareservice.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		var (
-			req requests.PrivateNoteRequest
+			req requests.AddCommentRequest
+			comment model.NoteComment
 			span stdopentracing.Span
 		)
 
 		span = stdopentracing.SpanFromContext(ctx)
-		span.SetTag("Endpoint", shareservice.PrivateNoteServiceName)
+		span.SetTag("Endpoint", shareservice.AddCommentServiceName)
 		defer span.Finish()
 
-		req = request.(requests.PrivateNoteRequest)
-		err = svc.PrivateNote(ctx, req.NoteID)
+		req = request.(requests.AddCommentRequest)
+		comment, err = svc.AddComment(ctx, req.NoteID, req.Author, req.Body, req.StartLine, req.EndLine)
 		if err != nil {
-			return responses.PrivateNoteResponse{
+			return responses.AddCommentResponse{
 				Error: err.Error(),
 			}, nil
 		}
 
-		return responses.PrivateNoteResponse{
+		return responses.AddCommentResponse{
+			Comment: comment,
+			Error:    "",
+		}, nil
+	}
+}
+
+func MakeListCommentsEndpoint(svc shareservice.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		var (
+			req requests.ListCommentsRequest
+			comments []model.NoteComment
+			span stdopentracing.Span
+		)
+
+		span = stdopentracing.SpanFromContext(ctx)
+		span.SetTag("Endpoint", shareservice.ListCommentsServiceName)
+		defer span.Finish()
+
+		req = request.(requests.ListCommentsRequest)
+		comments, err = svc.ListComments(ctx, req.NoteID, req.IncludeResolved)
+		if err != nil {
+			return responses.ListCommentsResponse{
+				Error: err.Error(),
+			}, nil
+		}
+
+		return responses.ListCommentsResponse{
+			Comments: comments,
+			Error:    "",
+		}, nil
+	}
+}
+
+func MakeResolveCommentEndpoint(svc shareservice.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		var (
+			req requests.ResolveCommentRequest
+			comment model.NoteComment
+			span stdopentracing.Span
+		)
+
+		span = stdopentracing.SpanFromContext(ctx)
+		span.SetTag("Endpoint", shareservice.ResolveCommentServiceName)
+		defer span.Finish()
+
+		req = request.(requests.ResolveCommentRequest)
+		comment, err = svc.ResolveComment(ctx, req.NoteID, req.CommentID, req.User)
+		if err != nil {
+			return responses.ResolveCommentResponse{
+				Error: err.Error(),
+			}, nil
+		}
+
+		return responses.ResolveCommentResponse{
+			Comment: comment,
+			Error:    "",
+		}, nil
+	}
+}
+
+func MakeGetNoteStatsEndpoint(svc shareservice.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		var (
+			req requests.GetNoteStatsRequest
+			stats model.NoteStats
+			span stdopentracing.Span
+		)
+
+		span = stdopentracing.SpanFromContext(ctx)
+		span.SetTag("Endpoint", shareservice.GetNoteStatsServiceName)
+		defer span.Finish()
+
+		req = request.(requests.GetNoteStatsRequest)
+		stats, err = svc.GetNoteStats(ctx, req.NoteID, req.OwnerToken, req.Days)
+		if err != nil {
+			return responses.GetNoteStatsResponse{
+				Error: err.Error(),
+			}, nil
+		}
+
+		return responses.GetNoteStatsResponse{
+			Stats: stats,
 			Error:    "",
 		}, nil
 	}
-}
\ No newline at end of file
+}
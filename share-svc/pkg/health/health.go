@@ -0,0 +1,189 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrShuttingDown is the result of the checks of a checker shut down.
+var ErrShuttingDown = errors.New("the instance is shutting down")
+
+// Check reports whether a dependency is usable, it must return once ctx is done.
+type Check func(ctx context.Context) error
+
+// Checker runs its checks at an interval and keeps their latest results, it
+// is healthy when all of them passed.
+type Checker struct {
+	interval time.Duration
+	timeout time.Duration
+
+	names []string
+	checks map[string]Check
+
+	mu sync.RWMutex
+	results map[string]error
+	healthy bool
+	watchers []func(healthy bool)
+	started bool
+
+	quit chan struct{}
+	done chan struct{}
+	stop sync.Once
+}
+
+// NewChecker returns a Checker running its checks every interval, each check
+// failing after timeout.
+func NewChecker(interval, timeout time.Duration) *Checker {
+	return &Checker{
+		interval: interval,
+		timeout: timeout,
+		checks: map[string]Check{},
+		results: map[string]error{},
+		quit: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+// Add adds the check named name, it must be called before Start.
+func (c *Checker) Add(name string, check Check) {
+	if _, ok := c.checks[name]; !ok {
+		c.names = append(c.names, name)
+	}
+	c.checks[name] = check
+}
+
+// Watch calls fn with the health of the checker after every run of the checks
+// where it changed, and after the first run.
+func (c *Checker) Watch(fn func(healthy bool)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.watchers = append(c.watchers, fn)
+}
+
+// Start runs the checks once, then every interval until Stop is called.
+func (c *Checker) Start() {
+	c.mu.Lock()
+	c.started = true
+	c.mu.Unlock()
+
+	c.run(true)
+
+	go func() {
+		defer close(c.done)
+
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				c.run(false)
+			case <-c.quit:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops running the checks.
+func (c *Checker) Stop() {
+	c.stop.Do(func() {
+		close(c.quit)
+	})
+
+	c.mu.RLock()
+	started := c.started
+	c.mu.RUnlock()
+	if started {
+		<-c.done
+	}
+}
+
+// Shutdown stops running the checks, and fails them with ErrShuttingDown so
+// the instance stops receiving calls.
+func (c *Checker) Shutdown() {
+	c.Stop()
+
+	c.mu.Lock()
+	for _, name := range c.names {
+		c.results[name] = ErrShuttingDown
+	}
+	notify := c.healthy
+	c.healthy = false
+	watchers := c.watchers
+	c.mu.Unlock()
+
+	if notify {
+		for _, fn := range watchers {
+			fn(false)
+		}
+	}
+}
+
+// Healthy reports whether all the checks passed on their latest run.
+func (c *Checker) Healthy() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.healthy
+}
+
+// Results returns the error of each check on its latest run, nil when it passed.
+func (c *Checker) Results() map[string]error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	results := make(map[string]error, len(c.results))
+	for name, err := range c.results {
+		results[name] = err
+	}
+	return results
+}
+
+// run runs the checks concurrently, and notifies the watchers when the
+// health changed or notify is set.
+func (c *Checker) run(notify bool) {
+	var (
+		results = make(map[string]error, len(c.checks))
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	for _, name := range c.names {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+			defer cancel()
+
+			err := check(ctx)
+			mu.Lock()
+			results[name] = err
+			mu.Unlock()
+		}(name, c.checks[name])
+	}
+	wg.Wait()
+
+	healthy := true
+	for _, err := range results {
+		if err != nil {
+			healthy = false
+			break
+		}
+	}
+
+	c.mu.Lock()
+	notify = notify || healthy != c.healthy
+	c.results = results
+	c.healthy = healthy
+	watchers := c.watchers
+	c.mu.Unlock()
+
+	if notify {
+		for _, fn := range watchers {
+			fn(healthy)
+		}
+	}
+}
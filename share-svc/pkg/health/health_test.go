@@ -0,0 +1,127 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// check is a Check whose result can be changed between runs.
+type check struct {
+	mu  sync.Mutex
+	err error
+}
+
+func (c *check) set(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+}
+
+func (c *check) check(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+func TestChecker(t *testing.T) {
+	var (
+		errDown = errors.New("down")
+		mongo   = &check{}
+		tracer  = &check{}
+		c       = NewChecker(10*time.Millisecond, time.Second)
+		changes = make(chan bool, 16)
+	)
+
+	c.Add("mongo", mongo.check)
+	c.Add("tracer", tracer.check)
+	c.Watch(func(healthy bool) {
+		changes <- healthy
+	})
+
+	expect := func(want bool) {
+		t.Helper()
+
+		select {
+		case healthy := <-changes:
+			if healthy != want || c.Healthy() != want {
+				t.Fatalf("got %v, want %v", healthy, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("no change to %v", want)
+		}
+	}
+
+	// the first run is notified and done by Start
+	c.Start()
+	defer c.Stop()
+	if !c.Healthy() {
+		t.Fatal("not healthy after Start")
+	}
+	expect(true)
+
+	tracer.set(errDown)
+	expect(false)
+	if results := c.Results(); results["tracer"] != errDown || results["mongo"] != nil {
+		t.Fatalf("got %v", results)
+	}
+
+	tracer.set(nil)
+	expect(true)
+
+	// the runs which do not change the health are not notified
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case healthy := <-changes:
+		t.Fatalf("notified %v", healthy)
+	default:
+	}
+
+	c.Shutdown()
+	expect(false)
+	for name, err := range c.Results() {
+		if err != ErrShuttingDown {
+			t.Fatalf("%s: got %v", name, err)
+		}
+	}
+
+	// the checks are not run anymore
+	time.Sleep(50 * time.Millisecond)
+	if c.Healthy() {
+		t.Fatal("healthy after Shutdown")
+	}
+}
+
+func TestCheckerTimeout(t *testing.T) {
+	c := NewChecker(time.Hour, 10*time.Millisecond)
+	c.Add("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	c.Start()
+	defer c.Stop()
+
+	if c.Healthy() || c.Results()["slow"] != context.DeadlineExceeded {
+		t.Fatalf("got %v", c.Results())
+	}
+}
+
+func TestCheckerStopWithoutStart(t *testing.T) {
+	c := NewChecker(time.Hour, time.Second)
+
+	done := make(chan struct{})
+	go func() {
+		c.Stop()
+		c.Shutdown()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Stop blocks")
+	}
+}
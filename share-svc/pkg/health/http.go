@@ -0,0 +1,69 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// The paths the probes are served on.
+const (
+	LivenessPath = "/healthz"
+	ReadinessPath = "/readyz"
+)
+
+const (
+	StatusOK = "ok"
+	StatusFailing = "failing"
+)
+
+// Report is the JSON body of the liveness and readiness probes.
+type Report struct {
+	Status string `json:"status"`
+	Checks map[string]CheckReport `json:"checks,omitempty"`
+}
+
+type CheckReport struct {
+	Status string `json:"status"`
+	Error string `json:"error,omitempty"`
+}
+
+// LivenessHandler returns an HTTP handler answering as long as the process
+// serves HTTP, the dependencies are not checked.
+func LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, http.StatusOK, Report{Status: StatusOK})
+	})
+}
+
+// ReadinessHandler returns an HTTP handler reporting the latest results of
+// the checks of c, it answers with 503 Service Unavailable unless c is
+// healthy. The checks are not run by the handler.
+func ReadinessHandler(c *Checker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			code = http.StatusOK
+			report = Report{Status: StatusOK, Checks: map[string]CheckReport{}}
+		)
+
+		if !c.Healthy() {
+			code = http.StatusServiceUnavailable
+			report.Status = StatusFailing
+		}
+
+		for name, err := range c.Results() {
+			if err != nil {
+				report.Checks[name] = CheckReport{Status: StatusFailing, Error: err.Error()}
+				continue
+			}
+			report.Checks[name] = CheckReport{Status: StatusOK}
+		}
+		writeReport(w, code, report)
+	})
+}
+
+func writeReport(w http.ResponseWriter, code int, report Report) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(report)
+}
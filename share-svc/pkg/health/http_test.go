@@ -0,0 +1,95 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLivenessHandler(t *testing.T) {
+	w := httptest.NewRecorder()
+	LivenessHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, LivenessPath, nil))
+
+	if got := decodeReport(t, w, http.StatusOK); !reflect.DeepEqual(got, Report{Status: StatusOK}) {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+func TestReadinessHandler(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		checks map[string]error
+		code   int
+		want   Report
+	}{
+		{
+			"no check", nil,
+			http.StatusOK, Report{Status: StatusOK},
+		},
+		{
+			"passing", map[string]error{"mongo": nil},
+			http.StatusOK, Report{Status: StatusOK, Checks: map[string]CheckReport{"mongo": {Status: StatusOK}}},
+		},
+		{
+			"failing", map[string]error{"mongo": nil, "tracer": errors.New("down")},
+			http.StatusServiceUnavailable, Report{Status: StatusFailing, Checks: map[string]CheckReport{
+				"mongo":  {Status: StatusOK},
+				"tracer": {Status: StatusFailing, Error: "down"},
+			}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewChecker(time.Hour, time.Second)
+			for name, err := range tc.checks {
+				err := err
+				c.Add(name, func(context.Context) error { return err })
+			}
+			c.Start()
+			defer c.Stop()
+
+			w := httptest.NewRecorder()
+			ReadinessHandler(c).ServeHTTP(w, httptest.NewRequest(http.MethodGet, ReadinessPath, nil))
+
+			if got := decodeReport(t, w, tc.code); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReadinessHandlerShutdown(t *testing.T) {
+	c := NewChecker(time.Hour, time.Second)
+	c.Add("mongo", func(context.Context) error { return nil })
+	c.Start()
+	c.Shutdown()
+
+	w := httptest.NewRecorder()
+	ReadinessHandler(c).ServeHTTP(w, httptest.NewRequest(http.MethodGet, ReadinessPath, nil))
+
+	want := Report{Status: StatusFailing, Checks: map[string]CheckReport{
+		"mongo": {Status: StatusFailing, Error: ErrShuttingDown.Error()},
+	}}
+	if got := decodeReport(t, w, http.StatusServiceUnavailable); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func decodeReport(t *testing.T, w *httptest.ResponseRecorder, code int) (report Report) {
+	t.Helper()
+
+	if w.Code != code {
+		t.Fatalf("got %d, want %d", w.Code, code)
+	}
+	if w.Header().Get("Cache-Control") != "no-store" {
+		t.Fatalf("got Cache-Control %q", w.Header().Get("Cache-Control"))
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	return report
+}
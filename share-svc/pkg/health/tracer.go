@@ -0,0 +1,70 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"github.com/uber/jaeger-lib/metrics"
+	"sync"
+	"sync/atomic"
+)
+
+// ReporterState counts the spans reported by a Jaeger tracer, it is passed
+// to the tracer as its metrics factory, with jaegerconfig.Metrics.
+type ReporterState struct {
+	metrics.Factory
+
+	reported int64
+	failed int64
+
+	mu sync.Mutex
+	lastReported int64
+	lastFailed int64
+}
+
+func NewReporterState() *ReporterState {
+	return &ReporterState{Factory: metrics.NullFactory}
+}
+
+func (s *ReporterState) Namespace(metrics.NSOptions) metrics.Factory {
+	return s
+}
+
+func (s *ReporterState) Counter(opts metrics.Options) metrics.Counter {
+	if opts.Name != "reporter_spans" {
+		return metrics.NullCounter
+	}
+
+	switch opts.Tags["result"] {
+	case "ok":
+		return counter{&s.reported}
+	case "err", "dropped":
+		return counter{&s.failed}
+	default:
+		return metrics.NullCounter
+	}
+}
+
+// Check fails when spans were lost since the previous check and none could
+// be reported, the tracer does not report anything otherwise.
+func (s *ReporterState) Check(context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reported, failed := atomic.LoadInt64(&s.reported), atomic.LoadInt64(&s.failed)
+	lost := failed - s.lastFailed
+	ok := reported > s.lastReported
+	s.lastReported, s.lastFailed = reported, failed
+
+	if lost > 0 && !ok {
+		return fmt.Errorf("%d spans could not be reported", lost)
+	}
+	return nil
+}
+
+type counter struct {
+	n *int64
+}
+
+func (c counter) Inc(delta int64) {
+	atomic.AddInt64(c.n, delta)
+}
@@ -0,0 +1,44 @@
+package health
+
+import (
+	"context"
+	"github.com/uber/jaeger-lib/metrics"
+	"testing"
+)
+
+func TestReporterState(t *testing.T) {
+	var (
+		s        = NewReporterState()
+		factory  = s.Namespace(metrics.NSOptions{Name: "jaeger"})
+		reported = factory.Counter(metrics.Options{Name: "reporter_spans", Tags: map[string]string{"result": "ok"}})
+		failed   = factory.Counter(metrics.Options{Name: "reporter_spans", Tags: map[string]string{"result": "err"}})
+		dropped  = factory.Counter(metrics.Options{Name: "reporter_spans", Tags: map[string]string{"result": "dropped"}})
+		other    = factory.Counter(metrics.Options{Name: "traces", Tags: map[string]string{"result": "err"}})
+	)
+
+	for _, tc := range []struct {
+		name                     string
+		reported, failed, others int64
+		fails                    bool
+	}{
+		{"idle", 0, 0, 0, false},
+		{"reported", 2, 0, 0, false},
+		{"some lost", 1, 1, 0, false},
+		{"all lost", 0, 2, 0, true},
+		{"recovered", 1, 0, 0, false},
+		{"other counters", 0, 0, 5, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			reported.Inc(tc.reported)
+			if tc.failed > 0 {
+				failed.Inc(tc.failed - 1)
+				dropped.Inc(1)
+			}
+			other.Inc(tc.others)
+
+			if err := s.Check(context.Background()); (err != nil) != tc.fails {
+				t.Fatalf("got %v", err)
+			}
+		})
+	}
+}
@@ -1,13 +1,17 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"github.com/al8n/shareable-notes/share-svc/config"
 	sharepb "github.com/al8n/shareable-notes/share-svc/pb"
 	shareendpoint "github.com/al8n/shareable-notes/share-svc/pkg/endpoint"
+	"github.com/al8n/shareable-notes/share-svc/pkg/health"
 	shareservice "github.com/al8n/shareable-notes/share-svc/pkg/service"
 	sharetransport "github.com/al8n/shareable-notes/share-svc/pkg/transport"
+	sharethrift "github.com/al8n/shareable-notes/share-svc/thrift/gen-go/share"
 	bootapi "github.com/al8n/micro-boot/api"
+	"github.com/apache/thrift/lib/go/thrift"
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/metrics"
 	"github.com/go-kit/kit/metrics/prometheus"
@@ -15,12 +19,19 @@ import (
 	kitgrpc "github.com/go-kit/kit/transport/grpc"
 	"github.com/gorilla/mux"
 	"github.com/hashicorp/consul/api"
+	"github.com/nats-io/nats.go"
 	stdopentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	jaeger "github.com/uber/jaeger-client-go"
 	jaegerconfig "github.com/uber/jaeger-client-go/config"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 	"io"
 	"net"
 	"net/http"
@@ -42,9 +53,11 @@ func GetServer() *Server {
 
 type Server struct {
 	httpListener net.Listener
+	httpServer *http.Server
 	httpConsulRegister *consulsd.Registrar
 
 	httpsListener net.Listener
+	httpsServer *http.Server
 	httpsConsulRegister *consulsd.Registrar
 	router *mux.Router
 
@@ -52,6 +65,29 @@ type Server struct {
 	grpcServer  *grpc.Server
 	grpcListener net.Listener
 	grpcConsulRegister *consulsd.Registrar
+	healthServer *grpchealth.Server
+
+	// restConn is the connection of the REST routes to the gRPC server.
+	restConn *grpc.ClientConn
+
+	natsConn *nats.Conn
+	// natsClosed is closed once the NATS connection is drained.
+	natsClosed chan struct{}
+
+	thriftServer *thrift.TSimpleServer
+
+	// shutdown is cancelled first when the server stops, it ends the watch
+	// and edit streams served by the transports, which would never drain.
+	shutdown context.Context
+	stopStreams context.CancelFunc
+
+	// service is closed once the transports are drained.
+	service shareservice.Service
+
+	// checker checks the dependencies of the service, with a MongoDB client
+	// of its own so the checks do not queue behind the calls.
+	checker *health.Checker
+	mongoClient *mongo.Client
 
 	tracerCloser io.Closer
 
@@ -74,6 +110,7 @@ func (s *Server) Serve() (err error) {
 	var (
 		tracer stdopentracing.Tracer
 		jaegerCfg *jaegerconfig.Configuration
+		reporter = health.NewReporterState()
 	)
 	{
 		jaegerCfg, err = jaegerconfig.FromEnv()
@@ -91,7 +128,10 @@ func (s *Server) Serve() (err error) {
 		jaegerCfg.ServiceName = cfg.Service.Name
 
 
-		tracer, s.tracerCloser, err = jaegerCfg.NewTracer(jaegerconfig.Logger(jaeger.StdLogger))
+		tracer, s.tracerCloser, err = jaegerCfg.NewTracer(
+			jaegerconfig.Logger(jaeger.StdLogger),
+			jaegerconfig.Metrics(reporter),
+		)
 		if err != nil {
 			return err
 		}
@@ -127,21 +167,28 @@ func (s *Server) Serve() (err error) {
 		}
 	}
 
+	s.shutdown, s.stopStreams = context.WithCancel(context.Background())
+
 	var (
-		service shareservice.Service
 		endpoints *shareendpoint.Set
 		httpAddr  = ":" + cfg.HTTP.Port
 		httpsAddr  = ":" + cfg.HTTPS.Port
 		grpcAddr = ":" + cfg.GRPC.Port
 	)
 	{
-		service, err = shareservice.New(logger, ctrs, tracer)
+		err = s.checkHealth(logger, reporter)
+		if err != nil {
+			logger.Log("during", "Health", "err", err)
+			return err
+		}
+
+		s.service, err = shareservice.New(logger, ctrs, tracer)
 		if err != nil {
 			logger.Log("err", err)
 			return err
 		}
 
-		endpoints, err = shareendpoint.New(service, logger, duration, tracer)
+		endpoints, err = shareendpoint.New(s.service, logger, duration, tracer)
 		if err != nil {
 			logger.Log("err", err)
 			return err
@@ -149,7 +196,18 @@ func (s *Server) Serve() (err error) {
 
 		if cfg.HTTP.Runnable || cfg.HTTPS.Runnable {
 			s.router = sharetransport.NewHTTPHandler(*endpoints, tracer, logger, cfg.Service.APIs)
+			s.router.Handle(cfg.Service.JSONRPC.Path, sharetransport.NewJSONRPCHandler(*endpoints, tracer, logger, cfg.Service.JSONRPC.MaxBatch))
 			s.router.Handle(cfg.Prom.Path, promhttp.Handler())
+			s.router.Methods(http.MethodGet).Path(health.LivenessPath).Handler(health.LivenessHandler())
+			s.router.Methods(http.MethodGet).Path(health.ReadinessPath).Handler(health.ReadinessHandler(s.checker))
+
+			if cfg.GRPC.Runnable {
+				err = s.serveREST(grpcAddr)
+				if err != nil {
+					logger.Log("transport", "REST", "during", "Register", "err", err)
+					return err
+				}
+			}
 		} else {
 			r := mux.NewRouter()
 			r.Handle(cfg.Prom.Path, promhttp.Handler())
@@ -171,69 +229,357 @@ func (s *Server) Serve() (err error) {
 		}
 
 		if cfg.GRPC.Runnable {
-			err = s.serveRPC(grpcAddr, *endpoints, logger, tracer, cfg.Service.APIs)
+			err = s.serveRPC(grpcAddr, *endpoints, logger, tracer, cfg.Service.APIs, cfg.Service.Deadlines)
+			if err != nil {
+				return err
+			}
+		}
+
+		if cfg.Service.NATS.URL != "" {
+			err = s.serveNATS(*endpoints, logger, tracer)
+			if err != nil {
+				return err
+			}
+		}
+
+		if cfg.Service.Thrift.Addr != "" {
+			err = s.serveThrift(*endpoints, logger, tracer)
 			if err != nil {
 				return err
 			}
 		}
 	}
 
+	s.checker.Start()
 	s.wg.Wait()
 	return
 }
 
+// Close shuts the server down in order: the instance is deregistered from
+// Consul and reported unhealthy first, then the edit and watch streams are
+// ended, the calls in flight are drained within the shutdown timeout, the
+// gRPC ones within a timeout of their own, and last the service, MongoDB and
+// the tracer are closed.
 func (s *Server) Close() (err error) {
 	var cfg = config.GetConfig()
 
-	if cfg.HTTP.Runnable {
-		s.httpConsulRegister.Deregister()
-		s.logger.Log("transport", "HTTP", "op", "Close", "error", s.httpListener.Close())
+	for _, register := range []*consulsd.Registrar{s.httpConsulRegister, s.httpsConsulRegister, s.grpcConsulRegister} {
+		if register != nil {
+			register.Deregister()
+		}
+	}
+
+	if s.checker != nil {
+		s.checker.Shutdown()
 	}
 
-	if cfg.HTTPS.Runnable {
-		s.httpsConsulRegister.Deregister()
-		s.logger.Log("transport", "gRPC", "op", "Close", "error", s.httpsListener.Close())
+	if s.healthServer != nil {
+		s.healthServer.Shutdown()
 	}
 
-	if cfg.GRPC.Runnable {
-		s.grpcConsulRegister.Deregister()
-		s.logger.Log("transport", "gRPC", "op", "Close", "error", s.grpcListener.Close())
+	// the streams never drain, the edit sessions are told the service shuts
+	// down and their content is saved before the watch streams are ended
+	if s.service != nil {
+		s.logger.Log("op", "CloseStreams", "service", cfg.Service.Name, "error", shareservice.CloseStreams(s.service))
 	}
 
-	s.tracerCloser.Close()
+	if s.stopStreams != nil {
+		s.stopStreams()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Service.Shutdown.Timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for transport, srv := range map[string]*http.Server{"HTTP": s.httpServer, "HTTPS": s.httpsServer} {
+		if srv == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(transport string, srv *http.Server) {
+			defer wg.Done()
+
+			err := srv.Shutdown(ctx)
+			if err != nil {
+				srv.Close()
+			}
+			s.logger.Log("transport", transport, "op", "Shutdown", "error", err)
+		}(transport, srv)
+	}
+
+	if s.natsConn != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := s.natsConn.Drain()
+			if err == nil {
+				select {
+				case <-s.natsClosed:
+				case <-ctx.Done():
+					err = ctx.Err()
+					s.natsConn.Close()
+				}
+			}
+			s.logger.Log("transport", "NATS", "op", "Shutdown", "error", err)
+		}()
+	}
+
+	if s.thriftServer != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// the connections are closed by their clients, they are given
+			// up on past the timeout
+			stopped := make(chan struct{})
+			go func() {
+				s.thriftServer.Stop()
+				close(stopped)
+			}()
+
+			var err error
+			select {
+			case <-stopped:
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+			s.logger.Log("transport", "Thrift", "op", "Shutdown", "error", err)
+		}()
+	}
+	wg.Wait()
+
+	// the REST routes are drained with the HTTP servers
+	if s.restConn != nil {
+		s.restConn.Close()
+	}
+
+	// the gRPC calls are given a budget of their own, they are not cut by the
+	// time the other transports took
+	if s.grpcServer != nil {
+		grpcCtx, grpcCancel := context.WithTimeout(context.Background(), cfg.Service.Shutdown.GRPCTimeout)
+		defer grpcCancel()
+
+		stopped := make(chan struct{})
+		go func() {
+			s.grpcServer.GracefulStop()
+			close(stopped)
+		}()
+
+		var err error
+		select {
+		case <-stopped:
+		case <-grpcCtx.Done():
+			err = grpcCtx.Err()
+			s.grpcServer.Stop()
+		}
+		s.logger.Log("transport", "gRPC", "op", "Shutdown", "error", err)
+	}
+
+	if s.service != nil {
+		s.logger.Log("op", "Shutdown", "service", cfg.Service.Name, "error", shareservice.Close(s.service))
+	}
+
+	if s.mongoClient != nil {
+		s.mongoClient.Disconnect(context.Background())
+	}
+
+	if s.tracerCloser != nil {
+		s.tracerCloser.Close()
+	}
 
 	return nil
 }
 
-func (s *Server) serveRPC(address string,  endpoints shareendpoint.Set, logger log.Logger, tracer stdopentracing.Tracer, apis bootapi.APIs) (err error) {
+func (s *Server) serveRPC(address string,  endpoints shareendpoint.Set, logger log.Logger, tracer stdopentracing.Tracer, apis bootapi.APIs, deadlines config.Deadlines) (err error) {
 	s.grpcListener, err = net.Listen("tcp", address)
 	if err != nil {
 		logger.Log("transport", "gRPC", "during", "Listen", "err", err)
 		return err
 	}
 
-	s.grpcServer = grpc.NewServer(grpc.UnaryInterceptor(kitgrpc.Interceptor))
+	s.grpcServer = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			sharetransport.RequestIDUnaryInterceptor(),
+			sharetransport.RecoveryUnaryInterceptor(logger),
+			sharetransport.DeadlineUnaryInterceptor(deadlines),
+			kitgrpc.Interceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			sharetransport.ShutdownStreamInterceptor(s.shutdown),
+			sharetransport.RequestIDStreamInterceptor(),
+			sharetransport.RecoveryStreamInterceptor(logger),
+			sharetransport.DeadlineStreamInterceptor(deadlines),
+		),
+	)
 
 	s.shareServer = sharetransport.NewGRPCServer(endpoints, tracer, logger, apis)
 
 	sharepb.RegisterShareServer(s.grpcServer, s.shareServer)
+	var services = s.grpcServer.GetServiceInfo()
+
+	// the services are not served until the first checks pass
+	s.healthServer = grpchealth.NewServer()
+	s.healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(s.grpcServer, s.healthServer)
+	reflection.Register(s.grpcServer)
+
+	s.checker.Watch(func(healthy bool) {
+		status := healthpb.HealthCheckResponse_NOT_SERVING
+		if healthy {
+			status = healthpb.HealthCheckResponse_SERVING
+		}
+
+		s.healthServer.SetServingStatus("", status)
+		for name := range services {
+			s.healthServer.SetServingStatus(name, status)
+		}
+	})
 
 	s.grpcConsulRegister, err = NewConsulGRPCRegister(logger)
 	if err != nil {
 		return err
 	}
 
+	s.wg.Add(1)
 	go func() {
-		s.wg.Add(1)
+		defer s.wg.Done()
 		logger.Log("transport", "gRPC", "addr", address)
 		s.grpcConsulRegister.Register()
 		s.grpcServer.Serve(s.grpcListener)
-		s.wg.Done()
 	}()
 
 	return nil
 }
 
+// checkHealth prepares the checks of the dependencies of the service, they
+// are started once the transports are served.
+func (s *Server) checkHealth(logger log.Logger, reporter *health.ReporterState) (err error)  {
+	var (
+		cfg = config.GetConfig()
+		opt *options.ClientOptions
+	)
+
+	opt, err = cfg.Mongo.Standardize()
+	if err != nil {
+		return err
+	}
+
+	s.mongoClient, err = mongo.Connect(context.Background(), opt)
+	if err != nil {
+		return err
+	}
+
+	s.checker = health.NewChecker(cfg.Service.Health.Interval, cfg.Service.Health.Timeout)
+	s.checker.Add("mongo", func(ctx context.Context) error {
+		return s.mongoClient.Ping(ctx, readpref.Primary())
+	})
+	s.checker.Add("tracer", reporter.Check)
+
+	s.checker.Watch(func(healthy bool) {
+		var kvs = []interface{}{"op", "Health", "healthy", healthy}
+		for name, err := range s.checker.Results() {
+			if err != nil {
+				kvs = append(kvs, name, err)
+			}
+		}
+		logger.Log(kvs...)
+	})
+	return nil
+}
+
+// serveREST routes the REST mapping of the RPCs, under /v1, to the gRPC
+// server listening on address.
+func (s *Server) serveREST(address string) (err error)  {
+	var handler http.Handler
+
+	s.restConn, err = grpc.Dial("localhost" + address, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+
+	handler, err = sharetransport.NewRESTHandler(sharepb.NewShareClient(s.restConn))
+	if err != nil {
+		return err
+	}
+
+	s.router.PathPrefix("/v1/").Handler(handler)
+	return nil
+}
+
+// serveNATS subscribes the NATS transport, the subscriptions are served by
+// the goroutines of the connection.
+func (s *Server) serveNATS(endpoints shareendpoint.Set, logger log.Logger, tracer stdopentracing.Tracer) (err error)  {
+	var cfg = config.GetConfig().Service.NATS
+
+	s.natsClosed = make(chan struct{})
+	s.natsConn, err = nats.Connect(
+		cfg.URL,
+		nats.Name(config.GetConfig().Service.Name),
+		nats.DrainTimeout(config.GetConfig().Service.Shutdown.Timeout),
+		nats.ClosedHandler(func(*nats.Conn) {
+			close(s.natsClosed)
+		}),
+	)
+	if err != nil {
+		logger.Log("transport", "NATS", "during", "Connect", "err", err)
+		return err
+	}
+
+	_, err = sharetransport.NewNATSServer(endpoints, tracer, logger).Subscribe(s.natsConn, cfg.Queue, cfg.Subjects)
+	if err != nil {
+		logger.Log("transport", "NATS", "during", "Subscribe", "err", err)
+		s.natsConn.Close()
+		return err
+	}
+
+	logger.Log("transport", "NATS", "addr", s.natsConn.ConnectedUrl())
+	return nil
+}
+
+// serveThrift serves the Thrift transport, each connection is served by a
+// goroutine of its own.
+func (s *Server) serveThrift(endpoints shareendpoint.Set, logger log.Logger, tracer stdopentracing.Tracer) (err error)  {
+	var cfg = config.GetConfig().Service.Thrift
+
+	socket, err := thrift.NewTServerSocket(cfg.Addr)
+	if err != nil {
+		logger.Log("transport", "Thrift", "during", "Listen", "err", err)
+		return err
+	}
+
+	protocolFactory, transportFactory := sharetransport.NewThriftFactories(cfg)
+	s.thriftServer = thrift.NewTSimpleServer4(
+		sharethrift.NewShareProcessor(sharetransport.NewThriftServer(endpoints, tracer, logger)),
+		socket,
+		transportFactory,
+		protocolFactory,
+	)
+
+	if err = s.thriftServer.Listen(); err != nil {
+		logger.Log("transport", "Thrift", "during", "Listen", "err", err)
+		s.thriftServer = nil
+		return err
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		logger.Log("transport", "Thrift", "addr", socket.Addr())
+		if err := s.thriftServer.AcceptLoop(); err != nil {
+			logger.Log("transport", "Thrift", "during", "Serve", "err", err)
+		}
+	}()
+	return nil
+}
+
+// baseContext carries the shutdown context to the HTTP requests, see
+// sharetransport.WithShutdown.
+func (s *Server) baseContext(net.Listener) context.Context {
+	return sharetransport.WithShutdown(context.Background(), s.shutdown)
+}
+
 func (s *Server) serveHTTP(address string, logger log.Logger) (err error)  {
 	s.httpListener, err = net.Listen("tcp", address)
 	if err != nil {
@@ -246,12 +592,16 @@ func (s *Server) serveHTTP(address string, logger log.Logger) (err error)  {
 		return err
 	}
 
+	s.httpServer = &http.Server{Handler: s.router, BaseContext: s.baseContext}
+
+	s.wg.Add(1)
 	go func() {
-		s.wg.Add(1)
+		defer s.wg.Done()
 		logger.Log("transport", "HTTP", "addr", address)
 		s.httpConsulRegister.Register()
-		http.Serve(s.httpListener, s.router)
-		s.wg.Done()
+		if err := s.httpServer.Serve(s.httpListener); err != http.ErrServerClosed {
+			logger.Log("transport", "HTTP", "during", "Serve", "err", err)
+		}
 	}()
 
 	return nil
@@ -269,12 +619,16 @@ func (s *Server) serveHTTPS(address string, logger log.Logger, cert, key string)
 		return err
 	}
 
+	s.httpsServer = &http.Server{Handler: s.router, BaseContext: s.baseContext}
+
+	s.wg.Add(1)
 	go func() {
-		s.wg.Add(1)
+		defer s.wg.Done()
 		logger.Log("transport", "HTTPS", "addr", address)
-		s.httpsConsulRegister.Deregister()
-		http.ServeTLS(s.httpsListener, s.router, cert, key)
-		s.wg.Done()
+		s.httpsConsulRegister.Register()
+		if err := s.httpsServer.ServeTLS(s.httpsListener, cert, key); err != http.ErrServerClosed {
+			logger.Log("transport", "HTTPS", "during", "Serve", "err", err)
+		}
 	}()
 
 	return nil
@@ -304,6 +658,17 @@ func NewConsulGRPCRegister(logger log.Logger) (register *consulsd.Registrar, err
 	reg.Name = cfg.GRPC.Name
 	reg.Port = cfg.GRPC.GetIntPort()
 
+	// Consul asks the gRPC health service for the share service
+	address := reg.Address
+	if address == "" {
+		address = cfg.Host
+	}
+	reg.Check = &api.AgentServiceCheck{
+		GRPC: fmt.Sprintf("%v:%v/%v", address, cfg.GRPC.Port, sharepb.ShareServiceName),
+		Interval: cfg.Service.Health.Interval.String(),
+		Timeout: cfg.Service.Health.Timeout.String(),
+	}
+
 	return consulsd.NewRegistrar(client, reg, logger), nil
 }
 
@@ -330,6 +695,28 @@ func NewConsulHTTPRegister(logger log.Logger) (register *consulsd.Registrar, err
 	reg.Name = cfg.HTTP.Name
 	reg.Port = cfg.HTTP.GetIntPort()
 
+	// Consul probes the instance as the orchestrator does
+	address := reg.Address
+	if address == "" {
+		address = cfg.Host
+	}
+	reg.Checks = api.AgentServiceChecks{
+		{
+			Name: "liveness",
+			HTTP: fmt.Sprintf("http://%v:%v%v", address, cfg.HTTP.Port, health.LivenessPath),
+			Method: http.MethodGet,
+			Interval: cfg.Service.Health.Interval.String(),
+			Timeout: cfg.Service.Health.Timeout.String(),
+		},
+		{
+			Name: "readiness",
+			HTTP: fmt.Sprintf("http://%v:%v%v", address, cfg.HTTP.Port, health.ReadinessPath),
+			Method: http.MethodGet,
+			Interval: cfg.Service.Health.Interval.String(),
+			Timeout: cfg.Service.Health.Timeout.String(),
+		},
+	}
+
 	return consulsd.NewRegistrar(client, reg, logger), nil
 }
 
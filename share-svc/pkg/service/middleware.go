@@ -2,10 +2,12 @@ package service
 
 import (
 	"context"
+	"github.com/al8n/shareable-notes/share-svc/model"
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/metrics"
 	stdopentracing "github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
+	"io"
 )
 
 type Middleware func(Service) Service
@@ -22,6 +24,10 @@ func LoggingMiddleware(logger log.Logger) Middleware {
 	}
 }
 
+func (mw loggingMiddleware) unwrap() Service {
+	return mw.next
+}
+
 func (mw loggingMiddleware) PrivateNote(ctx context.Context, id string) (err error) {
 	defer func() {
 		mw.logger.Log("method", "PrivateNote", "id", id, "err", err)
@@ -29,18 +35,165 @@ func (mw loggingMiddleware) PrivateNote(ctx context.Context, id string) (err err
 	return mw.next.PrivateNote(ctx, id)
 }
 
-func (mw loggingMiddleware) ShareNote(ctx context.Context, name, content string) (url, shareID string, err error)  {
+func (mw loggingMiddleware) ShareNote(ctx context.Context, name, content string) (url, shareID, ownerToken string, err error)  {
 	defer func() {
 		mw.logger.Log("method", "ShareNote", "name", name, "err", err)
 	}()
 	return mw.next.ShareNote(ctx, name, content)
 }
 
-func (mw loggingMiddleware) GetNote(ctx context.Context, id string) (name, content string, err error) {
+func (mw loggingMiddleware) GetNote(ctx context.Context, id string, cond model.NoteCondition) (name, content string, version model.NoteVersion, err error) {
+	defer func() {
+		mw.logger.Log("method", "GetNote", "id", id, "etag", version.ETag, "err", err)
+	}()
+	return mw.next.GetNote(ctx, id, cond)
+}
+
+func (mw loggingMiddleware) ShareNoteStream(ctx context.Context, name string, content io.Reader) (url, shareID, ownerToken string, err error)  {
+	defer func() {
+		mw.logger.Log("method", "ShareNoteStream", "name", name, "err", err)
+	}()
+	return mw.next.ShareNoteStream(ctx, name, content)
+}
+
+func (mw loggingMiddleware) GetNoteStream(ctx context.Context, id string) (name string, content io.ReadCloser, err error) {
+	defer func() {
+		mw.logger.Log("method", "GetNoteStream", "id", id, "err", err)
+	}()
+	return mw.next.GetNoteStream(ctx, id)
+}
+
+func (mw loggingMiddleware) WatchNote(ctx context.Context, id string) (events <-chan model.NoteEvent, err error) {
 	defer func() {
-		mw.logger.Log("method", "GetNote", "id", id, "err", err)
+		mw.logger.Log("method", "WatchNote", "id", id, "err", err)
 	}()
-	return mw.next.GetNote(ctx, id)
+	return mw.next.WatchNote(ctx, id)
+}
+
+func (mw loggingMiddleware) WatchNotes(ctx context.Context) (events <-chan model.NoteEvent, err error) {
+	defer func() {
+		mw.logger.Log("method", "WatchNotes", "err", err)
+	}()
+	return mw.next.WatchNotes(ctx)
+}
+
+func (mw loggingMiddleware) EditNote(ctx context.Context, id, user string) (session model.EditSession, err error) {
+	defer func() {
+		mw.logger.Log("method", "EditNote", "id", id, "user", user, "err", err)
+	}()
+	return mw.next.EditNote(ctx, id, user)
+}
+
+func (mw loggingMiddleware) SyncNotes(ctx context.Context, clientID string, since int64, noteIDs []string, changes []model.NoteChange) (result model.SyncResult, err error) {
+	defer func() {
+		mw.logger.Log("method", "SyncNotes", "client", clientID, "since", since, "changes", len(changes), "conflicts", len(result.Conflicts), "err", err)
+	}()
+	return mw.next.SyncNotes(ctx, clientID, since, noteIDs, changes)
+}
+
+func (mw loggingMiddleware) ForkNote(ctx context.Context, id, owner string) (url, shareID, ownerToken string, err error) {
+	defer func() {
+		mw.logger.Log("method", "ForkNote", "id", id, "owner", owner, "fork", shareID, "err", err)
+	}()
+	return mw.next.ForkNote(ctx, id, owner)
+}
+
+func (mw loggingMiddleware) ListForks(ctx context.Context, id string) (forks []model.NoteFork, err error) {
+	defer func() {
+		mw.logger.Log("method", "ListForks", "id", id, "forks", len(forks), "err", err)
+	}()
+	return mw.next.ListForks(ctx, id)
+}
+
+func (mw loggingMiddleware) MarkTemplate(ctx context.Context, id string, template bool) (variables []model.TemplateVariable, err error) {
+	defer func() {
+		mw.logger.Log("method", "MarkTemplate", "id", id, "template", template, "variables", len(variables), "err", err)
+	}()
+	return mw.next.MarkTemplate(ctx, id, template)
+}
+
+func (mw loggingMiddleware) InstantiateTemplate(ctx context.Context, id string, vars map[string]string) (url, shareID, ownerToken string, err error) {
+	defer func() {
+		mw.logger.Log("method", "InstantiateTemplate", "id", id, "vars", len(vars), "note", shareID, "err", err)
+	}()
+	return mw.next.InstantiateTemplate(ctx, id, vars)
+}
+
+func (mw loggingMiddleware) CreateCollection(ctx context.Context, name string, noteIDs []string) (url, collectionID string, err error) {
+	defer func() {
+		mw.logger.Log("method", "CreateCollection", "name", name, "notes", len(noteIDs), "collection", collectionID, "err", err)
+	}()
+	return mw.next.CreateCollection(ctx, name, noteIDs)
+}
+
+func (mw loggingMiddleware) AddCollectionNotes(ctx context.Context, id string, noteIDs []string) (err error) {
+	defer func() {
+		mw.logger.Log("method", "AddCollectionNotes", "id", id, "notes", len(noteIDs), "err", err)
+	}()
+	return mw.next.AddCollectionNotes(ctx, id, noteIDs)
+}
+
+func (mw loggingMiddleware) RemoveCollectionNotes(ctx context.Context, id string, noteIDs []string) (err error) {
+	defer func() {
+		mw.logger.Log("method", "RemoveCollectionNotes", "id", id, "notes", len(noteIDs), "err", err)
+	}()
+	return mw.next.RemoveCollectionNotes(ctx, id, noteIDs)
+}
+
+func (mw loggingMiddleware) ReorderCollection(ctx context.Context, id string, noteIDs []string) (err error) {
+	defer func() {
+		mw.logger.Log("method", "ReorderCollection", "id", id, "notes", len(noteIDs), "err", err)
+	}()
+	return mw.next.ReorderCollection(ctx, id, noteIDs)
+}
+
+func (mw loggingMiddleware) GetCollection(ctx context.Context, id string) (name string, notes []model.CollectionNote, err error) {
+	defer func() {
+		mw.logger.Log("method", "GetCollection", "id", id, "notes", len(notes), "err", err)
+	}()
+	return mw.next.GetCollection(ctx, id)
+}
+
+func (mw loggingMiddleware) UploadAttachment(ctx context.Context, noteID, name string, content io.Reader) (attachment model.AttachmentInfo, err error) {
+	defer func() {
+		mw.logger.Log("method", "UploadAttachment", "note_id", noteID, "name", name, "size", attachment.Size, "err", err)
+	}()
+	return mw.next.UploadAttachment(ctx, noteID, name, content)
+}
+
+func (mw loggingMiddleware) DownloadAttachment(ctx context.Context, noteID, attachmentID string) (attachment model.AttachmentInfo, content io.ReadCloser, err error) {
+	defer func() {
+		mw.logger.Log("method", "DownloadAttachment", "note_id", noteID, "id", attachmentID, "err", err)
+	}()
+	return mw.next.DownloadAttachment(ctx, noteID, attachmentID)
+}
+
+func (mw loggingMiddleware) AddComment(ctx context.Context, noteID, author, body string, startLine, endLine int64) (comment model.NoteComment, err error) {
+	defer func() {
+		mw.logger.Log("method", "AddComment", "note_id", noteID, "author", author, "start_line", startLine, "end_line", endLine, "comment", comment.ID, "err", err)
+	}()
+	return mw.next.AddComment(ctx, noteID, author, body, startLine, endLine)
+}
+
+func (mw loggingMiddleware) ListComments(ctx context.Context, noteID string, includeResolved bool) (comments []model.NoteComment, err error) {
+	defer func() {
+		mw.logger.Log("method", "ListComments", "note_id", noteID, "include_resolved", includeResolved, "comments", len(comments), "err", err)
+	}()
+	return mw.next.ListComments(ctx, noteID, includeResolved)
+}
+
+func (mw loggingMiddleware) ResolveComment(ctx context.Context, noteID, commentID, user string) (comment model.NoteComment, err error) {
+	defer func() {
+		mw.logger.Log("method", "ResolveComment", "note_id", noteID, "id", commentID, "user", user, "err", err)
+	}()
+	return mw.next.ResolveComment(ctx, noteID, commentID, user)
+}
+
+func (mw loggingMiddleware) GetNoteStats(ctx context.Context, id, ownerToken string, days int) (stats model.NoteStats, err error) {
+	defer func() {
+		mw.logger.Log("method", "GetNoteStats", "id", id, "days", days, "views", stats.Views, "err", err)
+	}()
+	return mw.next.GetNoteStats(ctx, id, ownerToken, days)
 }
 
 
@@ -49,24 +202,154 @@ type instrumentingMiddleware struct {
 	next  Service
 }
 
+func (mw instrumentingMiddleware) unwrap() Service {
+	return mw.next
+}
+
 func (mw instrumentingMiddleware) PrivateNote(ctx context.Context, id string) (err error) {
 	err = mw.next.PrivateNote(ctx, id)
 	mw.ctrs[PrivateNoteServiceName].Add(1)
 	return
 }
 
-func (mw instrumentingMiddleware) ShareNote(ctx context.Context, name, content string) (url, shareID string, err error) {
-	url, shareID, err = mw.next.ShareNote(ctx, name, content)
+func (mw instrumentingMiddleware) ShareNote(ctx context.Context, name, content string) (url, shareID, ownerToken string, err error) {
+	url, shareID, ownerToken, err = mw.next.ShareNote(ctx, name, content)
 	mw.ctrs[ShareNoteServiceName].Add(1)
 	return
 }
 
-func (mw instrumentingMiddleware) GetNote(ctx context.Context, id string) (name, content string, err error)  {
-	name, content, err = mw.next.GetNote(ctx, id)
+func (mw instrumentingMiddleware) GetNote(ctx context.Context, id string, cond model.NoteCondition) (name, content string, version model.NoteVersion, err error)  {
+	name, content, version, err = mw.next.GetNote(ctx, id, cond)
 	mw.ctrs[GetNoteServiceName].Add(1)
 	return
 }
 
+func (mw instrumentingMiddleware) ShareNoteStream(ctx context.Context, name string, content io.Reader) (url, shareID, ownerToken string, err error) {
+	url, shareID, ownerToken, err = mw.next.ShareNoteStream(ctx, name, content)
+	mw.ctrs[ShareNoteStreamServiceName].Add(1)
+	return
+}
+
+func (mw instrumentingMiddleware) GetNoteStream(ctx context.Context, id string) (name string, content io.ReadCloser, err error)  {
+	name, content, err = mw.next.GetNoteStream(ctx, id)
+	mw.ctrs[GetNoteStreamServiceName].Add(1)
+	return
+}
+
+func (mw instrumentingMiddleware) WatchNote(ctx context.Context, id string) (events <-chan model.NoteEvent, err error)  {
+	events, err = mw.next.WatchNote(ctx, id)
+	mw.ctrs[WatchNoteServiceName].Add(1)
+	return
+}
+
+func (mw instrumentingMiddleware) WatchNotes(ctx context.Context) (events <-chan model.NoteEvent, err error)  {
+	events, err = mw.next.WatchNotes(ctx)
+	mw.ctrs[WatchNotesServiceName].Add(1)
+	return
+}
+
+func (mw instrumentingMiddleware) EditNote(ctx context.Context, id, user string) (session model.EditSession, err error)  {
+	session, err = mw.next.EditNote(ctx, id, user)
+	mw.ctrs[EditNoteServiceName].Add(1)
+	return
+}
+
+func (mw instrumentingMiddleware) SyncNotes(ctx context.Context, clientID string, since int64, noteIDs []string, changes []model.NoteChange) (result model.SyncResult, err error)  {
+	result, err = mw.next.SyncNotes(ctx, clientID, since, noteIDs, changes)
+	mw.ctrs[SyncNotesServiceName].Add(1)
+	return
+}
+
+func (mw instrumentingMiddleware) ForkNote(ctx context.Context, id, owner string) (url, shareID, ownerToken string, err error)  {
+	url, shareID, ownerToken, err = mw.next.ForkNote(ctx, id, owner)
+	mw.ctrs[ForkNoteServiceName].Add(1)
+	return
+}
+
+func (mw instrumentingMiddleware) ListForks(ctx context.Context, id string) (forks []model.NoteFork, err error)  {
+	forks, err = mw.next.ListForks(ctx, id)
+	mw.ctrs[ListForksServiceName].Add(1)
+	return
+}
+
+func (mw instrumentingMiddleware) MarkTemplate(ctx context.Context, id string, template bool) (variables []model.TemplateVariable, err error)  {
+	variables, err = mw.next.MarkTemplate(ctx, id, template)
+	mw.ctrs[MarkTemplateServiceName].Add(1)
+	return
+}
+
+func (mw instrumentingMiddleware) InstantiateTemplate(ctx context.Context, id string, vars map[string]string) (url, shareID, ownerToken string, err error)  {
+	url, shareID, ownerToken, err = mw.next.InstantiateTemplate(ctx, id, vars)
+	mw.ctrs[InstantiateTemplateServiceName].Add(1)
+	return
+}
+
+func (mw instrumentingMiddleware) CreateCollection(ctx context.Context, name string, noteIDs []string) (url, collectionID string, err error)  {
+	url, collectionID, err = mw.next.CreateCollection(ctx, name, noteIDs)
+	mw.ctrs[CreateCollectionServiceName].Add(1)
+	return
+}
+
+func (mw instrumentingMiddleware) AddCollectionNotes(ctx context.Context, id string, noteIDs []string) (err error)  {
+	err = mw.next.AddCollectionNotes(ctx, id, noteIDs)
+	mw.ctrs[AddCollectionNotesServiceName].Add(1)
+	return
+}
+
+func (mw instrumentingMiddleware) RemoveCollectionNotes(ctx context.Context, id string, noteIDs []string) (err error)  {
+	err = mw.next.RemoveCollectionNotes(ctx, id, noteIDs)
+	mw.ctrs[RemoveCollectionNotesServiceName].Add(1)
+	return
+}
+
+func (mw instrumentingMiddleware) ReorderCollection(ctx context.Context, id string, noteIDs []string) (err error)  {
+	err = mw.next.ReorderCollection(ctx, id, noteIDs)
+	mw.ctrs[ReorderCollectionServiceName].Add(1)
+	return
+}
+
+func (mw instrumentingMiddleware) GetCollection(ctx context.Context, id string) (name string, notes []model.CollectionNote, err error)  {
+	name, notes, err = mw.next.GetCollection(ctx, id)
+	mw.ctrs[GetCollectionServiceName].Add(1)
+	return
+}
+
+func (mw instrumentingMiddleware) UploadAttachment(ctx context.Context, noteID, name string, content io.Reader) (attachment model.AttachmentInfo, err error)  {
+	attachment, err = mw.next.UploadAttachment(ctx, noteID, name, content)
+	mw.ctrs[UploadAttachmentServiceName].Add(1)
+	return
+}
+
+func (mw instrumentingMiddleware) DownloadAttachment(ctx context.Context, noteID, attachmentID string) (attachment model.AttachmentInfo, content io.ReadCloser, err error)  {
+	attachment, content, err = mw.next.DownloadAttachment(ctx, noteID, attachmentID)
+	mw.ctrs[DownloadAttachmentServiceName].Add(1)
+	return
+}
+
+func (mw instrumentingMiddleware) AddComment(ctx context.Context, noteID, author, body string, startLine, endLine int64) (comment model.NoteComment, err error)  {
+	comment, err = mw.next.AddComment(ctx, noteID, author, body, startLine, endLine)
+	mw.ctrs[AddCommentServiceName].Add(1)
+	return
+}
+
+func (mw instrumentingMiddleware) ListComments(ctx context.Context, noteID string, includeResolved bool) (comments []model.NoteComment, err error)  {
+	comments, err = mw.next.ListComments(ctx, noteID, includeResolved)
+	mw.ctrs[ListCommentsServiceName].Add(1)
+	return
+}
+
+func (mw instrumentingMiddleware) ResolveComment(ctx context.Context, noteID, commentID, user string) (comment model.NoteComment, err error)  {
+	comment, err = mw.next.ResolveComment(ctx, noteID, commentID, user)
+	mw.ctrs[ResolveCommentServiceName].Add(1)
+	return
+}
+
+func (mw instrumentingMiddleware) GetNoteStats(ctx context.Context, id, ownerToken string, days int) (stats model.NoteStats, err error)  {
+	stats, err = mw.next.GetNoteStats(ctx, id, ownerToken, days)
+	mw.ctrs[GetNoteStatsServiceName].Add(1)
+	return
+}
+
 func InstrumentingMiddleware(ctrs map[string]metrics.Counter) Middleware  {
 	return func(next Service) Service {
 		return instrumentingMiddleware{
@@ -90,6 +373,10 @@ func TracingMiddleware(tracer stdopentracing.Tracer) Middleware {
 	}
 }
 
+func (mw tracerMiddleware) unwrap() Service {
+	return mw.next
+}
+
 func (mw tracerMiddleware) PrivateNote(ctx context.Context, id string) (err error) {
 	var (
 		span stdopentracing.Span
@@ -107,7 +394,7 @@ func (mw tracerMiddleware) PrivateNote(ctx context.Context, id string) (err erro
 	return
 }
 
-func (mw tracerMiddleware) ShareNote(ctx context.Context, name, content string) (url, shareID string, err error) {
+func (mw tracerMiddleware) ShareNote(ctx context.Context, name, content string) (url, shareID, ownerToken string, err error) {
 	var (
 		span stdopentracing.Span
 		spanCtx context.Context
@@ -116,13 +403,13 @@ func (mw tracerMiddleware) ShareNote(ctx context.Context, name, content string)
 	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "Share Note Service")
 	defer span.Finish()
 
-	url, shareID, err = mw.next.ShareNote(spanCtx, name, content)
+	url, shareID, ownerToken, err = mw.next.ShareNote(spanCtx, name, content)
 	span.SetTag("url", url)
 	span.LogKV("error", err)
 	return
 }
 
-func (mw tracerMiddleware) GetNote(ctx context.Context, id string) (name, content string, err error)  {
+func (mw tracerMiddleware) GetNote(ctx context.Context, id string, cond model.NoteCondition) (name, content string, version model.NoteVersion, err error)  {
 	var (
 		span stdopentracing.Span
 		spanCtx context.Context
@@ -131,8 +418,353 @@ func (mw tracerMiddleware) GetNote(ctx context.Context, id string) (name, conten
 	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "Get Note Service")
 	defer span.Finish()
 
-	name, content, err = mw.next.GetNote(spanCtx, id)
+	name, content, version, err = mw.next.GetNote(spanCtx, id, cond)
+	span.SetTag("name", name)
+	span.SetTag("etag", version.ETag)
+	span.LogKV("error", err)
+	return
+}
+
+func (mw tracerMiddleware) ShareNoteStream(ctx context.Context, name string, content io.Reader) (url, shareID, ownerToken string, err error) {
+	var (
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "Share Note Stream Service")
+	defer span.Finish()
+
+	url, shareID, ownerToken, err = mw.next.ShareNoteStream(spanCtx, name, content)
+	span.SetTag("url", url)
+	span.LogKV("error", err)
+	return
+}
+
+func (mw tracerMiddleware) GetNoteStream(ctx context.Context, id string) (name string, content io.ReadCloser, err error)  {
+	var (
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "Get Note Stream Service")
+	defer span.Finish()
+
+	name, content, err = mw.next.GetNoteStream(spanCtx, id)
+	span.SetTag("name", name)
+	span.LogKV("error", err)
+	return
+}
+
+func (mw tracerMiddleware) WatchNote(ctx context.Context, id string) (events <-chan model.NoteEvent, err error)  {
+	var (
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "Watch Note Service")
+	defer span.Finish()
+
+	span.SetTag("id", id)
+
+	events, err = mw.next.WatchNote(spanCtx, id)
+	span.LogKV("error", err)
+	return
+}
+
+func (mw tracerMiddleware) WatchNotes(ctx context.Context) (events <-chan model.NoteEvent, err error)  {
+	var (
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "Watch Notes Service")
+	defer span.Finish()
+
+	events, err = mw.next.WatchNotes(spanCtx)
+	span.LogKV("error", err)
+	return
+}
+
+func (mw tracerMiddleware) EditNote(ctx context.Context, id, user string) (session model.EditSession, err error)  {
+	var (
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "Edit Note Service")
+	defer span.Finish()
+
+	span.SetTag("id", id)
+	span.SetTag("user", user)
+
+	session, err = mw.next.EditNote(spanCtx, id, user)
+	span.LogKV("error", err)
+	return
+}
+
+func (mw tracerMiddleware) SyncNotes(ctx context.Context, clientID string, since int64, noteIDs []string, changes []model.NoteChange) (result model.SyncResult, err error)  {
+	var (
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "Sync Notes Service")
+	defer span.Finish()
+
+	span.SetTag("client", clientID)
+	span.SetTag("since", since)
+
+	result, err = mw.next.SyncNotes(spanCtx, clientID, since, noteIDs, changes)
+	span.LogKV("changes", len(result.Changes), "conflicts", len(result.Conflicts), "error", err)
+	return
+}
+
+func (mw tracerMiddleware) ForkNote(ctx context.Context, id, owner string) (url, shareID, ownerToken string, err error)  {
+	var (
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "Fork Note Service")
+	defer span.Finish()
+
+	span.SetTag("id", id)
+	span.SetTag("owner", owner)
+
+	url, shareID, ownerToken, err = mw.next.ForkNote(spanCtx, id, owner)
+	span.LogKV("fork", shareID, "error", err)
+	return
+}
+
+func (mw tracerMiddleware) ListForks(ctx context.Context, id string) (forks []model.NoteFork, err error)  {
+	var (
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "List Forks Service")
+	defer span.Finish()
+
+	span.SetTag("id", id)
+
+	forks, err = mw.next.ListForks(spanCtx, id)
+	span.LogKV("forks", len(forks), "error", err)
+	return
+}
+
+func (mw tracerMiddleware) MarkTemplate(ctx context.Context, id string, template bool) (variables []model.TemplateVariable, err error)  {
+	var (
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "Mark Template Service")
+	defer span.Finish()
+
+	span.SetTag("id", id)
+	span.SetTag("template", template)
+
+	variables, err = mw.next.MarkTemplate(spanCtx, id, template)
+	span.LogKV("variables", len(variables), "error", err)
+	return
+}
+
+func (mw tracerMiddleware) InstantiateTemplate(ctx context.Context, id string, vars map[string]string) (url, shareID, ownerToken string, err error)  {
+	var (
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "Instantiate Template Service")
+	defer span.Finish()
+
+	span.SetTag("id", id)
+
+	url, shareID, ownerToken, err = mw.next.InstantiateTemplate(spanCtx, id, vars)
+	span.LogKV("note", shareID, "error", err)
+	return
+}
+
+func (mw tracerMiddleware) CreateCollection(ctx context.Context, name string, noteIDs []string) (url, collectionID string, err error)  {
+	var (
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "Create Collection Service")
+	defer span.Finish()
+
 	span.SetTag("name", name)
+
+	url, collectionID, err = mw.next.CreateCollection(spanCtx, name, noteIDs)
+	span.LogKV("collection", collectionID, "error", err)
+	return
+}
+
+func (mw tracerMiddleware) AddCollectionNotes(ctx context.Context, id string, noteIDs []string) (err error)  {
+	var (
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "Add Collection Notes Service")
+	defer span.Finish()
+
+	span.SetTag("id", id)
+
+	err = mw.next.AddCollectionNotes(spanCtx, id, noteIDs)
+	span.LogKV("error", err)
+	return
+}
+
+func (mw tracerMiddleware) RemoveCollectionNotes(ctx context.Context, id string, noteIDs []string) (err error)  {
+	var (
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "Remove Collection Notes Service")
+	defer span.Finish()
+
+	span.SetTag("id", id)
+
+	err = mw.next.RemoveCollectionNotes(spanCtx, id, noteIDs)
+	span.LogKV("error", err)
+	return
+}
+
+func (mw tracerMiddleware) ReorderCollection(ctx context.Context, id string, noteIDs []string) (err error)  {
+	var (
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "Reorder Collection Service")
+	defer span.Finish()
+
+	span.SetTag("id", id)
+
+	err = mw.next.ReorderCollection(spanCtx, id, noteIDs)
 	span.LogKV("error", err)
 	return
 }
+
+func (mw tracerMiddleware) GetCollection(ctx context.Context, id string) (name string, notes []model.CollectionNote, err error)  {
+	var (
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "Get Collection Service")
+	defer span.Finish()
+
+	span.SetTag("id", id)
+
+	name, notes, err = mw.next.GetCollection(spanCtx, id)
+	span.LogKV("notes", len(notes), "error", err)
+	return
+}
+
+func (mw tracerMiddleware) UploadAttachment(ctx context.Context, noteID, name string, content io.Reader) (attachment model.AttachmentInfo, err error)  {
+	var (
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "Upload Attachment Service")
+	defer span.Finish()
+
+	span.SetTag("note_id", noteID)
+
+	attachment, err = mw.next.UploadAttachment(spanCtx, noteID, name, content)
+	span.SetTag("url", attachment.URL)
+	span.LogKV("size", attachment.Size, "content_type", attachment.ContentType, "error", err)
+	return
+}
+
+func (mw tracerMiddleware) DownloadAttachment(ctx context.Context, noteID, attachmentID string) (attachment model.AttachmentInfo, content io.ReadCloser, err error)  {
+	var (
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "Download Attachment Service")
+	defer span.Finish()
+
+	span.SetTag("note_id", noteID)
+	span.SetTag("id", attachmentID)
+
+	attachment, content, err = mw.next.DownloadAttachment(spanCtx, noteID, attachmentID)
+	span.LogKV("size", attachment.Size, "error", err)
+	return
+}
+
+func (mw tracerMiddleware) AddComment(ctx context.Context, noteID, author, body string, startLine, endLine int64) (comment model.NoteComment, err error)  {
+	var (
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "Add Comment Service")
+	defer span.Finish()
+
+	span.SetTag("note_id", noteID)
+	span.SetTag("author", author)
+
+	comment, err = mw.next.AddComment(spanCtx, noteID, author, body, startLine, endLine)
+	span.SetTag("id", comment.ID)
+	span.LogKV("start_line", startLine, "end_line", endLine, "error", err)
+	return
+}
+
+func (mw tracerMiddleware) ListComments(ctx context.Context, noteID string, includeResolved bool) (comments []model.NoteComment, err error)  {
+	var (
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "List Comments Service")
+	defer span.Finish()
+
+	span.SetTag("note_id", noteID)
+	span.SetTag("include_resolved", includeResolved)
+
+	comments, err = mw.next.ListComments(spanCtx, noteID, includeResolved)
+	span.LogKV("comments", len(comments), "error", err)
+	return
+}
+
+func (mw tracerMiddleware) ResolveComment(ctx context.Context, noteID, commentID, user string) (comment model.NoteComment, err error)  {
+	var (
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "Resolve Comment Service")
+	defer span.Finish()
+
+	span.SetTag("note_id", noteID)
+	span.SetTag("id", commentID)
+	span.SetTag("user", user)
+
+	comment, err = mw.next.ResolveComment(spanCtx, noteID, commentID, user)
+	span.LogKV("error", err)
+	return
+}
+
+func (mw tracerMiddleware) GetNoteStats(ctx context.Context, id, ownerToken string, days int) (stats model.NoteStats, err error)  {
+	var (
+		span stdopentracing.Span
+		spanCtx context.Context
+	)
+
+	span, spanCtx = stdopentracing.StartSpanFromContext(ctx, "Get Note Stats Service")
+	defer span.Finish()
+
+	span.SetTag("id", id)
+	span.SetTag("days", days)
+
+	stats, err = mw.next.GetNoteStats(spanCtx, id, ownerToken, days)
+	span.LogKV("views", stats.Views, "error", err)
+	return
+}
@@ -2,22 +2,71 @@ package service
 
 import (
 	"context"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/config"
+	"github.com/al8n/shareable-notes/share-svc/internal/analytics"
+	"github.com/al8n/shareable-notes/share-svc/internal/collab"
+	"github.com/al8n/shareable-notes/share-svc/internal/envelope"
 	"github.com/al8n/shareable-notes/share-svc/internal/repositories"
+	"github.com/al8n/shareable-notes/share-svc/model"
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/metrics"
 	stdopentracing "github.com/opentracing/opentracing-go"
+	"io"
 )
 
 const (
 	ShareNoteServiceName = "ShareNote"
 	PrivateNoteServiceName = "PrivateNote"
 	GetNoteServiceName = "GetNote"
+	ShareNoteStreamServiceName = "ShareNoteStream"
+	GetNoteStreamServiceName = "GetNoteStream"
+	WatchNoteServiceName = "WatchNote"
+	WatchNotesServiceName = "WatchNotes"
+	EditNoteServiceName = "EditNote"
+	SyncNotesServiceName = "SyncNotes"
+	ForkNoteServiceName = "ForkNote"
+	ListForksServiceName = "ListForks"
+	MarkTemplateServiceName = "MarkTemplate"
+	InstantiateTemplateServiceName = "InstantiateTemplate"
+	CreateCollectionServiceName = "CreateCollection"
+	AddCollectionNotesServiceName = "AddCollectionNotes"
+	RemoveCollectionNotesServiceName = "RemoveCollectionNotes"
+	ReorderCollectionServiceName = "ReorderCollection"
+	GetCollectionServiceName = "GetCollection"
+	UploadAttachmentServiceName = "UploadAttachment"
+	DownloadAttachmentServiceName = "DownloadAttachment"
+	AddCommentServiceName = "AddComment"
+	ListCommentsServiceName = "ListComments"
+	ResolveCommentServiceName = "ResolveComment"
+	GetNoteStatsServiceName = "GetNoteStats"
 )
 
 type Service interface {
-	ShareNote(ctx context.Context, name, content string) (url, sharedID string, err error)
+	ShareNote(ctx context.Context, name, content string) (url, sharedID, ownerToken string, err error)
 	PrivateNote(ctx context.Context, id string) (err error)
-	GetNote(ctx context.Context, id string) (name, content string, err error)
+	GetNote(ctx context.Context, id string, cond model.NoteCondition) (name, content string, version model.NoteVersion, err error)
+	ShareNoteStream(ctx context.Context, name string, content io.Reader) (url, sharedID, ownerToken string, err error)
+	GetNoteStream(ctx context.Context, id string) (name string, content io.ReadCloser, err error)
+	WatchNote(ctx context.Context, id string) (events <-chan model.NoteEvent, err error)
+	WatchNotes(ctx context.Context) (events <-chan model.NoteEvent, err error)
+	EditNote(ctx context.Context, id, user string) (session model.EditSession, err error)
+	SyncNotes(ctx context.Context, clientID string, since int64, noteIDs []string, changes []model.NoteChange) (result model.SyncResult, err error)
+	ForkNote(ctx context.Context, id, owner string) (url, sharedID, ownerToken string, err error)
+	ListForks(ctx context.Context, id string) (forks []model.NoteFork, err error)
+	MarkTemplate(ctx context.Context, id string, template bool) (variables []model.TemplateVariable, err error)
+	InstantiateTemplate(ctx context.Context, id string, vars map[string]string) (url, sharedID, ownerToken string, err error)
+	CreateCollection(ctx context.Context, name string, noteIDs []string) (url, collectionID string, err error)
+	AddCollectionNotes(ctx context.Context, id string, noteIDs []string) (err error)
+	RemoveCollectionNotes(ctx context.Context, id string, noteIDs []string) (err error)
+	ReorderCollection(ctx context.Context, id string, noteIDs []string) (err error)
+	GetCollection(ctx context.Context, id string) (name string, notes []model.CollectionNote, err error)
+	UploadAttachment(ctx context.Context, noteID, name string, content io.Reader) (attachment model.AttachmentInfo, err error)
+	DownloadAttachment(ctx context.Context, noteID, attachmentID string) (attachment model.AttachmentInfo, content io.ReadCloser, err error)
+	AddComment(ctx context.Context, noteID, author, body string, startLine, endLine int64) (comment model.NoteComment, err error)
+	ListComments(ctx context.Context, noteID string, includeResolved bool) (comments []model.NoteComment, err error)
+	ResolveComment(ctx context.Context, noteID, commentID, user string) (comment model.NoteComment, err error)
+	GetNoteStats(ctx context.Context, id, ownerToken string, days int) (stats model.NoteStats, err error)
 }
 
 // New returns a basic Service with all of the expected middlewares wired in.
@@ -37,9 +86,14 @@ func New(logger log.Logger, counters map[string]metrics.Counter, tracer stdopent
 
 type basicService struct {
 	repo *repositories.Repo
+	hub *collab.Hub
+	views *analytics.Recorder
+
+	// rewrapper is nil when the notes are not encrypted
+	rewrapper *envelope.Rewrapper
 }
 
-func (svc basicService) ShareNote(ctx context.Context, name, content string) (url, sharedID string, err error) {
+func (svc basicService) ShareNote(ctx context.Context, name, content string) (url, sharedID, ownerToken string, err error) {
 	return svc.repo.ShareNote(ctx, name, content)
 }
 
@@ -47,19 +101,185 @@ func (svc basicService) PrivateNote(ctx context.Context, id string) (err error)
 	return svc.repo.PrivateNote(ctx, id)
 }
 
-func (svc basicService) GetNote(ctx context.Context, id string) (name,content string, err error) {
-	return svc.repo.GetNote(ctx, id)
+// GetNote returns the note, or common.ErrorNoteNotModified when the client
+// holds its version already, which is checked without reading the content.
+// Both are recorded as views.
+func (svc basicService) GetNote(ctx context.Context, id string, cond model.NoteCondition) (name, content string, version model.NoteVersion, err error) {
+	if cond != (model.NoteCondition{}) {
+		version, err = svc.repo.NoteVersion(ctx, id)
+		if err != nil {
+			return "", "", version, err
+		}
+
+		if cond.Unchanged(version) {
+			// the client shows the version it holds, it is a view all the same
+			svc.views.Record(ctx, id)
+			return "", "", version, common.ErrorNoteNotModified
+		}
+	}
+
+	name, content, version, err = svc.repo.GetNote(ctx, id)
+	if err == nil {
+		svc.views.Record(ctx, id)
+	}
+	return name, content, version, err
+}
+
+func (svc basicService) ShareNoteStream(ctx context.Context, name string, content io.Reader) (url, sharedID, ownerToken string, err error) {
+	return svc.repo.ShareNoteStream(ctx, name, content)
+}
+
+func (svc basicService) GetNoteStream(ctx context.Context, id string) (name string, content io.ReadCloser, err error) {
+	name, content, err = svc.repo.GetNoteStream(ctx, id)
+	if err == nil {
+		svc.views.Record(ctx, id)
+	}
+	return name, content, err
+}
+
+func (svc basicService) WatchNote(ctx context.Context, id string) (events <-chan model.NoteEvent, err error) {
+	return svc.repo.WatchNote(ctx, id)
+}
+
+func (svc basicService) WatchNotes(ctx context.Context) (events <-chan model.NoteEvent, err error) {
+	return svc.repo.WatchNotes(ctx)
+}
+
+func (svc basicService) EditNote(ctx context.Context, id, user string) (session model.EditSession, err error) {
+	return svc.hub.Join(ctx, id, user)
+}
+
+func (svc basicService) SyncNotes(ctx context.Context, clientID string, since int64, noteIDs []string, changes []model.NoteChange) (result model.SyncResult, err error) {
+	return svc.repo.SyncNotes(ctx, clientID, since, noteIDs, changes)
+}
+
+func (svc basicService) ForkNote(ctx context.Context, id, owner string) (url, sharedID, ownerToken string, err error) {
+	return svc.repo.ForkNote(ctx, id, owner)
+}
+
+func (svc basicService) ListForks(ctx context.Context, id string) (forks []model.NoteFork, err error) {
+	return svc.repo.ListForks(ctx, id)
+}
+
+func (svc basicService) MarkTemplate(ctx context.Context, id string, template bool) (variables []model.TemplateVariable, err error) {
+	return svc.repo.MarkTemplate(ctx, id, template)
+}
+
+func (svc basicService) InstantiateTemplate(ctx context.Context, id string, vars map[string]string) (url, sharedID, ownerToken string, err error) {
+	return svc.repo.InstantiateTemplate(ctx, id, vars)
+}
+
+func (svc basicService) CreateCollection(ctx context.Context, name string, noteIDs []string) (url, collectionID string, err error) {
+	return svc.repo.CreateCollection(ctx, name, noteIDs)
+}
+
+func (svc basicService) AddCollectionNotes(ctx context.Context, id string, noteIDs []string) (err error) {
+	return svc.repo.AddCollectionNotes(ctx, id, noteIDs)
+}
+
+func (svc basicService) RemoveCollectionNotes(ctx context.Context, id string, noteIDs []string) (err error) {
+	return svc.repo.RemoveCollectionNotes(ctx, id, noteIDs)
+}
+
+func (svc basicService) ReorderCollection(ctx context.Context, id string, noteIDs []string) (err error) {
+	return svc.repo.ReorderCollection(ctx, id, noteIDs)
+}
+
+func (svc basicService) GetCollection(ctx context.Context, id string) (name string, notes []model.CollectionNote, err error) {
+	return svc.repo.GetCollection(ctx, id)
+}
+
+func (svc basicService) UploadAttachment(ctx context.Context, noteID, name string, content io.Reader) (attachment model.AttachmentInfo, err error) {
+	return svc.repo.UploadAttachment(ctx, noteID, name, content)
+}
+
+func (svc basicService) DownloadAttachment(ctx context.Context, noteID, attachmentID string) (attachment model.AttachmentInfo, content io.ReadCloser, err error) {
+	return svc.repo.DownloadAttachment(ctx, noteID, attachmentID)
+}
+
+func (svc basicService) AddComment(ctx context.Context, noteID, author, body string, startLine, endLine int64) (comment model.NoteComment, err error) {
+	return svc.repo.AddComment(ctx, noteID, author, body, startLine, endLine)
+}
+
+func (svc basicService) ListComments(ctx context.Context, noteID string, includeResolved bool) (comments []model.NoteComment, err error) {
+	return svc.repo.ListComments(ctx, noteID, includeResolved)
+}
+
+func (svc basicService) ResolveComment(ctx context.Context, noteID, commentID, user string) (comment model.NoteComment, err error) {
+	return svc.repo.ResolveComment(ctx, noteID, commentID, user)
+}
+
+func (svc basicService) GetNoteStats(ctx context.Context, id, ownerToken string, days int) (stats model.NoteStats, err error) {
+	return svc.repo.GetNoteStats(ctx, id, ownerToken, days)
+}
+
+// Close stops the basic service, once the edit rooms saved their content and
+// the queued views are stored.
+func (svc basicService) Close() error {
+	svc.hub.Close()
+	svc.views.Close()
+	if svc.rewrapper != nil {
+		svc.rewrapper.Close()
+	}
+	return svc.repo.Close(context.Background())
+}
+
+// CloseStreams ends the edit sessions, which never drain, once their rooms
+// saved the content. No session is opened afterwards.
+func (svc basicService) CloseStreams() error {
+	return svc.hub.Close()
+}
+
+// CloseStreams ends the streams of the basic service wrapped by the
+// middlewares of svc, when it has any.
+func CloseStreams(svc Service) error {
+	for {
+		switch s := svc.(type) {
+		case interface{ CloseStreams() error }:
+			return s.CloseStreams()
+		case interface{ unwrap() Service }:
+			svc = s.unwrap()
+		default:
+			return nil
+		}
+	}
+}
+
+// Close stops the basic service wrapped by the middlewares of svc, when it
+// can be stopped.
+func Close(svc Service) error {
+	for {
+		switch s := svc.(type) {
+		case io.Closer:
+			return s.Close()
+		case interface{ unwrap() Service }:
+			svc = s.unwrap()
+		default:
+			return nil
+		}
+	}
 }
 
 func NewBasicService() (svc Service, err error ) {
-	var repo *repositories.Repo
+	var (
+		repo *repositories.Repo
+		cfg = config.GetConfig().Service
+	)
 
 	repo, err = repositories.NewRepo()
 	if err != nil {
 		return
 	}
 
-	return &basicService{
+	basic := &basicService{
 		repo: repo,
-	}, nil
+		hub: collab.NewHub(repo, cfg.Collab.SnapshotInterval, cfg.Collab.RelayInterval, cfg.Collab.History),
+		views: analytics.NewRecorder(repo, cfg.Analytics.Salt, cfg.Analytics.Buffer, cfg.Analytics.BatchSize, cfg.Analytics.FlushInterval),
+	}
+
+	// the data keys wrapped by a former master key are rewrapped after a rotation
+	if cfg.Encryption.Keyfile != "" {
+		basic.rewrapper = envelope.NewRewrapper(repo, cfg.Encryption.RewrapBatch, cfg.Encryption.RewrapInterval)
+	}
+	return basic, nil
 }
\ No newline at end of file
@@ -0,0 +1,166 @@
+package transport
+
+import (
+	"github.com/al8n/shareable-notes/share-svc/internal/compress"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// minCompressSize is the response size from which the HTTP responses are
+// compressed, the smaller ones do not shrink enough to be worth it.
+const minCompressSize = 1 << 10
+
+// CompressHandler compresses the responses of next with the codec preferred
+// by the Accept-Encoding header of the requests. The WebSocket upgrades, the
+// responses encoded already and the media compressed already are sent as is.
+func CompressHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		codec := compress.Negotiate(r.Header.Get("Accept-Encoding"))
+		if codec == compress.None || r.Method == http.MethodHead || r.Header.Get("Upgrade") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, codec: codec}
+		defer cw.Close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// compressWriter holds the first bytes of a response until it knows whether
+// the response is large enough to be compressed.
+type compressWriter struct {
+	http.ResponseWriter
+	codec string
+
+	status int
+	started bool
+	buf []byte
+
+	// w compresses the body, it is nil when the body is sent as is
+	w io.WriteCloser
+}
+
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.status != 0 {
+		return
+	}
+	cw.status = status
+
+	if !cw.compressible() {
+		cw.start(false)
+	}
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if cw.status == 0 {
+		cw.WriteHeader(http.StatusOK)
+	}
+
+	if cw.started {
+		if cw.w != nil {
+			return cw.w.Write(p)
+		}
+		return cw.ResponseWriter.Write(p)
+	}
+
+	cw.buf = append(cw.buf, p...)
+	if len(cw.buf) < minCompressSize {
+		return len(p), nil
+	}
+
+	if err := cw.start(true); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush sends the bytes held and the ones compressed so far, the streamed
+// responses are compressed whatever their size.
+func (cw *compressWriter) Flush() {
+	if cw.status == 0 {
+		cw.WriteHeader(http.StatusOK)
+	}
+
+	if !cw.started {
+		cw.start(true)
+	}
+
+	if f, ok := cw.w.(interface{ Flush() error }); ok {
+		f.Flush()
+	}
+
+	if f, ok := cw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Close sends the bytes held, uncompressed when they are too few, and ends
+// the compressed body.
+func (cw *compressWriter) Close() error {
+	if cw.status == 0 {
+		return nil
+	}
+
+	if !cw.started {
+		if err := cw.start(false); err != nil {
+			return err
+		}
+	}
+
+	if cw.w != nil {
+		return cw.w.Close()
+	}
+	return nil
+}
+
+// start writes the header, compressing the body when compressed holds, and
+// the bytes held.
+func (cw *compressWriter) start(compressed bool) (err error) {
+	cw.started = true
+
+	if compressed && cw.compressible() {
+		if cw.w, err = compress.NewWriter(cw.codec, cw.ResponseWriter); err != nil {
+			return err
+		}
+
+		cw.Header().Set("Content-Encoding", cw.codec)
+		cw.Header().Del("Content-Length")
+	}
+
+	cw.ResponseWriter.WriteHeader(cw.status)
+	if len(cw.buf) == 0 {
+		return nil
+	}
+
+	buf := cw.buf
+	cw.buf = nil
+	if cw.w != nil {
+		_, err = cw.w.Write(buf)
+	} else {
+		_, err = cw.ResponseWriter.Write(buf)
+	}
+	return err
+}
+
+// compressible reports whether the response has a body worth compressing.
+func (cw *compressWriter) compressible() bool {
+	if cw.status < http.StatusOK || cw.status == http.StatusNoContent || cw.status == http.StatusNotModified {
+		return false
+	}
+
+	if cw.Header().Get("Content-Encoding") != "" {
+		return false
+	}
+
+	contentType := cw.Header().Get("Content-Type")
+	for _, prefix := range []string{"image/", "video/", "audio/", "application/zip", "application/gzip", "application/zstd"} {
+		if strings.HasPrefix(contentType, prefix) && !strings.HasPrefix(contentType, "image/svg") {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,219 @@
+package transport
+
+import (
+	"context"
+	"github.com/al8n/shareable-notes/share-svc/config"
+	"github.com/al8n/shareable-notes/share-svc/internal/codec/httpcodec"
+	"github.com/al8n/shareable-notes/share-svc/internal/codec/httpcodec/httpdecode"
+	"github.com/al8n/shareable-notes/share-svc/internal/codec/httpcodec/httpencode"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/tracing/opentracing"
+	"github.com/go-kit/kit/transport"
+	"github.com/gorilla/websocket"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// wsPingPeriod is the interval of the pings sent to WebSocket peers, a
+	// peer not answering within wsPongWait is disconnected.
+	wsPingPeriod = 30 * time.Second
+	wsPongWait = 60 * time.Second
+	wsWriteWait = 10 * time.Second
+
+	// wsMaxMessageSize bounds the edit messages read from WebSocket peers.
+	wsMaxMessageSize = 1 << 20
+
+	// wsMaxCloseReason is the longest reason a close frame can carry.
+	wsMaxCloseReason = 123
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize: 4096,
+	WriteBufferSize: 4096,
+	CheckOrigin: checkOrigin,
+}
+
+// checkOrigin accepts the clients which are not browsers, which send no
+// Origin, the pages of the host serving the request and the allowed origins.
+func checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+
+	for _, allowed := range config.GetConfig().Service.Collab.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewEditNoteHandler returns an HTTP handler upgrading the requests to
+// WebSocket connections, relayed to the edit sessions opened by ep. The
+// messages are model.EditMessage encoded as JSON text frames.
+func NewEditNoteHandler(ep endpoint.Endpoint, otTracer stdopentracing.Tracer, logger log.Logger) http.Handler {
+	errorHandler := transport.NewLogErrorHandler(logger)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			ctx = untilShutdown(opentracing.HTTPToContext(otTracer, "EditNote", logger)(r.Context(), r))
+			req, resp interface{}
+			session model.EditSession
+			conn *websocket.Conn
+			err error
+		)
+
+		req, err = httpdecode.EditNoteRequest(ctx, r)
+		if err != nil {
+			errorHandler.Handle(ctx, err)
+			httpcodec.ErrorEncoder(ctx, err, w)
+			return
+		}
+
+		resp, err = ep(ctx, req)
+		if err != nil {
+			errorHandler.Handle(ctx, err)
+			httpcodec.ErrorEncoder(ctx, err, w)
+			return
+		}
+
+		session = httpencode.EditNoteResponse(ctx, w, resp)
+		if session == nil {
+			return
+		}
+
+		conn, err = upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			session.Close()
+			errorHandler.Handle(ctx, err)
+			return
+		}
+
+		if err = serveWebSocket(ctx, conn, session); err != nil {
+			errorHandler.Handle(ctx, err)
+		}
+	})
+}
+
+// serveWebSocket relays session over conn until ctx is done, keeping the
+// connection alive with pings.
+func serveWebSocket(ctx context.Context, conn *websocket.Conn, session model.EditSession) error {
+	defer conn.Close()
+
+	conn.SetReadLimit(wsMaxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		ticker := time.NewTicker(wsPingPeriod)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)) != nil {
+					return
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	err := relayEditSession(
+		ctx,
+		session,
+		func() (msg model.EditMessage, err error) {
+			err = conn.ReadJSON(&msg)
+			if err == nil {
+				// every message proves the peer is alive, not only pongs
+				conn.SetReadDeadline(time.Now().Add(wsPongWait))
+			}
+			return msg, err
+		},
+		func(msg model.EditMessage) error {
+			conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			return conn.WriteJSON(msg)
+		},
+	)
+
+	var reason string
+	if err != nil {
+		reason = err.Error()
+		if len(reason) > wsMaxCloseReason {
+			reason = reason[:wsMaxCloseReason]
+		}
+	}
+	conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, reason),
+		time.Now().Add(wsWriteWait))
+	return err
+}
+
+// relayEditSession relays the messages received from a client to session,
+// and the messages of session to the client, until either side ends or ctx
+// is done. The session is closed on return.
+func relayEditSession(ctx context.Context, session model.EditSession, recv func() (model.EditMessage, error), send func(model.EditMessage) error) error {
+	defer session.Close()
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			session.Close()
+		case <-stop:
+		}
+	}()
+
+	go func() {
+		for {
+			msg, err := recv()
+			if err != nil {
+				session.Close()
+				return
+			}
+
+			if session.Send(msg) != nil {
+				// the session has ended, its error message is on the way
+				return
+			}
+		}
+	}()
+
+	for {
+		msg, err := session.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err = send(msg); err != nil {
+			return err
+		}
+	}
+}
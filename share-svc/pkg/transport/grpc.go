@@ -2,8 +2,13 @@ package transport
 
 import (
 	"context"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/internal/analytics"
+	"github.com/al8n/shareable-notes/share-svc/config"
+	"github.com/al8n/shareable-notes/share-svc/internal/codec/grpccodec"
 	"github.com/al8n/shareable-notes/share-svc/internal/codec/grpccodec/grpcdecode"
 	"github.com/al8n/shareable-notes/share-svc/internal/codec/grpccodec/grpcencode"
+	"github.com/al8n/shareable-notes/share-svc/model"
 	"github.com/al8n/shareable-notes/share-svc/pb"
 	serviceendpoint "github.com/al8n/shareable-notes/share-svc/pkg/endpoint"
 	shareservice "github.com/al8n/shareable-notes/share-svc/pkg/service"
@@ -19,209 +24,1622 @@ import (
 	"github.com/sony/gobreaker"
 	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"io"
 )
 
 type GRPCServer struct {
 	shareNote grpctransport.Handler
 	privateNote grpctransport.Handler
 	getNote grpctransport.Handler
+	syncNotes grpctransport.Handler
+	forkNote grpctransport.Handler
+	listForks grpctransport.Handler
+	markTemplate grpctransport.Handler
+	instantiateTemplate grpctransport.Handler
+	createCollection grpctransport.Handler
+	addCollectionNotes grpctransport.Handler
+	removeCollectionNotes grpctransport.Handler
+	reorderCollection grpctransport.Handler
+	getCollection grpctransport.Handler
+	addComment grpctransport.Handler
+	listComments grpctransport.Handler
+	resolveComment grpctransport.Handler
+	getNoteStats grpctransport.Handler
+
+	// streaming RPCs are not supported by grpctransport.Handler, so they
+	// call their endpoints directly.
+	shareNoteStream endpoint.Endpoint
+	getNoteStream endpoint.Endpoint
+	watchNote endpoint.Endpoint
+	watchNotes endpoint.Endpoint
+	editNote endpoint.Endpoint
+	uploadAttachment endpoint.Endpoint
+	downloadAttachment endpoint.Endpoint
+
+	// proxies are trusted to forward the viewers of the requests
+	proxies analytics.Proxies
+
+	otTracer stdopentracing.Tracer
+	logger log.Logger
+	errorHandler transport.ErrorHandler
+}
+
+func (g GRPCServer) ShareNote(ctx context.Context, request *pb.ShareNoteRequest) (*pb.ShareNoteResponse, error) {
+	_, resp, err := g.shareNote.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.ShareNoteResponse), nil
+}
+
+func (g GRPCServer) PrivateNote(ctx context.Context, request *pb.PrivateNoteRequest) (*pb.PrivateNoteResponse, error) {
+	_, resp, err := g.privateNote.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.PrivateNoteResponse), nil
+}
+
+func (g GRPCServer) GetNote(ctx context.Context, request *pb.GetNoteRequest) (*pb.GetNoteResponse, error) {
+	_, resp, err := g.getNote.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.GetNoteResponse), nil
+}
+
+func (g GRPCServer) SyncNotes(ctx context.Context, request *pb.SyncNotesRequest) (*pb.SyncNotesResponse, error) {
+	_, resp, err := g.syncNotes.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.SyncNotesResponse), nil
+}
+
+func (g GRPCServer) ForkNote(ctx context.Context, request *pb.ForkNoteRequest) (*pb.ForkNoteResponse, error) {
+	_, resp, err := g.forkNote.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.ForkNoteResponse), nil
+}
+
+func (g GRPCServer) ListForks(ctx context.Context, request *pb.ListForksRequest) (*pb.ListForksResponse, error) {
+	_, resp, err := g.listForks.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.ListForksResponse), nil
+}
+
+func (g GRPCServer) MarkTemplate(ctx context.Context, request *pb.MarkTemplateRequest) (*pb.MarkTemplateResponse, error) {
+	_, resp, err := g.markTemplate.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.MarkTemplateResponse), nil
+}
+
+func (g GRPCServer) InstantiateTemplate(ctx context.Context, request *pb.InstantiateTemplateRequest) (*pb.InstantiateTemplateResponse, error) {
+	_, resp, err := g.instantiateTemplate.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.InstantiateTemplateResponse), nil
+}
+
+func (g GRPCServer) CreateCollection(ctx context.Context, request *pb.CreateCollectionRequest) (*pb.CreateCollectionResponse, error) {
+	_, resp, err := g.createCollection.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.CreateCollectionResponse), nil
+}
+
+func (g GRPCServer) AddCollectionNotes(ctx context.Context, request *pb.AddCollectionNotesRequest) (*pb.AddCollectionNotesResponse, error) {
+	_, resp, err := g.addCollectionNotes.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.AddCollectionNotesResponse), nil
+}
+
+func (g GRPCServer) RemoveCollectionNotes(ctx context.Context, request *pb.RemoveCollectionNotesRequest) (*pb.RemoveCollectionNotesResponse, error) {
+	_, resp, err := g.removeCollectionNotes.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.RemoveCollectionNotesResponse), nil
+}
+
+func (g GRPCServer) ReorderCollection(ctx context.Context, request *pb.ReorderCollectionRequest) (*pb.ReorderCollectionResponse, error) {
+	_, resp, err := g.reorderCollection.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.ReorderCollectionResponse), nil
+}
+
+func (g GRPCServer) GetCollection(ctx context.Context, request *pb.GetCollectionRequest) (*pb.GetCollectionResponse, error) {
+	_, resp, err := g.getCollection.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.GetCollectionResponse), nil
+}
+
+func (g GRPCServer) AddComment(ctx context.Context, request *pb.AddCommentRequest) (*pb.AddCommentResponse, error) {
+	_, resp, err := g.addComment.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.AddCommentResponse), nil
+}
+
+func (g GRPCServer) ListComments(ctx context.Context, request *pb.ListCommentsRequest) (*pb.ListCommentsResponse, error) {
+	_, resp, err := g.listComments.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.ListCommentsResponse), nil
+}
+
+func (g GRPCServer) ResolveComment(ctx context.Context, request *pb.ResolveCommentRequest) (*pb.ResolveCommentResponse, error) {
+	_, resp, err := g.resolveComment.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.ResolveCommentResponse), nil
+}
+
+func (g GRPCServer) GetNoteStats(ctx context.Context, request *pb.GetNoteStatsRequest) (*pb.GetNoteStatsResponse, error) {
+	_, resp, err := g.getNoteStats.ServeGRPC(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.GetNoteStatsResponse), nil
+}
+
+func (g GRPCServer) ShareNoteStream(stream pb.Share_ShareNoteStreamServer) error {
+	var (
+		ctx = g.streamContext(stream.Context(), "ShareNoteStream")
+		first *pb.ShareNoteChunk
+		req, resp, reply interface{}
+		err error
+	)
+
+	first, err = stream.Recv()
+	if err == io.EOF {
+		return common.ErrorEmptyNoteStream
+	}
+	if err != nil {
+		return err
+	}
+
+	req, err = grpcdecode.ShareNoteStreamRequest(first, stream.Recv)
+	if err != nil {
+		g.errorHandler.Handle(ctx, err)
+		return err
+	}
+
+	resp, err = g.shareNoteStream(ctx, req)
+	if err != nil {
+		g.errorHandler.Handle(ctx, err)
+		return err
+	}
+
+	reply, err = grpcencode.ShareNoteResponse(ctx, resp)
+	if err != nil {
+		g.errorHandler.Handle(ctx, err)
+		return err
+	}
+
+	return stream.SendAndClose(reply.(*pb.ShareNoteResponse))
+}
+
+func (g GRPCServer) GetNoteStream(request *pb.GetNoteRequest, stream pb.Share_GetNoteStreamServer) error {
+	var (
+		ctx = g.streamContext(stream.Context(), "GetNoteStream")
+		req, resp interface{}
+		err error
+	)
+
+	req, err = grpcdecode.GetNoteStreamRequest(ctx, request)
+	if err != nil {
+		g.errorHandler.Handle(ctx, err)
+		return err
+	}
+
+	resp, err = g.getNoteStream(ctx, req)
+	if err != nil {
+		g.errorHandler.Handle(ctx, err)
+		return err
+	}
+
+	err = grpcencode.GetNoteStreamResponse(ctx, resp, streamChunkSize(), stream.Send)
+	if err != nil {
+		g.errorHandler.Handle(ctx, err)
+		return err
+	}
+	return nil
 }
 
-func (g GRPCServer) ShareNote(ctx context.Context, request *pb.ShareNoteRequest) (*pb.ShareNoteResponse, error) {
-	_, resp, err := g.shareNote.ServeGRPC(ctx, request)
-	if err != nil {
-		return nil, err
-	}
-	return resp.(*pb.ShareNoteResponse), nil
-}
+func (g GRPCServer) UploadAttachment(stream pb.Share_UploadAttachmentServer) error {
+	var (
+		ctx = g.streamContext(stream.Context(), "UploadAttachment")
+		first *pb.AttachmentChunk
+		req, resp, reply interface{}
+		err error
+	)
+
+	first, err = stream.Recv()
+	if err == io.EOF {
+		return common.ErrorEmptyAttachmentStream
+	}
+	if err != nil {
+		return err
+	}
+
+	req, err = grpcdecode.UploadAttachmentRequest(first, stream.Recv)
+	if err != nil {
+		g.errorHandler.Handle(ctx, err)
+		return err
+	}
+
+	resp, err = g.uploadAttachment(ctx, req)
+	if err != nil {
+		g.errorHandler.Handle(ctx, err)
+		return err
+	}
+
+	reply, err = grpcencode.UploadAttachmentResponse(ctx, resp)
+	if err != nil {
+		g.errorHandler.Handle(ctx, err)
+		return err
+	}
+
+	return stream.SendAndClose(reply.(*pb.UploadAttachmentResponse))
+}
+
+func (g GRPCServer) DownloadAttachment(request *pb.DownloadAttachmentRequest, stream pb.Share_DownloadAttachmentServer) error {
+	var (
+		ctx = g.streamContext(stream.Context(), "DownloadAttachment")
+		req, resp interface{}
+		err error
+	)
+
+	req, err = grpcdecode.DownloadAttachmentRequest(ctx, request)
+	if err != nil {
+		g.errorHandler.Handle(ctx, err)
+		return err
+	}
+
+	resp, err = g.downloadAttachment(ctx, req)
+	if err != nil {
+		g.errorHandler.Handle(ctx, err)
+		return err
+	}
+
+	err = grpcencode.DownloadAttachmentResponse(ctx, resp, streamChunkSize(), stream.Send)
+	if err != nil {
+		g.errorHandler.Handle(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (g GRPCServer) WatchNote(request *pb.WatchNoteRequest, stream pb.Share_WatchNoteServer) error {
+	var (
+		ctx = untilShutdown(g.streamContext(stream.Context(), "WatchNote"))
+		req, resp interface{}
+		err error
+	)
+
+	req, err = grpcdecode.WatchNoteRequest(ctx, request)
+	if err != nil {
+		g.errorHandler.Handle(ctx, err)
+		return err
+	}
+
+	resp, err = g.watchNote(ctx, req)
+	if err != nil {
+		g.errorHandler.Handle(ctx, err)
+		return err
+	}
+
+	err = grpcencode.WatchNoteResponse(ctx, resp, stream)
+	if err != nil {
+		g.errorHandler.Handle(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (g GRPCServer) WatchNotes(request *pb.WatchNotesRequest, stream pb.Share_WatchNotesServer) error {
+	var (
+		ctx = untilShutdown(g.streamContext(stream.Context(), "WatchNotes"))
+		req, resp interface{}
+		err error
+	)
+
+	req, err = grpcdecode.WatchNotesRequest(ctx, request)
+	if err != nil {
+		g.errorHandler.Handle(ctx, err)
+		return err
+	}
+
+	resp, err = g.watchNotes(ctx, req)
+	if err != nil {
+		g.errorHandler.Handle(ctx, err)
+		return err
+	}
+
+	err = grpcencode.WatchNotesResponse(ctx, resp, stream)
+	if err != nil {
+		g.errorHandler.Handle(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (g GRPCServer) EditNote(stream pb.Share_EditNoteServer) error {
+	var (
+		ctx = untilShutdown(g.streamContext(stream.Context(), "EditNote"))
+		first *pb.EditMessage
+		session model.EditSession
+		req, resp interface{}
+		err error
+	)
+
+	first, err = stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	req, err = grpcdecode.EditNoteRequest(ctx, first)
+	if err != nil {
+		g.errorHandler.Handle(ctx, err)
+		return err
+	}
+
+	resp, err = g.editNote(ctx, req)
+	if err != nil {
+		g.errorHandler.Handle(ctx, err)
+		return err
+	}
+
+	session, err = grpcencode.EditNoteResponse(ctx, resp, stream.Send)
+	if err != nil {
+		g.errorHandler.Handle(ctx, err)
+		return err
+	}
+
+	err = relayEditSession(
+		ctx,
+		session,
+		func() (model.EditMessage, error) {
+			msg, err := stream.Recv()
+			if err != nil {
+				return model.EditMessage{}, err
+			}
+			return grpccodec.EditMessagepb2EditMessage(msg), nil
+		},
+		func(msg model.EditMessage) error {
+			return stream.Send(grpccodec.EditMessage2pbEditMessage(msg))
+		},
+	)
+	if err != nil {
+		g.errorHandler.Handle(ctx, err)
+		return err
+	}
+	return nil
+}
+
+// streamContext does for streaming RPCs what the ServerBefore options do for
+// the unary ones.
+func (g GRPCServer) streamContext(ctx context.Context, operationName string) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = analytics.GRPCToContext(g.proxies)(ctx, md)
+	return opentracing.GRPCToContext(g.otTracer, operationName, g.logger)(ctx, md)
+}
+
+func NewGRPCServer(set serviceendpoint.Set, otTracer stdopentracing.Tracer, logger log.Logger, apis bootapi.APIs) pb.ShareServer  {
+	proxies := trustedProxies()
+	options := []grpctransport.ServerOption{
+		grpctransport.ServerErrorHandler(transport.NewLogErrorHandler(logger)),
+		grpctransport.ServerBefore(analytics.GRPCToContext(proxies)),
+	}
+
+	//if zipkinTracer != nil {
+	//	// Zipkin GRPC Server Trace can either be instantiated per gRPC method with a
+	//	// provided operation name or a global tracing service can be instantiated
+	//	// without an operation name and fed to each Go kit gRPC server as a
+	//	// ServerOption.
+	//	// In the latter case, the operation name will be the endpoint's grpc method
+	//	// path if used in combination with the Go kit gRPC Interceptor.
+	//	//
+	//	// In this example, we demonstrate a global Zipkin tracing service with
+	//	// Go kit gRPC Interceptor.
+	//	options = append(options, zipkin.GRPCServerTrace(zipkinTracer))
+	//}
+
+	return &GRPCServer{
+		shareNote:   grpctransport.NewServer(
+			set.ShareNoteEndpoint,
+			grpcdecode.ShareNoteRequest,
+			grpcencode.ShareNoteResponse,
+			append(
+				options,
+				grpctransport.ServerBefore(
+					opentracing.GRPCToContext(
+						otTracer,
+						"ShareNote",
+						logger)),
+				)...,
+			),
+		privateNote: grpctransport.NewServer(
+			set.PrivateNoteEndpoint,
+			grpcdecode.PrivateNoteRequest,
+			grpcencode.PrivateNoteResponse,
+			append(
+				options,
+				grpctransport.ServerBefore(
+					opentracing.GRPCToContext(
+						otTracer,
+						"PrivateNote",
+						logger)),
+			)...,
+		),
+		getNote:    grpctransport.NewServer(
+			set.GetNoteEndpoint,
+			grpcdecode.GetNoteRequest,
+			grpcencode.GetNoteResponse,
+			append(
+				options,
+				grpctransport.ServerBefore(
+					opentracing.GRPCToContext(
+						otTracer,
+						"GetNote",
+						logger)),
+			)...,
+		),
+		syncNotes:    grpctransport.NewServer(
+			set.SyncNotesEndpoint,
+			grpcdecode.SyncNotesRequest,
+			grpcencode.SyncNotesResponse,
+			append(
+				options,
+				grpctransport.ServerBefore(
+					opentracing.GRPCToContext(
+						otTracer,
+						"SyncNotes",
+						logger)),
+			)...,
+		),
+		forkNote:    grpctransport.NewServer(
+			set.ForkNoteEndpoint,
+			grpcdecode.ForkNoteRequest,
+			grpcencode.ForkNoteResponse,
+			append(
+				options,
+				grpctransport.ServerBefore(
+					opentracing.GRPCToContext(
+						otTracer,
+						"ForkNote",
+						logger)),
+			)...,
+		),
+		listForks:    grpctransport.NewServer(
+			set.ListForksEndpoint,
+			grpcdecode.ListForksRequest,
+			grpcencode.ListForksResponse,
+			append(
+				options,
+				grpctransport.ServerBefore(
+					opentracing.GRPCToContext(
+						otTracer,
+						"ListForks",
+						logger)),
+			)...,
+		),
+		markTemplate:    grpctransport.NewServer(
+			set.MarkTemplateEndpoint,
+			grpcdecode.MarkTemplateRequest,
+			grpcencode.MarkTemplateResponse,
+			append(
+				options,
+				grpctransport.ServerBefore(
+					opentracing.GRPCToContext(
+						otTracer,
+						"MarkTemplate",
+						logger)),
+			)...,
+		),
+		instantiateTemplate:    grpctransport.NewServer(
+			set.InstantiateTemplateEndpoint,
+			grpcdecode.InstantiateTemplateRequest,
+			grpcencode.InstantiateTemplateResponse,
+			append(
+				options,
+				grpctransport.ServerBefore(
+					opentracing.GRPCToContext(
+						otTracer,
+						"InstantiateTemplate",
+						logger)),
+			)...,
+		),
+		createCollection:    grpctransport.NewServer(
+			set.CreateCollectionEndpoint,
+			grpcdecode.CreateCollectionRequest,
+			grpcencode.CreateCollectionResponse,
+			append(
+				options,
+				grpctransport.ServerBefore(
+					opentracing.GRPCToContext(
+						otTracer,
+						"CreateCollection",
+						logger)),
+			)...,
+		),
+		addCollectionNotes:    grpctransport.NewServer(
+			set.AddCollectionNotesEndpoint,
+			grpcdecode.AddCollectionNotesRequest,
+			grpcencode.AddCollectionNotesResponse,
+			append(
+				options,
+				grpctransport.ServerBefore(
+					opentracing.GRPCToContext(
+						otTracer,
+						"AddCollectionNotes",
+						logger)),
+			)...,
+		),
+		removeCollectionNotes:    grpctransport.NewServer(
+			set.RemoveCollectionNotesEndpoint,
+			grpcdecode.RemoveCollectionNotesRequest,
+			grpcencode.RemoveCollectionNotesResponse,
+			append(
+				options,
+				grpctransport.ServerBefore(
+					opentracing.GRPCToContext(
+						otTracer,
+						"RemoveCollectionNotes",
+						logger)),
+			)...,
+		),
+		reorderCollection:    grpctransport.NewServer(
+			set.ReorderCollectionEndpoint,
+			grpcdecode.ReorderCollectionRequest,
+			grpcencode.ReorderCollectionResponse,
+			append(
+				options,
+				grpctransport.ServerBefore(
+					opentracing.GRPCToContext(
+						otTracer,
+						"ReorderCollection",
+						logger)),
+			)...,
+		),
+		getCollection:    grpctransport.NewServer(
+			set.GetCollectionEndpoint,
+			grpcdecode.GetCollectionRequest,
+			grpcencode.GetCollectionResponse,
+			append(
+				options,
+				grpctransport.ServerBefore(
+					opentracing.GRPCToContext(
+						otTracer,
+						"GetCollection",
+						logger)),
+			)...,
+		),
+		addComment:    grpctransport.NewServer(
+			set.AddCommentEndpoint,
+			grpcdecode.AddCommentRequest,
+			grpcencode.AddCommentResponse,
+			append(
+				options,
+				grpctransport.ServerBefore(
+					opentracing.GRPCToContext(
+						otTracer,
+						"AddComment",
+						logger)),
+			)...,
+		),
+		listComments:    grpctransport.NewServer(
+			set.ListCommentsEndpoint,
+			grpcdecode.ListCommentsRequest,
+			grpcencode.ListCommentsResponse,
+			append(
+				options,
+				grpctransport.ServerBefore(
+					opentracing.GRPCToContext(
+						otTracer,
+						"ListComments",
+						logger)),
+			)...,
+		),
+		resolveComment:    grpctransport.NewServer(
+			set.ResolveCommentEndpoint,
+			grpcdecode.ResolveCommentRequest,
+			grpcencode.ResolveCommentResponse,
+			append(
+				options,
+				grpctransport.ServerBefore(
+					opentracing.GRPCToContext(
+						otTracer,
+						"ResolveComment",
+						logger)),
+			)...,
+		),
+		getNoteStats:    grpctransport.NewServer(
+			set.GetNoteStatsEndpoint,
+			grpcdecode.GetNoteStatsRequest,
+			grpcencode.GetNoteStatsResponse,
+			append(
+				options,
+				grpctransport.ServerBefore(
+					opentracing.GRPCToContext(
+						otTracer,
+						"GetNoteStats",
+						logger)),
+			)...,
+		),
+		shareNoteStream: set.ShareNoteStreamEndpoint,
+		getNoteStream: set.GetNoteStreamEndpoint,
+		watchNote: set.WatchNoteEndpoint,
+		watchNotes: set.WatchNotesEndpoint,
+		editNote: set.EditNoteEndpoint,
+		uploadAttachment: set.UploadAttachmentEndpoint,
+		downloadAttachment: set.DownloadAttachmentEndpoint,
+		proxies: proxies,
+		otTracer: otTracer,
+		logger: logger,
+		errorHandler: transport.NewLogErrorHandler(logger),
+	}
+}
+
+func NewGRPCClient(conn *grpc.ClientConn, otTracer stdopentracing.Tracer, logger log.Logger, apis bootapi.APIs) shareservice.Service {
+
+	// global client middlewares
+	var (
+		options = []grpctransport.ClientOption{
+			grpctransport.ClientBefore(analytics.ContextToGRPC()),
+		}
+		serviceName = "pb.Share"
+	)
+
+	// Each individual endpoint is an grpc/transport.Client (which implements
+	// endpoint.Endpoint) that gets wrapped with various middlewares. If you
+	// made your own client library, you'd do this work there, so your server
+	// could rely on a consistent set of client behavior.
+	var shareNoteEndpoint endpoint.Endpoint
+	{
+		var (
+
+			name = shareservice.ShareNoteServiceName
+			rl = apis[name].RateLimit
+			bkr = apis[name].Breaker
+		)
+
+		shareNoteEndpoint = grpctransport.NewClient(
+			conn,
+			serviceName,
+			name,
+			grpcencode.ShareNoteRequest,
+			grpcdecode.ShareNoteResponse,
+			pb.ShareNoteResponse{},
+			append(
+				options,
+				grpctransport.ClientBefore(
+					opentracing.ContextToGRPC(
+							otTracer,
+							logger,
+						),
+					),
+				)...,
+		).Endpoint()
+
+		shareNoteEndpoint = opentracing.TraceClient(otTracer, name)(shareNoteEndpoint)
+
+		// We construct a single ratelimiter middleware, to limit the total outgoing
+		// QPS from this client to all methods on the remote instance. We also
+		// construct per-endpoint circuitbreaker middlewares to demonstrate how
+		// that's done, although they could easily be combined into a single breaker
+		// for the entire remote instance, too.
+		shareNoteEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(rl.Duration),
+				rl.Delta),
+			)(shareNoteEndpoint)
+
+		shareNoteEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				bkr.Standardize()),
+			)(shareNoteEndpoint)
+	}
+
+	var privateNoteEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.PrivateNoteServiceName
+			rl = apis[name].RateLimit
+			bkr = apis[name].Breaker
+		)
+
+		privateNoteEndpoint = grpctransport.NewClient(
+			conn,
+			serviceName,
+			name,
+			grpcencode.PrivateNoteRequest,
+			grpcdecode.PrivateNoteResponse,
+			pb.PrivateNoteResponse{},
+			append(
+				options,
+				grpctransport.ClientBefore(
+					opentracing.ContextToGRPC(otTracer, logger)),
+				)...,
+		).Endpoint()
+
+		privateNoteEndpoint = opentracing.TraceClient(otTracer, name)(privateNoteEndpoint)
+
+		privateNoteEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(
+					rl.Duration),
+					rl.Delta),
+			)(privateNoteEndpoint)
+
+		privateNoteEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				bkr.Standardize()),
+			)(privateNoteEndpoint)
+	}
+
+	var getNoteEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.GetNoteServiceName
+			rl = apis[name].RateLimit
+			bkr = apis[name].Breaker
+		)
+
+		getNoteEndpoint = grpctransport.NewClient(
+			conn,
+			serviceName,
+			name,
+			grpcencode.GetNoteRequest,
+			grpcdecode.GetNoteResponse,
+			pb.GetNoteResponse{},
+			append(
+				options,
+				grpctransport.ClientBefore(
+					opentracing.ContextToGRPC(otTracer, logger)),
+			)...,
+		).Endpoint()
+
+		getNoteEndpoint = opentracing.TraceClient(otTracer, name)(getNoteEndpoint)
+
+		getNoteEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(
+					rl.Duration),
+					rl.Delta),
+		)(getNoteEndpoint)
+
+		getNoteEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				bkr.Standardize()),
+		)(getNoteEndpoint)
+	}
+
+	var syncNotesEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.SyncNotesServiceName
+			rl = apis[name].RateLimit
+			bkr = apis[name].Breaker
+		)
+
+		syncNotesEndpoint = grpctransport.NewClient(
+			conn,
+			serviceName,
+			name,
+			grpcencode.SyncNotesRequest,
+			grpcdecode.SyncNotesResponse,
+			pb.SyncNotesResponse{},
+			append(
+				options,
+				grpctransport.ClientBefore(
+					opentracing.ContextToGRPC(otTracer, logger)),
+			)...,
+		).Endpoint()
+
+		syncNotesEndpoint = opentracing.TraceClient(otTracer, name)(syncNotesEndpoint)
+
+		syncNotesEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(
+					rl.Duration),
+					rl.Delta),
+		)(syncNotesEndpoint)
+
+		syncNotesEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				bkr.Standardize()),
+		)(syncNotesEndpoint)
+	}
+
+	var forkNoteEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.ForkNoteServiceName
+			rl = apis[name].RateLimit
+			bkr = apis[name].Breaker
+		)
+
+		forkNoteEndpoint = grpctransport.NewClient(
+			conn,
+			serviceName,
+			name,
+			grpcencode.ForkNoteRequest,
+			grpcdecode.ForkNoteResponse,
+			pb.ForkNoteResponse{},
+			append(
+				options,
+				grpctransport.ClientBefore(
+					opentracing.ContextToGRPC(otTracer, logger)),
+			)...,
+		).Endpoint()
+
+		forkNoteEndpoint = opentracing.TraceClient(otTracer, name)(forkNoteEndpoint)
+
+		forkNoteEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(
+					rl.Duration),
+					rl.Delta),
+		)(forkNoteEndpoint)
+
+		forkNoteEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				bkr.Standardize()),
+		)(forkNoteEndpoint)
+	}
+
+	var listForksEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.ListForksServiceName
+			rl = apis[name].RateLimit
+			bkr = apis[name].Breaker
+		)
+
+		listForksEndpoint = grpctransport.NewClient(
+			conn,
+			serviceName,
+			name,
+			grpcencode.ListForksRequest,
+			grpcdecode.ListForksResponse,
+			pb.ListForksResponse{},
+			append(
+				options,
+				grpctransport.ClientBefore(
+					opentracing.ContextToGRPC(otTracer, logger)),
+			)...,
+		).Endpoint()
+
+		listForksEndpoint = opentracing.TraceClient(otTracer, name)(listForksEndpoint)
+
+		listForksEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(
+					rl.Duration),
+					rl.Delta),
+		)(listForksEndpoint)
+
+		listForksEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				bkr.Standardize()),
+		)(listForksEndpoint)
+	}
+
+	// Streaming RPCs are not supported by grpctransport.Client, the client
+	// endpoints are built on the generated pb.ShareClient instead.
+	var client = pb.NewShareClient(conn)
+
+	var shareNoteStreamEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.ShareNoteStreamServiceName
+			rl = apis[name].RateLimit
+			bkr = apis[name].Breaker
+		)
+
+		shareNoteStreamEndpoint = func(ctx context.Context, request interface{}) (interface{}, error) {
+			stream, err := client.ShareNoteStream(contextToGRPC(ctx, otTracer, logger))
+			if err != nil {
+				return nil, err
+			}
+
+			err = grpcencode.ShareNoteStreamRequest(ctx, request, streamChunkSize(), stream.Send)
+			if err != nil {
+				return nil, err
+			}
+
+			reply, err := stream.CloseAndRecv()
+			if err != nil {
+				return nil, err
+			}
+			return grpcdecode.ShareNoteResponse(ctx, reply)
+		}
+
+		shareNoteStreamEndpoint = opentracing.TraceClient(otTracer, name)(shareNoteStreamEndpoint)
+
+		shareNoteStreamEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(
+					rl.Duration),
+					rl.Delta),
+		)(shareNoteStreamEndpoint)
+
+		shareNoteStreamEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				bkr.Standardize()),
+		)(shareNoteStreamEndpoint)
+	}
+
+	var getNoteStreamEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.GetNoteStreamServiceName
+			rl = apis[name].RateLimit
+			bkr = apis[name].Breaker
+		)
+
+		getNoteStreamEndpoint = func(ctx context.Context, request interface{}) (interface{}, error) {
+			req, err := grpcencode.GetNoteStreamRequest(ctx, request)
+			if err != nil {
+				return nil, err
+			}
+
+			// the stream outlives this call, it is cancelled when the content is closed
+			streamCtx, cancel := context.WithCancel(contextToGRPC(ctx, otTracer, logger))
+			stream, err := client.GetNoteStream(streamCtx, req.(*pb.GetNoteRequest))
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+
+			first, err := stream.Recv()
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			return grpcdecode.GetNoteStreamResponse(first, stream.Recv, cancel)
+		}
+
+		getNoteStreamEndpoint = opentracing.TraceClient(otTracer, name)(getNoteStreamEndpoint)
+
+		getNoteStreamEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(
+					rl.Duration),
+					rl.Delta),
+		)(getNoteStreamEndpoint)
+
+		getNoteStreamEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				bkr.Standardize()),
+		)(getNoteStreamEndpoint)
+	}
+
+	var uploadAttachmentEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.UploadAttachmentServiceName
+			rl = apis[name].RateLimit
+			bkr = apis[name].Breaker
+		)
+
+		uploadAttachmentEndpoint = func(ctx context.Context, request interface{}) (interface{}, error) {
+			stream, err := client.UploadAttachment(contextToGRPC(ctx, otTracer, logger))
+			if err != nil {
+				return nil, err
+			}
+
+			err = grpcencode.UploadAttachmentRequest(ctx, request, streamChunkSize(), stream.Send)
+			if err != nil {
+				return nil, err
+			}
+
+			reply, err := stream.CloseAndRecv()
+			if err != nil {
+				return nil, err
+			}
+			return grpcdecode.UploadAttachmentResponse(ctx, reply)
+		}
+
+		uploadAttachmentEndpoint = opentracing.TraceClient(otTracer, name)(uploadAttachmentEndpoint)
+
+		uploadAttachmentEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(
+					rl.Duration),
+					rl.Delta),
+		)(uploadAttachmentEndpoint)
+
+		uploadAttachmentEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				bkr.Standardize()),
+		)(uploadAttachmentEndpoint)
+	}
+
+	var downloadAttachmentEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.DownloadAttachmentServiceName
+			rl = apis[name].RateLimit
+			bkr = apis[name].Breaker
+		)
+
+		downloadAttachmentEndpoint = func(ctx context.Context, request interface{}) (interface{}, error) {
+			req, err := grpcencode.DownloadAttachmentRequest(ctx, request)
+			if err != nil {
+				return nil, err
+			}
+
+			// the stream outlives this call, it is cancelled when the content is closed
+			streamCtx, cancel := context.WithCancel(contextToGRPC(ctx, otTracer, logger))
+			stream, err := client.DownloadAttachment(streamCtx, req.(*pb.DownloadAttachmentRequest))
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+
+			first, err := stream.Recv()
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			return grpcdecode.DownloadAttachmentResponse(first, stream.Recv, cancel)
+		}
+
+		downloadAttachmentEndpoint = opentracing.TraceClient(otTracer, name)(downloadAttachmentEndpoint)
+
+		downloadAttachmentEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(
+					rl.Duration),
+					rl.Delta),
+		)(downloadAttachmentEndpoint)
+
+		downloadAttachmentEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				bkr.Standardize()),
+		)(downloadAttachmentEndpoint)
+	}
+
+	var watchNoteEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.WatchNoteServiceName
+			rl = apis[name].RateLimit
+			bkr = apis[name].Breaker
+		)
+
+		watchNoteEndpoint = func(ctx context.Context, request interface{}) (interface{}, error) {
+			req, err := grpcencode.WatchNoteRequest(ctx, request)
+			if err != nil {
+				return nil, err
+			}
+
+			// the stream outlives this call, it ends when ctx is done
+			stream, err := client.WatchNote(contextToGRPC(ctx, otTracer, logger), req.(*pb.WatchNoteRequest))
+			if err != nil {
+				return nil, err
+			}
+
+			// wait for the headers, so a note that cannot be watched fails here
+			// instead of closing the events channel.
+			if _, err = stream.Header(); err != nil {
+				return nil, err
+			}
+			return grpcdecode.WatchNoteResponse(ctx, stream.Recv)
+		}
+
+		watchNoteEndpoint = opentracing.TraceClient(otTracer, name)(watchNoteEndpoint)
+
+		watchNoteEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(
+					rl.Duration),
+					rl.Delta),
+		)(watchNoteEndpoint)
+
+		watchNoteEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				bkr.Standardize()),
+		)(watchNoteEndpoint)
+	}
+
+	var watchNotesEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.WatchNotesServiceName
+			rl = apis[name].RateLimit
+			bkr = apis[name].Breaker
+		)
+
+		watchNotesEndpoint = func(ctx context.Context, request interface{}) (interface{}, error) {
+			req, err := grpcencode.WatchNotesRequest(ctx, request)
+			if err != nil {
+				return nil, err
+			}
+
+			// the stream outlives this call, it ends when ctx is done
+			stream, err := client.WatchNotes(contextToGRPC(ctx, otTracer, logger), req.(*pb.WatchNotesRequest))
+			if err != nil {
+				return nil, err
+			}
+
+			if _, err = stream.Header(); err != nil {
+				return nil, err
+			}
+			return grpcdecode.WatchNotesResponse(ctx, stream.Recv)
+		}
+
+		watchNotesEndpoint = opentracing.TraceClient(otTracer, name)(watchNotesEndpoint)
+
+		watchNotesEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(
+					rl.Duration),
+					rl.Delta),
+		)(watchNotesEndpoint)
+
+		watchNotesEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				bkr.Standardize()),
+		)(watchNotesEndpoint)
+	}
+
+	var editNoteEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.EditNoteServiceName
+			rl = apis[name].RateLimit
+			bkr = apis[name].Breaker
+		)
+
+		editNoteEndpoint = func(ctx context.Context, request interface{}) (interface{}, error) {
+			join, err := grpcencode.EditNoteRequest(ctx, request)
+			if err != nil {
+				return nil, err
+			}
+
+			// the stream outlives this call, it is cancelled when the session is closed
+			streamCtx, cancel := context.WithCancel(contextToGRPC(ctx, otTracer, logger))
+			stream, err := client.EditNote(streamCtx)
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+
+			if err = stream.Send(join); err != nil {
+				cancel()
+				return nil, err
+			}
+
+			first, err := stream.Recv()
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			return grpcdecode.EditNoteResponse(first, stream, cancel)
+		}
+
+		editNoteEndpoint = opentracing.TraceClient(otTracer, name)(editNoteEndpoint)
+
+		editNoteEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(
+					rl.Duration),
+					rl.Delta),
+		)(editNoteEndpoint)
+
+		editNoteEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				bkr.Standardize()),
+		)(editNoteEndpoint)
+	}
+
+	var markTemplateEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.MarkTemplateServiceName
+			rl = apis[name].RateLimit
+			bkr = apis[name].Breaker
+		)
+
+		markTemplateEndpoint = grpctransport.NewClient(
+			conn,
+			serviceName,
+			name,
+			grpcencode.MarkTemplateRequest,
+			grpcdecode.MarkTemplateResponse,
+			pb.MarkTemplateResponse{},
+			append(
+				options,
+				grpctransport.ClientBefore(
+					opentracing.ContextToGRPC(otTracer, logger)),
+			)...,
+		).Endpoint()
+
+		markTemplateEndpoint = opentracing.TraceClient(otTracer, name)(markTemplateEndpoint)
+
+		markTemplateEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(
+					rl.Duration),
+					rl.Delta),
+		)(markTemplateEndpoint)
+
+		markTemplateEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				bkr.Standardize()),
+		)(markTemplateEndpoint)
+	}
+
+	var instantiateTemplateEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.InstantiateTemplateServiceName
+			rl = apis[name].RateLimit
+			bkr = apis[name].Breaker
+		)
+
+		instantiateTemplateEndpoint = grpctransport.NewClient(
+			conn,
+			serviceName,
+			name,
+			grpcencode.InstantiateTemplateRequest,
+			grpcdecode.InstantiateTemplateResponse,
+			pb.InstantiateTemplateResponse{},
+			append(
+				options,
+				grpctransport.ClientBefore(
+					opentracing.ContextToGRPC(otTracer, logger)),
+			)...,
+		).Endpoint()
+
+		instantiateTemplateEndpoint = opentracing.TraceClient(otTracer, name)(instantiateTemplateEndpoint)
+
+		instantiateTemplateEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(
+					rl.Duration),
+					rl.Delta),
+		)(instantiateTemplateEndpoint)
+
+		instantiateTemplateEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				bkr.Standardize()),
+		)(instantiateTemplateEndpoint)
+	}
+
+	var createCollectionEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.CreateCollectionServiceName
+			rl = apis[name].RateLimit
+			bkr = apis[name].Breaker
+		)
+
+		createCollectionEndpoint = grpctransport.NewClient(
+			conn,
+			serviceName,
+			name,
+			grpcencode.CreateCollectionRequest,
+			grpcdecode.CreateCollectionResponse,
+			pb.CreateCollectionResponse{},
+			append(
+				options,
+				grpctransport.ClientBefore(
+					opentracing.ContextToGRPC(otTracer, logger)),
+			)...,
+		).Endpoint()
+
+		createCollectionEndpoint = opentracing.TraceClient(otTracer, name)(createCollectionEndpoint)
+
+		createCollectionEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(
+					rl.Duration),
+					rl.Delta),
+		)(createCollectionEndpoint)
 
-func (g GRPCServer) PrivateNote(ctx context.Context, request *pb.PrivateNoteRequest) (*pb.PrivateNoteResponse, error) {
-	_, resp, err := g.privateNote.ServeGRPC(ctx, request)
-	if err != nil {
-		return nil, err
+		createCollectionEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				bkr.Standardize()),
+		)(createCollectionEndpoint)
 	}
-	return resp.(*pb.PrivateNoteResponse), nil
-}
 
-func (g GRPCServer) GetNote(ctx context.Context, request *pb.GetNoteRequest) (*pb.GetNoteResponse, error) {
-	_, resp, err := g.getNote.ServeGRPC(ctx, request)
-	if err != nil {
-		return nil, err
-	}
-	return resp.(*pb.GetNoteResponse), nil
-}
+	var addCollectionNotesEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.AddCollectionNotesServiceName
+			rl = apis[name].RateLimit
+			bkr = apis[name].Breaker
+		)
 
-func NewGRPCServer(set serviceendpoint.Set, otTracer stdopentracing.Tracer, logger log.Logger, apis bootapi.APIs) pb.ShareServer  {
-	options := []grpctransport.ServerOption{
-		grpctransport.ServerErrorHandler(transport.NewLogErrorHandler(logger)),
+		addCollectionNotesEndpoint = grpctransport.NewClient(
+			conn,
+			serviceName,
+			name,
+			grpcencode.AddCollectionNotesRequest,
+			grpcdecode.AddCollectionNotesResponse,
+			pb.AddCollectionNotesResponse{},
+			append(
+				options,
+				grpctransport.ClientBefore(
+					opentracing.ContextToGRPC(otTracer, logger)),
+			)...,
+		).Endpoint()
+
+		addCollectionNotesEndpoint = opentracing.TraceClient(otTracer, name)(addCollectionNotesEndpoint)
+
+		addCollectionNotesEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(
+					rl.Duration),
+					rl.Delta),
+		)(addCollectionNotesEndpoint)
+
+		addCollectionNotesEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				bkr.Standardize()),
+		)(addCollectionNotesEndpoint)
 	}
 
-	//if zipkinTracer != nil {
-	//	// Zipkin GRPC Server Trace can either be instantiated per gRPC method with a
-	//	// provided operation name or a global tracing service can be instantiated
-	//	// without an operation name and fed to each Go kit gRPC server as a
-	//	// ServerOption.
-	//	// In the latter case, the operation name will be the endpoint's grpc method
-	//	// path if used in combination with the Go kit gRPC Interceptor.
-	//	//
-	//	// In this example, we demonstrate a global Zipkin tracing service with
-	//	// Go kit gRPC Interceptor.
-	//	options = append(options, zipkin.GRPCServerTrace(zipkinTracer))
-	//}
+	var removeCollectionNotesEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.RemoveCollectionNotesServiceName
+			rl = apis[name].RateLimit
+			bkr = apis[name].Breaker
+		)
 
-	return &GRPCServer{
-		shareNote:   grpctransport.NewServer(
-			set.ShareNoteEndpoint,
-			grpcdecode.ShareNoteRequest,
-			grpcencode.ShareNoteResponse,
+		removeCollectionNotesEndpoint = grpctransport.NewClient(
+			conn,
+			serviceName,
+			name,
+			grpcencode.RemoveCollectionNotesRequest,
+			grpcdecode.RemoveCollectionNotesResponse,
+			pb.RemoveCollectionNotesResponse{},
 			append(
 				options,
-				grpctransport.ServerBefore(
-					opentracing.GRPCToContext(
-						otTracer,
-						"ShareNote",
-						logger)),
-				)...,
-			),
-		privateNote: grpctransport.NewServer(
-			set.PrivateNoteEndpoint,
-			grpcdecode.PrivateNoteRequest,
-			grpcencode.PrivateNoteResponse,
+				grpctransport.ClientBefore(
+					opentracing.ContextToGRPC(otTracer, logger)),
+			)...,
+		).Endpoint()
+
+		removeCollectionNotesEndpoint = opentracing.TraceClient(otTracer, name)(removeCollectionNotesEndpoint)
+
+		removeCollectionNotesEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(
+					rl.Duration),
+					rl.Delta),
+		)(removeCollectionNotesEndpoint)
+
+		removeCollectionNotesEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				bkr.Standardize()),
+		)(removeCollectionNotesEndpoint)
+	}
+
+	var reorderCollectionEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.ReorderCollectionServiceName
+			rl = apis[name].RateLimit
+			bkr = apis[name].Breaker
+		)
+
+		reorderCollectionEndpoint = grpctransport.NewClient(
+			conn,
+			serviceName,
+			name,
+			grpcencode.ReorderCollectionRequest,
+			grpcdecode.ReorderCollectionResponse,
+			pb.ReorderCollectionResponse{},
 			append(
 				options,
-				grpctransport.ServerBefore(
-					opentracing.GRPCToContext(
-						otTracer,
-						"PrivateNote",
-						logger)),
+				grpctransport.ClientBefore(
+					opentracing.ContextToGRPC(otTracer, logger)),
 			)...,
-		),
-		getNote:    grpctransport.NewServer(
-			set.GetNoteEndpoint,
-			grpcdecode.GetNoteRequest,
-			grpcencode.GetNoteResponse,
+		).Endpoint()
+
+		reorderCollectionEndpoint = opentracing.TraceClient(otTracer, name)(reorderCollectionEndpoint)
+
+		reorderCollectionEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(
+					rl.Duration),
+					rl.Delta),
+		)(reorderCollectionEndpoint)
+
+		reorderCollectionEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				bkr.Standardize()),
+		)(reorderCollectionEndpoint)
+	}
+
+	var getCollectionEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.GetCollectionServiceName
+			rl = apis[name].RateLimit
+			bkr = apis[name].Breaker
+		)
+
+		getCollectionEndpoint = grpctransport.NewClient(
+			conn,
+			serviceName,
+			name,
+			grpcencode.GetCollectionRequest,
+			grpcdecode.GetCollectionResponse,
+			pb.GetCollectionResponse{},
 			append(
 				options,
-				grpctransport.ServerBefore(
-					opentracing.GRPCToContext(
-						otTracer,
-						"GetNote",
-						logger)),
+				grpctransport.ClientBefore(
+					opentracing.ContextToGRPC(otTracer, logger)),
 			)...,
-		),
-	}
-}
+		).Endpoint()
 
-func NewGRPCClient(conn *grpc.ClientConn, otTracer stdopentracing.Tracer, logger log.Logger, apis bootapi.APIs) shareservice.Service {
+		getCollectionEndpoint = opentracing.TraceClient(otTracer, name)(getCollectionEndpoint)
 
-	// global client middlewares
-	var (
-		options []grpctransport.ClientOption
-		serviceName = "pb.Share"
-	)
+		getCollectionEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(
+					rl.Duration),
+					rl.Delta),
+		)(getCollectionEndpoint)
 
-	// Each individual endpoint is an grpc/transport.Client (which implements
-	// endpoint.Endpoint) that gets wrapped with various middlewares. If you
-	// made your own client library, you'd do this work there, so your server
-	// could rely on a consistent set of client behavior.
-	var shareNoteEndpoint endpoint.Endpoint
+		getCollectionEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				bkr.Standardize()),
+		)(getCollectionEndpoint)
+	}
+
+	var addCommentEndpoint endpoint.Endpoint
 	{
 		var (
+			name = shareservice.AddCommentServiceName
+			rl = apis[name].RateLimit
+			bkr = apis[name].Breaker
+		)
 
-			name = shareservice.ShareNoteServiceName
+		addCommentEndpoint = grpctransport.NewClient(
+			conn,
+			serviceName,
+			name,
+			grpcencode.AddCommentRequest,
+			grpcdecode.AddCommentResponse,
+			pb.AddCommentResponse{},
+			append(
+				options,
+				grpctransport.ClientBefore(
+					opentracing.ContextToGRPC(otTracer, logger)),
+			)...,
+		).Endpoint()
+
+		addCommentEndpoint = opentracing.TraceClient(otTracer, name)(addCommentEndpoint)
+
+		addCommentEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(
+					rl.Duration),
+					rl.Delta),
+		)(addCommentEndpoint)
+
+		addCommentEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				bkr.Standardize()),
+		)(addCommentEndpoint)
+	}
+
+	var listCommentsEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.ListCommentsServiceName
 			rl = apis[name].RateLimit
 			bkr = apis[name].Breaker
 		)
 
-		shareNoteEndpoint = grpctransport.NewClient(
+		listCommentsEndpoint = grpctransport.NewClient(
 			conn,
 			serviceName,
 			name,
-			grpcencode.ShareNoteRequest,
-			grpcdecode.ShareNoteResponse,
-			pb.ShareNoteResponse{},
+			grpcencode.ListCommentsRequest,
+			grpcdecode.ListCommentsResponse,
+			pb.ListCommentsResponse{},
 			append(
 				options,
 				grpctransport.ClientBefore(
-					opentracing.ContextToGRPC(
-							otTracer,
-							logger,
-						),
-					),
-				)...,
+					opentracing.ContextToGRPC(otTracer, logger)),
+			)...,
 		).Endpoint()
 
-		shareNoteEndpoint = opentracing.TraceClient(otTracer, name)(shareNoteEndpoint)
+		listCommentsEndpoint = opentracing.TraceClient(otTracer, name)(listCommentsEndpoint)
 
-		// We construct a single ratelimiter middleware, to limit the total outgoing
-		// QPS from this client to all methods on the remote instance. We also
-		// construct per-endpoint circuitbreaker middlewares to demonstrate how
-		// that's done, although they could easily be combined into a single breaker
-		// for the entire remote instance, too.
-		shareNoteEndpoint = ratelimit.NewErroringLimiter(
+		listCommentsEndpoint = ratelimit.NewErroringLimiter(
 			rate.NewLimiter(
-				rate.Every(rl.Duration),
-				rl.Delta),
-			)(shareNoteEndpoint)
+				rate.Every(
+					rl.Duration),
+					rl.Delta),
+		)(listCommentsEndpoint)
 
-		shareNoteEndpoint = circuitbreaker.Gobreaker(
+		listCommentsEndpoint = circuitbreaker.Gobreaker(
 			gobreaker.NewCircuitBreaker(
 				bkr.Standardize()),
-			)(shareNoteEndpoint)
+		)(listCommentsEndpoint)
 	}
 
-	var privateNoteEndpoint endpoint.Endpoint
+	var resolveCommentEndpoint endpoint.Endpoint
 	{
 		var (
-			name = shareservice.PrivateNoteServiceName
+			name = shareservice.ResolveCommentServiceName
 			rl = apis[name].RateLimit
 			bkr = apis[name].Breaker
 		)
 
-		privateNoteEndpoint = grpctransport.NewClient(
+		resolveCommentEndpoint = grpctransport.NewClient(
 			conn,
 			serviceName,
 			name,
-			grpcencode.PrivateNoteRequest,
-			grpcdecode.PrivateNoteResponse,
-			pb.PrivateNoteResponse{},
+			grpcencode.ResolveCommentRequest,
+			grpcdecode.ResolveCommentResponse,
+			pb.ResolveCommentResponse{},
 			append(
 				options,
 				grpctransport.ClientBefore(
 					opentracing.ContextToGRPC(otTracer, logger)),
-				)...,
+			)...,
 		).Endpoint()
 
-		privateNoteEndpoint = opentracing.TraceClient(otTracer, name)(privateNoteEndpoint)
+		resolveCommentEndpoint = opentracing.TraceClient(otTracer, name)(resolveCommentEndpoint)
 
-		privateNoteEndpoint = ratelimit.NewErroringLimiter(
+		resolveCommentEndpoint = ratelimit.NewErroringLimiter(
 			rate.NewLimiter(
 				rate.Every(
 					rl.Duration),
 					rl.Delta),
-			)(privateNoteEndpoint)
+		)(resolveCommentEndpoint)
 
-		privateNoteEndpoint = circuitbreaker.Gobreaker(
+		resolveCommentEndpoint = circuitbreaker.Gobreaker(
 			gobreaker.NewCircuitBreaker(
 				bkr.Standardize()),
-			)(privateNoteEndpoint)
+		)(resolveCommentEndpoint)
 	}
 
-	var getNoteEndpoint endpoint.Endpoint
+	var getNoteStatsEndpoint endpoint.Endpoint
 	{
 		var (
-			name = shareservice.GetNoteServiceName
+			name = shareservice.GetNoteStatsServiceName
 			rl = apis[name].RateLimit
 			bkr = apis[name].Breaker
 		)
 
-		getNoteEndpoint = grpctransport.NewClient(
+		getNoteStatsEndpoint = grpctransport.NewClient(
 			conn,
 			serviceName,
 			name,
-			grpcencode.GetNoteRequest,
-			grpcdecode.GetNoteResponse,
-			pb.GetNoteResponse{},
+			grpcencode.GetNoteStatsRequest,
+			grpcdecode.GetNoteStatsResponse,
+			pb.GetNoteStatsResponse{},
 			append(
 				options,
 				grpctransport.ClientBefore(
@@ -229,19 +1647,19 @@ func NewGRPCClient(conn *grpc.ClientConn, otTracer stdopentracing.Tracer, logger
 			)...,
 		).Endpoint()
 
-		getNoteEndpoint = opentracing.TraceClient(otTracer, name)(getNoteEndpoint)
+		getNoteStatsEndpoint = opentracing.TraceClient(otTracer, name)(getNoteStatsEndpoint)
 
-		getNoteEndpoint = ratelimit.NewErroringLimiter(
+		getNoteStatsEndpoint = ratelimit.NewErroringLimiter(
 			rate.NewLimiter(
 				rate.Every(
 					rl.Duration),
 					rl.Delta),
-		)(getNoteEndpoint)
+		)(getNoteStatsEndpoint)
 
-		getNoteEndpoint = circuitbreaker.Gobreaker(
+		getNoteStatsEndpoint = circuitbreaker.Gobreaker(
 			gobreaker.NewCircuitBreaker(
 				bkr.Standardize()),
-		)(getNoteEndpoint)
+		)(getNoteStatsEndpoint)
 	}
 
 	// Returning the endpoint.Endpoints as a service.Service relies on the
@@ -251,6 +1669,43 @@ func NewGRPCClient(conn *grpc.ClientConn, otTracer stdopentracing.Tracer, logger
 		ShareNoteEndpoint: shareNoteEndpoint,
 		PrivateNoteEndpoint: privateNoteEndpoint,
 		GetNoteEndpoint: getNoteEndpoint,
+		ShareNoteStreamEndpoint: shareNoteStreamEndpoint,
+		GetNoteStreamEndpoint: getNoteStreamEndpoint,
+		WatchNoteEndpoint: watchNoteEndpoint,
+		WatchNotesEndpoint: watchNotesEndpoint,
+		EditNoteEndpoint: editNoteEndpoint,
+		SyncNotesEndpoint: syncNotesEndpoint,
+		ForkNoteEndpoint: forkNoteEndpoint,
+		ListForksEndpoint: listForksEndpoint,
+		MarkTemplateEndpoint: markTemplateEndpoint,
+		InstantiateTemplateEndpoint: instantiateTemplateEndpoint,
+		CreateCollectionEndpoint: createCollectionEndpoint,
+		AddCollectionNotesEndpoint: addCollectionNotesEndpoint,
+		RemoveCollectionNotesEndpoint: removeCollectionNotesEndpoint,
+		ReorderCollectionEndpoint: reorderCollectionEndpoint,
+		GetCollectionEndpoint: getCollectionEndpoint,
+		UploadAttachmentEndpoint: uploadAttachmentEndpoint,
+		DownloadAttachmentEndpoint: downloadAttachmentEndpoint,
+		AddCommentEndpoint: addCommentEndpoint,
+		ListCommentsEndpoint: listCommentsEndpoint,
+		ResolveCommentEndpoint: resolveCommentEndpoint,
+		GetNoteStatsEndpoint: getNoteStatsEndpoint,
+	}
+}
+
+// contextToGRPC does for streaming RPCs what the ClientBefore options do for
+// the unary ones.
+func contextToGRPC(ctx context.Context, otTracer stdopentracing.Tracer, logger log.Logger) context.Context {
+	md := metadata.MD{}
+	ctx = opentracing.ContextToGRPC(otTracer, logger)(ctx, &md)
+	ctx = analytics.ContextToGRPC()(ctx, &md)
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+func streamChunkSize() int {
+	if size := config.GetConfig().Service.Stream.ChunkSize; size > 0 {
+		return size
 	}
+	return config.DefaultStreamChunkSize
 }
 
@@ -1,6 +1,8 @@
 package transport
 
 import (
+	"context"
+	"github.com/al8n/shareable-notes/share-svc/internal/analytics"
 	"github.com/al8n/shareable-notes/share-svc/internal/codec/httpcodec"
 	"github.com/al8n/shareable-notes/share-svc/internal/codec/httpcodec/httpdecode"
 	"github.com/al8n/shareable-notes/share-svc/internal/codec/httpcodec/httpencode"
@@ -15,9 +17,11 @@ import (
 	"github.com/go-kit/kit/transport"
 	httptransport "github.com/go-kit/kit/transport/http"
 	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
 	stdopentracing "github.com/opentracing/opentracing-go"
 	"github.com/sony/gobreaker"
 	"golang.org/x/time/rate"
+	"net/http"
 	"net/url"
 	"strings"
 )
@@ -29,6 +33,8 @@ func NewHTTPHandler(endpoints serviceendpoint.Set, otTracer stdopentracing.Trace
 	options := []httptransport.ServerOption{
 		httptransport.ServerErrorEncoder(httpcodec.ErrorEncoder),
 		httptransport.ServerErrorHandler(transport.NewLogErrorHandler(logger)),
+		httptransport.ServerBefore(analytics.HTTPToContext(trustedProxies())),
+		httptransport.ServerBefore(httpcodec.NegotiateToContext()),
 	}
 
 	//if zipkinTracer != nil {
@@ -46,15 +52,34 @@ func NewHTTPHandler(endpoints serviceendpoint.Set, otTracer stdopentracing.Trace
 		sn bootapi.API
 		pn bootapi.API
 		gn bootapi.API
+		wn bootapi.API
+		en bootapi.API
+		syn bootapi.API
+		fn bootapi.API
+		lf bootapi.API
+		mt bootapi.API
+		it bootapi.API
+		cc bootapi.API
+		acn bootapi.API
+		rcn bootapi.API
+		roc bootapi.API
+		gc bootapi.API
+		ua bootapi.API
+		da bootapi.API
+		ac bootapi.API
+		lc bootapi.API
+		rc bootapi.API
+		gs bootapi.API
 	)
 	{
 		r = mux.NewRouter()
+		r.Use(CompressHandler)
 		sn = apis[shareservice.ShareNoteServiceName]
 
 
 		r.Methods(sn.Method).Path(sn.Path).Handler(	httptransport.NewServer(
 			endpoints.ShareNoteEndpoint,
-			httpdecode.ShareNoteRequest,
+			httpcodec.Negotiated(httpdecode.ShareNoteRequest),
 			httpencode.ShareNoteResponse,
 			append(options, httptransport.ServerBefore(opentracing.HTTPToContext(otTracer, "ShareNote", logger)))...,
 		))
@@ -62,7 +87,7 @@ func NewHTTPHandler(endpoints serviceendpoint.Set, otTracer stdopentracing.Trace
 		pn = apis[shareservice.PrivateNoteServiceName]
 		r.Methods(pn.Method).Path(pn.Path).Handler(httptransport.NewServer(
 			endpoints.PrivateNoteEndpoint,
-			httpdecode.PrivateNoteRequest,
+			httpcodec.Negotiated(httpdecode.PrivateNoteRequest),
 			httpencode.PrivateNoteResponse,
 			append(options, httptransport.ServerBefore(opentracing.HTTPToContext(otTracer, "PrivateNote", logger)))...,
 		))
@@ -70,11 +95,154 @@ func NewHTTPHandler(endpoints serviceendpoint.Set, otTracer stdopentracing.Trace
 		gn = apis[shareservice.GetNoteServiceName]
 		r.Methods(gn.Method).Path(gn.Path).Handler(httptransport.NewServer(
 			endpoints.GetNoteEndpoint,
-			httpdecode.GetNoteRequest,
+			httpcodec.Negotiated(httpdecode.GetNoteRequest),
 			httpencode.GetNoteResponse,
 			append(options, httptransport.ServerBefore(opentracing.HTTPToContext(otTracer, "GetNote", logger)))...,
 		))
 
+		wn = apis[shareservice.WatchNoteServiceName]
+		r.Methods(wn.Method).Path(wn.Path).Handler(httptransport.NewServer(
+			endpoints.WatchNoteEndpoint,
+			httpdecode.WatchNoteRequest,
+			httpencode.WatchNoteResponse,
+			append(options, httptransport.ServerBefore(opentracing.HTTPToContext(otTracer, "WatchNote", logger), untilShutdownRequest))...,
+		))
+
+		en = apis[shareservice.EditNoteServiceName]
+		r.Methods(en.Method).Path(en.Path).Handler(NewEditNoteHandler(
+			endpoints.EditNoteEndpoint,
+			otTracer,
+			logger,
+		))
+
+		syn = apis[shareservice.SyncNotesServiceName]
+		r.Methods(syn.Method).Path(syn.Path).Handler(httptransport.NewServer(
+			endpoints.SyncNotesEndpoint,
+			httpcodec.Negotiated(httpdecode.SyncNotesRequest),
+			httpencode.SyncNotesResponse,
+			append(options, httptransport.ServerBefore(opentracing.HTTPToContext(otTracer, "SyncNotes", logger)))...,
+		))
+
+		fn = apis[shareservice.ForkNoteServiceName]
+		r.Methods(fn.Method).Path(fn.Path).Handler(httptransport.NewServer(
+			endpoints.ForkNoteEndpoint,
+			httpcodec.Negotiated(httpdecode.ForkNoteRequest),
+			httpencode.ForkNoteResponse,
+			append(options, httptransport.ServerBefore(opentracing.HTTPToContext(otTracer, "ForkNote", logger)))...,
+		))
+
+		lf = apis[shareservice.ListForksServiceName]
+		r.Methods(lf.Method).Path(lf.Path).Handler(httptransport.NewServer(
+			endpoints.ListForksEndpoint,
+			httpcodec.Negotiated(httpdecode.ListForksRequest),
+			httpencode.ListForksResponse,
+			append(options, httptransport.ServerBefore(opentracing.HTTPToContext(otTracer, "ListForks", logger)))...,
+		))
+
+		mt = apis[shareservice.MarkTemplateServiceName]
+		r.Methods(mt.Method).Path(mt.Path).Handler(httptransport.NewServer(
+			endpoints.MarkTemplateEndpoint,
+			httpcodec.Negotiated(httpdecode.MarkTemplateRequest),
+			httpencode.MarkTemplateResponse,
+			append(options, httptransport.ServerBefore(opentracing.HTTPToContext(otTracer, "MarkTemplate", logger)))...,
+		))
+
+		it = apis[shareservice.InstantiateTemplateServiceName]
+		r.Methods(it.Method).Path(it.Path).Handler(httptransport.NewServer(
+			endpoints.InstantiateTemplateEndpoint,
+			httpcodec.Negotiated(httpdecode.InstantiateTemplateRequest),
+			httpencode.InstantiateTemplateResponse,
+			append(options, httptransport.ServerBefore(opentracing.HTTPToContext(otTracer, "InstantiateTemplate", logger)))...,
+		))
+
+		cc = apis[shareservice.CreateCollectionServiceName]
+		r.Methods(cc.Method).Path(cc.Path).Handler(httptransport.NewServer(
+			endpoints.CreateCollectionEndpoint,
+			httpcodec.Negotiated(httpdecode.CreateCollectionRequest),
+			httpencode.CreateCollectionResponse,
+			append(options, httptransport.ServerBefore(opentracing.HTTPToContext(otTracer, "CreateCollection", logger)))...,
+		))
+
+		acn = apis[shareservice.AddCollectionNotesServiceName]
+		r.Methods(acn.Method).Path(acn.Path).Handler(httptransport.NewServer(
+			endpoints.AddCollectionNotesEndpoint,
+			httpcodec.Negotiated(httpdecode.AddCollectionNotesRequest),
+			httpencode.AddCollectionNotesResponse,
+			append(options, httptransport.ServerBefore(opentracing.HTTPToContext(otTracer, "AddCollectionNotes", logger)))...,
+		))
+
+		rcn = apis[shareservice.RemoveCollectionNotesServiceName]
+		r.Methods(rcn.Method).Path(rcn.Path).Handler(httptransport.NewServer(
+			endpoints.RemoveCollectionNotesEndpoint,
+			httpcodec.Negotiated(httpdecode.RemoveCollectionNotesRequest),
+			httpencode.RemoveCollectionNotesResponse,
+			append(options, httptransport.ServerBefore(opentracing.HTTPToContext(otTracer, "RemoveCollectionNotes", logger)))...,
+		))
+
+		roc = apis[shareservice.ReorderCollectionServiceName]
+		r.Methods(roc.Method).Path(roc.Path).Handler(httptransport.NewServer(
+			endpoints.ReorderCollectionEndpoint,
+			httpcodec.Negotiated(httpdecode.ReorderCollectionRequest),
+			httpencode.ReorderCollectionResponse,
+			append(options, httptransport.ServerBefore(opentracing.HTTPToContext(otTracer, "ReorderCollection", logger)))...,
+		))
+
+		gc = apis[shareservice.GetCollectionServiceName]
+		r.Methods(gc.Method).Path(gc.Path).Handler(httptransport.NewServer(
+			endpoints.GetCollectionEndpoint,
+			httpcodec.Negotiated(httpdecode.GetCollectionRequest),
+			httpencode.GetCollectionResponse,
+			append(options, httptransport.ServerBefore(opentracing.HTTPToContext(otTracer, "GetCollection", logger)))...,
+		))
+
+		ua = apis[shareservice.UploadAttachmentServiceName]
+		r.Methods(ua.Method).Path(ua.Path).Handler(httptransport.NewServer(
+			endpoints.UploadAttachmentEndpoint,
+			httpcodec.Negotiated(httpdecode.UploadAttachmentRequest),
+			httpencode.UploadAttachmentResponse,
+			append(options, httptransport.ServerBefore(opentracing.HTTPToContext(otTracer, "UploadAttachment", logger)))...,
+		))
+
+		da = apis[shareservice.DownloadAttachmentServiceName]
+		r.Methods(da.Method).Path(da.Path).Handler(httptransport.NewServer(
+			endpoints.DownloadAttachmentEndpoint,
+			httpdecode.DownloadAttachmentRequest,
+			httpencode.DownloadAttachmentResponse,
+			append(options, httptransport.ServerBefore(opentracing.HTTPToContext(otTracer, "DownloadAttachment", logger)))...,
+		))
+
+		ac = apis[shareservice.AddCommentServiceName]
+		r.Methods(ac.Method).Path(ac.Path).Handler(httptransport.NewServer(
+			endpoints.AddCommentEndpoint,
+			httpcodec.Negotiated(httpdecode.AddCommentRequest),
+			httpencode.AddCommentResponse,
+			append(options, httptransport.ServerBefore(opentracing.HTTPToContext(otTracer, "AddComment", logger)))...,
+		))
+
+		lc = apis[shareservice.ListCommentsServiceName]
+		r.Methods(lc.Method).Path(lc.Path).Handler(httptransport.NewServer(
+			endpoints.ListCommentsEndpoint,
+			httpcodec.Negotiated(httpdecode.ListCommentsRequest),
+			httpencode.ListCommentsResponse,
+			append(options, httptransport.ServerBefore(opentracing.HTTPToContext(otTracer, "ListComments", logger)))...,
+		))
+
+		rc = apis[shareservice.ResolveCommentServiceName]
+		r.Methods(rc.Method).Path(rc.Path).Handler(httptransport.NewServer(
+			endpoints.ResolveCommentEndpoint,
+			httpcodec.Negotiated(httpdecode.ResolveCommentRequest),
+			httpencode.ResolveCommentResponse,
+			append(options, httptransport.ServerBefore(opentracing.HTTPToContext(otTracer, "ResolveComment", logger)))...,
+		))
+
+		gs = apis[shareservice.GetNoteStatsServiceName]
+		r.Methods(gs.Method).Path(gs.Path).Handler(httptransport.NewServer(
+			endpoints.GetNoteStatsEndpoint,
+			httpcodec.Negotiated(httpdecode.GetNoteStatsRequest),
+			httpencode.GetNoteStatsResponse,
+			append(options, httptransport.ServerBefore(opentracing.HTTPToContext(otTracer, "GetNoteStats", logger)))...,
+		))
+
 	}
 
 	return r
@@ -185,6 +353,507 @@ func NewHTTPClient(instance string, otTracer stdopentracing.Tracer, logger log.L
 		)(getNoteEndpoint)
 	}
 
+	var watchNoteEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.WatchNoteServiceName
+			wn = apis[name]
+		)
+
+		watchNoteEndpoint = httptransport.NewClient(
+			wn.Method,
+			copyURL(u, wn.Path),
+			httpencode.WatchNoteRequest,
+			httpdecode.WatchNoteResponse,
+			httptransport.ClientBefore(opentracing.ContextToHTTP(otTracer, logger)),
+			httptransport.BufferedStream(true),
+		).Endpoint()
+		watchNoteEndpoint = opentracing.TraceClient(otTracer, name)(watchNoteEndpoint)
+
+		watchNoteEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(wn.RateLimit.Duration),
+				wn.RateLimit.Delta))(watchNoteEndpoint)
+
+		watchNoteEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				wn.Breaker.Standardize()),
+		)(watchNoteEndpoint)
+	}
+
+	var editNoteEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.EditNoteServiceName
+			en = apis[name]
+		)
+
+		editNoteEndpoint = func(ctx context.Context, request interface{}) (interface{}, error) {
+			req, err := http.NewRequest(en.Method, copyURL(u, en.Path).String(), nil)
+			if err != nil {
+				return nil, err
+			}
+
+			if err = httpencode.EditNoteRequest(ctx, req, request); err != nil {
+				return nil, err
+			}
+			ctx = opentracing.ContextToHTTP(otTracer, logger)(ctx, req)
+
+			conn, resp, err := websocket.DefaultDialer.DialContext(ctx, wsURL(req.URL), req.Header)
+			if err == websocket.ErrBadHandshake {
+				return httpdecode.EditNoteHandshakeResponse(resp)
+			}
+			if err != nil {
+				return nil, err
+			}
+			return httpdecode.EditNoteResponse(conn)
+		}
+		editNoteEndpoint = opentracing.TraceClient(otTracer, name)(editNoteEndpoint)
+
+		editNoteEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(en.RateLimit.Duration),
+				en.RateLimit.Delta))(editNoteEndpoint)
+
+		editNoteEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				en.Breaker.Standardize()),
+		)(editNoteEndpoint)
+	}
+
+	var syncNotesEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.SyncNotesServiceName
+			syn = apis[name]
+		)
+
+		syncNotesEndpoint = httptransport.NewClient(
+			syn.Method,
+			copyURL(u, syn.Path),
+			httpencode.GenericRequest,
+			httpdecode.SyncNotesResponse,
+			httptransport.ClientBefore(opentracing.ContextToHTTP(otTracer, logger)),
+		).Endpoint()
+		syncNotesEndpoint = opentracing.TraceClient(otTracer, name)(syncNotesEndpoint)
+
+		syncNotesEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(syn.RateLimit.Duration),
+				syn.RateLimit.Delta))(syncNotesEndpoint)
+
+		syncNotesEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				syn.Breaker.Standardize()),
+		)(syncNotesEndpoint)
+	}
+
+	var forkNoteEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.ForkNoteServiceName
+			fn = apis[name]
+		)
+
+		forkNoteEndpoint = httptransport.NewClient(
+			fn.Method,
+			copyURL(u, fn.Path),
+			httpencode.ForkNoteRequest,
+			httpdecode.ForkNoteResponse,
+			httptransport.ClientBefore(opentracing.ContextToHTTP(otTracer, logger)),
+		).Endpoint()
+		forkNoteEndpoint = opentracing.TraceClient(otTracer, name)(forkNoteEndpoint)
+
+		forkNoteEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(fn.RateLimit.Duration),
+				fn.RateLimit.Delta))(forkNoteEndpoint)
+
+		forkNoteEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				fn.Breaker.Standardize()),
+		)(forkNoteEndpoint)
+	}
+
+	var listForksEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.ListForksServiceName
+			lf = apis[name]
+		)
+
+		listForksEndpoint = httptransport.NewClient(
+			lf.Method,
+			copyURL(u, lf.Path),
+			httpencode.ListForksRequest,
+			httpdecode.ListForksResponse,
+			httptransport.ClientBefore(opentracing.ContextToHTTP(otTracer, logger)),
+		).Endpoint()
+		listForksEndpoint = opentracing.TraceClient(otTracer, name)(listForksEndpoint)
+
+		listForksEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(lf.RateLimit.Duration),
+				lf.RateLimit.Delta))(listForksEndpoint)
+
+		listForksEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				lf.Breaker.Standardize()),
+		)(listForksEndpoint)
+	}
+
+	var markTemplateEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.MarkTemplateServiceName
+			mt = apis[name]
+		)
+
+		markTemplateEndpoint = httptransport.NewClient(
+			mt.Method,
+			copyURL(u, mt.Path),
+			httpencode.MarkTemplateRequest,
+			httpdecode.MarkTemplateResponse,
+			httptransport.ClientBefore(opentracing.ContextToHTTP(otTracer, logger)),
+		).Endpoint()
+		markTemplateEndpoint = opentracing.TraceClient(otTracer, name)(markTemplateEndpoint)
+
+		markTemplateEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(mt.RateLimit.Duration),
+				mt.RateLimit.Delta))(markTemplateEndpoint)
+
+		markTemplateEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				mt.Breaker.Standardize()),
+		)(markTemplateEndpoint)
+	}
+
+	var instantiateTemplateEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.InstantiateTemplateServiceName
+			it = apis[name]
+		)
+
+		instantiateTemplateEndpoint = httptransport.NewClient(
+			it.Method,
+			copyURL(u, it.Path),
+			httpencode.InstantiateTemplateRequest,
+			httpdecode.InstantiateTemplateResponse,
+			httptransport.ClientBefore(opentracing.ContextToHTTP(otTracer, logger)),
+		).Endpoint()
+		instantiateTemplateEndpoint = opentracing.TraceClient(otTracer, name)(instantiateTemplateEndpoint)
+
+		instantiateTemplateEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(it.RateLimit.Duration),
+				it.RateLimit.Delta))(instantiateTemplateEndpoint)
+
+		instantiateTemplateEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				it.Breaker.Standardize()),
+		)(instantiateTemplateEndpoint)
+	}
+
+	var createCollectionEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.CreateCollectionServiceName
+			cc = apis[name]
+		)
+
+		createCollectionEndpoint = httptransport.NewClient(
+			cc.Method,
+			copyURL(u, cc.Path),
+			httpencode.GenericRequest,
+			httpdecode.CreateCollectionResponse,
+			httptransport.ClientBefore(opentracing.ContextToHTTP(otTracer, logger)),
+		).Endpoint()
+		createCollectionEndpoint = opentracing.TraceClient(otTracer, name)(createCollectionEndpoint)
+
+		createCollectionEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(cc.RateLimit.Duration),
+				cc.RateLimit.Delta))(createCollectionEndpoint)
+
+		createCollectionEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				cc.Breaker.Standardize()),
+		)(createCollectionEndpoint)
+	}
+
+	var addCollectionNotesEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.AddCollectionNotesServiceName
+			acn = apis[name]
+		)
+
+		addCollectionNotesEndpoint = httptransport.NewClient(
+			acn.Method,
+			copyURL(u, acn.Path),
+			httpencode.AddCollectionNotesRequest,
+			httpdecode.AddCollectionNotesResponse,
+			httptransport.ClientBefore(opentracing.ContextToHTTP(otTracer, logger)),
+		).Endpoint()
+		addCollectionNotesEndpoint = opentracing.TraceClient(otTracer, name)(addCollectionNotesEndpoint)
+
+		addCollectionNotesEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(acn.RateLimit.Duration),
+				acn.RateLimit.Delta))(addCollectionNotesEndpoint)
+
+		addCollectionNotesEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				acn.Breaker.Standardize()),
+		)(addCollectionNotesEndpoint)
+	}
+
+	var removeCollectionNotesEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.RemoveCollectionNotesServiceName
+			rcn = apis[name]
+		)
+
+		removeCollectionNotesEndpoint = httptransport.NewClient(
+			rcn.Method,
+			copyURL(u, rcn.Path),
+			httpencode.RemoveCollectionNotesRequest,
+			httpdecode.RemoveCollectionNotesResponse,
+			httptransport.ClientBefore(opentracing.ContextToHTTP(otTracer, logger)),
+		).Endpoint()
+		removeCollectionNotesEndpoint = opentracing.TraceClient(otTracer, name)(removeCollectionNotesEndpoint)
+
+		removeCollectionNotesEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(rcn.RateLimit.Duration),
+				rcn.RateLimit.Delta))(removeCollectionNotesEndpoint)
+
+		removeCollectionNotesEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				rcn.Breaker.Standardize()),
+		)(removeCollectionNotesEndpoint)
+	}
+
+	var reorderCollectionEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.ReorderCollectionServiceName
+			roc = apis[name]
+		)
+
+		reorderCollectionEndpoint = httptransport.NewClient(
+			roc.Method,
+			copyURL(u, roc.Path),
+			httpencode.ReorderCollectionRequest,
+			httpdecode.ReorderCollectionResponse,
+			httptransport.ClientBefore(opentracing.ContextToHTTP(otTracer, logger)),
+		).Endpoint()
+		reorderCollectionEndpoint = opentracing.TraceClient(otTracer, name)(reorderCollectionEndpoint)
+
+		reorderCollectionEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(roc.RateLimit.Duration),
+				roc.RateLimit.Delta))(reorderCollectionEndpoint)
+
+		reorderCollectionEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				roc.Breaker.Standardize()),
+		)(reorderCollectionEndpoint)
+	}
+
+	var getCollectionEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.GetCollectionServiceName
+			gc = apis[name]
+		)
+
+		getCollectionEndpoint = httptransport.NewClient(
+			gc.Method,
+			copyURL(u, gc.Path),
+			httpencode.GetCollectionRequest,
+			httpdecode.GetCollectionResponse,
+			httptransport.ClientBefore(opentracing.ContextToHTTP(otTracer, logger)),
+		).Endpoint()
+		getCollectionEndpoint = opentracing.TraceClient(otTracer, name)(getCollectionEndpoint)
+
+		getCollectionEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(gc.RateLimit.Duration),
+				gc.RateLimit.Delta))(getCollectionEndpoint)
+
+		getCollectionEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				gc.Breaker.Standardize()),
+		)(getCollectionEndpoint)
+	}
+
+	var uploadAttachmentEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.UploadAttachmentServiceName
+			ua = apis[name]
+		)
+
+		uploadAttachmentEndpoint = httptransport.NewClient(
+			ua.Method,
+			copyURL(u, ua.Path),
+			httpencode.UploadAttachmentRequest,
+			httpdecode.UploadAttachmentResponse,
+			httptransport.ClientBefore(opentracing.ContextToHTTP(otTracer, logger)),
+		).Endpoint()
+		uploadAttachmentEndpoint = opentracing.TraceClient(otTracer, name)(uploadAttachmentEndpoint)
+
+		uploadAttachmentEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(ua.RateLimit.Duration),
+				ua.RateLimit.Delta))(uploadAttachmentEndpoint)
+
+		uploadAttachmentEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				ua.Breaker.Standardize()),
+		)(uploadAttachmentEndpoint)
+	}
+
+	var downloadAttachmentEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.DownloadAttachmentServiceName
+			da = apis[name]
+		)
+
+		downloadAttachmentEndpoint = httptransport.NewClient(
+			da.Method,
+			copyURL(u, da.Path),
+			httpencode.DownloadAttachmentRequest,
+			httpdecode.DownloadAttachmentResponse,
+			httptransport.ClientBefore(opentracing.ContextToHTTP(otTracer, logger)),
+			httptransport.BufferedStream(true),
+		).Endpoint()
+		downloadAttachmentEndpoint = opentracing.TraceClient(otTracer, name)(downloadAttachmentEndpoint)
+
+		downloadAttachmentEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(da.RateLimit.Duration),
+				da.RateLimit.Delta))(downloadAttachmentEndpoint)
+
+		downloadAttachmentEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				da.Breaker.Standardize()),
+		)(downloadAttachmentEndpoint)
+	}
+
+	var addCommentEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.AddCommentServiceName
+			ac = apis[name]
+		)
+
+		addCommentEndpoint = httptransport.NewClient(
+			ac.Method,
+			copyURL(u, ac.Path),
+			httpencode.AddCommentRequest,
+			httpdecode.AddCommentResponse,
+			httptransport.ClientBefore(opentracing.ContextToHTTP(otTracer, logger)),
+		).Endpoint()
+		addCommentEndpoint = opentracing.TraceClient(otTracer, name)(addCommentEndpoint)
+
+		addCommentEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(ac.RateLimit.Duration),
+				ac.RateLimit.Delta))(addCommentEndpoint)
+
+		addCommentEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				ac.Breaker.Standardize()),
+		)(addCommentEndpoint)
+	}
+
+	var listCommentsEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.ListCommentsServiceName
+			lc = apis[name]
+		)
+
+		listCommentsEndpoint = httptransport.NewClient(
+			lc.Method,
+			copyURL(u, lc.Path),
+			httpencode.ListCommentsRequest,
+			httpdecode.ListCommentsResponse,
+			httptransport.ClientBefore(opentracing.ContextToHTTP(otTracer, logger)),
+		).Endpoint()
+		listCommentsEndpoint = opentracing.TraceClient(otTracer, name)(listCommentsEndpoint)
+
+		listCommentsEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(lc.RateLimit.Duration),
+				lc.RateLimit.Delta))(listCommentsEndpoint)
+
+		listCommentsEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				lc.Breaker.Standardize()),
+		)(listCommentsEndpoint)
+	}
+
+	var resolveCommentEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.ResolveCommentServiceName
+			rc = apis[name]
+		)
+
+		resolveCommentEndpoint = httptransport.NewClient(
+			rc.Method,
+			copyURL(u, rc.Path),
+			httpencode.ResolveCommentRequest,
+			httpdecode.ResolveCommentResponse,
+			httptransport.ClientBefore(opentracing.ContextToHTTP(otTracer, logger)),
+		).Endpoint()
+		resolveCommentEndpoint = opentracing.TraceClient(otTracer, name)(resolveCommentEndpoint)
+
+		resolveCommentEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(rc.RateLimit.Duration),
+				rc.RateLimit.Delta))(resolveCommentEndpoint)
+
+		resolveCommentEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				rc.Breaker.Standardize()),
+		)(resolveCommentEndpoint)
+	}
+
+	var getNoteStatsEndpoint endpoint.Endpoint
+	{
+		var (
+			name = shareservice.GetNoteStatsServiceName
+			gs = apis[name]
+		)
+
+		getNoteStatsEndpoint = httptransport.NewClient(
+			gs.Method,
+			copyURL(u, gs.Path),
+			httpencode.GetNoteStatsRequest,
+			httpdecode.GetNoteStatsResponse,
+			httptransport.ClientBefore(opentracing.ContextToHTTP(otTracer, logger)),
+		).Endpoint()
+		getNoteStatsEndpoint = opentracing.TraceClient(otTracer, name)(getNoteStatsEndpoint)
+
+		getNoteStatsEndpoint = ratelimit.NewErroringLimiter(
+			rate.NewLimiter(
+				rate.Every(gs.RateLimit.Duration),
+				gs.RateLimit.Delta))(getNoteStatsEndpoint)
+
+		getNoteStatsEndpoint = circuitbreaker.Gobreaker(
+			gobreaker.NewCircuitBreaker(
+				gs.Breaker.Standardize()),
+		)(getNoteStatsEndpoint)
+	}
+
 	// Returning the endpoint.Set as a service.Service relies on the
 	// endpoint.Set implementing the Service methods. That's just a simple bit
 	// of glue code.
@@ -192,6 +861,24 @@ func NewHTTPClient(instance string, otTracer stdopentracing.Tracer, logger log.L
 		ShareNoteEndpoint:    shareNoteEndpoint,
 		PrivateNoteEndpoint: privateNoteEndpoint,
 		GetNoteEndpoint: getNoteEndpoint,
+		WatchNoteEndpoint: watchNoteEndpoint,
+		EditNoteEndpoint: editNoteEndpoint,
+		SyncNotesEndpoint: syncNotesEndpoint,
+		ForkNoteEndpoint: forkNoteEndpoint,
+		ListForksEndpoint: listForksEndpoint,
+		MarkTemplateEndpoint: markTemplateEndpoint,
+		InstantiateTemplateEndpoint: instantiateTemplateEndpoint,
+		CreateCollectionEndpoint: createCollectionEndpoint,
+		AddCollectionNotesEndpoint: addCollectionNotesEndpoint,
+		RemoveCollectionNotesEndpoint: removeCollectionNotesEndpoint,
+		ReorderCollectionEndpoint: reorderCollectionEndpoint,
+		GetCollectionEndpoint: getCollectionEndpoint,
+		UploadAttachmentEndpoint: uploadAttachmentEndpoint,
+		DownloadAttachmentEndpoint: downloadAttachmentEndpoint,
+		AddCommentEndpoint: addCommentEndpoint,
+		ListCommentsEndpoint: listCommentsEndpoint,
+		ResolveCommentEndpoint: resolveCommentEndpoint,
+		GetNoteStatsEndpoint: getNoteStatsEndpoint,
 	}, nil
 }
 
@@ -199,4 +886,15 @@ func copyURL(base *url.URL, path string) *url.URL {
 	next := *base
 	next.Path = path
 	return &next
+}
+
+// wsURL returns the WebSocket URL of an HTTP URL.
+func wsURL(u *url.URL) string {
+	next := *u
+	if next.Scheme == "https" {
+		next.Scheme = "wss"
+	} else {
+		next.Scheme = "ws"
+	}
+	return next.String()
 }
\ No newline at end of file
@@ -0,0 +1,185 @@
+package transport
+
+import (
+	"context"
+	"github.com/al8n/shareable-notes/share-svc/config"
+	"github.com/go-kit/kit/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+// serverStream is a grpc.ServerStream served with ctx, keeping the headers
+// it is sent.
+type serverStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// logs keeps the keyvals of the lines it is logged.
+type logs [][]interface{}
+
+func (l *logs) Log(keyvals ...interface{}) error {
+	*l = append(*l, keyvals)
+	return nil
+}
+
+func TestRequestID(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		md   metadata.MD
+		want string
+	}{
+		{"sent", metadata.Pairs(RequestIDHeader, "id"), "id"},
+		{"empty", metadata.Pairs(RequestIDHeader, ""), ""},
+		{"missing", metadata.MD{}, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tc.md)
+
+			var got string
+			_, err := RequestIDUnaryInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+				got = RequestIDFromContext(ctx)
+				return nil, nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkRequestID(t, got, tc.want)
+
+			ss := &serverStream{ctx: ctx}
+			err = RequestIDStreamInterceptor()(nil, ss, &grpc.StreamServerInfo{}, func(_ interface{}, stream grpc.ServerStream) error {
+				got = RequestIDFromContext(stream.Context())
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkRequestID(t, got, tc.want)
+
+			if sent := ss.header.Get(RequestIDHeader); len(sent) != 1 || sent[0] != got {
+				t.Fatalf("sent %v, want %q", sent, got)
+			}
+		})
+	}
+
+	if RequestIDFromContext(context.Background()) != "" {
+		t.Fatal("id without a call")
+	}
+}
+
+// checkRequestID checks got is want, or a generated id when want is empty.
+func checkRequestID(t *testing.T, got, want string) {
+	t.Helper()
+
+	if want == "" && len(got) != 32 || want != "" && got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestRecoveryInterceptors(t *testing.T) {
+	var l logs
+
+	_, err := RecoveryUnaryInterceptor(&l)(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/pb.Share/GetNote"}, func(context.Context, interface{}) (interface{}, error) {
+		panic("boom")
+	})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("got %v", err)
+	}
+
+	err = RecoveryStreamInterceptor(&l)(nil, &serverStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/pb.Share/WatchNote"}, func(interface{}, grpc.ServerStream) error {
+		panic("boom")
+	})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("got %v", err)
+	}
+
+	if len(l) != 2 {
+		t.Fatalf("got %d log lines", len(l))
+	}
+	for i, method := range []string{"/pb.Share/GetNote", "/pb.Share/WatchNote"} {
+		if l[i][3] != method || l[i][7] != "boom" {
+			t.Fatalf("got %v", l[i])
+		}
+	}
+
+	// the calls which do not panic are left alone
+	resp, err := RecoveryUnaryInterceptor(log.NewNopLogger())(context.Background(), nil, &grpc.UnaryServerInfo{}, func(context.Context, interface{}) (interface{}, error) {
+		return "resp", status.Error(codes.NotFound, "not found")
+	})
+	if resp != "resp" || status.Code(err) != codes.NotFound {
+		t.Fatalf("got %v %v", resp, err)
+	}
+}
+
+func TestDeadlineInterceptors(t *testing.T) {
+	deadlines := config.Deadlines{
+		Default: time.Minute,
+		Methods: map[string]time.Duration{
+			"ShareNote": time.Hour,
+			"GetNote":   0,
+			"WatchNote": time.Second,
+		},
+	}
+
+	for _, tc := range []struct {
+		name   string
+		method string
+		unary  time.Duration
+		stream time.Duration
+	}{
+		{"default", "/pb.Share/PrivateNote", time.Minute, 0},
+		{"overridden", "/pb.Share/ShareNote", time.Hour, time.Hour},
+		{"lifted", "/pb.Share/GetNote", 0, 0},
+		{"stream", "/pb.Share/WatchNote", time.Second, time.Second},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := DeadlineUnaryInterceptor(deadlines)(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: tc.method}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+				checkDeadline(t, ctx, tc.unary)
+				return nil, nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = DeadlineStreamInterceptor(deadlines)(nil, &serverStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: tc.method}, func(_ interface{}, stream grpc.ServerStream) error {
+				checkDeadline(t, stream.Context(), tc.stream)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// checkDeadline checks ctx is done in about timeout, or has no deadline when
+// timeout is zero.
+func checkDeadline(t *testing.T, ctx context.Context, timeout time.Duration) {
+	t.Helper()
+
+	deadline, ok := ctx.Deadline()
+	if timeout == 0 {
+		if ok {
+			t.Fatalf("got deadline in %v", time.Until(deadline))
+		}
+		return
+	}
+
+	if left := time.Until(deadline); !ok || left > timeout || left < timeout-time.Second {
+		t.Fatalf("got deadline in %v, want %v", left, timeout)
+	}
+}
@@ -0,0 +1,220 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/al8n/shareable-notes/share-svc/internal/analytics"
+	"github.com/al8n/shareable-notes/share-svc/internal/codec/jsonrpccodec"
+	serviceendpoint "github.com/al8n/shareable-notes/share-svc/pkg/endpoint"
+	shareservice "github.com/al8n/shareable-notes/share-svc/pkg/service"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/tracing/opentracing"
+	"github.com/go-kit/kit/transport"
+	"github.com/go-kit/kit/transport/http/jsonrpc"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// JSONRPCServer serves the methods of a jsonrpc.EndpointCodecMap over HTTP,
+// as single calls or as batches. The go-kit jsonrpc.Server handles single
+// calls only.
+type JSONRPCServer struct {
+	ecm jsonrpc.EndpointCodecMap
+	maxBatch int
+	proxies analytics.Proxies
+	otTracer stdopentracing.Tracer
+	logger log.Logger
+	errorHandler transport.ErrorHandler
+}
+
+// NewJSONRPCHandler returns an HTTP handler serving ShareNote, PrivateNote
+// and GetNote as JSON-RPC 2.0 methods of the same names. A batch holds up to
+// maxBatch calls, which are served concurrently.
+func NewJSONRPCHandler(endpoints serviceendpoint.Set, otTracer stdopentracing.Tracer, logger log.Logger, maxBatch int) *JSONRPCServer {
+	return &JSONRPCServer{
+		ecm: jsonrpc.EndpointCodecMap{
+			shareservice.ShareNoteServiceName: jsonrpc.EndpointCodec{
+				Endpoint: endpoints.ShareNoteEndpoint,
+				Decode:   jsonrpccodec.ShareNoteRequest,
+				Encode:   jsonrpccodec.ShareNoteResponse,
+			},
+			shareservice.PrivateNoteServiceName: jsonrpc.EndpointCodec{
+				Endpoint: endpoints.PrivateNoteEndpoint,
+				Decode:   jsonrpccodec.PrivateNoteRequest,
+				Encode:   jsonrpccodec.PrivateNoteResponse,
+			},
+			shareservice.GetNoteServiceName: jsonrpc.EndpointCodec{
+				Endpoint: endpoints.GetNoteEndpoint,
+				Decode:   jsonrpccodec.GetNoteRequest,
+				Encode:   jsonrpccodec.GetNoteResponse,
+			},
+		},
+		maxBatch: maxBatch,
+		proxies: trustedProxies(),
+		otTracer: otTracer,
+		logger: logger,
+		errorHandler: transport.NewLogErrorHandler(logger),
+	}
+}
+
+// ServeHTTP implements http.Handler. Notifications, the calls without an id,
+// are served but not answered, a request of notifications only gets an empty
+// response.
+func (s *JSONRPCServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		_, _ = w.Write([]byte("405 must POST\n"))
+		return
+	}
+
+	var (
+		ctx = analytics.HTTPToContext(s.proxies)(r.Context(), r)
+		body []byte
+		batch []json.RawMessage
+		responses []*jsonrpc.Response
+		err error
+	)
+
+	body, err = ioutil.ReadAll(r.Body)
+	if err != nil {
+		s.errorHandler.Handle(ctx, err)
+		s.encode(ctx, w, errorResponse(nil, jsonrpc.Error{Code: jsonrpc.ParseError, Message: err.Error()}))
+		return
+	}
+
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 || body[0] != '[' {
+		s.encode(ctx, w, s.call(ctx, r, body))
+		return
+	}
+
+	if err = json.Unmarshal(body, &batch); err != nil {
+		s.encode(ctx, w, errorResponse(nil, jsonrpc.Error{Code: jsonrpc.ParseError, Message: err.Error()}))
+		return
+	}
+
+	if len(batch) == 0 || len(batch) > s.maxBatch {
+		s.encode(ctx, w, errorResponse(nil, jsonrpc.Error{
+			Code:    jsonrpc.InvalidRequestError,
+			Message: fmt.Sprintf("batch must hold 1 to %d calls", s.maxBatch),
+		}))
+		return
+	}
+
+	var wg sync.WaitGroup
+	responses = make([]*jsonrpc.Response, len(batch))
+	for i := range batch {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			responses[i] = s.call(ctx, r, batch[i])
+		}(i)
+	}
+	wg.Wait()
+
+	// the responses of notifications are left out
+	n := 0
+	for _, resp := range responses {
+		if resp != nil {
+			responses[n] = resp
+			n++
+		}
+	}
+
+	if n == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	s.encode(ctx, w, responses[:n])
+}
+
+// call serves a single call, it returns nil for notifications.
+func (s *JSONRPCServer) call(ctx context.Context, r *http.Request, raw json.RawMessage) *jsonrpc.Response {
+	var (
+		req jsonrpc.Request
+		reqParams, resp interface{}
+		result json.RawMessage
+		err error
+	)
+
+	if err = json.Unmarshal(raw, &req); err != nil {
+		if _, ok := err.(*json.SyntaxError); ok {
+			return errorResponse(nil, jsonrpc.Error{Code: jsonrpc.ParseError, Message: err.Error()})
+		}
+		return errorResponse(nil, jsonrpc.Error{Code: jsonrpc.InvalidRequestError, Message: err.Error()})
+	}
+
+	if req.JSONRPC != jsonrpc.Version || req.Method == "" {
+		return errorResponse(req.ID, jsonrpc.Error{
+			Code:    jsonrpc.InvalidRequestError,
+			Message: jsonrpc.ErrorMessage(jsonrpc.InvalidRequestError),
+		})
+	}
+
+	ecm, ok := s.ecm[req.Method]
+	if !ok {
+		return reply(req.ID, errorResponse(req.ID, jsonrpc.Error{
+			Code:    jsonrpc.MethodNotFoundError,
+			Message: fmt.Sprintf("method %s was not found", req.Method),
+		}))
+	}
+
+	ctx = opentracing.HTTPToContext(s.otTracer, req.Method, s.logger)(ctx, r)
+
+	reqParams, err = ecm.Decode(ctx, req.Params)
+	if err == nil {
+		resp, err = ecm.Endpoint(ctx, reqParams)
+	}
+	if err == nil {
+		result, err = ecm.Encode(ctx, resp)
+	}
+	if err != nil {
+		s.errorHandler.Handle(ctx, err)
+
+		rpcErr, ok := err.(jsonrpc.Error)
+		if !ok {
+			rpcErr = jsonrpccodec.Error(err)
+		}
+		return reply(req.ID, errorResponse(req.ID, rpcErr))
+	}
+
+	return reply(req.ID, &jsonrpc.Response{
+		JSONRPC: jsonrpc.Version,
+		Result:  result,
+		ID:      req.ID,
+	})
+}
+
+func (s *JSONRPCServer) encode(ctx context.Context, w http.ResponseWriter, response interface{}) {
+	if resp, ok := response.(*jsonrpc.Response); ok && resp == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	w.Header().Set("Content-Type", jsonrpc.ContentType)
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		s.errorHandler.Handle(ctx, err)
+	}
+}
+
+// reply drops the response of a notification.
+func reply(id *jsonrpc.RequestID, resp *jsonrpc.Response) *jsonrpc.Response {
+	if id == nil {
+		return nil
+	}
+	return resp
+}
+
+func errorResponse(id *jsonrpc.RequestID, err jsonrpc.Error) *jsonrpc.Response {
+	return &jsonrpc.Response{
+		JSONRPC: jsonrpc.Version,
+		Error:   &err,
+		ID:      id,
+	}
+}
@@ -0,0 +1,142 @@
+package transport
+
+import (
+	"context"
+	"github.com/al8n/shareable-notes/share-svc/config"
+	"github.com/al8n/shareable-notes/share-svc/internal/analytics"
+	"github.com/al8n/shareable-notes/share-svc/internal/codec/natscodec"
+	"github.com/al8n/shareable-notes/share-svc/internal/codec/natscodec/natsdecode"
+	"github.com/al8n/shareable-notes/share-svc/internal/codec/natscodec/natsencode"
+	"github.com/al8n/shareable-notes/share-svc/model/requests"
+	serviceendpoint "github.com/al8n/shareable-notes/share-svc/pkg/endpoint"
+	shareservice "github.com/al8n/shareable-notes/share-svc/pkg/service"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/tracing/opentracing"
+	"github.com/go-kit/kit/transport"
+	natstransport "github.com/go-kit/kit/transport/nats"
+	"github.com/nats-io/nats.go"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+// NATSServer serves ShareNote, PrivateNote and GetNote on request-reply
+// subjects. The requests and the responses are the JSON encoded
+// model/requests and model/responses. A request published without a reply
+// subject is served and nothing is replied, which is how fire-and-forget
+// publishers share notes.
+type NATSServer struct {
+	shareNote *natstransport.Subscriber
+	privateNote *natstransport.Subscriber
+	getNote *natstransport.Subscriber
+}
+
+func NewNATSServer(endpoints serviceendpoint.Set, otTracer stdopentracing.Tracer, logger log.Logger) NATSServer {
+	options := []natstransport.SubscriberOption{
+		natstransport.SubscriberErrorEncoder(natscodec.ErrorEncoder),
+		natstransport.SubscriberErrorHandler(transport.NewLogErrorHandler(logger)),
+		natstransport.SubscriberBefore(func(ctx context.Context, _ *nats.Msg) context.Context {
+			return analytics.NewContext(ctx, analytics.Viewer{Transport: analytics.TransportNATS})
+		}),
+	}
+
+	return NATSServer{
+		shareNote: natstransport.NewSubscriber(
+			endpoints.ShareNoteEndpoint,
+			natsdecode.ShareNoteRequest,
+			natsencode.ShareNoteResponse,
+			append(options, natstransport.SubscriberBefore(natsToContext(otTracer, "ShareNote")))...,
+		),
+		privateNote: natstransport.NewSubscriber(
+			endpoints.PrivateNoteEndpoint,
+			natsdecode.PrivateNoteRequest,
+			natsencode.PrivateNoteResponse,
+			append(options, natstransport.SubscriberBefore(natsToContext(otTracer, "PrivateNote")))...,
+		),
+		getNote: natstransport.NewSubscriber(
+			endpoints.GetNoteEndpoint,
+			natsdecode.GetNoteRequest,
+			natsencode.GetNoteResponse,
+			append(options, natstransport.SubscriberBefore(natsToContext(otTracer, "GetNote")))...,
+		),
+	}
+}
+
+// natsToContext starts the span of a request in the context, as
+// opentracing.HTTPToContext does for HTTP, the endpoints finish it.
+func natsToContext(otTracer stdopentracing.Tracer, operationName string) natstransport.RequestFunc {
+	return func(ctx context.Context, msg *nats.Msg) context.Context {
+		span := otTracer.StartSpan(operationName, ext.SpanKindRPCServer)
+		span.SetTag("subject", msg.Subject)
+		return stdopentracing.ContextWithSpan(ctx, span)
+	}
+}
+
+// Subscribe subscribes the methods to their subjects in the queue group, so
+// each request is served by a single instance.
+func (n NATSServer) Subscribe(nc *nats.Conn, queue string, subjects config.NATSSubjects) (subs []*nats.Subscription, err error)  {
+	var sub *nats.Subscription
+
+	for subject, subscriber := range map[string]*natstransport.Subscriber{
+		subjects.ShareNote: n.shareNote,
+		subjects.PrivateNote: n.privateNote,
+		subjects.GetNote: n.getNote,
+	} {
+		sub, err = nc.QueueSubscribe(subject, queue, subscriber.ServeMsg(nc))
+		if err != nil {
+			for _, sub := range subs {
+				_ = sub.Unsubscribe()
+			}
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
+
+// NewNATSClient returns a set of the ShareNote, PrivateNote and GetNote
+// endpoints calling the service over NATS, the other endpoints are nil.
+func NewNATSClient(nc *nats.Conn, otTracer stdopentracing.Tracer, subjects config.NATSSubjects) serviceendpoint.Set {
+	var (
+		shareNoteEndpoint = natstransport.NewPublisher(
+			nc,
+			subjects.ShareNote,
+			natsencode.ShareNoteRequest,
+			natsdecode.ShareNoteResponse,
+		).Endpoint()
+
+		privateNoteEndpoint = natstransport.NewPublisher(
+			nc,
+			subjects.PrivateNote,
+			natsencode.PrivateNoteRequest,
+			natsdecode.PrivateNoteResponse,
+		).Endpoint()
+
+		getNoteEndpoint = natstransport.NewPublisher(
+			nc,
+			subjects.GetNote,
+			natsencode.GetNoteRequest,
+			natsdecode.GetNoteResponse,
+		).Endpoint()
+	)
+
+	return serviceendpoint.Set{
+		ShareNoteEndpoint: opentracing.TraceClient(otTracer, shareservice.ShareNoteServiceName)(shareNoteEndpoint),
+		PrivateNoteEndpoint: opentracing.TraceClient(otTracer, shareservice.PrivateNoteServiceName)(privateNoteEndpoint),
+		GetNoteEndpoint: opentracing.TraceClient(otTracer, shareservice.GetNoteServiceName)(getNoteEndpoint),
+	}
+}
+
+// PublishShareNote shares a note without waiting for the service, neither the
+// URL of the note nor an error is reported back.
+func PublishShareNote(ctx context.Context, nc *nats.Conn, subject, name, content string) error {
+	msg := &nats.Msg{Subject: subject}
+
+	err := natsencode.ShareNoteRequest(ctx, msg, requests.ShareNoteRequest{
+		Name: name,
+		Content: content,
+	})
+	if err != nil {
+		return err
+	}
+	return nc.PublishMsg(msg)
+}
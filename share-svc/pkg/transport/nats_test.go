@@ -0,0 +1,171 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/config"
+	"github.com/al8n/shareable-notes/share-svc/internal/codec/natscodec"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	serviceendpoint "github.com/al8n/shareable-notes/share-svc/pkg/endpoint"
+	shareservice "github.com/al8n/shareable-notes/share-svc/pkg/service"
+	"github.com/go-kit/kit/log"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	"testing"
+	"time"
+)
+
+var natsSubjects = config.NATSSubjects{
+	ShareNote:   "test.note.share",
+	PrivateNote: "test.note.private",
+	GetNote:     "test.note.get",
+}
+
+// natsService serves the note "found" only, the other methods are not
+// served over NATS.
+type natsService struct {
+	shareservice.Service
+	shared chan string
+}
+
+func (s natsService) ShareNote(_ context.Context, name, content string) (url, sharedID, ownerToken string, err error) {
+	if name == "" {
+		return "", "", "", common.ErrorEmptyNoteStream
+	}
+
+	s.shared <- name
+	return "http://localhost/v1/note/" + name, name, "token", nil
+}
+
+func (s natsService) PrivateNote(_ context.Context, id string) error {
+	if id != "found" {
+		return common.ErrorNoteNotFound
+	}
+	return nil
+}
+
+func (s natsService) GetNote(_ context.Context, id string, _ model.NoteCondition) (name, content string, version model.NoteVersion, err error) {
+	if id != "found" {
+		return "", "", version, common.ErrorNoteNotFound
+	}
+	return "name", "content", model.NoteVersion{ETag: `"1-0-1"`, LastModified: 1}, nil
+}
+
+// startNATS starts a NATS server in process, serving svc until the test ends.
+func startNATS(t *testing.T, svc shareservice.Service) *nats.Conn {
+	t.Helper()
+
+	ns, err := server.NewServer(&server.Options{Host: "127.0.0.1", Port: -1, NoLog: true, NoSigs: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go ns.Start()
+	t.Cleanup(ns.Shutdown)
+
+	if !ns.ReadyForConnections(5 * time.Second) {
+		t.Fatal("NATS server is not ready")
+	}
+
+	nc, err := nats.Connect(ns.ClientURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(nc.Close)
+
+	endpoints := serviceendpoint.Set{
+		ShareNoteEndpoint:   serviceendpoint.MakeShareNoteEndpoint(svc),
+		PrivateNoteEndpoint: serviceendpoint.MakePrivateNoteEndpoint(svc),
+		GetNoteEndpoint:     serviceendpoint.MakeGetNoteEndpoint(svc),
+	}
+
+	_, err = NewNATSServer(endpoints, stdopentracing.NoopTracer{}, log.NewNopLogger()).Subscribe(nc, "test", natsSubjects)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return nc
+}
+
+func TestNATS(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		svc    = natsService{shared: make(chan string, 1)}
+		nc     = startNATS(t, svc)
+		client = NewNATSClient(nc, stdopentracing.NoopTracer{}, natsSubjects)
+	)
+
+	t.Run("ShareNote", func(t *testing.T) {
+		url, id, token, err := client.ShareNote(ctx, "note", "content")
+		if err != nil || url != "http://localhost/v1/note/note" || id != "note" || token != "token" {
+			t.Fatalf("got %q %q %q %v", url, id, token, err)
+		}
+		<-svc.shared
+	})
+
+	t.Run("ShareNote error", func(t *testing.T) {
+		if _, _, _, err := client.ShareNote(ctx, "", "content"); !errors.Is(err, common.ErrorEmptyNoteStream) {
+			t.Fatalf("got %v", err)
+		}
+	})
+
+	t.Run("PublishShareNote", func(t *testing.T) {
+		if err := PublishShareNote(ctx, nc, natsSubjects.ShareNote, "published", "content"); err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case name := <-svc.shared:
+			if name != "published" {
+				t.Fatalf("shared %q", name)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("published note is not shared")
+		}
+	})
+
+	t.Run("PrivateNote", func(t *testing.T) {
+		if err := client.PrivateNote(ctx, "found"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("PrivateNote error", func(t *testing.T) {
+		if err := client.PrivateNote(ctx, "missing"); !errors.Is(err, common.ErrorNoteNotFound) {
+			t.Fatalf("got %v", err)
+		}
+	})
+
+	t.Run("GetNote", func(t *testing.T) {
+		name, content, version, err := client.GetNote(ctx, "found", model.NoteCondition{})
+		if err != nil || name != "name" || content != "content" || version.ETag != `"1-0-1"` {
+			t.Fatalf("got %q %q %+v %v", name, content, version, err)
+		}
+	})
+
+	t.Run("GetNote error", func(t *testing.T) {
+		if _, _, _, err := client.GetNote(ctx, "missing", model.NoteCondition{}); !errors.Is(err, common.ErrorNoteNotFound) {
+			t.Fatalf("got %v", err)
+		}
+	})
+
+	t.Run("malformed request", func(t *testing.T) {
+		msg, err := nc.Request(natsSubjects.GetNote, []byte("{"), 5*time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var reply natscodec.ErrorWrapper
+		if err = json.Unmarshal(msg.Data, &reply); err != nil || reply.Error == "" {
+			t.Fatalf("got %s %v", msg.Data, err)
+		}
+	})
+
+	t.Run("unknown subject", func(t *testing.T) {
+		if _, err := nc.Request("test.note.unknown", []byte("{}"), 200*time.Millisecond); err != nats.ErrTimeout {
+			t.Fatalf("got %v", err)
+		}
+	})
+}
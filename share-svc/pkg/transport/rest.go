@@ -0,0 +1,43 @@
+package transport
+
+import (
+	"context"
+	"github.com/al8n/shareable-notes/share-svc/config"
+	"github.com/al8n/shareable-notes/share-svc/internal/analytics"
+	"github.com/al8n/shareable-notes/share-svc/pb"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/metadata"
+	"net/http"
+)
+
+// NewRESTHandler returns an HTTP handler serving the REST routes the
+// google.api.http annotations of share.proto map to the RPCs, called through
+// client. The JSON fields keep their proto names.
+func NewRESTHandler(client pb.ShareClient) (http.Handler, error) {
+	proxies := trustedProxies()
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			OrigName:     true,
+			EmitDefaults: true,
+		}),
+		// forward the viewer of the HTTP request, the RPCs only see the
+		// gateway as their peer
+		runtime.WithMetadata(func(ctx context.Context, r *http.Request) metadata.MD {
+			md := metadata.MD{}
+			analytics.ContextToGRPC()(analytics.HTTPToContext(proxies)(ctx, r), &md)
+			return md
+		}),
+	)
+
+	if err := pb.RegisterShareHandlerClient(context.Background(), mux, client); err != nil {
+		return nil, err
+	}
+	return mux, nil
+}
+
+// trustedProxies returns the proxies trusted to forward the viewers of the
+// requests, which were validated with the configuration.
+func trustedProxies() analytics.Proxies {
+	proxies, _ := analytics.ParseProxies(config.GetConfig().Service.Analytics.TrustedProxies)
+	return proxies
+}
@@ -0,0 +1,173 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/al8n/shareable-notes/share-svc/config"
+	"github.com/al8n/shareable-notes/share-svc/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// restClient serves the note "found", and keeps the last request it got and
+// its metadata. The other RPCs are not called by the tests.
+type restClient struct {
+	pb.ShareClient
+	req interface{}
+	md  metadata.MD
+}
+
+func (c *restClient) called(ctx context.Context, req interface{}) {
+	c.req = req
+	c.md, _ = metadata.FromOutgoingContext(ctx)
+}
+
+func (c *restClient) ShareNote(ctx context.Context, in *pb.ShareNoteRequest, _ ...grpc.CallOption) (*pb.ShareNoteResponse, error) {
+	c.called(ctx, in)
+	return &pb.ShareNoteResponse{Url: "url", NoteId: "found", OwnerToken: "token"}, nil
+}
+
+func (c *restClient) GetNote(ctx context.Context, in *pb.GetNoteRequest, _ ...grpc.CallOption) (*pb.GetNoteResponse, error) {
+	c.called(ctx, in)
+	if in.Id != "found" {
+		return nil, status.Error(codes.NotFound, "note cannot be found")
+	}
+	return &pb.GetNoteResponse{Name: "name", Content: "content", Etag: `"1"`, LastModified: 1}, nil
+}
+
+func (c *restClient) GetNoteStats(ctx context.Context, in *pb.GetNoteStatsRequest, _ ...grpc.CallOption) (*pb.GetNoteStatsResponse, error) {
+	c.called(ctx, in)
+	return &pb.GetNoteStatsResponse{Stats: &pb.NoteStats{NoteId: in.Id, Views: 2}}, nil
+}
+
+func TestRESTHandler(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		code   int
+		req    interface{}
+		resp   map[string]interface{}
+	}{
+		{
+			"share", http.MethodPost, "/v1/note", `{"name":"name","content":"content"}`,
+			http.StatusOK,
+			&pb.ShareNoteRequest{Name: "name", Content: "content"},
+			map[string]interface{}{"url": "url", "note_id": "found", "owner_token": "token", "error": ""},
+		},
+		{
+			"get", http.MethodGet, "/v1/note/found", "",
+			http.StatusOK,
+			&pb.GetNoteRequest{Id: "found"},
+			map[string]interface{}{"name": "name", "content": "content", "etag": `"1"`, "last_modified": "1", "not_modified": false, "error": ""},
+		},
+		{
+			"conditional get", http.MethodGet, "/v1/note/found?if_none_match=%221%22&if_modified_since=5", "",
+			http.StatusOK,
+			&pb.GetNoteRequest{Id: "found", IfNoneMatch: `"1"`, IfModifiedSince: 5},
+			nil,
+		},
+		{
+			"not found", http.MethodGet, "/v1/note/missing", "",
+			http.StatusNotFound,
+			&pb.GetNoteRequest{Id: "missing"},
+			nil,
+		},
+		{
+			"stats", http.MethodGet, "/v1/note/found/stats?owner_token=token&days=7", "",
+			http.StatusOK,
+			&pb.GetNoteStatsRequest{Id: "found", OwnerToken: "token", Days: 7},
+			nil,
+		},
+		{
+			"unknown route", http.MethodGet, "/v1/notes", "",
+			http.StatusNotFound,
+			nil,
+			nil,
+		},
+		{
+			"wrong method", http.MethodDelete, "/v1/note", "",
+			http.StatusMethodNotAllowed,
+			nil,
+			nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &restClient{}
+			h, err := NewRESTHandler(client)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body)))
+
+			if w.Code != tc.code {
+				t.Fatalf("got %d, want %d: %s", w.Code, tc.code, w.Body)
+			}
+			if !reflect.DeepEqual(client.req, tc.req) {
+				t.Fatalf("got %+v, want %+v", client.req, tc.req)
+			}
+
+			if tc.resp == nil {
+				return
+			}
+			var resp map[string]interface{}
+			if err = json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(resp, tc.resp) {
+				t.Fatalf("got %v, want %v", resp, tc.resp)
+			}
+		})
+	}
+}
+
+func TestRESTHandlerViewer(t *testing.T) {
+	proxies := config.GetConfig().Service.Analytics.TrustedProxies
+	config.GetConfig().Service.Analytics.TrustedProxies = []string{"10.0.0.1"}
+	defer func() {
+		config.GetConfig().Service.Analytics.TrustedProxies = proxies
+	}()
+
+	for _, tc := range []struct {
+		name   string
+		remote string
+		ip     string
+	}{
+		{"direct", "203.0.113.1:1234", "203.0.113.1"},
+		{"proxied", "10.0.0.1:1234", "198.51.100.1"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &restClient{}
+			h, err := NewRESTHandler(client)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := httptest.NewRequest(http.MethodGet, "/v1/note/found", nil)
+			r.RemoteAddr = tc.remote
+			r.Header.Set("X-Forwarded-For", "198.51.100.1")
+			r.Header.Set("Referer", "https://example.com")
+			h.ServeHTTP(httptest.NewRecorder(), r)
+
+			for key, want := range map[string]string{
+				"x-viewer-transport": "http",
+				"x-viewer-referrer":  "https://example.com",
+				"x-viewer-ip":        tc.ip,
+			} {
+				if got := client.md.Get(key); len(got) != 1 || got[0] != want {
+					t.Fatalf("%s: got %v, want %q", key, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package transport
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"net/http"
+)
+
+type shutdownKey struct{}
+
+// WithShutdown returns a context carrying shutdown, which is cancelled when
+// the server starts to stop. The WatchNote and EditNote streams served with
+// the context end once it is cancelled, as they would never drain, the other
+// calls are left to finish.
+func WithShutdown(ctx context.Context, shutdown context.Context) context.Context {
+	return context.WithValue(ctx, shutdownKey{}, shutdown)
+}
+
+// ShutdownStreamInterceptor attaches shutdown to the context of the streams,
+// see WithShutdown.
+func ShutdownStreamInterceptor(shutdown context.Context) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, contextStream{ServerStream: ss, ctx: WithShutdown(ss.Context(), shutdown)})
+	}
+}
+
+// untilShutdown returns a context cancelled with ctx, or once the shutdown
+// context carried by ctx is cancelled.
+func untilShutdown(ctx context.Context) context.Context {
+	shutdown, ok := ctx.Value(shutdownKey{}).(context.Context)
+	if !ok {
+		return ctx
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-shutdown.Done():
+		case <-ctx.Done():
+		}
+		cancel()
+	}()
+	return ctx
+}
+
+// untilShutdownRequest is untilShutdown as an http RequestFunc.
+func untilShutdownRequest(ctx context.Context, _ *http.Request) context.Context {
+	return untilShutdown(ctx)
+}
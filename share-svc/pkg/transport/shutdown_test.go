@@ -0,0 +1,198 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	"google.golang.org/grpc"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUntilShutdown(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		shutdown bool
+		cancel   bool
+	}{
+		{"running", false, false},
+		{"shutting down", true, false},
+		{"call ended", false, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			shutdown, stop := context.WithCancel(context.Background())
+			defer stop()
+			parent, cancel := context.WithCancel(WithShutdown(context.Background(), shutdown))
+			defer cancel()
+
+			ctx := untilShutdown(parent)
+			if tc.shutdown {
+				stop()
+			}
+			if tc.cancel {
+				cancel()
+			}
+
+			checkDone(t, ctx, tc.shutdown || tc.cancel)
+		})
+	}
+
+	// the contexts served without shutdown are left alone
+	ctx := context.Background()
+	if untilShutdown(ctx) != ctx {
+		t.Fatal("context without shutdown is wrapped")
+	}
+}
+
+func TestShutdownStreamInterceptor(t *testing.T) {
+	shutdown, stop := context.WithCancel(context.Background())
+
+	var ctx context.Context
+	err := ShutdownStreamInterceptor(shutdown)(nil, &serverStream{ctx: context.Background()}, &grpc.StreamServerInfo{}, func(_ interface{}, stream grpc.ServerStream) error {
+		ctx = untilShutdown(stream.Context())
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkDone(t, ctx, false)
+	stop()
+	checkDone(t, ctx, true)
+}
+
+// checkDone checks whether ctx is done, or becomes done shortly.
+func checkDone(t *testing.T, ctx context.Context, done bool) {
+	t.Helper()
+
+	wait := 10 * time.Millisecond
+	if done {
+		wait = time.Second
+	}
+
+	select {
+	case <-ctx.Done():
+		if !done {
+			t.Fatal("done")
+		}
+	case <-time.After(wait):
+		if done {
+			t.Fatal("not done")
+		}
+	}
+}
+
+// editSession relays the operations it is sent back as acks, until it is
+// closed.
+type editSession struct {
+	out  chan model.EditMessage
+	done chan struct{}
+	once sync.Once
+}
+
+func newEditSession() *editSession {
+	return &editSession{out: make(chan model.EditMessage, 16), done: make(chan struct{})}
+}
+
+func (s *editSession) Send(msg model.EditMessage) error {
+	select {
+	case <-s.done:
+		return io.EOF
+	case s.out <- model.EditMessage{Type: model.EditMessageAck, Revision: msg.Revision}:
+		return nil
+	}
+}
+
+func (s *editSession) Recv() (model.EditMessage, error) {
+	select {
+	case <-s.done:
+		return model.EditMessage{}, io.EOF
+	case msg := <-s.out:
+		return msg, nil
+	}
+}
+
+func (s *editSession) Close() error {
+	s.once.Do(func() { close(s.done) })
+	return nil
+}
+
+func (s *editSession) closed() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestRelayEditSession(t *testing.T) {
+	errSend := errors.New("client is gone")
+
+	for _, tc := range []struct {
+		name string
+		// end ends the relay, leave makes the client leave
+		end  func(cancel context.CancelFunc, leave chan struct{})
+		send error
+		want error
+	}{
+		{"shutdown", func(cancel context.CancelFunc, _ chan struct{}) { cancel() }, nil, nil},
+		{"client left", func(_ context.CancelFunc, leave chan struct{}) { close(leave) }, nil, nil},
+		{"send failed", func(context.CancelFunc, chan struct{}) {}, errSend, errSend},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				session     = newEditSession()
+				client      = make(chan model.EditMessage)
+				leave       = make(chan struct{})
+				quit        = make(chan struct{})
+				sent        = make(chan model.EditMessage, 16)
+				ctx, cancel = context.WithCancel(context.Background())
+				result      = make(chan error, 1)
+			)
+			defer cancel()
+			defer close(quit)
+
+			go func() {
+				result <- relayEditSession(ctx, session, func() (model.EditMessage, error) {
+					select {
+					case msg := <-client:
+						return msg, nil
+					case <-leave:
+					case <-quit:
+					}
+					return model.EditMessage{}, io.EOF
+				}, func(msg model.EditMessage) error {
+					sent <- msg
+					return tc.send
+				})
+			}()
+
+			client <- model.EditMessage{Type: model.EditMessageOperation, Revision: 1}
+			select {
+			case msg := <-sent:
+				if msg.Type != model.EditMessageAck || msg.Revision != 1 {
+					t.Fatalf("got %+v", msg)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("message is not relayed")
+			}
+
+			tc.end(cancel, leave)
+			select {
+			case err := <-result:
+				if err != tc.want {
+					t.Fatalf("got %v, want %v", err, tc.want)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("relay does not end")
+			}
+
+			if !session.closed() {
+				t.Fatal("session is not closed")
+			}
+		})
+	}
+}
@@ -0,0 +1,402 @@
+package transport
+
+import (
+	"context"
+	"github.com/al8n/shareable-notes/share-svc/config"
+	"github.com/al8n/shareable-notes/share-svc/internal/analytics"
+	"github.com/al8n/shareable-notes/share-svc/internal/codec/thriftcodec/thriftdecode"
+	"github.com/al8n/shareable-notes/share-svc/internal/codec/thriftcodec/thriftencode"
+	serviceendpoint "github.com/al8n/shareable-notes/share-svc/pkg/endpoint"
+	shareservice "github.com/al8n/shareable-notes/share-svc/pkg/service"
+	sharethrift "github.com/al8n/shareable-notes/share-svc/thrift/gen-go/share"
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/tracing/opentracing"
+	"github.com/go-kit/kit/transport"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+// thriftCodecFunc converts a request or a response between the model and
+// the generated Thrift types.
+type thriftCodecFunc = func(context.Context, interface{}) (interface{}, error)
+
+// thriftHandler serves a method as the go-kit servers do, the request is
+// decoded, served by the endpoint and its response encoded.
+type thriftHandler struct {
+	operationName string
+	endpoint endpoint.Endpoint
+	dec thriftCodecFunc
+	enc thriftCodecFunc
+	otTracer stdopentracing.Tracer
+	errorHandler transport.ErrorHandler
+}
+
+type thriftServer struct {
+	shareNote thriftHandler
+	privateNote thriftHandler
+	getNote thriftHandler
+	syncNotes thriftHandler
+	forkNote thriftHandler
+	listForks thriftHandler
+	markTemplate thriftHandler
+	instantiateTemplate thriftHandler
+	createCollection thriftHandler
+	addCollectionNotes thriftHandler
+	removeCollectionNotes thriftHandler
+	reorderCollection thriftHandler
+	getCollection thriftHandler
+	uploadAttachment thriftHandler
+	downloadAttachment thriftHandler
+	addComment thriftHandler
+	listComments thriftHandler
+	resolveComment thriftHandler
+	getNoteStats thriftHandler
+}
+
+// NewThriftServer returns the handler of the Share service of thrift/share.thrift.
+// The errors are returned in the error field the responses share, so the
+// clients decode them as failed responses.
+func NewThriftServer(endpoints serviceendpoint.Set, otTracer stdopentracing.Tracer, logger log.Logger) sharethrift.Share {
+	var errorHandler = transport.NewLogErrorHandler(logger)
+
+	handler := func(operationName string, e endpoint.Endpoint, dec, enc thriftCodecFunc) thriftHandler {
+		return thriftHandler{
+			operationName: operationName,
+			endpoint: e,
+			dec: dec,
+			enc: enc,
+			otTracer: otTracer,
+			errorHandler: errorHandler,
+		}
+	}
+
+	return &thriftServer{
+		shareNote: handler("ShareNote", endpoints.ShareNoteEndpoint, thriftdecode.ShareNoteRequest, thriftencode.ShareNoteResponse),
+		privateNote: handler("PrivateNote", endpoints.PrivateNoteEndpoint, thriftdecode.PrivateNoteRequest, thriftencode.PrivateNoteResponse),
+		getNote: handler("GetNote", endpoints.GetNoteEndpoint, thriftdecode.GetNoteRequest, thriftencode.GetNoteResponse),
+		syncNotes: handler("SyncNotes", endpoints.SyncNotesEndpoint, thriftdecode.SyncNotesRequest, thriftencode.SyncNotesResponse),
+		forkNote: handler("ForkNote", endpoints.ForkNoteEndpoint, thriftdecode.ForkNoteRequest, thriftencode.ForkNoteResponse),
+		listForks: handler("ListForks", endpoints.ListForksEndpoint, thriftdecode.ListForksRequest, thriftencode.ListForksResponse),
+		markTemplate: handler("MarkTemplate", endpoints.MarkTemplateEndpoint, thriftdecode.MarkTemplateRequest, thriftencode.MarkTemplateResponse),
+		instantiateTemplate: handler("InstantiateTemplate", endpoints.InstantiateTemplateEndpoint, thriftdecode.InstantiateTemplateRequest, thriftencode.InstantiateTemplateResponse),
+		createCollection: handler("CreateCollection", endpoints.CreateCollectionEndpoint, thriftdecode.CreateCollectionRequest, thriftencode.CreateCollectionResponse),
+		addCollectionNotes: handler("AddCollectionNotes", endpoints.AddCollectionNotesEndpoint, thriftdecode.AddCollectionNotesRequest, thriftencode.AddCollectionNotesResponse),
+		removeCollectionNotes: handler("RemoveCollectionNotes", endpoints.RemoveCollectionNotesEndpoint, thriftdecode.RemoveCollectionNotesRequest, thriftencode.RemoveCollectionNotesResponse),
+		reorderCollection: handler("ReorderCollection", endpoints.ReorderCollectionEndpoint, thriftdecode.ReorderCollectionRequest, thriftencode.ReorderCollectionResponse),
+		getCollection: handler("GetCollection", endpoints.GetCollectionEndpoint, thriftdecode.GetCollectionRequest, thriftencode.GetCollectionResponse),
+		uploadAttachment: handler("UploadAttachment", endpoints.UploadAttachmentEndpoint, thriftdecode.UploadAttachmentRequest, thriftencode.UploadAttachmentResponse),
+		downloadAttachment: handler("DownloadAttachment", endpoints.DownloadAttachmentEndpoint, thriftdecode.DownloadAttachmentRequest, thriftencode.DownloadAttachmentResponse),
+		addComment: handler("AddComment", endpoints.AddCommentEndpoint, thriftdecode.AddCommentRequest, thriftencode.AddCommentResponse),
+		listComments: handler("ListComments", endpoints.ListCommentsEndpoint, thriftdecode.ListCommentsRequest, thriftencode.ListCommentsResponse),
+		resolveComment: handler("ResolveComment", endpoints.ResolveCommentEndpoint, thriftdecode.ResolveCommentRequest, thriftencode.ResolveCommentResponse),
+		getNoteStats: handler("GetNoteStats", endpoints.GetNoteStatsEndpoint, thriftdecode.GetNoteStatsRequest, thriftencode.GetNoteStatsResponse),
+	}
+}
+
+// serve starts the span of the call in the context, as natsToContext does for
+// NATS, the endpoints finish it.
+func (h thriftHandler) serve(ctx context.Context, thriftReq interface{}) (interface{}, error)  {
+	span := h.otTracer.StartSpan(h.operationName, ext.SpanKindRPCServer)
+	ctx = stdopentracing.ContextWithSpan(ctx, span)
+	ctx = analytics.NewContext(ctx, analytics.Viewer{Transport: analytics.TransportThrift})
+
+	request, err := h.dec(ctx, thriftReq)
+	if err != nil {
+		span.Finish()
+		h.errorHandler.Handle(ctx, err)
+		return nil, err
+	}
+
+	response, err := h.endpoint(ctx, request)
+	if err != nil {
+		h.errorHandler.Handle(ctx, err)
+		return nil, err
+	}
+
+	thriftResp, err := h.enc(ctx, response)
+	if err != nil {
+		h.errorHandler.Handle(ctx, err)
+		return nil, err
+	}
+	return thriftResp, nil
+}
+
+func (s *thriftServer) ShareNote(ctx context.Context, req *sharethrift.ShareNoteRequest) (*sharethrift.ShareNoteResponse, error)  {
+	resp, err := s.shareNote.serve(ctx, req)
+	if err != nil {
+		return &sharethrift.ShareNoteResponse{Error: err.Error()}, nil
+	}
+	return resp.(*sharethrift.ShareNoteResponse), nil
+}
+
+func (s *thriftServer) PrivateNote(ctx context.Context, req *sharethrift.PrivateNoteRequest) (*sharethrift.PrivateNoteResponse, error)  {
+	resp, err := s.privateNote.serve(ctx, req)
+	if err != nil {
+		return &sharethrift.PrivateNoteResponse{Error: err.Error()}, nil
+	}
+	return resp.(*sharethrift.PrivateNoteResponse), nil
+}
+
+func (s *thriftServer) GetNote(ctx context.Context, req *sharethrift.GetNoteRequest) (*sharethrift.GetNoteResponse, error)  {
+	resp, err := s.getNote.serve(ctx, req)
+	if err != nil {
+		return &sharethrift.GetNoteResponse{Error: err.Error()}, nil
+	}
+	return resp.(*sharethrift.GetNoteResponse), nil
+}
+
+func (s *thriftServer) SyncNotes(ctx context.Context, req *sharethrift.SyncNotesRequest) (*sharethrift.SyncNotesResponse, error)  {
+	resp, err := s.syncNotes.serve(ctx, req)
+	if err != nil {
+		return &sharethrift.SyncNotesResponse{Error: err.Error()}, nil
+	}
+	return resp.(*sharethrift.SyncNotesResponse), nil
+}
+
+func (s *thriftServer) ForkNote(ctx context.Context, req *sharethrift.ForkNoteRequest) (*sharethrift.ForkNoteResponse, error)  {
+	resp, err := s.forkNote.serve(ctx, req)
+	if err != nil {
+		return &sharethrift.ForkNoteResponse{Error: err.Error()}, nil
+	}
+	return resp.(*sharethrift.ForkNoteResponse), nil
+}
+
+func (s *thriftServer) ListForks(ctx context.Context, req *sharethrift.ListForksRequest) (*sharethrift.ListForksResponse, error)  {
+	resp, err := s.listForks.serve(ctx, req)
+	if err != nil {
+		return &sharethrift.ListForksResponse{Error: err.Error()}, nil
+	}
+	return resp.(*sharethrift.ListForksResponse), nil
+}
+
+func (s *thriftServer) MarkTemplate(ctx context.Context, req *sharethrift.MarkTemplateRequest) (*sharethrift.MarkTemplateResponse, error)  {
+	resp, err := s.markTemplate.serve(ctx, req)
+	if err != nil {
+		return &sharethrift.MarkTemplateResponse{Error: err.Error()}, nil
+	}
+	return resp.(*sharethrift.MarkTemplateResponse), nil
+}
+
+func (s *thriftServer) InstantiateTemplate(ctx context.Context, req *sharethrift.InstantiateTemplateRequest) (*sharethrift.InstantiateTemplateResponse, error)  {
+	resp, err := s.instantiateTemplate.serve(ctx, req)
+	if err != nil {
+		return &sharethrift.InstantiateTemplateResponse{Error: err.Error()}, nil
+	}
+	return resp.(*sharethrift.InstantiateTemplateResponse), nil
+}
+
+func (s *thriftServer) CreateCollection(ctx context.Context, req *sharethrift.CreateCollectionRequest) (*sharethrift.CreateCollectionResponse, error)  {
+	resp, err := s.createCollection.serve(ctx, req)
+	if err != nil {
+		return &sharethrift.CreateCollectionResponse{Error: err.Error()}, nil
+	}
+	return resp.(*sharethrift.CreateCollectionResponse), nil
+}
+
+func (s *thriftServer) AddCollectionNotes(ctx context.Context, req *sharethrift.AddCollectionNotesRequest) (*sharethrift.AddCollectionNotesResponse, error)  {
+	resp, err := s.addCollectionNotes.serve(ctx, req)
+	if err != nil {
+		return &sharethrift.AddCollectionNotesResponse{Error: err.Error()}, nil
+	}
+	return resp.(*sharethrift.AddCollectionNotesResponse), nil
+}
+
+func (s *thriftServer) RemoveCollectionNotes(ctx context.Context, req *sharethrift.RemoveCollectionNotesRequest) (*sharethrift.RemoveCollectionNotesResponse, error)  {
+	resp, err := s.removeCollectionNotes.serve(ctx, req)
+	if err != nil {
+		return &sharethrift.RemoveCollectionNotesResponse{Error: err.Error()}, nil
+	}
+	return resp.(*sharethrift.RemoveCollectionNotesResponse), nil
+}
+
+func (s *thriftServer) ReorderCollection(ctx context.Context, req *sharethrift.ReorderCollectionRequest) (*sharethrift.ReorderCollectionResponse, error)  {
+	resp, err := s.reorderCollection.serve(ctx, req)
+	if err != nil {
+		return &sharethrift.ReorderCollectionResponse{Error: err.Error()}, nil
+	}
+	return resp.(*sharethrift.ReorderCollectionResponse), nil
+}
+
+func (s *thriftServer) GetCollection(ctx context.Context, req *sharethrift.GetCollectionRequest) (*sharethrift.GetCollectionResponse, error)  {
+	resp, err := s.getCollection.serve(ctx, req)
+	if err != nil {
+		return &sharethrift.GetCollectionResponse{Error: err.Error()}, nil
+	}
+	return resp.(*sharethrift.GetCollectionResponse), nil
+}
+
+func (s *thriftServer) UploadAttachment(ctx context.Context, req *sharethrift.UploadAttachmentRequest) (*sharethrift.UploadAttachmentResponse, error)  {
+	resp, err := s.uploadAttachment.serve(ctx, req)
+	if err != nil {
+		return &sharethrift.UploadAttachmentResponse{Error: err.Error()}, nil
+	}
+	return resp.(*sharethrift.UploadAttachmentResponse), nil
+}
+
+func (s *thriftServer) DownloadAttachment(ctx context.Context, req *sharethrift.DownloadAttachmentRequest) (*sharethrift.DownloadAttachmentResponse, error)  {
+	resp, err := s.downloadAttachment.serve(ctx, req)
+	if err != nil {
+		return &sharethrift.DownloadAttachmentResponse{Error: err.Error()}, nil
+	}
+	return resp.(*sharethrift.DownloadAttachmentResponse), nil
+}
+
+func (s *thriftServer) AddComment(ctx context.Context, req *sharethrift.AddCommentRequest) (*sharethrift.AddCommentResponse, error)  {
+	resp, err := s.addComment.serve(ctx, req)
+	if err != nil {
+		return &sharethrift.AddCommentResponse{Error: err.Error()}, nil
+	}
+	return resp.(*sharethrift.AddCommentResponse), nil
+}
+
+func (s *thriftServer) ListComments(ctx context.Context, req *sharethrift.ListCommentsRequest) (*sharethrift.ListCommentsResponse, error)  {
+	resp, err := s.listComments.serve(ctx, req)
+	if err != nil {
+		return &sharethrift.ListCommentsResponse{Error: err.Error()}, nil
+	}
+	return resp.(*sharethrift.ListCommentsResponse), nil
+}
+
+func (s *thriftServer) ResolveComment(ctx context.Context, req *sharethrift.ResolveCommentRequest) (*sharethrift.ResolveCommentResponse, error)  {
+	resp, err := s.resolveComment.serve(ctx, req)
+	if err != nil {
+		return &sharethrift.ResolveCommentResponse{Error: err.Error()}, nil
+	}
+	return resp.(*sharethrift.ResolveCommentResponse), nil
+}
+
+func (s *thriftServer) GetNoteStats(ctx context.Context, req *sharethrift.GetNoteStatsRequest) (*sharethrift.GetNoteStatsResponse, error)  {
+	resp, err := s.getNoteStats.serve(ctx, req)
+	if err != nil {
+		return &sharethrift.GetNoteStatsResponse{Error: err.Error()}, nil
+	}
+	return resp.(*sharethrift.GetNoteStatsResponse), nil
+}
+
+// NewThriftFactories returns the protocol and the transport factories of the
+// calls, which the server and its clients share.
+func NewThriftFactories(cfg config.Thrift) (thrift.TProtocolFactory, thrift.TTransportFactory)  {
+	var protocolFactory thrift.TProtocolFactory
+	switch cfg.Protocol {
+	case config.ThriftProtocolCompact:
+		protocolFactory = thrift.NewTCompactProtocolFactory()
+	case config.ThriftProtocolJSON:
+		protocolFactory = thrift.NewTJSONProtocolFactory()
+	default:
+		protocolFactory = thrift.NewTBinaryProtocolFactoryDefault()
+	}
+
+	var transportFactory thrift.TTransportFactory = thrift.NewTBufferedTransportFactory(cfg.BufferSize)
+	if cfg.Framed {
+		transportFactory = thrift.NewTFramedTransportFactory(transportFactory)
+	}
+	return protocolFactory, transportFactory
+}
+
+// NewThriftClient returns a set of the endpoints calling the service over
+// Thrift. The streaming endpoints, WatchNote, WatchNotes and EditNote are
+// nil, and the set is not safe for concurrent use, as the generated client
+// is not.
+func NewThriftClient(client *sharethrift.ShareClient, otTracer stdopentracing.Tracer) serviceendpoint.Set {
+	var trace = func(operationName string, e endpoint.Endpoint) endpoint.Endpoint {
+		return opentracing.TraceClient(otTracer, operationName)(e)
+	}
+
+	return serviceendpoint.Set{
+		ShareNoteEndpoint: trace(shareservice.ShareNoteServiceName, thriftEndpoint(thriftencode.ShareNoteRequest, thriftdecode.ShareNoteResponse,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return client.ShareNote(ctx, req.(*sharethrift.ShareNoteRequest))
+			})),
+		PrivateNoteEndpoint: trace(shareservice.PrivateNoteServiceName, thriftEndpoint(thriftencode.PrivateNoteRequest, thriftdecode.PrivateNoteResponse,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return client.PrivateNote(ctx, req.(*sharethrift.PrivateNoteRequest))
+			})),
+		GetNoteEndpoint: trace(shareservice.GetNoteServiceName, thriftEndpoint(thriftencode.GetNoteRequest, thriftdecode.GetNoteResponse,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return client.GetNote(ctx, req.(*sharethrift.GetNoteRequest))
+			})),
+		SyncNotesEndpoint: trace(shareservice.SyncNotesServiceName, thriftEndpoint(thriftencode.SyncNotesRequest, thriftdecode.SyncNotesResponse,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return client.SyncNotes(ctx, req.(*sharethrift.SyncNotesRequest))
+			})),
+		ForkNoteEndpoint: trace(shareservice.ForkNoteServiceName, thriftEndpoint(thriftencode.ForkNoteRequest, thriftdecode.ForkNoteResponse,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return client.ForkNote(ctx, req.(*sharethrift.ForkNoteRequest))
+			})),
+		ListForksEndpoint: trace(shareservice.ListForksServiceName, thriftEndpoint(thriftencode.ListForksRequest, thriftdecode.ListForksResponse,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return client.ListForks(ctx, req.(*sharethrift.ListForksRequest))
+			})),
+		MarkTemplateEndpoint: trace(shareservice.MarkTemplateServiceName, thriftEndpoint(thriftencode.MarkTemplateRequest, thriftdecode.MarkTemplateResponse,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return client.MarkTemplate(ctx, req.(*sharethrift.MarkTemplateRequest))
+			})),
+		InstantiateTemplateEndpoint: trace(shareservice.InstantiateTemplateServiceName, thriftEndpoint(thriftencode.InstantiateTemplateRequest, thriftdecode.InstantiateTemplateResponse,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return client.InstantiateTemplate(ctx, req.(*sharethrift.InstantiateTemplateRequest))
+			})),
+		CreateCollectionEndpoint: trace(shareservice.CreateCollectionServiceName, thriftEndpoint(thriftencode.CreateCollectionRequest, thriftdecode.CreateCollectionResponse,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return client.CreateCollection(ctx, req.(*sharethrift.CreateCollectionRequest))
+			})),
+		AddCollectionNotesEndpoint: trace(shareservice.AddCollectionNotesServiceName, thriftEndpoint(thriftencode.AddCollectionNotesRequest, thriftdecode.AddCollectionNotesResponse,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return client.AddCollectionNotes(ctx, req.(*sharethrift.AddCollectionNotesRequest))
+			})),
+		RemoveCollectionNotesEndpoint: trace(shareservice.RemoveCollectionNotesServiceName, thriftEndpoint(thriftencode.RemoveCollectionNotesRequest, thriftdecode.RemoveCollectionNotesResponse,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return client.RemoveCollectionNotes(ctx, req.(*sharethrift.RemoveCollectionNotesRequest))
+			})),
+		ReorderCollectionEndpoint: trace(shareservice.ReorderCollectionServiceName, thriftEndpoint(thriftencode.ReorderCollectionRequest, thriftdecode.ReorderCollectionResponse,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return client.ReorderCollection(ctx, req.(*sharethrift.ReorderCollectionRequest))
+			})),
+		GetCollectionEndpoint: trace(shareservice.GetCollectionServiceName, thriftEndpoint(thriftencode.GetCollectionRequest, thriftdecode.GetCollectionResponse,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return client.GetCollection(ctx, req.(*sharethrift.GetCollectionRequest))
+			})),
+		UploadAttachmentEndpoint: trace(shareservice.UploadAttachmentServiceName, thriftEndpoint(thriftencode.UploadAttachmentRequest, thriftdecode.UploadAttachmentResponse,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return client.UploadAttachment(ctx, req.(*sharethrift.UploadAttachmentRequest))
+			})),
+		DownloadAttachmentEndpoint: trace(shareservice.DownloadAttachmentServiceName, thriftEndpoint(thriftencode.DownloadAttachmentRequest, thriftdecode.DownloadAttachmentResponse,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return client.DownloadAttachment(ctx, req.(*sharethrift.DownloadAttachmentRequest))
+			})),
+		AddCommentEndpoint: trace(shareservice.AddCommentServiceName, thriftEndpoint(thriftencode.AddCommentRequest, thriftdecode.AddCommentResponse,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return client.AddComment(ctx, req.(*sharethrift.AddCommentRequest))
+			})),
+		ListCommentsEndpoint: trace(shareservice.ListCommentsServiceName, thriftEndpoint(thriftencode.ListCommentsRequest, thriftdecode.ListCommentsResponse,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return client.ListComments(ctx, req.(*sharethrift.ListCommentsRequest))
+			})),
+		ResolveCommentEndpoint: trace(shareservice.ResolveCommentServiceName, thriftEndpoint(thriftencode.ResolveCommentRequest, thriftdecode.ResolveCommentResponse,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return client.ResolveComment(ctx, req.(*sharethrift.ResolveCommentRequest))
+			})),
+		GetNoteStatsEndpoint: trace(shareservice.GetNoteStatsServiceName, thriftEndpoint(thriftencode.GetNoteStatsRequest, thriftdecode.GetNoteStatsResponse,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return client.GetNoteStats(ctx, req.(*sharethrift.GetNoteStatsRequest))
+			})),
+	}
+}
+
+// thriftEndpoint returns an endpoint encoding its request with enc, making
+// the call and decoding its response with dec.
+func thriftEndpoint(enc, dec thriftCodecFunc, call func(context.Context, interface{}) (interface{}, error)) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := enc(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := call(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return dec(ctx, resp)
+	}
+}
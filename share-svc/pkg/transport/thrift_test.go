@@ -0,0 +1,196 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"github.com/al8n/shareable-notes/share-svc/common"
+	"github.com/al8n/shareable-notes/share-svc/config"
+	"github.com/al8n/shareable-notes/share-svc/internal/analytics"
+	"github.com/al8n/shareable-notes/share-svc/model"
+	serviceendpoint "github.com/al8n/shareable-notes/share-svc/pkg/endpoint"
+	shareservice "github.com/al8n/shareable-notes/share-svc/pkg/service"
+	sharethrift "github.com/al8n/shareable-notes/share-svc/thrift/gen-go/share"
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/go-kit/kit/log"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// thriftService serves the note "found" and its attachment "file" only, the
+// other methods are not called.
+type thriftService struct {
+	shareservice.Service
+	viewers chan analytics.Viewer
+}
+
+func (s thriftService) ShareNote(_ context.Context, name, content string) (url, sharedID, ownerToken string, err error) {
+	if name == "" {
+		return "", "", "", common.ErrorEmptyNoteStream
+	}
+	return "http://localhost/v1/note/" + name, name, "token", nil
+}
+
+func (s thriftService) GetNote(ctx context.Context, id string, cond model.NoteCondition) (name, content string, version model.NoteVersion, err error) {
+	viewer, _ := analytics.FromContext(ctx)
+	s.viewers <- viewer
+
+	if id != "found" {
+		return "", "", version, common.ErrorNoteNotFound
+	}
+
+	version = model.NoteVersion{ETag: `"1-0-1"`, LastModified: 1}
+	if cond.Unchanged(version) {
+		return "", "", version, common.ErrorNoteNotModified
+	}
+	return "name", "content", version, nil
+}
+
+func (s thriftService) UploadAttachment(_ context.Context, noteID, name string, content io.Reader) (attachment model.AttachmentInfo, err error) {
+	b, err := ioutil.ReadAll(content)
+	if err != nil {
+		return attachment, err
+	}
+	return model.AttachmentInfo{ID: "file", NoteID: noteID, Name: name, Size: int64(len(b))}, nil
+}
+
+func (s thriftService) DownloadAttachment(_ context.Context, noteID, attachmentID string) (attachment model.AttachmentInfo, content io.ReadCloser, err error) {
+	if attachmentID != "file" {
+		return attachment, nil, common.ErrorAttachmentNotFound
+	}
+	return model.AttachmentInfo{ID: "file", NoteID: noteID, Size: 5}, ioutil.NopCloser(strings.NewReader("bytes")), nil
+}
+
+// startThrift starts a Thrift server serving svc until the test ends, and
+// returns a client of it.
+func startThrift(t *testing.T, svc shareservice.Service, cfg config.Thrift) serviceendpoint.Set {
+	t.Helper()
+
+	endpoints := serviceendpoint.Set{
+		ShareNoteEndpoint:          serviceendpoint.MakeShareNoteEndpoint(svc),
+		GetNoteEndpoint:            serviceendpoint.MakeGetNoteEndpoint(svc),
+		UploadAttachmentEndpoint:   serviceendpoint.MakeUploadAttachmentEndpoint(svc),
+		DownloadAttachmentEndpoint: serviceendpoint.MakeDownloadAttachmentEndpoint(svc),
+	}
+
+	socket, err := thrift.NewTServerSocket("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	protocolFactory, transportFactory := NewThriftFactories(cfg)
+	server := thrift.NewTSimpleServer4(
+		sharethrift.NewShareProcessor(NewThriftServer(endpoints, stdopentracing.NoopTracer{}, log.NewNopLogger())),
+		socket,
+		transportFactory,
+		protocolFactory,
+	)
+	if err = server.Listen(); err != nil {
+		t.Fatal(err)
+	}
+	go server.AcceptLoop()
+
+	clientSocket, err := thrift.NewTSocket(socket.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trans, err := transportFactory.GetTransport(clientSocket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = trans.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the connection is closed before the server stops, which waits for it
+	t.Cleanup(func() {
+		trans.Close()
+		server.Stop()
+	})
+
+	return NewThriftClient(sharethrift.NewShareClientFactory(trans, protocolFactory), stdopentracing.NoopTracer{})
+}
+
+func TestThrift(t *testing.T) {
+	var ctx = context.Background()
+
+	for _, cfg := range []config.Thrift{
+		{Protocol: config.ThriftProtocolBinary, BufferSize: 8 << 10},
+		{Protocol: config.ThriftProtocolCompact, BufferSize: 8 << 10, Framed: true},
+		{Protocol: config.ThriftProtocolJSON, BufferSize: 8 << 10},
+	} {
+		t.Run(cfg.Protocol, func(t *testing.T) {
+			var (
+				svc    = thriftService{viewers: make(chan analytics.Viewer, 1)}
+				client = startThrift(t, svc, cfg)
+			)
+
+			t.Run("ShareNote", func(t *testing.T) {
+				url, id, token, err := client.ShareNote(ctx, "note", "content")
+				if err != nil || url != "http://localhost/v1/note/note" || id != "note" || token != "token" {
+					t.Fatalf("got %q %q %q %v", url, id, token, err)
+				}
+			})
+
+			t.Run("ShareNote error", func(t *testing.T) {
+				if _, _, _, err := client.ShareNote(ctx, "", "content"); !errors.Is(err, common.ErrorEmptyNoteStream) {
+					t.Fatalf("got %v", err)
+				}
+			})
+
+			t.Run("GetNote", func(t *testing.T) {
+				name, content, version, err := client.GetNote(ctx, "found", model.NoteCondition{})
+				if err != nil || name != "name" || content != "content" || version.ETag != `"1-0-1"` {
+					t.Fatalf("got %q %q %+v %v", name, content, version, err)
+				}
+
+				if viewer := <-svc.viewers; viewer.Transport != analytics.TransportThrift {
+					t.Fatalf("viewed through %q", viewer.Transport)
+				}
+			})
+
+			t.Run("GetNote not modified", func(t *testing.T) {
+				_, _, version, err := client.GetNote(ctx, "found", model.NoteCondition{IfNoneMatch: `"1-0-1"`})
+				if err != common.ErrorNoteNotModified || version.LastModified != 1 {
+					t.Fatalf("got %+v %v", version, err)
+				}
+				<-svc.viewers
+			})
+
+			t.Run("GetNote error", func(t *testing.T) {
+				if _, _, _, err := client.GetNote(ctx, "missing", model.NoteCondition{}); !errors.Is(err, common.ErrorNoteNotFound) {
+					t.Fatalf("got %v", err)
+				}
+				<-svc.viewers
+			})
+
+			t.Run("UploadAttachment", func(t *testing.T) {
+				attachment, err := client.UploadAttachment(ctx, "found", "file.txt", strings.NewReader("content"))
+				if err != nil || attachment.ID != "file" || attachment.Name != "file.txt" || attachment.Size != 7 {
+					t.Fatalf("got %+v %v", attachment, err)
+				}
+			})
+
+			t.Run("DownloadAttachment", func(t *testing.T) {
+				attachment, content, err := client.DownloadAttachment(ctx, "found", "file")
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer content.Close()
+
+				if b, _ := ioutil.ReadAll(content); string(b) != "bytes" || attachment.Size != 5 {
+					t.Fatalf("got %+v %q", attachment, b)
+				}
+			})
+
+			t.Run("DownloadAttachment error", func(t *testing.T) {
+				if _, _, err := client.DownloadAttachment(ctx, "found", "missing"); !errors.Is(err, common.ErrorAttachmentNotFound) {
+					t.Fatalf("got %v", err)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,13199 @@
+// Autogenerated by Thrift Compiler (0.13.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+package share
+
+import(
+	"bytes"
+	"context"
+	"reflect"
+	"fmt"
+	"github.com/apache/thrift/lib/go/thrift"
+)
+
+// (needed to ensure safety because of naive import list construction.)
+var _ = thrift.ZERO
+var _ = fmt.Printf
+var _ = context.Background
+var _ = reflect.DeepEqual
+var _ = bytes.Equal
+
+// Attributes:
+//  - NoteID
+type PrivateNoteRequest struct {
+  NoteID string `thrift:"note_id,1" db:"note_id" json:"note_id"`
+}
+
+func NewPrivateNoteRequest() *PrivateNoteRequest {
+  return &PrivateNoteRequest{}
+}
+
+
+func (p *PrivateNoteRequest) GetNoteID() string {
+  return p.NoteID
+}
+func (p *PrivateNoteRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *PrivateNoteRequest)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.NoteID = v
+}
+  return nil
+}
+
+func (p *PrivateNoteRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("PrivateNoteRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *PrivateNoteRequest) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("note_id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:note_id: ", p), err) }
+  if err := oprot.WriteString(string(p.NoteID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.note_id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:note_id: ", p), err) }
+  return err
+}
+
+func (p *PrivateNoteRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("PrivateNoteRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - Error
+type PrivateNoteResponse struct {
+  Error string `thrift:"error,1" db:"error" json:"error"`
+}
+
+func NewPrivateNoteResponse() *PrivateNoteResponse {
+  return &PrivateNoteResponse{}
+}
+
+
+func (p *PrivateNoteResponse) GetError() string {
+  return p.Error
+}
+func (p *PrivateNoteResponse) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *PrivateNoteResponse)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.Error = v
+}
+  return nil
+}
+
+func (p *PrivateNoteResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("PrivateNoteResponse"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *PrivateNoteResponse) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("error", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:error: ", p), err) }
+  if err := oprot.WriteString(string(p.Error)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.error (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:error: ", p), err) }
+  return err
+}
+
+func (p *PrivateNoteResponse) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("PrivateNoteResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - Name
+//  - Content
+type ShareNoteRequest struct {
+  // unused field # 1
+  Name string `thrift:"name,2" db:"name" json:"name"`
+  Content string `thrift:"content,3" db:"content" json:"content"`
+}
+
+func NewShareNoteRequest() *ShareNoteRequest {
+  return &ShareNoteRequest{}
+}
+
+
+func (p *ShareNoteRequest) GetName() string {
+  return p.Name
+}
+
+func (p *ShareNoteRequest) GetContent() string {
+  return p.Content
+}
+func (p *ShareNoteRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareNoteRequest)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.Name = v
+}
+  return nil
+}
+
+func (p *ShareNoteRequest)  ReadField3(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 3: ", err)
+} else {
+  p.Content = v
+}
+  return nil
+}
+
+func (p *ShareNoteRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ShareNoteRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareNoteRequest) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("name", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:name: ", p), err) }
+  if err := oprot.WriteString(string(p.Name)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.name (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:name: ", p), err) }
+  return err
+}
+
+func (p *ShareNoteRequest) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("content", thrift.STRING, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:content: ", p), err) }
+  if err := oprot.WriteString(string(p.Content)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.content (3) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:content: ", p), err) }
+  return err
+}
+
+func (p *ShareNoteRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareNoteRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - URL
+//  - NoteID
+//  - Error
+//  - OwnerToken
+type ShareNoteResponse struct {
+  URL string `thrift:"url,1" db:"url" json:"url"`
+  NoteID string `thrift:"note_id,2" db:"note_id" json:"note_id"`
+  Error string `thrift:"error,3" db:"error" json:"error"`
+  OwnerToken string `thrift:"owner_token,4" db:"owner_token" json:"owner_token"`
+}
+
+func NewShareNoteResponse() *ShareNoteResponse {
+  return &ShareNoteResponse{}
+}
+
+
+func (p *ShareNoteResponse) GetURL() string {
+  return p.URL
+}
+
+func (p *ShareNoteResponse) GetNoteID() string {
+  return p.NoteID
+}
+
+func (p *ShareNoteResponse) GetError() string {
+  return p.Error
+}
+
+func (p *ShareNoteResponse) GetOwnerToken() string {
+  return p.OwnerToken
+}
+func (p *ShareNoteResponse) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 4:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField4(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareNoteResponse)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.URL = v
+}
+  return nil
+}
+
+func (p *ShareNoteResponse)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.NoteID = v
+}
+  return nil
+}
+
+func (p *ShareNoteResponse)  ReadField3(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 3: ", err)
+} else {
+  p.Error = v
+}
+  return nil
+}
+
+func (p *ShareNoteResponse)  ReadField4(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 4: ", err)
+} else {
+  p.OwnerToken = v
+}
+  return nil
+}
+
+func (p *ShareNoteResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ShareNoteResponse"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareNoteResponse) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("url", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:url: ", p), err) }
+  if err := oprot.WriteString(string(p.URL)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.url (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:url: ", p), err) }
+  return err
+}
+
+func (p *ShareNoteResponse) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("note_id", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:note_id: ", p), err) }
+  if err := oprot.WriteString(string(p.NoteID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.note_id (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:note_id: ", p), err) }
+  return err
+}
+
+func (p *ShareNoteResponse) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("error", thrift.STRING, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:error: ", p), err) }
+  if err := oprot.WriteString(string(p.Error)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.error (3) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:error: ", p), err) }
+  return err
+}
+
+func (p *ShareNoteResponse) writeField4(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("owner_token", thrift.STRING, 4); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:owner_token: ", p), err) }
+  if err := oprot.WriteString(string(p.OwnerToken)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.owner_token (4) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 4:owner_token: ", p), err) }
+  return err
+}
+
+func (p *ShareNoteResponse) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareNoteResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+//  - IfNoneMatch
+//  - IfModifiedSince
+type GetNoteRequest struct {
+  ID string `thrift:"id,1" db:"id" json:"id"`
+  IfNoneMatch string `thrift:"if_none_match,2" db:"if_none_match" json:"if_none_match"`
+  IfModifiedSince int64 `thrift:"if_modified_since,3" db:"if_modified_since" json:"if_modified_since"`
+}
+
+func NewGetNoteRequest() *GetNoteRequest {
+  return &GetNoteRequest{}
+}
+
+
+func (p *GetNoteRequest) GetID() string {
+  return p.ID
+}
+
+func (p *GetNoteRequest) GetIfNoneMatch() string {
+  return p.IfNoneMatch
+}
+
+func (p *GetNoteRequest) GetIfModifiedSince() int64 {
+  return p.IfModifiedSince
+}
+func (p *GetNoteRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.I64 {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *GetNoteRequest)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.ID = v
+}
+  return nil
+}
+
+func (p *GetNoteRequest)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.IfNoneMatch = v
+}
+  return nil
+}
+
+func (p *GetNoteRequest)  ReadField3(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 3: ", err)
+} else {
+  p.IfModifiedSince = v
+}
+  return nil
+}
+
+func (p *GetNoteRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("GetNoteRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *GetNoteRequest) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err) }
+  if err := oprot.WriteString(string(p.ID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err) }
+  return err
+}
+
+func (p *GetNoteRequest) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("if_none_match", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:if_none_match: ", p), err) }
+  if err := oprot.WriteString(string(p.IfNoneMatch)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.if_none_match (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:if_none_match: ", p), err) }
+  return err
+}
+
+func (p *GetNoteRequest) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("if_modified_since", thrift.I64, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:if_modified_since: ", p), err) }
+  if err := oprot.WriteI64(int64(p.IfModifiedSince)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.if_modified_since (3) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:if_modified_since: ", p), err) }
+  return err
+}
+
+func (p *GetNoteRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("GetNoteRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - Name
+//  - Content
+//  - Error
+//  - Etag
+//  - LastModified
+//  - NotModified
+type GetNoteResponse struct {
+  Name string `thrift:"name,1" db:"name" json:"name"`
+  Content string `thrift:"content,2" db:"content" json:"content"`
+  Error string `thrift:"error,3" db:"error" json:"error"`
+  Etag string `thrift:"etag,4" db:"etag" json:"etag"`
+  LastModified int64 `thrift:"last_modified,5" db:"last_modified" json:"last_modified"`
+  NotModified bool `thrift:"not_modified,6" db:"not_modified" json:"not_modified"`
+}
+
+func NewGetNoteResponse() *GetNoteResponse {
+  return &GetNoteResponse{}
+}
+
+
+func (p *GetNoteResponse) GetName() string {
+  return p.Name
+}
+
+func (p *GetNoteResponse) GetContent() string {
+  return p.Content
+}
+
+func (p *GetNoteResponse) GetError() string {
+  return p.Error
+}
+
+func (p *GetNoteResponse) GetEtag() string {
+  return p.Etag
+}
+
+func (p *GetNoteResponse) GetLastModified() int64 {
+  return p.LastModified
+}
+
+func (p *GetNoteResponse) GetNotModified() bool {
+  return p.NotModified
+}
+func (p *GetNoteResponse) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 4:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField4(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 5:
+      if fieldTypeId == thrift.I64 {
+        if err := p.ReadField5(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 6:
+      if fieldTypeId == thrift.BOOL {
+        if err := p.ReadField6(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *GetNoteResponse)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.Name = v
+}
+  return nil
+}
+
+func (p *GetNoteResponse)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.Content = v
+}
+  return nil
+}
+
+func (p *GetNoteResponse)  ReadField3(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 3: ", err)
+} else {
+  p.Error = v
+}
+  return nil
+}
+
+func (p *GetNoteResponse)  ReadField4(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 4: ", err)
+} else {
+  p.Etag = v
+}
+  return nil
+}
+
+func (p *GetNoteResponse)  ReadField5(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 5: ", err)
+} else {
+  p.LastModified = v
+}
+  return nil
+}
+
+func (p *GetNoteResponse)  ReadField6(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadBool(); err != nil {
+  return thrift.PrependError("error reading field 6: ", err)
+} else {
+  p.NotModified = v
+}
+  return nil
+}
+
+func (p *GetNoteResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("GetNoteResponse"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
+    if err := p.writeField5(oprot); err != nil { return err }
+    if err := p.writeField6(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *GetNoteResponse) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("name", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:name: ", p), err) }
+  if err := oprot.WriteString(string(p.Name)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.name (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:name: ", p), err) }
+  return err
+}
+
+func (p *GetNoteResponse) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("content", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:content: ", p), err) }
+  if err := oprot.WriteString(string(p.Content)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.content (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:content: ", p), err) }
+  return err
+}
+
+func (p *GetNoteResponse) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("error", thrift.STRING, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:error: ", p), err) }
+  if err := oprot.WriteString(string(p.Error)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.error (3) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:error: ", p), err) }
+  return err
+}
+
+func (p *GetNoteResponse) writeField4(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("etag", thrift.STRING, 4); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:etag: ", p), err) }
+  if err := oprot.WriteString(string(p.Etag)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.etag (4) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 4:etag: ", p), err) }
+  return err
+}
+
+func (p *GetNoteResponse) writeField5(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("last_modified", thrift.I64, 5); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:last_modified: ", p), err) }
+  if err := oprot.WriteI64(int64(p.LastModified)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.last_modified (5) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 5:last_modified: ", p), err) }
+  return err
+}
+
+func (p *GetNoteResponse) writeField6(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("not_modified", thrift.BOOL, 6); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:not_modified: ", p), err) }
+  if err := oprot.WriteBool(bool(p.NotModified)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.not_modified (6) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 6:not_modified: ", p), err) }
+  return err
+}
+
+func (p *GetNoteResponse) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("GetNoteResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - NoteID
+//  - Name
+//  - Content
+//  - Versions
+//  - UpdatedAt
+//  - UpdatedBy
+//  - Deactivated
+type NoteChange struct {
+  NoteID string `thrift:"note_id,1" db:"note_id" json:"note_id"`
+  Name string `thrift:"name,2" db:"name" json:"name"`
+  Content string `thrift:"content,3" db:"content" json:"content"`
+  Versions map[string]int64 `thrift:"versions,4" db:"versions" json:"versions"`
+  UpdatedAt int64 `thrift:"updated_at,5" db:"updated_at" json:"updated_at"`
+  UpdatedBy string `thrift:"updated_by,6" db:"updated_by" json:"updated_by"`
+  Deactivated bool `thrift:"deactivated,7" db:"deactivated" json:"deactivated"`
+}
+
+func NewNoteChange() *NoteChange {
+  return &NoteChange{}
+}
+
+
+func (p *NoteChange) GetNoteID() string {
+  return p.NoteID
+}
+
+func (p *NoteChange) GetName() string {
+  return p.Name
+}
+
+func (p *NoteChange) GetContent() string {
+  return p.Content
+}
+
+func (p *NoteChange) GetVersions() map[string]int64 {
+  return p.Versions
+}
+
+func (p *NoteChange) GetUpdatedAt() int64 {
+  return p.UpdatedAt
+}
+
+func (p *NoteChange) GetUpdatedBy() string {
+  return p.UpdatedBy
+}
+
+func (p *NoteChange) GetDeactivated() bool {
+  return p.Deactivated
+}
+func (p *NoteChange) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 4:
+      if fieldTypeId == thrift.MAP {
+        if err := p.ReadField4(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 5:
+      if fieldTypeId == thrift.I64 {
+        if err := p.ReadField5(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 6:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField6(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 7:
+      if fieldTypeId == thrift.BOOL {
+        if err := p.ReadField7(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *NoteChange)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.NoteID = v
+}
+  return nil
+}
+
+func (p *NoteChange)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.Name = v
+}
+  return nil
+}
+
+func (p *NoteChange)  ReadField3(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 3: ", err)
+} else {
+  p.Content = v
+}
+  return nil
+}
+
+func (p *NoteChange)  ReadField4(iprot thrift.TProtocol) error {
+  _, _, size, err := iprot.ReadMapBegin()
+  if err != nil {
+    return thrift.PrependError("error reading map begin: ", err)
+  }
+  tMap := make(map[string]int64, size)
+  p.Versions =  tMap
+  for i := 0; i < size; i ++ {
+var _key0 string
+    if v, err := iprot.ReadString(); err != nil {
+    return thrift.PrependError("error reading field 0: ", err)
+} else {
+    _key0 = v
+}
+var _val1 int64
+    if v, err := iprot.ReadI64(); err != nil {
+    return thrift.PrependError("error reading field 0: ", err)
+} else {
+    _val1 = v
+}
+    p.Versions[_key0] = _val1
+  }
+  if err := iprot.ReadMapEnd(); err != nil {
+    return thrift.PrependError("error reading map end: ", err)
+  }
+  return nil
+}
+
+func (p *NoteChange)  ReadField5(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 5: ", err)
+} else {
+  p.UpdatedAt = v
+}
+  return nil
+}
+
+func (p *NoteChange)  ReadField6(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 6: ", err)
+} else {
+  p.UpdatedBy = v
+}
+  return nil
+}
+
+func (p *NoteChange)  ReadField7(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadBool(); err != nil {
+  return thrift.PrependError("error reading field 7: ", err)
+} else {
+  p.Deactivated = v
+}
+  return nil
+}
+
+func (p *NoteChange) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("NoteChange"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
+    if err := p.writeField5(oprot); err != nil { return err }
+    if err := p.writeField6(oprot); err != nil { return err }
+    if err := p.writeField7(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *NoteChange) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("note_id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:note_id: ", p), err) }
+  if err := oprot.WriteString(string(p.NoteID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.note_id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:note_id: ", p), err) }
+  return err
+}
+
+func (p *NoteChange) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("name", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:name: ", p), err) }
+  if err := oprot.WriteString(string(p.Name)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.name (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:name: ", p), err) }
+  return err
+}
+
+func (p *NoteChange) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("content", thrift.STRING, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:content: ", p), err) }
+  if err := oprot.WriteString(string(p.Content)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.content (3) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:content: ", p), err) }
+  return err
+}
+
+func (p *NoteChange) writeField4(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("versions", thrift.MAP, 4); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:versions: ", p), err) }
+  if err := oprot.WriteMapBegin(thrift.STRING, thrift.I64, len(p.Versions)); err != nil {
+    return thrift.PrependError("error writing map begin: ", err)
+  }
+  for k, v := range p.Versions {
+    if err := oprot.WriteString(string(k)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err) }
+    if err := oprot.WriteI64(int64(v)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err) }
+  }
+  if err := oprot.WriteMapEnd(); err != nil {
+    return thrift.PrependError("error writing map end: ", err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 4:versions: ", p), err) }
+  return err
+}
+
+func (p *NoteChange) writeField5(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("updated_at", thrift.I64, 5); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:updated_at: ", p), err) }
+  if err := oprot.WriteI64(int64(p.UpdatedAt)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.updated_at (5) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 5:updated_at: ", p), err) }
+  return err
+}
+
+func (p *NoteChange) writeField6(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("updated_by", thrift.STRING, 6); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:updated_by: ", p), err) }
+  if err := oprot.WriteString(string(p.UpdatedBy)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.updated_by (6) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 6:updated_by: ", p), err) }
+  return err
+}
+
+func (p *NoteChange) writeField7(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("deactivated", thrift.BOOL, 7); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:deactivated: ", p), err) }
+  if err := oprot.WriteBool(bool(p.Deactivated)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.deactivated (7) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 7:deactivated: ", p), err) }
+  return err
+}
+
+func (p *NoteChange) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("NoteChange(%+v)", *p)
+}
+
+// Attributes:
+//  - NoteID
+//  - Local
+//  - Server
+//  - Resolution
+type SyncConflict struct {
+  NoteID string `thrift:"note_id,1" db:"note_id" json:"note_id"`
+  Local *NoteChange `thrift:"local,2" db:"local" json:"local"`
+  Server *NoteChange `thrift:"server,3" db:"server" json:"server"`
+  Resolution string `thrift:"resolution,4" db:"resolution" json:"resolution"`
+}
+
+func NewSyncConflict() *SyncConflict {
+  return &SyncConflict{}
+}
+
+
+func (p *SyncConflict) GetNoteID() string {
+  return p.NoteID
+}
+var SyncConflict_Local_DEFAULT *NoteChange
+func (p *SyncConflict) GetLocal() *NoteChange {
+  if !p.IsSetLocal() {
+    return SyncConflict_Local_DEFAULT
+  }
+return p.Local
+}
+var SyncConflict_Server_DEFAULT *NoteChange
+func (p *SyncConflict) GetServer() *NoteChange {
+  if !p.IsSetServer() {
+    return SyncConflict_Server_DEFAULT
+  }
+return p.Server
+}
+
+func (p *SyncConflict) GetResolution() string {
+  return p.Resolution
+}
+func (p *SyncConflict) IsSetLocal() bool {
+  return p.Local != nil
+}
+
+func (p *SyncConflict) IsSetServer() bool {
+  return p.Server != nil
+}
+
+func (p *SyncConflict) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 4:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField4(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *SyncConflict)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.NoteID = v
+}
+  return nil
+}
+
+func (p *SyncConflict)  ReadField2(iprot thrift.TProtocol) error {
+  p.Local = &NoteChange{}
+  if err := p.Local.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Local), err)
+  }
+  return nil
+}
+
+func (p *SyncConflict)  ReadField3(iprot thrift.TProtocol) error {
+  p.Server = &NoteChange{}
+  if err := p.Server.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Server), err)
+  }
+  return nil
+}
+
+func (p *SyncConflict)  ReadField4(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 4: ", err)
+} else {
+  p.Resolution = v
+}
+  return nil
+}
+
+func (p *SyncConflict) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("SyncConflict"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *SyncConflict) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("note_id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:note_id: ", p), err) }
+  if err := oprot.WriteString(string(p.NoteID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.note_id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:note_id: ", p), err) }
+  return err
+}
+
+func (p *SyncConflict) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("local", thrift.STRUCT, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:local: ", p), err) }
+  if err := p.Local.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Local), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:local: ", p), err) }
+  return err
+}
+
+func (p *SyncConflict) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("server", thrift.STRUCT, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:server: ", p), err) }
+  if err := p.Server.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Server), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:server: ", p), err) }
+  return err
+}
+
+func (p *SyncConflict) writeField4(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("resolution", thrift.STRING, 4); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:resolution: ", p), err) }
+  if err := oprot.WriteString(string(p.Resolution)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.resolution (4) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 4:resolution: ", p), err) }
+  return err
+}
+
+func (p *SyncConflict) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("SyncConflict(%+v)", *p)
+}
+
+// Attributes:
+//  - ClientID
+//  - Since
+//  - NoteIds
+//  - Changes
+type SyncNotesRequest struct {
+  ClientID string `thrift:"client_id,1" db:"client_id" json:"client_id"`
+  Since int64 `thrift:"since,2" db:"since" json:"since"`
+  NoteIds []string `thrift:"note_ids,3" db:"note_ids" json:"note_ids"`
+  Changes []*NoteChange `thrift:"changes,4" db:"changes" json:"changes"`
+}
+
+func NewSyncNotesRequest() *SyncNotesRequest {
+  return &SyncNotesRequest{}
+}
+
+
+func (p *SyncNotesRequest) GetClientID() string {
+  return p.ClientID
+}
+
+func (p *SyncNotesRequest) GetSince() int64 {
+  return p.Since
+}
+
+func (p *SyncNotesRequest) GetNoteIds() []string {
+  return p.NoteIds
+}
+
+func (p *SyncNotesRequest) GetChanges() []*NoteChange {
+  return p.Changes
+}
+func (p *SyncNotesRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.I64 {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.LIST {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 4:
+      if fieldTypeId == thrift.LIST {
+        if err := p.ReadField4(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *SyncNotesRequest)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.ClientID = v
+}
+  return nil
+}
+
+func (p *SyncNotesRequest)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.Since = v
+}
+  return nil
+}
+
+func (p *SyncNotesRequest)  ReadField3(iprot thrift.TProtocol) error {
+  _, size, err := iprot.ReadListBegin()
+  if err != nil {
+    return thrift.PrependError("error reading list begin: ", err)
+  }
+  tSlice := make([]string, 0, size)
+  p.NoteIds =  tSlice
+  for i := 0; i < size; i ++ {
+var _elem2 string
+    if v, err := iprot.ReadString(); err != nil {
+    return thrift.PrependError("error reading field 0: ", err)
+} else {
+    _elem2 = v
+}
+    p.NoteIds = append(p.NoteIds, _elem2)
+  }
+  if err := iprot.ReadListEnd(); err != nil {
+    return thrift.PrependError("error reading list end: ", err)
+  }
+  return nil
+}
+
+func (p *SyncNotesRequest)  ReadField4(iprot thrift.TProtocol) error {
+  _, size, err := iprot.ReadListBegin()
+  if err != nil {
+    return thrift.PrependError("error reading list begin: ", err)
+  }
+  tSlice := make([]*NoteChange, 0, size)
+  p.Changes =  tSlice
+  for i := 0; i < size; i ++ {
+    _elem3 := &NoteChange{}
+    if err := _elem3.Read(iprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem3), err)
+    }
+    p.Changes = append(p.Changes, _elem3)
+  }
+  if err := iprot.ReadListEnd(); err != nil {
+    return thrift.PrependError("error reading list end: ", err)
+  }
+  return nil
+}
+
+func (p *SyncNotesRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("SyncNotesRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *SyncNotesRequest) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("client_id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:client_id: ", p), err) }
+  if err := oprot.WriteString(string(p.ClientID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.client_id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:client_id: ", p), err) }
+  return err
+}
+
+func (p *SyncNotesRequest) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("since", thrift.I64, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:since: ", p), err) }
+  if err := oprot.WriteI64(int64(p.Since)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.since (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:since: ", p), err) }
+  return err
+}
+
+func (p *SyncNotesRequest) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("note_ids", thrift.LIST, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:note_ids: ", p), err) }
+  if err := oprot.WriteListBegin(thrift.STRING, len(p.NoteIds)); err != nil {
+    return thrift.PrependError("error writing list begin: ", err)
+  }
+  for _, v := range p.NoteIds {
+    if err := oprot.WriteString(string(v)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err) }
+  }
+  if err := oprot.WriteListEnd(); err != nil {
+    return thrift.PrependError("error writing list end: ", err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:note_ids: ", p), err) }
+  return err
+}
+
+func (p *SyncNotesRequest) writeField4(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("changes", thrift.LIST, 4); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:changes: ", p), err) }
+  if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Changes)); err != nil {
+    return thrift.PrependError("error writing list begin: ", err)
+  }
+  for _, v := range p.Changes {
+    if err := v.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+    }
+  }
+  if err := oprot.WriteListEnd(); err != nil {
+    return thrift.PrependError("error writing list end: ", err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 4:changes: ", p), err) }
+  return err
+}
+
+func (p *SyncNotesRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("SyncNotesRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - Version
+//  - Changes
+//  - Conflicts
+//  - Error
+type SyncNotesResponse struct {
+  Version int64 `thrift:"version,1" db:"version" json:"version"`
+  Changes []*NoteChange `thrift:"changes,2" db:"changes" json:"changes"`
+  Conflicts []*SyncConflict `thrift:"conflicts,3" db:"conflicts" json:"conflicts"`
+  Error string `thrift:"error,4" db:"error" json:"error"`
+}
+
+func NewSyncNotesResponse() *SyncNotesResponse {
+  return &SyncNotesResponse{}
+}
+
+
+func (p *SyncNotesResponse) GetVersion() int64 {
+  return p.Version
+}
+
+func (p *SyncNotesResponse) GetChanges() []*NoteChange {
+  return p.Changes
+}
+
+func (p *SyncNotesResponse) GetConflicts() []*SyncConflict {
+  return p.Conflicts
+}
+
+func (p *SyncNotesResponse) GetError() string {
+  return p.Error
+}
+func (p *SyncNotesResponse) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.I64 {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.LIST {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.LIST {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 4:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField4(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *SyncNotesResponse)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.Version = v
+}
+  return nil
+}
+
+func (p *SyncNotesResponse)  ReadField2(iprot thrift.TProtocol) error {
+  _, size, err := iprot.ReadListBegin()
+  if err != nil {
+    return thrift.PrependError("error reading list begin: ", err)
+  }
+  tSlice := make([]*NoteChange, 0, size)
+  p.Changes =  tSlice
+  for i := 0; i < size; i ++ {
+    _elem4 := &NoteChange{}
+    if err := _elem4.Read(iprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem4), err)
+    }
+    p.Changes = append(p.Changes, _elem4)
+  }
+  if err := iprot.ReadListEnd(); err != nil {
+    return thrift.PrependError("error reading list end: ", err)
+  }
+  return nil
+}
+
+func (p *SyncNotesResponse)  ReadField3(iprot thrift.TProtocol) error {
+  _, size, err := iprot.ReadListBegin()
+  if err != nil {
+    return thrift.PrependError("error reading list begin: ", err)
+  }
+  tSlice := make([]*SyncConflict, 0, size)
+  p.Conflicts =  tSlice
+  for i := 0; i < size; i ++ {
+    _elem5 := &SyncConflict{}
+    if err := _elem5.Read(iprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem5), err)
+    }
+    p.Conflicts = append(p.Conflicts, _elem5)
+  }
+  if err := iprot.ReadListEnd(); err != nil {
+    return thrift.PrependError("error reading list end: ", err)
+  }
+  return nil
+}
+
+func (p *SyncNotesResponse)  ReadField4(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 4: ", err)
+} else {
+  p.Error = v
+}
+  return nil
+}
+
+func (p *SyncNotesResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("SyncNotesResponse"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *SyncNotesResponse) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("version", thrift.I64, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:version: ", p), err) }
+  if err := oprot.WriteI64(int64(p.Version)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.version (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:version: ", p), err) }
+  return err
+}
+
+func (p *SyncNotesResponse) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("changes", thrift.LIST, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:changes: ", p), err) }
+  if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Changes)); err != nil {
+    return thrift.PrependError("error writing list begin: ", err)
+  }
+  for _, v := range p.Changes {
+    if err := v.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+    }
+  }
+  if err := oprot.WriteListEnd(); err != nil {
+    return thrift.PrependError("error writing list end: ", err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:changes: ", p), err) }
+  return err
+}
+
+func (p *SyncNotesResponse) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("conflicts", thrift.LIST, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:conflicts: ", p), err) }
+  if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Conflicts)); err != nil {
+    return thrift.PrependError("error writing list begin: ", err)
+  }
+  for _, v := range p.Conflicts {
+    if err := v.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+    }
+  }
+  if err := oprot.WriteListEnd(); err != nil {
+    return thrift.PrependError("error writing list end: ", err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:conflicts: ", p), err) }
+  return err
+}
+
+func (p *SyncNotesResponse) writeField4(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("error", thrift.STRING, 4); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:error: ", p), err) }
+  if err := oprot.WriteString(string(p.Error)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.error (4) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 4:error: ", p), err) }
+  return err
+}
+
+func (p *SyncNotesResponse) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("SyncNotesResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+//  - Owner
+type ForkNoteRequest struct {
+  ID string `thrift:"id,1" db:"id" json:"id"`
+  Owner string `thrift:"owner,2" db:"owner" json:"owner"`
+}
+
+func NewForkNoteRequest() *ForkNoteRequest {
+  return &ForkNoteRequest{}
+}
+
+
+func (p *ForkNoteRequest) GetID() string {
+  return p.ID
+}
+
+func (p *ForkNoteRequest) GetOwner() string {
+  return p.Owner
+}
+func (p *ForkNoteRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ForkNoteRequest)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.ID = v
+}
+  return nil
+}
+
+func (p *ForkNoteRequest)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.Owner = v
+}
+  return nil
+}
+
+func (p *ForkNoteRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ForkNoteRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ForkNoteRequest) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err) }
+  if err := oprot.WriteString(string(p.ID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err) }
+  return err
+}
+
+func (p *ForkNoteRequest) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("owner", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:owner: ", p), err) }
+  if err := oprot.WriteString(string(p.Owner)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.owner (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:owner: ", p), err) }
+  return err
+}
+
+func (p *ForkNoteRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ForkNoteRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - URL
+//  - NoteID
+//  - Error
+//  - OwnerToken
+type ForkNoteResponse struct {
+  URL string `thrift:"url,1" db:"url" json:"url"`
+  NoteID string `thrift:"note_id,2" db:"note_id" json:"note_id"`
+  Error string `thrift:"error,3" db:"error" json:"error"`
+  OwnerToken string `thrift:"owner_token,4" db:"owner_token" json:"owner_token"`
+}
+
+func NewForkNoteResponse() *ForkNoteResponse {
+  return &ForkNoteResponse{}
+}
+
+
+func (p *ForkNoteResponse) GetURL() string {
+  return p.URL
+}
+
+func (p *ForkNoteResponse) GetNoteID() string {
+  return p.NoteID
+}
+
+func (p *ForkNoteResponse) GetError() string {
+  return p.Error
+}
+
+func (p *ForkNoteResponse) GetOwnerToken() string {
+  return p.OwnerToken
+}
+func (p *ForkNoteResponse) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 4:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField4(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ForkNoteResponse)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.URL = v
+}
+  return nil
+}
+
+func (p *ForkNoteResponse)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.NoteID = v
+}
+  return nil
+}
+
+func (p *ForkNoteResponse)  ReadField3(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 3: ", err)
+} else {
+  p.Error = v
+}
+  return nil
+}
+
+func (p *ForkNoteResponse)  ReadField4(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 4: ", err)
+} else {
+  p.OwnerToken = v
+}
+  return nil
+}
+
+func (p *ForkNoteResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ForkNoteResponse"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ForkNoteResponse) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("url", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:url: ", p), err) }
+  if err := oprot.WriteString(string(p.URL)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.url (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:url: ", p), err) }
+  return err
+}
+
+func (p *ForkNoteResponse) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("note_id", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:note_id: ", p), err) }
+  if err := oprot.WriteString(string(p.NoteID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.note_id (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:note_id: ", p), err) }
+  return err
+}
+
+func (p *ForkNoteResponse) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("error", thrift.STRING, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:error: ", p), err) }
+  if err := oprot.WriteString(string(p.Error)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.error (3) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:error: ", p), err) }
+  return err
+}
+
+func (p *ForkNoteResponse) writeField4(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("owner_token", thrift.STRING, 4); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:owner_token: ", p), err) }
+  if err := oprot.WriteString(string(p.OwnerToken)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.owner_token (4) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 4:owner_token: ", p), err) }
+  return err
+}
+
+func (p *ForkNoteResponse) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ForkNoteResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+type ListForksRequest struct {
+  ID string `thrift:"id,1" db:"id" json:"id"`
+}
+
+func NewListForksRequest() *ListForksRequest {
+  return &ListForksRequest{}
+}
+
+
+func (p *ListForksRequest) GetID() string {
+  return p.ID
+}
+func (p *ListForksRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ListForksRequest)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.ID = v
+}
+  return nil
+}
+
+func (p *ListForksRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ListForksRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ListForksRequest) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err) }
+  if err := oprot.WriteString(string(p.ID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err) }
+  return err
+}
+
+func (p *ListForksRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ListForksRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - NoteID
+//  - Name
+//  - Owner
+//  - URL
+//  - CreatedAt
+type NoteFork struct {
+  NoteID string `thrift:"note_id,1" db:"note_id" json:"note_id"`
+  Name string `thrift:"name,2" db:"name" json:"name"`
+  Owner string `thrift:"owner,3" db:"owner" json:"owner"`
+  URL string `thrift:"url,4" db:"url" json:"url"`
+  CreatedAt int64 `thrift:"created_at,5" db:"created_at" json:"created_at"`
+}
+
+func NewNoteFork() *NoteFork {
+  return &NoteFork{}
+}
+
+
+func (p *NoteFork) GetNoteID() string {
+  return p.NoteID
+}
+
+func (p *NoteFork) GetName() string {
+  return p.Name
+}
+
+func (p *NoteFork) GetOwner() string {
+  return p.Owner
+}
+
+func (p *NoteFork) GetURL() string {
+  return p.URL
+}
+
+func (p *NoteFork) GetCreatedAt() int64 {
+  return p.CreatedAt
+}
+func (p *NoteFork) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 4:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField4(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 5:
+      if fieldTypeId == thrift.I64 {
+        if err := p.ReadField5(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *NoteFork)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.NoteID = v
+}
+  return nil
+}
+
+func (p *NoteFork)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.Name = v
+}
+  return nil
+}
+
+func (p *NoteFork)  ReadField3(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 3: ", err)
+} else {
+  p.Owner = v
+}
+  return nil
+}
+
+func (p *NoteFork)  ReadField4(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 4: ", err)
+} else {
+  p.URL = v
+}
+  return nil
+}
+
+func (p *NoteFork)  ReadField5(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 5: ", err)
+} else {
+  p.CreatedAt = v
+}
+  return nil
+}
+
+func (p *NoteFork) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("NoteFork"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
+    if err := p.writeField5(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *NoteFork) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("note_id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:note_id: ", p), err) }
+  if err := oprot.WriteString(string(p.NoteID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.note_id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:note_id: ", p), err) }
+  return err
+}
+
+func (p *NoteFork) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("name", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:name: ", p), err) }
+  if err := oprot.WriteString(string(p.Name)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.name (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:name: ", p), err) }
+  return err
+}
+
+func (p *NoteFork) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("owner", thrift.STRING, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:owner: ", p), err) }
+  if err := oprot.WriteString(string(p.Owner)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.owner (3) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:owner: ", p), err) }
+  return err
+}
+
+func (p *NoteFork) writeField4(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("url", thrift.STRING, 4); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:url: ", p), err) }
+  if err := oprot.WriteString(string(p.URL)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.url (4) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 4:url: ", p), err) }
+  return err
+}
+
+func (p *NoteFork) writeField5(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("created_at", thrift.I64, 5); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:created_at: ", p), err) }
+  if err := oprot.WriteI64(int64(p.CreatedAt)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.created_at (5) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 5:created_at: ", p), err) }
+  return err
+}
+
+func (p *NoteFork) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("NoteFork(%+v)", *p)
+}
+
+// Attributes:
+//  - Forks
+//  - Error
+type ListForksResponse struct {
+  Forks []*NoteFork `thrift:"forks,1" db:"forks" json:"forks"`
+  Error string `thrift:"error,2" db:"error" json:"error"`
+}
+
+func NewListForksResponse() *ListForksResponse {
+  return &ListForksResponse{}
+}
+
+
+func (p *ListForksResponse) GetForks() []*NoteFork {
+  return p.Forks
+}
+
+func (p *ListForksResponse) GetError() string {
+  return p.Error
+}
+func (p *ListForksResponse) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.LIST {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ListForksResponse)  ReadField1(iprot thrift.TProtocol) error {
+  _, size, err := iprot.ReadListBegin()
+  if err != nil {
+    return thrift.PrependError("error reading list begin: ", err)
+  }
+  tSlice := make([]*NoteFork, 0, size)
+  p.Forks =  tSlice
+  for i := 0; i < size; i ++ {
+    _elem6 := &NoteFork{}
+    if err := _elem6.Read(iprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem6), err)
+    }
+    p.Forks = append(p.Forks, _elem6)
+  }
+  if err := iprot.ReadListEnd(); err != nil {
+    return thrift.PrependError("error reading list end: ", err)
+  }
+  return nil
+}
+
+func (p *ListForksResponse)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.Error = v
+}
+  return nil
+}
+
+func (p *ListForksResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ListForksResponse"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ListForksResponse) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("forks", thrift.LIST, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:forks: ", p), err) }
+  if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Forks)); err != nil {
+    return thrift.PrependError("error writing list begin: ", err)
+  }
+  for _, v := range p.Forks {
+    if err := v.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+    }
+  }
+  if err := oprot.WriteListEnd(); err != nil {
+    return thrift.PrependError("error writing list end: ", err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:forks: ", p), err) }
+  return err
+}
+
+func (p *ListForksResponse) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("error", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:error: ", p), err) }
+  if err := oprot.WriteString(string(p.Error)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.error (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:error: ", p), err) }
+  return err
+}
+
+func (p *ListForksResponse) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ListForksResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+//  - Template
+type MarkTemplateRequest struct {
+  ID string `thrift:"id,1" db:"id" json:"id"`
+  Template bool `thrift:"template,2" db:"template" json:"template"`
+}
+
+func NewMarkTemplateRequest() *MarkTemplateRequest {
+  return &MarkTemplateRequest{}
+}
+
+
+func (p *MarkTemplateRequest) GetID() string {
+  return p.ID
+}
+
+func (p *MarkTemplateRequest) GetTemplate() bool {
+  return p.Template
+}
+func (p *MarkTemplateRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.BOOL {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *MarkTemplateRequest)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.ID = v
+}
+  return nil
+}
+
+func (p *MarkTemplateRequest)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadBool(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.Template = v
+}
+  return nil
+}
+
+func (p *MarkTemplateRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("MarkTemplateRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *MarkTemplateRequest) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err) }
+  if err := oprot.WriteString(string(p.ID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err) }
+  return err
+}
+
+func (p *MarkTemplateRequest) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("template", thrift.BOOL, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:template: ", p), err) }
+  if err := oprot.WriteBool(bool(p.Template)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.template (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:template: ", p), err) }
+  return err
+}
+
+func (p *MarkTemplateRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("MarkTemplateRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - Name
+//  - Required
+//  - DefaultValue
+type TemplateVariable struct {
+  Name string `thrift:"name,1" db:"name" json:"name"`
+  Required bool `thrift:"required,2" db:"required" json:"required"`
+  DefaultValue string `thrift:"default_value,3" db:"default_value" json:"default_value"`
+}
+
+func NewTemplateVariable() *TemplateVariable {
+  return &TemplateVariable{}
+}
+
+
+func (p *TemplateVariable) GetName() string {
+  return p.Name
+}
+
+func (p *TemplateVariable) GetRequired() bool {
+  return p.Required
+}
+
+func (p *TemplateVariable) GetDefaultValue() string {
+  return p.DefaultValue
+}
+func (p *TemplateVariable) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.BOOL {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *TemplateVariable)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.Name = v
+}
+  return nil
+}
+
+func (p *TemplateVariable)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadBool(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.Required = v
+}
+  return nil
+}
+
+func (p *TemplateVariable)  ReadField3(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 3: ", err)
+} else {
+  p.DefaultValue = v
+}
+  return nil
+}
+
+func (p *TemplateVariable) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("TemplateVariable"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *TemplateVariable) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("name", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:name: ", p), err) }
+  if err := oprot.WriteString(string(p.Name)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.name (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:name: ", p), err) }
+  return err
+}
+
+func (p *TemplateVariable) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("required", thrift.BOOL, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:required: ", p), err) }
+  if err := oprot.WriteBool(bool(p.Required)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.required (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:required: ", p), err) }
+  return err
+}
+
+func (p *TemplateVariable) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("default_value", thrift.STRING, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:default_value: ", p), err) }
+  if err := oprot.WriteString(string(p.DefaultValue)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.default_value (3) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:default_value: ", p), err) }
+  return err
+}
+
+func (p *TemplateVariable) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("TemplateVariable(%+v)", *p)
+}
+
+// Attributes:
+//  - Variables
+//  - Error
+type MarkTemplateResponse struct {
+  Variables []*TemplateVariable `thrift:"variables,1" db:"variables" json:"variables"`
+  Error string `thrift:"error,2" db:"error" json:"error"`
+}
+
+func NewMarkTemplateResponse() *MarkTemplateResponse {
+  return &MarkTemplateResponse{}
+}
+
+
+func (p *MarkTemplateResponse) GetVariables() []*TemplateVariable {
+  return p.Variables
+}
+
+func (p *MarkTemplateResponse) GetError() string {
+  return p.Error
+}
+func (p *MarkTemplateResponse) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.LIST {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *MarkTemplateResponse)  ReadField1(iprot thrift.TProtocol) error {
+  _, size, err := iprot.ReadListBegin()
+  if err != nil {
+    return thrift.PrependError("error reading list begin: ", err)
+  }
+  tSlice := make([]*TemplateVariable, 0, size)
+  p.Variables =  tSlice
+  for i := 0; i < size; i ++ {
+    _elem7 := &TemplateVariable{}
+    if err := _elem7.Read(iprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem7), err)
+    }
+    p.Variables = append(p.Variables, _elem7)
+  }
+  if err := iprot.ReadListEnd(); err != nil {
+    return thrift.PrependError("error reading list end: ", err)
+  }
+  return nil
+}
+
+func (p *MarkTemplateResponse)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.Error = v
+}
+  return nil
+}
+
+func (p *MarkTemplateResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("MarkTemplateResponse"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *MarkTemplateResponse) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("variables", thrift.LIST, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:variables: ", p), err) }
+  if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Variables)); err != nil {
+    return thrift.PrependError("error writing list begin: ", err)
+  }
+  for _, v := range p.Variables {
+    if err := v.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+    }
+  }
+  if err := oprot.WriteListEnd(); err != nil {
+    return thrift.PrependError("error writing list end: ", err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:variables: ", p), err) }
+  return err
+}
+
+func (p *MarkTemplateResponse) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("error", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:error: ", p), err) }
+  if err := oprot.WriteString(string(p.Error)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.error (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:error: ", p), err) }
+  return err
+}
+
+func (p *MarkTemplateResponse) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("MarkTemplateResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+//  - Vars
+type InstantiateTemplateRequest struct {
+  ID string `thrift:"id,1" db:"id" json:"id"`
+  Vars map[string]string `thrift:"vars,2" db:"vars" json:"vars"`
+}
+
+func NewInstantiateTemplateRequest() *InstantiateTemplateRequest {
+  return &InstantiateTemplateRequest{}
+}
+
+
+func (p *InstantiateTemplateRequest) GetID() string {
+  return p.ID
+}
+
+func (p *InstantiateTemplateRequest) GetVars() map[string]string {
+  return p.Vars
+}
+func (p *InstantiateTemplateRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.MAP {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *InstantiateTemplateRequest)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.ID = v
+}
+  return nil
+}
+
+func (p *InstantiateTemplateRequest)  ReadField2(iprot thrift.TProtocol) error {
+  _, _, size, err := iprot.ReadMapBegin()
+  if err != nil {
+    return thrift.PrependError("error reading map begin: ", err)
+  }
+  tMap := make(map[string]string, size)
+  p.Vars =  tMap
+  for i := 0; i < size; i ++ {
+var _key8 string
+    if v, err := iprot.ReadString(); err != nil {
+    return thrift.PrependError("error reading field 0: ", err)
+} else {
+    _key8 = v
+}
+var _val9 string
+    if v, err := iprot.ReadString(); err != nil {
+    return thrift.PrependError("error reading field 0: ", err)
+} else {
+    _val9 = v
+}
+    p.Vars[_key8] = _val9
+  }
+  if err := iprot.ReadMapEnd(); err != nil {
+    return thrift.PrependError("error reading map end: ", err)
+  }
+  return nil
+}
+
+func (p *InstantiateTemplateRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("InstantiateTemplateRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *InstantiateTemplateRequest) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err) }
+  if err := oprot.WriteString(string(p.ID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err) }
+  return err
+}
+
+func (p *InstantiateTemplateRequest) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("vars", thrift.MAP, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:vars: ", p), err) }
+  if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRING, len(p.Vars)); err != nil {
+    return thrift.PrependError("error writing map begin: ", err)
+  }
+  for k, v := range p.Vars {
+    if err := oprot.WriteString(string(k)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err) }
+    if err := oprot.WriteString(string(v)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err) }
+  }
+  if err := oprot.WriteMapEnd(); err != nil {
+    return thrift.PrependError("error writing map end: ", err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:vars: ", p), err) }
+  return err
+}
+
+func (p *InstantiateTemplateRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("InstantiateTemplateRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - URL
+//  - NoteID
+//  - Error
+//  - OwnerToken
+type InstantiateTemplateResponse struct {
+  URL string `thrift:"url,1" db:"url" json:"url"`
+  NoteID string `thrift:"note_id,2" db:"note_id" json:"note_id"`
+  Error string `thrift:"error,3" db:"error" json:"error"`
+  OwnerToken string `thrift:"owner_token,4" db:"owner_token" json:"owner_token"`
+}
+
+func NewInstantiateTemplateResponse() *InstantiateTemplateResponse {
+  return &InstantiateTemplateResponse{}
+}
+
+
+func (p *InstantiateTemplateResponse) GetURL() string {
+  return p.URL
+}
+
+func (p *InstantiateTemplateResponse) GetNoteID() string {
+  return p.NoteID
+}
+
+func (p *InstantiateTemplateResponse) GetError() string {
+  return p.Error
+}
+
+func (p *InstantiateTemplateResponse) GetOwnerToken() string {
+  return p.OwnerToken
+}
+func (p *InstantiateTemplateResponse) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 4:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField4(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *InstantiateTemplateResponse)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.URL = v
+}
+  return nil
+}
+
+func (p *InstantiateTemplateResponse)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.NoteID = v
+}
+  return nil
+}
+
+func (p *InstantiateTemplateResponse)  ReadField3(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 3: ", err)
+} else {
+  p.Error = v
+}
+  return nil
+}
+
+func (p *InstantiateTemplateResponse)  ReadField4(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 4: ", err)
+} else {
+  p.OwnerToken = v
+}
+  return nil
+}
+
+func (p *InstantiateTemplateResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("InstantiateTemplateResponse"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *InstantiateTemplateResponse) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("url", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:url: ", p), err) }
+  if err := oprot.WriteString(string(p.URL)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.url (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:url: ", p), err) }
+  return err
+}
+
+func (p *InstantiateTemplateResponse) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("note_id", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:note_id: ", p), err) }
+  if err := oprot.WriteString(string(p.NoteID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.note_id (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:note_id: ", p), err) }
+  return err
+}
+
+func (p *InstantiateTemplateResponse) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("error", thrift.STRING, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:error: ", p), err) }
+  if err := oprot.WriteString(string(p.Error)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.error (3) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:error: ", p), err) }
+  return err
+}
+
+func (p *InstantiateTemplateResponse) writeField4(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("owner_token", thrift.STRING, 4); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:owner_token: ", p), err) }
+  if err := oprot.WriteString(string(p.OwnerToken)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.owner_token (4) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 4:owner_token: ", p), err) }
+  return err
+}
+
+func (p *InstantiateTemplateResponse) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("InstantiateTemplateResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - Name
+//  - NoteIds
+type CreateCollectionRequest struct {
+  Name string `thrift:"name,1" db:"name" json:"name"`
+  NoteIds []string `thrift:"note_ids,2" db:"note_ids" json:"note_ids"`
+}
+
+func NewCreateCollectionRequest() *CreateCollectionRequest {
+  return &CreateCollectionRequest{}
+}
+
+
+func (p *CreateCollectionRequest) GetName() string {
+  return p.Name
+}
+
+func (p *CreateCollectionRequest) GetNoteIds() []string {
+  return p.NoteIds
+}
+func (p *CreateCollectionRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.LIST {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *CreateCollectionRequest)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.Name = v
+}
+  return nil
+}
+
+func (p *CreateCollectionRequest)  ReadField2(iprot thrift.TProtocol) error {
+  _, size, err := iprot.ReadListBegin()
+  if err != nil {
+    return thrift.PrependError("error reading list begin: ", err)
+  }
+  tSlice := make([]string, 0, size)
+  p.NoteIds =  tSlice
+  for i := 0; i < size; i ++ {
+var _elem10 string
+    if v, err := iprot.ReadString(); err != nil {
+    return thrift.PrependError("error reading field 0: ", err)
+} else {
+    _elem10 = v
+}
+    p.NoteIds = append(p.NoteIds, _elem10)
+  }
+  if err := iprot.ReadListEnd(); err != nil {
+    return thrift.PrependError("error reading list end: ", err)
+  }
+  return nil
+}
+
+func (p *CreateCollectionRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("CreateCollectionRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *CreateCollectionRequest) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("name", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:name: ", p), err) }
+  if err := oprot.WriteString(string(p.Name)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.name (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:name: ", p), err) }
+  return err
+}
+
+func (p *CreateCollectionRequest) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("note_ids", thrift.LIST, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:note_ids: ", p), err) }
+  if err := oprot.WriteListBegin(thrift.STRING, len(p.NoteIds)); err != nil {
+    return thrift.PrependError("error writing list begin: ", err)
+  }
+  for _, v := range p.NoteIds {
+    if err := oprot.WriteString(string(v)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err) }
+  }
+  if err := oprot.WriteListEnd(); err != nil {
+    return thrift.PrependError("error writing list end: ", err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:note_ids: ", p), err) }
+  return err
+}
+
+func (p *CreateCollectionRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("CreateCollectionRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - URL
+//  - CollectionID
+//  - Error
+type CreateCollectionResponse struct {
+  URL string `thrift:"url,1" db:"url" json:"url"`
+  CollectionID string `thrift:"collection_id,2" db:"collection_id" json:"collection_id"`
+  Error string `thrift:"error,3" db:"error" json:"error"`
+}
+
+func NewCreateCollectionResponse() *CreateCollectionResponse {
+  return &CreateCollectionResponse{}
+}
+
+
+func (p *CreateCollectionResponse) GetURL() string {
+  return p.URL
+}
+
+func (p *CreateCollectionResponse) GetCollectionID() string {
+  return p.CollectionID
+}
+
+func (p *CreateCollectionResponse) GetError() string {
+  return p.Error
+}
+func (p *CreateCollectionResponse) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *CreateCollectionResponse)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.URL = v
+}
+  return nil
+}
+
+func (p *CreateCollectionResponse)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.CollectionID = v
+}
+  return nil
+}
+
+func (p *CreateCollectionResponse)  ReadField3(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 3: ", err)
+} else {
+  p.Error = v
+}
+  return nil
+}
+
+func (p *CreateCollectionResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("CreateCollectionResponse"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *CreateCollectionResponse) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("url", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:url: ", p), err) }
+  if err := oprot.WriteString(string(p.URL)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.url (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:url: ", p), err) }
+  return err
+}
+
+func (p *CreateCollectionResponse) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("collection_id", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:collection_id: ", p), err) }
+  if err := oprot.WriteString(string(p.CollectionID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.collection_id (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:collection_id: ", p), err) }
+  return err
+}
+
+func (p *CreateCollectionResponse) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("error", thrift.STRING, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:error: ", p), err) }
+  if err := oprot.WriteString(string(p.Error)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.error (3) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:error: ", p), err) }
+  return err
+}
+
+func (p *CreateCollectionResponse) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("CreateCollectionResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+//  - NoteIds
+type AddCollectionNotesRequest struct {
+  ID string `thrift:"id,1" db:"id" json:"id"`
+  NoteIds []string `thrift:"note_ids,2" db:"note_ids" json:"note_ids"`
+}
+
+func NewAddCollectionNotesRequest() *AddCollectionNotesRequest {
+  return &AddCollectionNotesRequest{}
+}
+
+
+func (p *AddCollectionNotesRequest) GetID() string {
+  return p.ID
+}
+
+func (p *AddCollectionNotesRequest) GetNoteIds() []string {
+  return p.NoteIds
+}
+func (p *AddCollectionNotesRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.LIST {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *AddCollectionNotesRequest)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.ID = v
+}
+  return nil
+}
+
+func (p *AddCollectionNotesRequest)  ReadField2(iprot thrift.TProtocol) error {
+  _, size, err := iprot.ReadListBegin()
+  if err != nil {
+    return thrift.PrependError("error reading list begin: ", err)
+  }
+  tSlice := make([]string, 0, size)
+  p.NoteIds =  tSlice
+  for i := 0; i < size; i ++ {
+var _elem11 string
+    if v, err := iprot.ReadString(); err != nil {
+    return thrift.PrependError("error reading field 0: ", err)
+} else {
+    _elem11 = v
+}
+    p.NoteIds = append(p.NoteIds, _elem11)
+  }
+  if err := iprot.ReadListEnd(); err != nil {
+    return thrift.PrependError("error reading list end: ", err)
+  }
+  return nil
+}
+
+func (p *AddCollectionNotesRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("AddCollectionNotesRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *AddCollectionNotesRequest) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err) }
+  if err := oprot.WriteString(string(p.ID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err) }
+  return err
+}
+
+func (p *AddCollectionNotesRequest) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("note_ids", thrift.LIST, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:note_ids: ", p), err) }
+  if err := oprot.WriteListBegin(thrift.STRING, len(p.NoteIds)); err != nil {
+    return thrift.PrependError("error writing list begin: ", err)
+  }
+  for _, v := range p.NoteIds {
+    if err := oprot.WriteString(string(v)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err) }
+  }
+  if err := oprot.WriteListEnd(); err != nil {
+    return thrift.PrependError("error writing list end: ", err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:note_ids: ", p), err) }
+  return err
+}
+
+func (p *AddCollectionNotesRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("AddCollectionNotesRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - Error
+type AddCollectionNotesResponse struct {
+  Error string `thrift:"error,1" db:"error" json:"error"`
+}
+
+func NewAddCollectionNotesResponse() *AddCollectionNotesResponse {
+  return &AddCollectionNotesResponse{}
+}
+
+
+func (p *AddCollectionNotesResponse) GetError() string {
+  return p.Error
+}
+func (p *AddCollectionNotesResponse) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *AddCollectionNotesResponse)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.Error = v
+}
+  return nil
+}
+
+func (p *AddCollectionNotesResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("AddCollectionNotesResponse"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *AddCollectionNotesResponse) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("error", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:error: ", p), err) }
+  if err := oprot.WriteString(string(p.Error)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.error (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:error: ", p), err) }
+  return err
+}
+
+func (p *AddCollectionNotesResponse) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("AddCollectionNotesResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+//  - NoteIds
+type RemoveCollectionNotesRequest struct {
+  ID string `thrift:"id,1" db:"id" json:"id"`
+  NoteIds []string `thrift:"note_ids,2" db:"note_ids" json:"note_ids"`
+}
+
+func NewRemoveCollectionNotesRequest() *RemoveCollectionNotesRequest {
+  return &RemoveCollectionNotesRequest{}
+}
+
+
+func (p *RemoveCollectionNotesRequest) GetID() string {
+  return p.ID
+}
+
+func (p *RemoveCollectionNotesRequest) GetNoteIds() []string {
+  return p.NoteIds
+}
+func (p *RemoveCollectionNotesRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.LIST {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *RemoveCollectionNotesRequest)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.ID = v
+}
+  return nil
+}
+
+func (p *RemoveCollectionNotesRequest)  ReadField2(iprot thrift.TProtocol) error {
+  _, size, err := iprot.ReadListBegin()
+  if err != nil {
+    return thrift.PrependError("error reading list begin: ", err)
+  }
+  tSlice := make([]string, 0, size)
+  p.NoteIds =  tSlice
+  for i := 0; i < size; i ++ {
+var _elem12 string
+    if v, err := iprot.ReadString(); err != nil {
+    return thrift.PrependError("error reading field 0: ", err)
+} else {
+    _elem12 = v
+}
+    p.NoteIds = append(p.NoteIds, _elem12)
+  }
+  if err := iprot.ReadListEnd(); err != nil {
+    return thrift.PrependError("error reading list end: ", err)
+  }
+  return nil
+}
+
+func (p *RemoveCollectionNotesRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RemoveCollectionNotesRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *RemoveCollectionNotesRequest) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err) }
+  if err := oprot.WriteString(string(p.ID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err) }
+  return err
+}
+
+func (p *RemoveCollectionNotesRequest) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("note_ids", thrift.LIST, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:note_ids: ", p), err) }
+  if err := oprot.WriteListBegin(thrift.STRING, len(p.NoteIds)); err != nil {
+    return thrift.PrependError("error writing list begin: ", err)
+  }
+  for _, v := range p.NoteIds {
+    if err := oprot.WriteString(string(v)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err) }
+  }
+  if err := oprot.WriteListEnd(); err != nil {
+    return thrift.PrependError("error writing list end: ", err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:note_ids: ", p), err) }
+  return err
+}
+
+func (p *RemoveCollectionNotesRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("RemoveCollectionNotesRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - Error
+type RemoveCollectionNotesResponse struct {
+  Error string `thrift:"error,1" db:"error" json:"error"`
+}
+
+func NewRemoveCollectionNotesResponse() *RemoveCollectionNotesResponse {
+  return &RemoveCollectionNotesResponse{}
+}
+
+
+func (p *RemoveCollectionNotesResponse) GetError() string {
+  return p.Error
+}
+func (p *RemoveCollectionNotesResponse) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *RemoveCollectionNotesResponse)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.Error = v
+}
+  return nil
+}
+
+func (p *RemoveCollectionNotesResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RemoveCollectionNotesResponse"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *RemoveCollectionNotesResponse) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("error", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:error: ", p), err) }
+  if err := oprot.WriteString(string(p.Error)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.error (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:error: ", p), err) }
+  return err
+}
+
+func (p *RemoveCollectionNotesResponse) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("RemoveCollectionNotesResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+//  - NoteIds
+type ReorderCollectionRequest struct {
+  ID string `thrift:"id,1" db:"id" json:"id"`
+  NoteIds []string `thrift:"note_ids,2" db:"note_ids" json:"note_ids"`
+}
+
+func NewReorderCollectionRequest() *ReorderCollectionRequest {
+  return &ReorderCollectionRequest{}
+}
+
+
+func (p *ReorderCollectionRequest) GetID() string {
+  return p.ID
+}
+
+func (p *ReorderCollectionRequest) GetNoteIds() []string {
+  return p.NoteIds
+}
+func (p *ReorderCollectionRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.LIST {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ReorderCollectionRequest)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.ID = v
+}
+  return nil
+}
+
+func (p *ReorderCollectionRequest)  ReadField2(iprot thrift.TProtocol) error {
+  _, size, err := iprot.ReadListBegin()
+  if err != nil {
+    return thrift.PrependError("error reading list begin: ", err)
+  }
+  tSlice := make([]string, 0, size)
+  p.NoteIds =  tSlice
+  for i := 0; i < size; i ++ {
+var _elem13 string
+    if v, err := iprot.ReadString(); err != nil {
+    return thrift.PrependError("error reading field 0: ", err)
+} else {
+    _elem13 = v
+}
+    p.NoteIds = append(p.NoteIds, _elem13)
+  }
+  if err := iprot.ReadListEnd(); err != nil {
+    return thrift.PrependError("error reading list end: ", err)
+  }
+  return nil
+}
+
+func (p *ReorderCollectionRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ReorderCollectionRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ReorderCollectionRequest) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err) }
+  if err := oprot.WriteString(string(p.ID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err) }
+  return err
+}
+
+func (p *ReorderCollectionRequest) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("note_ids", thrift.LIST, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:note_ids: ", p), err) }
+  if err := oprot.WriteListBegin(thrift.STRING, len(p.NoteIds)); err != nil {
+    return thrift.PrependError("error writing list begin: ", err)
+  }
+  for _, v := range p.NoteIds {
+    if err := oprot.WriteString(string(v)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err) }
+  }
+  if err := oprot.WriteListEnd(); err != nil {
+    return thrift.PrependError("error writing list end: ", err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:note_ids: ", p), err) }
+  return err
+}
+
+func (p *ReorderCollectionRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ReorderCollectionRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - Error
+type ReorderCollectionResponse struct {
+  Error string `thrift:"error,1" db:"error" json:"error"`
+}
+
+func NewReorderCollectionResponse() *ReorderCollectionResponse {
+  return &ReorderCollectionResponse{}
+}
+
+
+func (p *ReorderCollectionResponse) GetError() string {
+  return p.Error
+}
+func (p *ReorderCollectionResponse) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ReorderCollectionResponse)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.Error = v
+}
+  return nil
+}
+
+func (p *ReorderCollectionResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ReorderCollectionResponse"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ReorderCollectionResponse) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("error", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:error: ", p), err) }
+  if err := oprot.WriteString(string(p.Error)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.error (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:error: ", p), err) }
+  return err
+}
+
+func (p *ReorderCollectionResponse) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ReorderCollectionResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+type GetCollectionRequest struct {
+  ID string `thrift:"id,1" db:"id" json:"id"`
+}
+
+func NewGetCollectionRequest() *GetCollectionRequest {
+  return &GetCollectionRequest{}
+}
+
+
+func (p *GetCollectionRequest) GetID() string {
+  return p.ID
+}
+func (p *GetCollectionRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *GetCollectionRequest)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.ID = v
+}
+  return nil
+}
+
+func (p *GetCollectionRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("GetCollectionRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *GetCollectionRequest) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err) }
+  if err := oprot.WriteString(string(p.ID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err) }
+  return err
+}
+
+func (p *GetCollectionRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("GetCollectionRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - NoteID
+//  - Name
+//  - URL
+type CollectionNote struct {
+  NoteID string `thrift:"note_id,1" db:"note_id" json:"note_id"`
+  Name string `thrift:"name,2" db:"name" json:"name"`
+  URL string `thrift:"url,3" db:"url" json:"url"`
+}
+
+func NewCollectionNote() *CollectionNote {
+  return &CollectionNote{}
+}
+
+
+func (p *CollectionNote) GetNoteID() string {
+  return p.NoteID
+}
+
+func (p *CollectionNote) GetName() string {
+  return p.Name
+}
+
+func (p *CollectionNote) GetURL() string {
+  return p.URL
+}
+func (p *CollectionNote) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *CollectionNote)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.NoteID = v
+}
+  return nil
+}
+
+func (p *CollectionNote)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.Name = v
+}
+  return nil
+}
+
+func (p *CollectionNote)  ReadField3(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 3: ", err)
+} else {
+  p.URL = v
+}
+  return nil
+}
+
+func (p *CollectionNote) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("CollectionNote"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *CollectionNote) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("note_id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:note_id: ", p), err) }
+  if err := oprot.WriteString(string(p.NoteID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.note_id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:note_id: ", p), err) }
+  return err
+}
+
+func (p *CollectionNote) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("name", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:name: ", p), err) }
+  if err := oprot.WriteString(string(p.Name)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.name (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:name: ", p), err) }
+  return err
+}
+
+func (p *CollectionNote) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("url", thrift.STRING, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:url: ", p), err) }
+  if err := oprot.WriteString(string(p.URL)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.url (3) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:url: ", p), err) }
+  return err
+}
+
+func (p *CollectionNote) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("CollectionNote(%+v)", *p)
+}
+
+// Attributes:
+//  - Name
+//  - Notes
+//  - Error
+type GetCollectionResponse struct {
+  Name string `thrift:"name,1" db:"name" json:"name"`
+  Notes []*CollectionNote `thrift:"notes,2" db:"notes" json:"notes"`
+  Error string `thrift:"error,3" db:"error" json:"error"`
+}
+
+func NewGetCollectionResponse() *GetCollectionResponse {
+  return &GetCollectionResponse{}
+}
+
+
+func (p *GetCollectionResponse) GetName() string {
+  return p.Name
+}
+
+func (p *GetCollectionResponse) GetNotes() []*CollectionNote {
+  return p.Notes
+}
+
+func (p *GetCollectionResponse) GetError() string {
+  return p.Error
+}
+func (p *GetCollectionResponse) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.LIST {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *GetCollectionResponse)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.Name = v
+}
+  return nil
+}
+
+func (p *GetCollectionResponse)  ReadField2(iprot thrift.TProtocol) error {
+  _, size, err := iprot.ReadListBegin()
+  if err != nil {
+    return thrift.PrependError("error reading list begin: ", err)
+  }
+  tSlice := make([]*CollectionNote, 0, size)
+  p.Notes =  tSlice
+  for i := 0; i < size; i ++ {
+    _elem14 := &CollectionNote{}
+    if err := _elem14.Read(iprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem14), err)
+    }
+    p.Notes = append(p.Notes, _elem14)
+  }
+  if err := iprot.ReadListEnd(); err != nil {
+    return thrift.PrependError("error reading list end: ", err)
+  }
+  return nil
+}
+
+func (p *GetCollectionResponse)  ReadField3(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 3: ", err)
+} else {
+  p.Error = v
+}
+  return nil
+}
+
+func (p *GetCollectionResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("GetCollectionResponse"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *GetCollectionResponse) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("name", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:name: ", p), err) }
+  if err := oprot.WriteString(string(p.Name)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.name (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:name: ", p), err) }
+  return err
+}
+
+func (p *GetCollectionResponse) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("notes", thrift.LIST, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:notes: ", p), err) }
+  if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Notes)); err != nil {
+    return thrift.PrependError("error writing list begin: ", err)
+  }
+  for _, v := range p.Notes {
+    if err := v.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+    }
+  }
+  if err := oprot.WriteListEnd(); err != nil {
+    return thrift.PrependError("error writing list end: ", err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:notes: ", p), err) }
+  return err
+}
+
+func (p *GetCollectionResponse) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("error", thrift.STRING, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:error: ", p), err) }
+  if err := oprot.WriteString(string(p.Error)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.error (3) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:error: ", p), err) }
+  return err
+}
+
+func (p *GetCollectionResponse) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("GetCollectionResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+//  - NoteID
+//  - Name
+//  - ContentType
+//  - Size
+//  - URL
+//  - CreatedAt
+type Attachment struct {
+  ID string `thrift:"id,1" db:"id" json:"id"`
+  NoteID string `thrift:"note_id,2" db:"note_id" json:"note_id"`
+  Name string `thrift:"name,3" db:"name" json:"name"`
+  ContentType string `thrift:"content_type,4" db:"content_type" json:"content_type"`
+  Size int64 `thrift:"size,5" db:"size" json:"size"`
+  URL string `thrift:"url,6" db:"url" json:"url"`
+  CreatedAt int64 `thrift:"created_at,7" db:"created_at" json:"created_at"`
+}
+
+func NewAttachment() *Attachment {
+  return &Attachment{}
+}
+
+
+func (p *Attachment) GetID() string {
+  return p.ID
+}
+
+func (p *Attachment) GetNoteID() string {
+  return p.NoteID
+}
+
+func (p *Attachment) GetName() string {
+  return p.Name
+}
+
+func (p *Attachment) GetContentType() string {
+  return p.ContentType
+}
+
+func (p *Attachment) GetSize() int64 {
+  return p.Size
+}
+
+func (p *Attachment) GetURL() string {
+  return p.URL
+}
+
+func (p *Attachment) GetCreatedAt() int64 {
+  return p.CreatedAt
+}
+func (p *Attachment) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 4:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField4(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 5:
+      if fieldTypeId == thrift.I64 {
+        if err := p.ReadField5(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 6:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField6(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 7:
+      if fieldTypeId == thrift.I64 {
+        if err := p.ReadField7(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *Attachment)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.ID = v
+}
+  return nil
+}
+
+func (p *Attachment)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.NoteID = v
+}
+  return nil
+}
+
+func (p *Attachment)  ReadField3(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 3: ", err)
+} else {
+  p.Name = v
+}
+  return nil
+}
+
+func (p *Attachment)  ReadField4(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 4: ", err)
+} else {
+  p.ContentType = v
+}
+  return nil
+}
+
+func (p *Attachment)  ReadField5(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 5: ", err)
+} else {
+  p.Size = v
+}
+  return nil
+}
+
+func (p *Attachment)  ReadField6(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 6: ", err)
+} else {
+  p.URL = v
+}
+  return nil
+}
+
+func (p *Attachment)  ReadField7(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 7: ", err)
+} else {
+  p.CreatedAt = v
+}
+  return nil
+}
+
+func (p *Attachment) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("Attachment"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
+    if err := p.writeField5(oprot); err != nil { return err }
+    if err := p.writeField6(oprot); err != nil { return err }
+    if err := p.writeField7(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *Attachment) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err) }
+  if err := oprot.WriteString(string(p.ID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err) }
+  return err
+}
+
+func (p *Attachment) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("note_id", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:note_id: ", p), err) }
+  if err := oprot.WriteString(string(p.NoteID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.note_id (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:note_id: ", p), err) }
+  return err
+}
+
+func (p *Attachment) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("name", thrift.STRING, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:name: ", p), err) }
+  if err := oprot.WriteString(string(p.Name)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.name (3) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:name: ", p), err) }
+  return err
+}
+
+func (p *Attachment) writeField4(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("content_type", thrift.STRING, 4); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:content_type: ", p), err) }
+  if err := oprot.WriteString(string(p.ContentType)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.content_type (4) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 4:content_type: ", p), err) }
+  return err
+}
+
+func (p *Attachment) writeField5(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("size", thrift.I64, 5); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:size: ", p), err) }
+  if err := oprot.WriteI64(int64(p.Size)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.size (5) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 5:size: ", p), err) }
+  return err
+}
+
+func (p *Attachment) writeField6(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("url", thrift.STRING, 6); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:url: ", p), err) }
+  if err := oprot.WriteString(string(p.URL)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.url (6) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 6:url: ", p), err) }
+  return err
+}
+
+func (p *Attachment) writeField7(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("created_at", thrift.I64, 7); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:created_at: ", p), err) }
+  if err := oprot.WriteI64(int64(p.CreatedAt)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.created_at (7) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 7:created_at: ", p), err) }
+  return err
+}
+
+func (p *Attachment) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("Attachment(%+v)", *p)
+}
+
+// Attributes:
+//  - NoteID
+//  - Name
+//  - Content
+type UploadAttachmentRequest struct {
+  NoteID string `thrift:"note_id,1" db:"note_id" json:"note_id"`
+  Name string `thrift:"name,2" db:"name" json:"name"`
+  Content []byte `thrift:"content,3" db:"content" json:"content"`
+}
+
+func NewUploadAttachmentRequest() *UploadAttachmentRequest {
+  return &UploadAttachmentRequest{}
+}
+
+
+func (p *UploadAttachmentRequest) GetNoteID() string {
+  return p.NoteID
+}
+
+func (p *UploadAttachmentRequest) GetName() string {
+  return p.Name
+}
+
+func (p *UploadAttachmentRequest) GetContent() []byte {
+  return p.Content
+}
+func (p *UploadAttachmentRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *UploadAttachmentRequest)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.NoteID = v
+}
+  return nil
+}
+
+func (p *UploadAttachmentRequest)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.Name = v
+}
+  return nil
+}
+
+func (p *UploadAttachmentRequest)  ReadField3(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadBinary(); err != nil {
+  return thrift.PrependError("error reading field 3: ", err)
+} else {
+  p.Content = v
+}
+  return nil
+}
+
+func (p *UploadAttachmentRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("UploadAttachmentRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *UploadAttachmentRequest) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("note_id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:note_id: ", p), err) }
+  if err := oprot.WriteString(string(p.NoteID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.note_id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:note_id: ", p), err) }
+  return err
+}
+
+func (p *UploadAttachmentRequest) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("name", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:name: ", p), err) }
+  if err := oprot.WriteString(string(p.Name)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.name (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:name: ", p), err) }
+  return err
+}
+
+func (p *UploadAttachmentRequest) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("content", thrift.STRING, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:content: ", p), err) }
+  if err := oprot.WriteBinary(p.Content); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.content (3) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:content: ", p), err) }
+  return err
+}
+
+func (p *UploadAttachmentRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("UploadAttachmentRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - Attachment
+//  - Error
+type UploadAttachmentResponse struct {
+  Attachment *Attachment `thrift:"attachment,1" db:"attachment" json:"attachment"`
+  Error string `thrift:"error,2" db:"error" json:"error"`
+}
+
+func NewUploadAttachmentResponse() *UploadAttachmentResponse {
+  return &UploadAttachmentResponse{}
+}
+
+var UploadAttachmentResponse_Attachment_DEFAULT *Attachment
+func (p *UploadAttachmentResponse) GetAttachment() *Attachment {
+  if !p.IsSetAttachment() {
+    return UploadAttachmentResponse_Attachment_DEFAULT
+  }
+return p.Attachment
+}
+
+func (p *UploadAttachmentResponse) GetError() string {
+  return p.Error
+}
+func (p *UploadAttachmentResponse) IsSetAttachment() bool {
+  return p.Attachment != nil
+}
+
+func (p *UploadAttachmentResponse) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *UploadAttachmentResponse)  ReadField1(iprot thrift.TProtocol) error {
+  p.Attachment = &Attachment{}
+  if err := p.Attachment.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Attachment), err)
+  }
+  return nil
+}
+
+func (p *UploadAttachmentResponse)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.Error = v
+}
+  return nil
+}
+
+func (p *UploadAttachmentResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("UploadAttachmentResponse"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *UploadAttachmentResponse) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("attachment", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:attachment: ", p), err) }
+  if err := p.Attachment.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Attachment), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:attachment: ", p), err) }
+  return err
+}
+
+func (p *UploadAttachmentResponse) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("error", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:error: ", p), err) }
+  if err := oprot.WriteString(string(p.Error)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.error (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:error: ", p), err) }
+  return err
+}
+
+func (p *UploadAttachmentResponse) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("UploadAttachmentResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - NoteID
+//  - ID
+type DownloadAttachmentRequest struct {
+  NoteID string `thrift:"note_id,1" db:"note_id" json:"note_id"`
+  ID string `thrift:"id,2" db:"id" json:"id"`
+}
+
+func NewDownloadAttachmentRequest() *DownloadAttachmentRequest {
+  return &DownloadAttachmentRequest{}
+}
+
+
+func (p *DownloadAttachmentRequest) GetNoteID() string {
+  return p.NoteID
+}
+
+func (p *DownloadAttachmentRequest) GetID() string {
+  return p.ID
+}
+func (p *DownloadAttachmentRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *DownloadAttachmentRequest)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.NoteID = v
+}
+  return nil
+}
+
+func (p *DownloadAttachmentRequest)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.ID = v
+}
+  return nil
+}
+
+func (p *DownloadAttachmentRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("DownloadAttachmentRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *DownloadAttachmentRequest) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("note_id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:note_id: ", p), err) }
+  if err := oprot.WriteString(string(p.NoteID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.note_id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:note_id: ", p), err) }
+  return err
+}
+
+func (p *DownloadAttachmentRequest) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("id", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:id: ", p), err) }
+  if err := oprot.WriteString(string(p.ID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.id (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:id: ", p), err) }
+  return err
+}
+
+func (p *DownloadAttachmentRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("DownloadAttachmentRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - Attachment
+//  - Content
+//  - Error
+type DownloadAttachmentResponse struct {
+  Attachment *Attachment `thrift:"attachment,1" db:"attachment" json:"attachment"`
+  Content []byte `thrift:"content,2" db:"content" json:"content"`
+  Error string `thrift:"error,3" db:"error" json:"error"`
+}
+
+func NewDownloadAttachmentResponse() *DownloadAttachmentResponse {
+  return &DownloadAttachmentResponse{}
+}
+
+var DownloadAttachmentResponse_Attachment_DEFAULT *Attachment
+func (p *DownloadAttachmentResponse) GetAttachment() *Attachment {
+  if !p.IsSetAttachment() {
+    return DownloadAttachmentResponse_Attachment_DEFAULT
+  }
+return p.Attachment
+}
+
+func (p *DownloadAttachmentResponse) GetContent() []byte {
+  return p.Content
+}
+
+func (p *DownloadAttachmentResponse) GetError() string {
+  return p.Error
+}
+func (p *DownloadAttachmentResponse) IsSetAttachment() bool {
+  return p.Attachment != nil
+}
+
+func (p *DownloadAttachmentResponse) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *DownloadAttachmentResponse)  ReadField1(iprot thrift.TProtocol) error {
+  p.Attachment = &Attachment{}
+  if err := p.Attachment.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Attachment), err)
+  }
+  return nil
+}
+
+func (p *DownloadAttachmentResponse)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadBinary(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.Content = v
+}
+  return nil
+}
+
+func (p *DownloadAttachmentResponse)  ReadField3(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 3: ", err)
+} else {
+  p.Error = v
+}
+  return nil
+}
+
+func (p *DownloadAttachmentResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("DownloadAttachmentResponse"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *DownloadAttachmentResponse) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("attachment", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:attachment: ", p), err) }
+  if err := p.Attachment.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Attachment), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:attachment: ", p), err) }
+  return err
+}
+
+func (p *DownloadAttachmentResponse) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("content", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:content: ", p), err) }
+  if err := oprot.WriteBinary(p.Content); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.content (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:content: ", p), err) }
+  return err
+}
+
+func (p *DownloadAttachmentResponse) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("error", thrift.STRING, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:error: ", p), err) }
+  if err := oprot.WriteString(string(p.Error)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.error (3) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:error: ", p), err) }
+  return err
+}
+
+func (p *DownloadAttachmentResponse) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("DownloadAttachmentResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+//  - NoteID
+//  - Author
+//  - Body
+//  - StartLine
+//  - EndLine
+//  - Resolved
+//  - ResolvedBy
+//  - CreatedAt
+//  - ResolvedAt
+type Comment struct {
+  ID string `thrift:"id,1" db:"id" json:"id"`
+  NoteID string `thrift:"note_id,2" db:"note_id" json:"note_id"`
+  Author string `thrift:"author,3" db:"author" json:"author"`
+  Body string `thrift:"body,4" db:"body" json:"body"`
+  StartLine int64 `thrift:"start_line,5" db:"start_line" json:"start_line"`
+  EndLine int64 `thrift:"end_line,6" db:"end_line" json:"end_line"`
+  Resolved bool `thrift:"resolved,7" db:"resolved" json:"resolved"`
+  ResolvedBy string `thrift:"resolved_by,8" db:"resolved_by" json:"resolved_by"`
+  CreatedAt int64 `thrift:"created_at,9" db:"created_at" json:"created_at"`
+  ResolvedAt int64 `thrift:"resolved_at,10" db:"resolved_at" json:"resolved_at"`
+}
+
+func NewComment() *Comment {
+  return &Comment{}
+}
+
+
+func (p *Comment) GetID() string {
+  return p.ID
+}
+
+func (p *Comment) GetNoteID() string {
+  return p.NoteID
+}
+
+func (p *Comment) GetAuthor() string {
+  return p.Author
+}
+
+func (p *Comment) GetBody() string {
+  return p.Body
+}
+
+func (p *Comment) GetStartLine() int64 {
+  return p.StartLine
+}
+
+func (p *Comment) GetEndLine() int64 {
+  return p.EndLine
+}
+
+func (p *Comment) GetResolved() bool {
+  return p.Resolved
+}
+
+func (p *Comment) GetResolvedBy() string {
+  return p.ResolvedBy
+}
+
+func (p *Comment) GetCreatedAt() int64 {
+  return p.CreatedAt
+}
+
+func (p *Comment) GetResolvedAt() int64 {
+  return p.ResolvedAt
+}
+func (p *Comment) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 4:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField4(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 5:
+      if fieldTypeId == thrift.I64 {
+        if err := p.ReadField5(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 6:
+      if fieldTypeId == thrift.I64 {
+        if err := p.ReadField6(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 7:
+      if fieldTypeId == thrift.BOOL {
+        if err := p.ReadField7(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 8:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField8(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 9:
+      if fieldTypeId == thrift.I64 {
+        if err := p.ReadField9(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 10:
+      if fieldTypeId == thrift.I64 {
+        if err := p.ReadField10(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *Comment)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.ID = v
+}
+  return nil
+}
+
+func (p *Comment)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.NoteID = v
+}
+  return nil
+}
+
+func (p *Comment)  ReadField3(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 3: ", err)
+} else {
+  p.Author = v
+}
+  return nil
+}
+
+func (p *Comment)  ReadField4(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 4: ", err)
+} else {
+  p.Body = v
+}
+  return nil
+}
+
+func (p *Comment)  ReadField5(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 5: ", err)
+} else {
+  p.StartLine = v
+}
+  return nil
+}
+
+func (p *Comment)  ReadField6(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 6: ", err)
+} else {
+  p.EndLine = v
+}
+  return nil
+}
+
+func (p *Comment)  ReadField7(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadBool(); err != nil {
+  return thrift.PrependError("error reading field 7: ", err)
+} else {
+  p.Resolved = v
+}
+  return nil
+}
+
+func (p *Comment)  ReadField8(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 8: ", err)
+} else {
+  p.ResolvedBy = v
+}
+  return nil
+}
+
+func (p *Comment)  ReadField9(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 9: ", err)
+} else {
+  p.CreatedAt = v
+}
+  return nil
+}
+
+func (p *Comment)  ReadField10(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 10: ", err)
+} else {
+  p.ResolvedAt = v
+}
+  return nil
+}
+
+func (p *Comment) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("Comment"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
+    if err := p.writeField5(oprot); err != nil { return err }
+    if err := p.writeField6(oprot); err != nil { return err }
+    if err := p.writeField7(oprot); err != nil { return err }
+    if err := p.writeField8(oprot); err != nil { return err }
+    if err := p.writeField9(oprot); err != nil { return err }
+    if err := p.writeField10(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *Comment) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err) }
+  if err := oprot.WriteString(string(p.ID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err) }
+  return err
+}
+
+func (p *Comment) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("note_id", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:note_id: ", p), err) }
+  if err := oprot.WriteString(string(p.NoteID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.note_id (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:note_id: ", p), err) }
+  return err
+}
+
+func (p *Comment) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("author", thrift.STRING, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:author: ", p), err) }
+  if err := oprot.WriteString(string(p.Author)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.author (3) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:author: ", p), err) }
+  return err
+}
+
+func (p *Comment) writeField4(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("body", thrift.STRING, 4); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:body: ", p), err) }
+  if err := oprot.WriteString(string(p.Body)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.body (4) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 4:body: ", p), err) }
+  return err
+}
+
+func (p *Comment) writeField5(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("start_line", thrift.I64, 5); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:start_line: ", p), err) }
+  if err := oprot.WriteI64(int64(p.StartLine)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.start_line (5) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 5:start_line: ", p), err) }
+  return err
+}
+
+func (p *Comment) writeField6(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("end_line", thrift.I64, 6); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:end_line: ", p), err) }
+  if err := oprot.WriteI64(int64(p.EndLine)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.end_line (6) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 6:end_line: ", p), err) }
+  return err
+}
+
+func (p *Comment) writeField7(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("resolved", thrift.BOOL, 7); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:resolved: ", p), err) }
+  if err := oprot.WriteBool(bool(p.Resolved)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.resolved (7) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 7:resolved: ", p), err) }
+  return err
+}
+
+func (p *Comment) writeField8(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("resolved_by", thrift.STRING, 8); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:resolved_by: ", p), err) }
+  if err := oprot.WriteString(string(p.ResolvedBy)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.resolved_by (8) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 8:resolved_by: ", p), err) }
+  return err
+}
+
+func (p *Comment) writeField9(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("created_at", thrift.I64, 9); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:created_at: ", p), err) }
+  if err := oprot.WriteI64(int64(p.CreatedAt)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.created_at (9) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 9:created_at: ", p), err) }
+  return err
+}
+
+func (p *Comment) writeField10(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("resolved_at", thrift.I64, 10); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:resolved_at: ", p), err) }
+  if err := oprot.WriteI64(int64(p.ResolvedAt)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.resolved_at (10) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 10:resolved_at: ", p), err) }
+  return err
+}
+
+func (p *Comment) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("Comment(%+v)", *p)
+}
+
+// Attributes:
+//  - NoteID
+//  - Author
+//  - Body
+//  - StartLine
+//  - EndLine
+type AddCommentRequest struct {
+  NoteID string `thrift:"note_id,1" db:"note_id" json:"note_id"`
+  Author string `thrift:"author,2" db:"author" json:"author"`
+  Body string `thrift:"body,3" db:"body" json:"body"`
+  StartLine int64 `thrift:"start_line,4" db:"start_line" json:"start_line"`
+  EndLine int64 `thrift:"end_line,5" db:"end_line" json:"end_line"`
+}
+
+func NewAddCommentRequest() *AddCommentRequest {
+  return &AddCommentRequest{}
+}
+
+
+func (p *AddCommentRequest) GetNoteID() string {
+  return p.NoteID
+}
+
+func (p *AddCommentRequest) GetAuthor() string {
+  return p.Author
+}
+
+func (p *AddCommentRequest) GetBody() string {
+  return p.Body
+}
+
+func (p *AddCommentRequest) GetStartLine() int64 {
+  return p.StartLine
+}
+
+func (p *AddCommentRequest) GetEndLine() int64 {
+  return p.EndLine
+}
+func (p *AddCommentRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 4:
+      if fieldTypeId == thrift.I64 {
+        if err := p.ReadField4(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 5:
+      if fieldTypeId == thrift.I64 {
+        if err := p.ReadField5(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *AddCommentRequest)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.NoteID = v
+}
+  return nil
+}
+
+func (p *AddCommentRequest)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.Author = v
+}
+  return nil
+}
+
+func (p *AddCommentRequest)  ReadField3(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 3: ", err)
+} else {
+  p.Body = v
+}
+  return nil
+}
+
+func (p *AddCommentRequest)  ReadField4(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 4: ", err)
+} else {
+  p.StartLine = v
+}
+  return nil
+}
+
+func (p *AddCommentRequest)  ReadField5(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 5: ", err)
+} else {
+  p.EndLine = v
+}
+  return nil
+}
+
+func (p *AddCommentRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("AddCommentRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
+    if err := p.writeField5(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *AddCommentRequest) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("note_id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:note_id: ", p), err) }
+  if err := oprot.WriteString(string(p.NoteID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.note_id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:note_id: ", p), err) }
+  return err
+}
+
+func (p *AddCommentRequest) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("author", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:author: ", p), err) }
+  if err := oprot.WriteString(string(p.Author)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.author (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:author: ", p), err) }
+  return err
+}
+
+func (p *AddCommentRequest) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("body", thrift.STRING, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:body: ", p), err) }
+  if err := oprot.WriteString(string(p.Body)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.body (3) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:body: ", p), err) }
+  return err
+}
+
+func (p *AddCommentRequest) writeField4(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("start_line", thrift.I64, 4); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:start_line: ", p), err) }
+  if err := oprot.WriteI64(int64(p.StartLine)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.start_line (4) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 4:start_line: ", p), err) }
+  return err
+}
+
+func (p *AddCommentRequest) writeField5(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("end_line", thrift.I64, 5); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:end_line: ", p), err) }
+  if err := oprot.WriteI64(int64(p.EndLine)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.end_line (5) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 5:end_line: ", p), err) }
+  return err
+}
+
+func (p *AddCommentRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("AddCommentRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - Comment
+//  - Error
+type AddCommentResponse struct {
+  Comment *Comment `thrift:"comment,1" db:"comment" json:"comment"`
+  Error string `thrift:"error,2" db:"error" json:"error"`
+}
+
+func NewAddCommentResponse() *AddCommentResponse {
+  return &AddCommentResponse{}
+}
+
+var AddCommentResponse_Comment_DEFAULT *Comment
+func (p *AddCommentResponse) GetComment() *Comment {
+  if !p.IsSetComment() {
+    return AddCommentResponse_Comment_DEFAULT
+  }
+return p.Comment
+}
+
+func (p *AddCommentResponse) GetError() string {
+  return p.Error
+}
+func (p *AddCommentResponse) IsSetComment() bool {
+  return p.Comment != nil
+}
+
+func (p *AddCommentResponse) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *AddCommentResponse)  ReadField1(iprot thrift.TProtocol) error {
+  p.Comment = &Comment{}
+  if err := p.Comment.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Comment), err)
+  }
+  return nil
+}
+
+func (p *AddCommentResponse)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.Error = v
+}
+  return nil
+}
+
+func (p *AddCommentResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("AddCommentResponse"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *AddCommentResponse) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("comment", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:comment: ", p), err) }
+  if err := p.Comment.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Comment), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:comment: ", p), err) }
+  return err
+}
+
+func (p *AddCommentResponse) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("error", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:error: ", p), err) }
+  if err := oprot.WriteString(string(p.Error)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.error (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:error: ", p), err) }
+  return err
+}
+
+func (p *AddCommentResponse) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("AddCommentResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - NoteID
+//  - IncludeResolved
+type ListCommentsRequest struct {
+  NoteID string `thrift:"note_id,1" db:"note_id" json:"note_id"`
+  IncludeResolved bool `thrift:"include_resolved,2" db:"include_resolved" json:"include_resolved"`
+}
+
+func NewListCommentsRequest() *ListCommentsRequest {
+  return &ListCommentsRequest{}
+}
+
+
+func (p *ListCommentsRequest) GetNoteID() string {
+  return p.NoteID
+}
+
+func (p *ListCommentsRequest) GetIncludeResolved() bool {
+  return p.IncludeResolved
+}
+func (p *ListCommentsRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.BOOL {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ListCommentsRequest)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.NoteID = v
+}
+  return nil
+}
+
+func (p *ListCommentsRequest)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadBool(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.IncludeResolved = v
+}
+  return nil
+}
+
+func (p *ListCommentsRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ListCommentsRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ListCommentsRequest) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("note_id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:note_id: ", p), err) }
+  if err := oprot.WriteString(string(p.NoteID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.note_id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:note_id: ", p), err) }
+  return err
+}
+
+func (p *ListCommentsRequest) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("include_resolved", thrift.BOOL, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:include_resolved: ", p), err) }
+  if err := oprot.WriteBool(bool(p.IncludeResolved)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.include_resolved (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:include_resolved: ", p), err) }
+  return err
+}
+
+func (p *ListCommentsRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ListCommentsRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - Comments
+//  - Error
+type ListCommentsResponse struct {
+  Comments []*Comment `thrift:"comments,1" db:"comments" json:"comments"`
+  Error string `thrift:"error,2" db:"error" json:"error"`
+}
+
+func NewListCommentsResponse() *ListCommentsResponse {
+  return &ListCommentsResponse{}
+}
+
+
+func (p *ListCommentsResponse) GetComments() []*Comment {
+  return p.Comments
+}
+
+func (p *ListCommentsResponse) GetError() string {
+  return p.Error
+}
+func (p *ListCommentsResponse) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.LIST {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ListCommentsResponse)  ReadField1(iprot thrift.TProtocol) error {
+  _, size, err := iprot.ReadListBegin()
+  if err != nil {
+    return thrift.PrependError("error reading list begin: ", err)
+  }
+  tSlice := make([]*Comment, 0, size)
+  p.Comments =  tSlice
+  for i := 0; i < size; i ++ {
+    _elem15 := &Comment{}
+    if err := _elem15.Read(iprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem15), err)
+    }
+    p.Comments = append(p.Comments, _elem15)
+  }
+  if err := iprot.ReadListEnd(); err != nil {
+    return thrift.PrependError("error reading list end: ", err)
+  }
+  return nil
+}
+
+func (p *ListCommentsResponse)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.Error = v
+}
+  return nil
+}
+
+func (p *ListCommentsResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ListCommentsResponse"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ListCommentsResponse) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("comments", thrift.LIST, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:comments: ", p), err) }
+  if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Comments)); err != nil {
+    return thrift.PrependError("error writing list begin: ", err)
+  }
+  for _, v := range p.Comments {
+    if err := v.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+    }
+  }
+  if err := oprot.WriteListEnd(); err != nil {
+    return thrift.PrependError("error writing list end: ", err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:comments: ", p), err) }
+  return err
+}
+
+func (p *ListCommentsResponse) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("error", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:error: ", p), err) }
+  if err := oprot.WriteString(string(p.Error)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.error (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:error: ", p), err) }
+  return err
+}
+
+func (p *ListCommentsResponse) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ListCommentsResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - NoteID
+//  - ID
+//  - User
+type ResolveCommentRequest struct {
+  NoteID string `thrift:"note_id,1" db:"note_id" json:"note_id"`
+  ID string `thrift:"id,2" db:"id" json:"id"`
+  User string `thrift:"user,3" db:"user" json:"user"`
+}
+
+func NewResolveCommentRequest() *ResolveCommentRequest {
+  return &ResolveCommentRequest{}
+}
+
+
+func (p *ResolveCommentRequest) GetNoteID() string {
+  return p.NoteID
+}
+
+func (p *ResolveCommentRequest) GetID() string {
+  return p.ID
+}
+
+func (p *ResolveCommentRequest) GetUser() string {
+  return p.User
+}
+func (p *ResolveCommentRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ResolveCommentRequest)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.NoteID = v
+}
+  return nil
+}
+
+func (p *ResolveCommentRequest)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.ID = v
+}
+  return nil
+}
+
+func (p *ResolveCommentRequest)  ReadField3(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 3: ", err)
+} else {
+  p.User = v
+}
+  return nil
+}
+
+func (p *ResolveCommentRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ResolveCommentRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ResolveCommentRequest) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("note_id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:note_id: ", p), err) }
+  if err := oprot.WriteString(string(p.NoteID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.note_id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:note_id: ", p), err) }
+  return err
+}
+
+func (p *ResolveCommentRequest) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("id", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:id: ", p), err) }
+  if err := oprot.WriteString(string(p.ID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.id (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:id: ", p), err) }
+  return err
+}
+
+func (p *ResolveCommentRequest) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("user", thrift.STRING, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:user: ", p), err) }
+  if err := oprot.WriteString(string(p.User)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.user (3) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:user: ", p), err) }
+  return err
+}
+
+func (p *ResolveCommentRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ResolveCommentRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - Comment
+//  - Error
+type ResolveCommentResponse struct {
+  Comment *Comment `thrift:"comment,1" db:"comment" json:"comment"`
+  Error string `thrift:"error,2" db:"error" json:"error"`
+}
+
+func NewResolveCommentResponse() *ResolveCommentResponse {
+  return &ResolveCommentResponse{}
+}
+
+var ResolveCommentResponse_Comment_DEFAULT *Comment
+func (p *ResolveCommentResponse) GetComment() *Comment {
+  if !p.IsSetComment() {
+    return ResolveCommentResponse_Comment_DEFAULT
+  }
+return p.Comment
+}
+
+func (p *ResolveCommentResponse) GetError() string {
+  return p.Error
+}
+func (p *ResolveCommentResponse) IsSetComment() bool {
+  return p.Comment != nil
+}
+
+func (p *ResolveCommentResponse) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ResolveCommentResponse)  ReadField1(iprot thrift.TProtocol) error {
+  p.Comment = &Comment{}
+  if err := p.Comment.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Comment), err)
+  }
+  return nil
+}
+
+func (p *ResolveCommentResponse)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.Error = v
+}
+  return nil
+}
+
+func (p *ResolveCommentResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ResolveCommentResponse"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ResolveCommentResponse) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("comment", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:comment: ", p), err) }
+  if err := p.Comment.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Comment), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:comment: ", p), err) }
+  return err
+}
+
+func (p *ResolveCommentResponse) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("error", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:error: ", p), err) }
+  if err := oprot.WriteString(string(p.Error)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.error (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:error: ", p), err) }
+  return err
+}
+
+func (p *ResolveCommentResponse) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ResolveCommentResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - ID
+//  - OwnerToken
+//  - Days
+type GetNoteStatsRequest struct {
+  ID string `thrift:"id,1" db:"id" json:"id"`
+  OwnerToken string `thrift:"owner_token,2" db:"owner_token" json:"owner_token"`
+  Days int32 `thrift:"days,3" db:"days" json:"days"`
+}
+
+func NewGetNoteStatsRequest() *GetNoteStatsRequest {
+  return &GetNoteStatsRequest{}
+}
+
+
+func (p *GetNoteStatsRequest) GetID() string {
+  return p.ID
+}
+
+func (p *GetNoteStatsRequest) GetOwnerToken() string {
+  return p.OwnerToken
+}
+
+func (p *GetNoteStatsRequest) GetDays() int32 {
+  return p.Days
+}
+func (p *GetNoteStatsRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.I32 {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *GetNoteStatsRequest)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.ID = v
+}
+  return nil
+}
+
+func (p *GetNoteStatsRequest)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.OwnerToken = v
+}
+  return nil
+}
+
+func (p *GetNoteStatsRequest)  ReadField3(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI32(); err != nil {
+  return thrift.PrependError("error reading field 3: ", err)
+} else {
+  p.Days = v
+}
+  return nil
+}
+
+func (p *GetNoteStatsRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("GetNoteStatsRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *GetNoteStatsRequest) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err) }
+  if err := oprot.WriteString(string(p.ID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err) }
+  return err
+}
+
+func (p *GetNoteStatsRequest) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("owner_token", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:owner_token: ", p), err) }
+  if err := oprot.WriteString(string(p.OwnerToken)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.owner_token (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:owner_token: ", p), err) }
+  return err
+}
+
+func (p *GetNoteStatsRequest) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("days", thrift.I32, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:days: ", p), err) }
+  if err := oprot.WriteI32(int32(p.Days)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.days (3) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:days: ", p), err) }
+  return err
+}
+
+func (p *GetNoteStatsRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("GetNoteStatsRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - Day
+//  - Views
+//  - Visitors
+type DailyViews struct {
+  Day string `thrift:"day,1" db:"day" json:"day"`
+  Views int64 `thrift:"views,2" db:"views" json:"views"`
+  Visitors int64 `thrift:"visitors,3" db:"visitors" json:"visitors"`
+}
+
+func NewDailyViews() *DailyViews {
+  return &DailyViews{}
+}
+
+
+func (p *DailyViews) GetDay() string {
+  return p.Day
+}
+
+func (p *DailyViews) GetViews() int64 {
+  return p.Views
+}
+
+func (p *DailyViews) GetVisitors() int64 {
+  return p.Visitors
+}
+func (p *DailyViews) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.I64 {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.I64 {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *DailyViews)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.Day = v
+}
+  return nil
+}
+
+func (p *DailyViews)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.Views = v
+}
+  return nil
+}
+
+func (p *DailyViews)  ReadField3(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 3: ", err)
+} else {
+  p.Visitors = v
+}
+  return nil
+}
+
+func (p *DailyViews) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("DailyViews"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *DailyViews) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("day", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:day: ", p), err) }
+  if err := oprot.WriteString(string(p.Day)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.day (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:day: ", p), err) }
+  return err
+}
+
+func (p *DailyViews) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("views", thrift.I64, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:views: ", p), err) }
+  if err := oprot.WriteI64(int64(p.Views)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.views (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:views: ", p), err) }
+  return err
+}
+
+func (p *DailyViews) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("visitors", thrift.I64, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:visitors: ", p), err) }
+  if err := oprot.WriteI64(int64(p.Visitors)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.visitors (3) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:visitors: ", p), err) }
+  return err
+}
+
+func (p *DailyViews) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("DailyViews(%+v)", *p)
+}
+
+// Attributes:
+//  - NoteID
+//  - Views
+//  - Transports
+//  - Referrers
+//  - Daily
+//  - FirstViewedAt
+//  - LastViewedAt
+type NoteStats struct {
+  NoteID string `thrift:"note_id,1" db:"note_id" json:"note_id"`
+  Views int64 `thrift:"views,2" db:"views" json:"views"`
+  Transports map[string]int64 `thrift:"transports,3" db:"transports" json:"transports"`
+  Referrers map[string]int64 `thrift:"referrers,4" db:"referrers" json:"referrers"`
+  Daily []*DailyViews `thrift:"daily,5" db:"daily" json:"daily"`
+  FirstViewedAt int64 `thrift:"first_viewed_at,6" db:"first_viewed_at" json:"first_viewed_at"`
+  LastViewedAt int64 `thrift:"last_viewed_at,7" db:"last_viewed_at" json:"last_viewed_at"`
+}
+
+func NewNoteStats() *NoteStats {
+  return &NoteStats{}
+}
+
+
+func (p *NoteStats) GetNoteID() string {
+  return p.NoteID
+}
+
+func (p *NoteStats) GetViews() int64 {
+  return p.Views
+}
+
+func (p *NoteStats) GetTransports() map[string]int64 {
+  return p.Transports
+}
+
+func (p *NoteStats) GetReferrers() map[string]int64 {
+  return p.Referrers
+}
+
+func (p *NoteStats) GetDaily() []*DailyViews {
+  return p.Daily
+}
+
+func (p *NoteStats) GetFirstViewedAt() int64 {
+  return p.FirstViewedAt
+}
+
+func (p *NoteStats) GetLastViewedAt() int64 {
+  return p.LastViewedAt
+}
+func (p *NoteStats) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.I64 {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 3:
+      if fieldTypeId == thrift.MAP {
+        if err := p.ReadField3(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 4:
+      if fieldTypeId == thrift.MAP {
+        if err := p.ReadField4(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 5:
+      if fieldTypeId == thrift.LIST {
+        if err := p.ReadField5(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 6:
+      if fieldTypeId == thrift.I64 {
+        if err := p.ReadField6(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 7:
+      if fieldTypeId == thrift.I64 {
+        if err := p.ReadField7(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *NoteStats)  ReadField1(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 1: ", err)
+} else {
+  p.NoteID = v
+}
+  return nil
+}
+
+func (p *NoteStats)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.Views = v
+}
+  return nil
+}
+
+func (p *NoteStats)  ReadField3(iprot thrift.TProtocol) error {
+  _, _, size, err := iprot.ReadMapBegin()
+  if err != nil {
+    return thrift.PrependError("error reading map begin: ", err)
+  }
+  tMap := make(map[string]int64, size)
+  p.Transports =  tMap
+  for i := 0; i < size; i ++ {
+var _key16 string
+    if v, err := iprot.ReadString(); err != nil {
+    return thrift.PrependError("error reading field 0: ", err)
+} else {
+    _key16 = v
+}
+var _val17 int64
+    if v, err := iprot.ReadI64(); err != nil {
+    return thrift.PrependError("error reading field 0: ", err)
+} else {
+    _val17 = v
+}
+    p.Transports[_key16] = _val17
+  }
+  if err := iprot.ReadMapEnd(); err != nil {
+    return thrift.PrependError("error reading map end: ", err)
+  }
+  return nil
+}
+
+func (p *NoteStats)  ReadField4(iprot thrift.TProtocol) error {
+  _, _, size, err := iprot.ReadMapBegin()
+  if err != nil {
+    return thrift.PrependError("error reading map begin: ", err)
+  }
+  tMap := make(map[string]int64, size)
+  p.Referrers =  tMap
+  for i := 0; i < size; i ++ {
+var _key18 string
+    if v, err := iprot.ReadString(); err != nil {
+    return thrift.PrependError("error reading field 0: ", err)
+} else {
+    _key18 = v
+}
+var _val19 int64
+    if v, err := iprot.ReadI64(); err != nil {
+    return thrift.PrependError("error reading field 0: ", err)
+} else {
+    _val19 = v
+}
+    p.Referrers[_key18] = _val19
+  }
+  if err := iprot.ReadMapEnd(); err != nil {
+    return thrift.PrependError("error reading map end: ", err)
+  }
+  return nil
+}
+
+func (p *NoteStats)  ReadField5(iprot thrift.TProtocol) error {
+  _, size, err := iprot.ReadListBegin()
+  if err != nil {
+    return thrift.PrependError("error reading list begin: ", err)
+  }
+  tSlice := make([]*DailyViews, 0, size)
+  p.Daily =  tSlice
+  for i := 0; i < size; i ++ {
+    _elem20 := &DailyViews{}
+    if err := _elem20.Read(iprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem20), err)
+    }
+    p.Daily = append(p.Daily, _elem20)
+  }
+  if err := iprot.ReadListEnd(); err != nil {
+    return thrift.PrependError("error reading list end: ", err)
+  }
+  return nil
+}
+
+func (p *NoteStats)  ReadField6(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 6: ", err)
+} else {
+  p.FirstViewedAt = v
+}
+  return nil
+}
+
+func (p *NoteStats)  ReadField7(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 7: ", err)
+} else {
+  p.LastViewedAt = v
+}
+  return nil
+}
+
+func (p *NoteStats) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("NoteStats"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
+    if err := p.writeField5(oprot); err != nil { return err }
+    if err := p.writeField6(oprot); err != nil { return err }
+    if err := p.writeField7(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *NoteStats) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("note_id", thrift.STRING, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:note_id: ", p), err) }
+  if err := oprot.WriteString(string(p.NoteID)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.note_id (1) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:note_id: ", p), err) }
+  return err
+}
+
+func (p *NoteStats) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("views", thrift.I64, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:views: ", p), err) }
+  if err := oprot.WriteI64(int64(p.Views)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.views (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:views: ", p), err) }
+  return err
+}
+
+func (p *NoteStats) writeField3(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("transports", thrift.MAP, 3); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:transports: ", p), err) }
+  if err := oprot.WriteMapBegin(thrift.STRING, thrift.I64, len(p.Transports)); err != nil {
+    return thrift.PrependError("error writing map begin: ", err)
+  }
+  for k, v := range p.Transports {
+    if err := oprot.WriteString(string(k)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err) }
+    if err := oprot.WriteI64(int64(v)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err) }
+  }
+  if err := oprot.WriteMapEnd(); err != nil {
+    return thrift.PrependError("error writing map end: ", err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 3:transports: ", p), err) }
+  return err
+}
+
+func (p *NoteStats) writeField4(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("referrers", thrift.MAP, 4); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:referrers: ", p), err) }
+  if err := oprot.WriteMapBegin(thrift.STRING, thrift.I64, len(p.Referrers)); err != nil {
+    return thrift.PrependError("error writing map begin: ", err)
+  }
+  for k, v := range p.Referrers {
+    if err := oprot.WriteString(string(k)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err) }
+    if err := oprot.WriteI64(int64(v)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err) }
+  }
+  if err := oprot.WriteMapEnd(); err != nil {
+    return thrift.PrependError("error writing map end: ", err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 4:referrers: ", p), err) }
+  return err
+}
+
+func (p *NoteStats) writeField5(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("daily", thrift.LIST, 5); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:daily: ", p), err) }
+  if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Daily)); err != nil {
+    return thrift.PrependError("error writing list begin: ", err)
+  }
+  for _, v := range p.Daily {
+    if err := v.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+    }
+  }
+  if err := oprot.WriteListEnd(); err != nil {
+    return thrift.PrependError("error writing list end: ", err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 5:daily: ", p), err) }
+  return err
+}
+
+func (p *NoteStats) writeField6(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("first_viewed_at", thrift.I64, 6); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:first_viewed_at: ", p), err) }
+  if err := oprot.WriteI64(int64(p.FirstViewedAt)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.first_viewed_at (6) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 6:first_viewed_at: ", p), err) }
+  return err
+}
+
+func (p *NoteStats) writeField7(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("last_viewed_at", thrift.I64, 7); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:last_viewed_at: ", p), err) }
+  if err := oprot.WriteI64(int64(p.LastViewedAt)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.last_viewed_at (7) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 7:last_viewed_at: ", p), err) }
+  return err
+}
+
+func (p *NoteStats) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("NoteStats(%+v)", *p)
+}
+
+// Attributes:
+//  - Stats
+//  - Error
+type GetNoteStatsResponse struct {
+  Stats *NoteStats `thrift:"stats,1" db:"stats" json:"stats"`
+  Error string `thrift:"error,2" db:"error" json:"error"`
+}
+
+func NewGetNoteStatsResponse() *GetNoteStatsResponse {
+  return &GetNoteStatsResponse{}
+}
+
+var GetNoteStatsResponse_Stats_DEFAULT *NoteStats
+func (p *GetNoteStatsResponse) GetStats() *NoteStats {
+  if !p.IsSetStats() {
+    return GetNoteStatsResponse_Stats_DEFAULT
+  }
+return p.Stats
+}
+
+func (p *GetNoteStatsResponse) GetError() string {
+  return p.Error
+}
+func (p *GetNoteStatsResponse) IsSetStats() bool {
+  return p.Stats != nil
+}
+
+func (p *GetNoteStatsResponse) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    case 2:
+      if fieldTypeId == thrift.STRING {
+        if err := p.ReadField2(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *GetNoteStatsResponse)  ReadField1(iprot thrift.TProtocol) error {
+  p.Stats = &NoteStats{}
+  if err := p.Stats.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Stats), err)
+  }
+  return nil
+}
+
+func (p *GetNoteStatsResponse)  ReadField2(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 2: ", err)
+} else {
+  p.Error = v
+}
+  return nil
+}
+
+func (p *GetNoteStatsResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("GetNoteStatsResponse"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *GetNoteStatsResponse) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("stats", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:stats: ", p), err) }
+  if err := p.Stats.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Stats), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:stats: ", p), err) }
+  return err
+}
+
+func (p *GetNoteStatsResponse) writeField2(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("error", thrift.STRING, 2); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:error: ", p), err) }
+  if err := oprot.WriteString(string(p.Error)); err != nil {
+  return thrift.PrependError(fmt.Sprintf("%T.error (2) field write error: ", p), err) }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 2:error: ", p), err) }
+  return err
+}
+
+func (p *GetNoteStatsResponse) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("GetNoteStatsResponse(%+v)", *p)
+}
+
+type Share interface {
+  // Parameters:
+  //  - Req
+  ShareNote(ctx context.Context, req *ShareNoteRequest) (r *ShareNoteResponse, err error)
+  // Parameters:
+  //  - Req
+  PrivateNote(ctx context.Context, req *PrivateNoteRequest) (r *PrivateNoteResponse, err error)
+  // Parameters:
+  //  - Req
+  GetNote(ctx context.Context, req *GetNoteRequest) (r *GetNoteResponse, err error)
+  // Parameters:
+  //  - Req
+  SyncNotes(ctx context.Context, req *SyncNotesRequest) (r *SyncNotesResponse, err error)
+  // Parameters:
+  //  - Req
+  ForkNote(ctx context.Context, req *ForkNoteRequest) (r *ForkNoteResponse, err error)
+  // Parameters:
+  //  - Req
+  ListForks(ctx context.Context, req *ListForksRequest) (r *ListForksResponse, err error)
+  // Parameters:
+  //  - Req
+  MarkTemplate(ctx context.Context, req *MarkTemplateRequest) (r *MarkTemplateResponse, err error)
+  // Parameters:
+  //  - Req
+  InstantiateTemplate(ctx context.Context, req *InstantiateTemplateRequest) (r *InstantiateTemplateResponse, err error)
+  // Parameters:
+  //  - Req
+  CreateCollection(ctx context.Context, req *CreateCollectionRequest) (r *CreateCollectionResponse, err error)
+  // Parameters:
+  //  - Req
+  AddCollectionNotes(ctx context.Context, req *AddCollectionNotesRequest) (r *AddCollectionNotesResponse, err error)
+  // Parameters:
+  //  - Req
+  RemoveCollectionNotes(ctx context.Context, req *RemoveCollectionNotesRequest) (r *RemoveCollectionNotesResponse, err error)
+  // Parameters:
+  //  - Req
+  ReorderCollection(ctx context.Context, req *ReorderCollectionRequest) (r *ReorderCollectionResponse, err error)
+  // Parameters:
+  //  - Req
+  GetCollection(ctx context.Context, req *GetCollectionRequest) (r *GetCollectionResponse, err error)
+  // Parameters:
+  //  - Req
+  UploadAttachment(ctx context.Context, req *UploadAttachmentRequest) (r *UploadAttachmentResponse, err error)
+  // Parameters:
+  //  - Req
+  DownloadAttachment(ctx context.Context, req *DownloadAttachmentRequest) (r *DownloadAttachmentResponse, err error)
+  // Parameters:
+  //  - Req
+  AddComment(ctx context.Context, req *AddCommentRequest) (r *AddCommentResponse, err error)
+  // Parameters:
+  //  - Req
+  ListComments(ctx context.Context, req *ListCommentsRequest) (r *ListCommentsResponse, err error)
+  // Parameters:
+  //  - Req
+  ResolveComment(ctx context.Context, req *ResolveCommentRequest) (r *ResolveCommentResponse, err error)
+  // Parameters:
+  //  - Req
+  GetNoteStats(ctx context.Context, req *GetNoteStatsRequest) (r *GetNoteStatsResponse, err error)
+}
+
+type ShareClient struct {
+  c thrift.TClient
+}
+
+func NewShareClientFactory(t thrift.TTransport, f thrift.TProtocolFactory) *ShareClient {
+  return &ShareClient{
+    c: thrift.NewTStandardClient(f.GetProtocol(t), f.GetProtocol(t)),
+  }
+}
+
+func NewShareClientProtocol(t thrift.TTransport, iprot thrift.TProtocol, oprot thrift.TProtocol) *ShareClient {
+  return &ShareClient{
+    c: thrift.NewTStandardClient(iprot, oprot),
+  }
+}
+
+func NewShareClient(c thrift.TClient) *ShareClient {
+  return &ShareClient{
+    c: c,
+  }
+}
+
+func (p *ShareClient) Client_() thrift.TClient {
+  return p.c
+}
+// Parameters:
+//  - Req
+func (p *ShareClient) ShareNote(ctx context.Context, req *ShareNoteRequest) (r *ShareNoteResponse, err error) {
+  var _args21 ShareShareNoteArgs
+  _args21.Req = req
+  var _result22 ShareShareNoteResult
+  if err = p.Client_().Call(ctx, "ShareNote", &_args21, &_result22); err != nil {
+    return
+  }
+  return _result22.GetSuccess(), nil
+}
+
+// Parameters:
+//  - Req
+func (p *ShareClient) PrivateNote(ctx context.Context, req *PrivateNoteRequest) (r *PrivateNoteResponse, err error) {
+  var _args23 SharePrivateNoteArgs
+  _args23.Req = req
+  var _result24 SharePrivateNoteResult
+  if err = p.Client_().Call(ctx, "PrivateNote", &_args23, &_result24); err != nil {
+    return
+  }
+  return _result24.GetSuccess(), nil
+}
+
+// Parameters:
+//  - Req
+func (p *ShareClient) GetNote(ctx context.Context, req *GetNoteRequest) (r *GetNoteResponse, err error) {
+  var _args25 ShareGetNoteArgs
+  _args25.Req = req
+  var _result26 ShareGetNoteResult
+  if err = p.Client_().Call(ctx, "GetNote", &_args25, &_result26); err != nil {
+    return
+  }
+  return _result26.GetSuccess(), nil
+}
+
+// Parameters:
+//  - Req
+func (p *ShareClient) SyncNotes(ctx context.Context, req *SyncNotesRequest) (r *SyncNotesResponse, err error) {
+  var _args27 ShareSyncNotesArgs
+  _args27.Req = req
+  var _result28 ShareSyncNotesResult
+  if err = p.Client_().Call(ctx, "SyncNotes", &_args27, &_result28); err != nil {
+    return
+  }
+  return _result28.GetSuccess(), nil
+}
+
+// Parameters:
+//  - Req
+func (p *ShareClient) ForkNote(ctx context.Context, req *ForkNoteRequest) (r *ForkNoteResponse, err error) {
+  var _args29 ShareForkNoteArgs
+  _args29.Req = req
+  var _result30 ShareForkNoteResult
+  if err = p.Client_().Call(ctx, "ForkNote", &_args29, &_result30); err != nil {
+    return
+  }
+  return _result30.GetSuccess(), nil
+}
+
+// Parameters:
+//  - Req
+func (p *ShareClient) ListForks(ctx context.Context, req *ListForksRequest) (r *ListForksResponse, err error) {
+  var _args31 ShareListForksArgs
+  _args31.Req = req
+  var _result32 ShareListForksResult
+  if err = p.Client_().Call(ctx, "ListForks", &_args31, &_result32); err != nil {
+    return
+  }
+  return _result32.GetSuccess(), nil
+}
+
+// Parameters:
+//  - Req
+func (p *ShareClient) MarkTemplate(ctx context.Context, req *MarkTemplateRequest) (r *MarkTemplateResponse, err error) {
+  var _args33 ShareMarkTemplateArgs
+  _args33.Req = req
+  var _result34 ShareMarkTemplateResult
+  if err = p.Client_().Call(ctx, "MarkTemplate", &_args33, &_result34); err != nil {
+    return
+  }
+  return _result34.GetSuccess(), nil
+}
+
+// Parameters:
+//  - Req
+func (p *ShareClient) InstantiateTemplate(ctx context.Context, req *InstantiateTemplateRequest) (r *InstantiateTemplateResponse, err error) {
+  var _args35 ShareInstantiateTemplateArgs
+  _args35.Req = req
+  var _result36 ShareInstantiateTemplateResult
+  if err = p.Client_().Call(ctx, "InstantiateTemplate", &_args35, &_result36); err != nil {
+    return
+  }
+  return _result36.GetSuccess(), nil
+}
+
+// Parameters:
+//  - Req
+func (p *ShareClient) CreateCollection(ctx context.Context, req *CreateCollectionRequest) (r *CreateCollectionResponse, err error) {
+  var _args37 ShareCreateCollectionArgs
+  _args37.Req = req
+  var _result38 ShareCreateCollectionResult
+  if err = p.Client_().Call(ctx, "CreateCollection", &_args37, &_result38); err != nil {
+    return
+  }
+  return _result38.GetSuccess(), nil
+}
+
+// Parameters:
+//  - Req
+func (p *ShareClient) AddCollectionNotes(ctx context.Context, req *AddCollectionNotesRequest) (r *AddCollectionNotesResponse, err error) {
+  var _args39 ShareAddCollectionNotesArgs
+  _args39.Req = req
+  var _result40 ShareAddCollectionNotesResult
+  if err = p.Client_().Call(ctx, "AddCollectionNotes", &_args39, &_result40); err != nil {
+    return
+  }
+  return _result40.GetSuccess(), nil
+}
+
+// Parameters:
+//  - Req
+func (p *ShareClient) RemoveCollectionNotes(ctx context.Context, req *RemoveCollectionNotesRequest) (r *RemoveCollectionNotesResponse, err error) {
+  var _args41 ShareRemoveCollectionNotesArgs
+  _args41.Req = req
+  var _result42 ShareRemoveCollectionNotesResult
+  if err = p.Client_().Call(ctx, "RemoveCollectionNotes", &_args41, &_result42); err != nil {
+    return
+  }
+  return _result42.GetSuccess(), nil
+}
+
+// Parameters:
+//  - Req
+func (p *ShareClient) ReorderCollection(ctx context.Context, req *ReorderCollectionRequest) (r *ReorderCollectionResponse, err error) {
+  var _args43 ShareReorderCollectionArgs
+  _args43.Req = req
+  var _result44 ShareReorderCollectionResult
+  if err = p.Client_().Call(ctx, "ReorderCollection", &_args43, &_result44); err != nil {
+    return
+  }
+  return _result44.GetSuccess(), nil
+}
+
+// Parameters:
+//  - Req
+func (p *ShareClient) GetCollection(ctx context.Context, req *GetCollectionRequest) (r *GetCollectionResponse, err error) {
+  var _args45 ShareGetCollectionArgs
+  _args45.Req = req
+  var _result46 ShareGetCollectionResult
+  if err = p.Client_().Call(ctx, "GetCollection", &_args45, &_result46); err != nil {
+    return
+  }
+  return _result46.GetSuccess(), nil
+}
+
+// Parameters:
+//  - Req
+func (p *ShareClient) UploadAttachment(ctx context.Context, req *UploadAttachmentRequest) (r *UploadAttachmentResponse, err error) {
+  var _args47 ShareUploadAttachmentArgs
+  _args47.Req = req
+  var _result48 ShareUploadAttachmentResult
+  if err = p.Client_().Call(ctx, "UploadAttachment", &_args47, &_result48); err != nil {
+    return
+  }
+  return _result48.GetSuccess(), nil
+}
+
+// Parameters:
+//  - Req
+func (p *ShareClient) DownloadAttachment(ctx context.Context, req *DownloadAttachmentRequest) (r *DownloadAttachmentResponse, err error) {
+  var _args49 ShareDownloadAttachmentArgs
+  _args49.Req = req
+  var _result50 ShareDownloadAttachmentResult
+  if err = p.Client_().Call(ctx, "DownloadAttachment", &_args49, &_result50); err != nil {
+    return
+  }
+  return _result50.GetSuccess(), nil
+}
+
+// Parameters:
+//  - Req
+func (p *ShareClient) AddComment(ctx context.Context, req *AddCommentRequest) (r *AddCommentResponse, err error) {
+  var _args51 ShareAddCommentArgs
+  _args51.Req = req
+  var _result52 ShareAddCommentResult
+  if err = p.Client_().Call(ctx, "AddComment", &_args51, &_result52); err != nil {
+    return
+  }
+  return _result52.GetSuccess(), nil
+}
+
+// Parameters:
+//  - Req
+func (p *ShareClient) ListComments(ctx context.Context, req *ListCommentsRequest) (r *ListCommentsResponse, err error) {
+  var _args53 ShareListCommentsArgs
+  _args53.Req = req
+  var _result54 ShareListCommentsResult
+  if err = p.Client_().Call(ctx, "ListComments", &_args53, &_result54); err != nil {
+    return
+  }
+  return _result54.GetSuccess(), nil
+}
+
+// Parameters:
+//  - Req
+func (p *ShareClient) ResolveComment(ctx context.Context, req *ResolveCommentRequest) (r *ResolveCommentResponse, err error) {
+  var _args55 ShareResolveCommentArgs
+  _args55.Req = req
+  var _result56 ShareResolveCommentResult
+  if err = p.Client_().Call(ctx, "ResolveComment", &_args55, &_result56); err != nil {
+    return
+  }
+  return _result56.GetSuccess(), nil
+}
+
+// Parameters:
+//  - Req
+func (p *ShareClient) GetNoteStats(ctx context.Context, req *GetNoteStatsRequest) (r *GetNoteStatsResponse, err error) {
+  var _args57 ShareGetNoteStatsArgs
+  _args57.Req = req
+  var _result58 ShareGetNoteStatsResult
+  if err = p.Client_().Call(ctx, "GetNoteStats", &_args57, &_result58); err != nil {
+    return
+  }
+  return _result58.GetSuccess(), nil
+}
+
+type ShareProcessor struct {
+  processorMap map[string]thrift.TProcessorFunction
+  handler Share
+}
+
+func (p *ShareProcessor) AddToProcessorMap(key string, processor thrift.TProcessorFunction) {
+  p.processorMap[key] = processor
+}
+
+func (p *ShareProcessor) GetProcessorFunction(key string) (processor thrift.TProcessorFunction, ok bool) {
+  processor, ok = p.processorMap[key]
+  return processor, ok
+}
+
+func (p *ShareProcessor) ProcessorMap() map[string]thrift.TProcessorFunction {
+  return p.processorMap
+}
+
+func NewShareProcessor(handler Share) *ShareProcessor {
+
+  self59 := &ShareProcessor{handler:handler, processorMap:make(map[string]thrift.TProcessorFunction)}
+  self59.processorMap["ShareNote"] = &shareProcessorShareNote{handler:handler}
+  self59.processorMap["PrivateNote"] = &shareProcessorPrivateNote{handler:handler}
+  self59.processorMap["GetNote"] = &shareProcessorGetNote{handler:handler}
+  self59.processorMap["SyncNotes"] = &shareProcessorSyncNotes{handler:handler}
+  self59.processorMap["ForkNote"] = &shareProcessorForkNote{handler:handler}
+  self59.processorMap["ListForks"] = &shareProcessorListForks{handler:handler}
+  self59.processorMap["MarkTemplate"] = &shareProcessorMarkTemplate{handler:handler}
+  self59.processorMap["InstantiateTemplate"] = &shareProcessorInstantiateTemplate{handler:handler}
+  self59.processorMap["CreateCollection"] = &shareProcessorCreateCollection{handler:handler}
+  self59.processorMap["AddCollectionNotes"] = &shareProcessorAddCollectionNotes{handler:handler}
+  self59.processorMap["RemoveCollectionNotes"] = &shareProcessorRemoveCollectionNotes{handler:handler}
+  self59.processorMap["ReorderCollection"] = &shareProcessorReorderCollection{handler:handler}
+  self59.processorMap["GetCollection"] = &shareProcessorGetCollection{handler:handler}
+  self59.processorMap["UploadAttachment"] = &shareProcessorUploadAttachment{handler:handler}
+  self59.processorMap["DownloadAttachment"] = &shareProcessorDownloadAttachment{handler:handler}
+  self59.processorMap["AddComment"] = &shareProcessorAddComment{handler:handler}
+  self59.processorMap["ListComments"] = &shareProcessorListComments{handler:handler}
+  self59.processorMap["ResolveComment"] = &shareProcessorResolveComment{handler:handler}
+  self59.processorMap["GetNoteStats"] = &shareProcessorGetNoteStats{handler:handler}
+return self59
+}
+
+func (p *ShareProcessor) Process(ctx context.Context, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  name, _, seqId, err := iprot.ReadMessageBegin()
+  if err != nil { return false, err }
+  if processor, ok := p.GetProcessorFunction(name); ok {
+    return processor.Process(ctx, seqId, iprot, oprot)
+  }
+  iprot.Skip(thrift.STRUCT)
+  iprot.ReadMessageEnd()
+  x60 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function " + name)
+  oprot.WriteMessageBegin(name, thrift.EXCEPTION, seqId)
+  x60.Write(oprot)
+  oprot.WriteMessageEnd()
+  oprot.Flush(ctx)
+  return false, x60
+
+}
+
+type shareProcessorShareNote struct {
+  handler Share
+}
+
+func (p *shareProcessorShareNote) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := ShareShareNoteArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("ShareNote", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := ShareShareNoteResult{}
+var retval *ShareNoteResponse
+  var err2 error
+  if retval, err2 = p.handler.ShareNote(ctx, args.Req); err2 != nil {
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ShareNote: " + err2.Error())
+    oprot.WriteMessageBegin("ShareNote", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return true, err2
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("ShareNote", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type shareProcessorPrivateNote struct {
+  handler Share
+}
+
+func (p *shareProcessorPrivateNote) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := SharePrivateNoteArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("PrivateNote", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := SharePrivateNoteResult{}
+var retval *PrivateNoteResponse
+  var err2 error
+  if retval, err2 = p.handler.PrivateNote(ctx, args.Req); err2 != nil {
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing PrivateNote: " + err2.Error())
+    oprot.WriteMessageBegin("PrivateNote", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return true, err2
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("PrivateNote", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type shareProcessorGetNote struct {
+  handler Share
+}
+
+func (p *shareProcessorGetNote) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := ShareGetNoteArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("GetNote", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := ShareGetNoteResult{}
+var retval *GetNoteResponse
+  var err2 error
+  if retval, err2 = p.handler.GetNote(ctx, args.Req); err2 != nil {
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetNote: " + err2.Error())
+    oprot.WriteMessageBegin("GetNote", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return true, err2
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("GetNote", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type shareProcessorSyncNotes struct {
+  handler Share
+}
+
+func (p *shareProcessorSyncNotes) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := ShareSyncNotesArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("SyncNotes", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := ShareSyncNotesResult{}
+var retval *SyncNotesResponse
+  var err2 error
+  if retval, err2 = p.handler.SyncNotes(ctx, args.Req); err2 != nil {
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing SyncNotes: " + err2.Error())
+    oprot.WriteMessageBegin("SyncNotes", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return true, err2
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("SyncNotes", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type shareProcessorForkNote struct {
+  handler Share
+}
+
+func (p *shareProcessorForkNote) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := ShareForkNoteArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("ForkNote", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := ShareForkNoteResult{}
+var retval *ForkNoteResponse
+  var err2 error
+  if retval, err2 = p.handler.ForkNote(ctx, args.Req); err2 != nil {
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ForkNote: " + err2.Error())
+    oprot.WriteMessageBegin("ForkNote", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return true, err2
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("ForkNote", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type shareProcessorListForks struct {
+  handler Share
+}
+
+func (p *shareProcessorListForks) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := ShareListForksArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("ListForks", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := ShareListForksResult{}
+var retval *ListForksResponse
+  var err2 error
+  if retval, err2 = p.handler.ListForks(ctx, args.Req); err2 != nil {
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ListForks: " + err2.Error())
+    oprot.WriteMessageBegin("ListForks", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return true, err2
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("ListForks", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type shareProcessorMarkTemplate struct {
+  handler Share
+}
+
+func (p *shareProcessorMarkTemplate) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := ShareMarkTemplateArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("MarkTemplate", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := ShareMarkTemplateResult{}
+var retval *MarkTemplateResponse
+  var err2 error
+  if retval, err2 = p.handler.MarkTemplate(ctx, args.Req); err2 != nil {
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing MarkTemplate: " + err2.Error())
+    oprot.WriteMessageBegin("MarkTemplate", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return true, err2
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("MarkTemplate", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type shareProcessorInstantiateTemplate struct {
+  handler Share
+}
+
+func (p *shareProcessorInstantiateTemplate) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := ShareInstantiateTemplateArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("InstantiateTemplate", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := ShareInstantiateTemplateResult{}
+var retval *InstantiateTemplateResponse
+  var err2 error
+  if retval, err2 = p.handler.InstantiateTemplate(ctx, args.Req); err2 != nil {
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing InstantiateTemplate: " + err2.Error())
+    oprot.WriteMessageBegin("InstantiateTemplate", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return true, err2
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("InstantiateTemplate", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type shareProcessorCreateCollection struct {
+  handler Share
+}
+
+func (p *shareProcessorCreateCollection) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := ShareCreateCollectionArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("CreateCollection", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := ShareCreateCollectionResult{}
+var retval *CreateCollectionResponse
+  var err2 error
+  if retval, err2 = p.handler.CreateCollection(ctx, args.Req); err2 != nil {
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing CreateCollection: " + err2.Error())
+    oprot.WriteMessageBegin("CreateCollection", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return true, err2
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("CreateCollection", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type shareProcessorAddCollectionNotes struct {
+  handler Share
+}
+
+func (p *shareProcessorAddCollectionNotes) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := ShareAddCollectionNotesArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("AddCollectionNotes", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := ShareAddCollectionNotesResult{}
+var retval *AddCollectionNotesResponse
+  var err2 error
+  if retval, err2 = p.handler.AddCollectionNotes(ctx, args.Req); err2 != nil {
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing AddCollectionNotes: " + err2.Error())
+    oprot.WriteMessageBegin("AddCollectionNotes", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return true, err2
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("AddCollectionNotes", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type shareProcessorRemoveCollectionNotes struct {
+  handler Share
+}
+
+func (p *shareProcessorRemoveCollectionNotes) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := ShareRemoveCollectionNotesArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("RemoveCollectionNotes", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := ShareRemoveCollectionNotesResult{}
+var retval *RemoveCollectionNotesResponse
+  var err2 error
+  if retval, err2 = p.handler.RemoveCollectionNotes(ctx, args.Req); err2 != nil {
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RemoveCollectionNotes: " + err2.Error())
+    oprot.WriteMessageBegin("RemoveCollectionNotes", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return true, err2
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("RemoveCollectionNotes", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type shareProcessorReorderCollection struct {
+  handler Share
+}
+
+func (p *shareProcessorReorderCollection) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := ShareReorderCollectionArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("ReorderCollection", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := ShareReorderCollectionResult{}
+var retval *ReorderCollectionResponse
+  var err2 error
+  if retval, err2 = p.handler.ReorderCollection(ctx, args.Req); err2 != nil {
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ReorderCollection: " + err2.Error())
+    oprot.WriteMessageBegin("ReorderCollection", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return true, err2
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("ReorderCollection", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type shareProcessorGetCollection struct {
+  handler Share
+}
+
+func (p *shareProcessorGetCollection) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := ShareGetCollectionArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("GetCollection", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := ShareGetCollectionResult{}
+var retval *GetCollectionResponse
+  var err2 error
+  if retval, err2 = p.handler.GetCollection(ctx, args.Req); err2 != nil {
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetCollection: " + err2.Error())
+    oprot.WriteMessageBegin("GetCollection", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return true, err2
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("GetCollection", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type shareProcessorUploadAttachment struct {
+  handler Share
+}
+
+func (p *shareProcessorUploadAttachment) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := ShareUploadAttachmentArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("UploadAttachment", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := ShareUploadAttachmentResult{}
+var retval *UploadAttachmentResponse
+  var err2 error
+  if retval, err2 = p.handler.UploadAttachment(ctx, args.Req); err2 != nil {
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing UploadAttachment: " + err2.Error())
+    oprot.WriteMessageBegin("UploadAttachment", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return true, err2
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("UploadAttachment", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type shareProcessorDownloadAttachment struct {
+  handler Share
+}
+
+func (p *shareProcessorDownloadAttachment) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := ShareDownloadAttachmentArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("DownloadAttachment", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := ShareDownloadAttachmentResult{}
+var retval *DownloadAttachmentResponse
+  var err2 error
+  if retval, err2 = p.handler.DownloadAttachment(ctx, args.Req); err2 != nil {
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing DownloadAttachment: " + err2.Error())
+    oprot.WriteMessageBegin("DownloadAttachment", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return true, err2
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("DownloadAttachment", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type shareProcessorAddComment struct {
+  handler Share
+}
+
+func (p *shareProcessorAddComment) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := ShareAddCommentArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("AddComment", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := ShareAddCommentResult{}
+var retval *AddCommentResponse
+  var err2 error
+  if retval, err2 = p.handler.AddComment(ctx, args.Req); err2 != nil {
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing AddComment: " + err2.Error())
+    oprot.WriteMessageBegin("AddComment", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return true, err2
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("AddComment", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type shareProcessorListComments struct {
+  handler Share
+}
+
+func (p *shareProcessorListComments) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := ShareListCommentsArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("ListComments", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := ShareListCommentsResult{}
+var retval *ListCommentsResponse
+  var err2 error
+  if retval, err2 = p.handler.ListComments(ctx, args.Req); err2 != nil {
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ListComments: " + err2.Error())
+    oprot.WriteMessageBegin("ListComments", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return true, err2
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("ListComments", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type shareProcessorResolveComment struct {
+  handler Share
+}
+
+func (p *shareProcessorResolveComment) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := ShareResolveCommentArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("ResolveComment", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := ShareResolveCommentResult{}
+var retval *ResolveCommentResponse
+  var err2 error
+  if retval, err2 = p.handler.ResolveComment(ctx, args.Req); err2 != nil {
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ResolveComment: " + err2.Error())
+    oprot.WriteMessageBegin("ResolveComment", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return true, err2
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("ResolveComment", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type shareProcessorGetNoteStats struct {
+  handler Share
+}
+
+func (p *shareProcessorGetNoteStats) Process(ctx context.Context, seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := ShareGetNoteStatsArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("GetNoteStats", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := ShareGetNoteStatsResult{}
+var retval *GetNoteStatsResponse
+  var err2 error
+  if retval, err2 = p.handler.GetNoteStats(ctx, args.Req); err2 != nil {
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetNoteStats: " + err2.Error())
+    oprot.WriteMessageBegin("GetNoteStats", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush(ctx)
+    return true, err2
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("GetNoteStats", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(ctx); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+
+// HELPER FUNCTIONS AND STRUCTURES
+
+// Attributes:
+//  - Req
+type ShareShareNoteArgs struct {
+  Req *ShareNoteRequest `thrift:"req,1" db:"req" json:"req"`
+}
+
+func NewShareShareNoteArgs() *ShareShareNoteArgs {
+  return &ShareShareNoteArgs{}
+}
+
+var ShareShareNoteArgs_Req_DEFAULT *ShareNoteRequest
+func (p *ShareShareNoteArgs) GetReq() *ShareNoteRequest {
+  if !p.IsSetReq() {
+    return ShareShareNoteArgs_Req_DEFAULT
+  }
+return p.Req
+}
+func (p *ShareShareNoteArgs) IsSetReq() bool {
+  return p.Req != nil
+}
+
+func (p *ShareShareNoteArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareShareNoteArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.Req = &ShareNoteRequest{}
+  if err := p.Req.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+  }
+  return nil
+}
+
+func (p *ShareShareNoteArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ShareNote_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareShareNoteArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err) }
+  if err := p.Req.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err) }
+  return err
+}
+
+func (p *ShareShareNoteArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareShareNoteArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type ShareShareNoteResult struct {
+  Success *ShareNoteResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewShareShareNoteResult() *ShareShareNoteResult {
+  return &ShareShareNoteResult{}
+}
+
+var ShareShareNoteResult_Success_DEFAULT *ShareNoteResponse
+func (p *ShareShareNoteResult) GetSuccess() *ShareNoteResponse {
+  if !p.IsSetSuccess() {
+    return ShareShareNoteResult_Success_DEFAULT
+  }
+return p.Success
+}
+func (p *ShareShareNoteResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *ShareShareNoteResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField0(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareShareNoteResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &ShareNoteResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *ShareShareNoteResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ShareNote_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareShareNoteResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *ShareShareNoteResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareShareNoteResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type SharePrivateNoteArgs struct {
+  Req *PrivateNoteRequest `thrift:"req,1" db:"req" json:"req"`
+}
+
+func NewSharePrivateNoteArgs() *SharePrivateNoteArgs {
+  return &SharePrivateNoteArgs{}
+}
+
+var SharePrivateNoteArgs_Req_DEFAULT *PrivateNoteRequest
+func (p *SharePrivateNoteArgs) GetReq() *PrivateNoteRequest {
+  if !p.IsSetReq() {
+    return SharePrivateNoteArgs_Req_DEFAULT
+  }
+return p.Req
+}
+func (p *SharePrivateNoteArgs) IsSetReq() bool {
+  return p.Req != nil
+}
+
+func (p *SharePrivateNoteArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *SharePrivateNoteArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.Req = &PrivateNoteRequest{}
+  if err := p.Req.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+  }
+  return nil
+}
+
+func (p *SharePrivateNoteArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("PrivateNote_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *SharePrivateNoteArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err) }
+  if err := p.Req.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err) }
+  return err
+}
+
+func (p *SharePrivateNoteArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("SharePrivateNoteArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type SharePrivateNoteResult struct {
+  Success *PrivateNoteResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewSharePrivateNoteResult() *SharePrivateNoteResult {
+  return &SharePrivateNoteResult{}
+}
+
+var SharePrivateNoteResult_Success_DEFAULT *PrivateNoteResponse
+func (p *SharePrivateNoteResult) GetSuccess() *PrivateNoteResponse {
+  if !p.IsSetSuccess() {
+    return SharePrivateNoteResult_Success_DEFAULT
+  }
+return p.Success
+}
+func (p *SharePrivateNoteResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *SharePrivateNoteResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField0(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *SharePrivateNoteResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &PrivateNoteResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *SharePrivateNoteResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("PrivateNote_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *SharePrivateNoteResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *SharePrivateNoteResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("SharePrivateNoteResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type ShareGetNoteArgs struct {
+  Req *GetNoteRequest `thrift:"req,1" db:"req" json:"req"`
+}
+
+func NewShareGetNoteArgs() *ShareGetNoteArgs {
+  return &ShareGetNoteArgs{}
+}
+
+var ShareGetNoteArgs_Req_DEFAULT *GetNoteRequest
+func (p *ShareGetNoteArgs) GetReq() *GetNoteRequest {
+  if !p.IsSetReq() {
+    return ShareGetNoteArgs_Req_DEFAULT
+  }
+return p.Req
+}
+func (p *ShareGetNoteArgs) IsSetReq() bool {
+  return p.Req != nil
+}
+
+func (p *ShareGetNoteArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareGetNoteArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.Req = &GetNoteRequest{}
+  if err := p.Req.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+  }
+  return nil
+}
+
+func (p *ShareGetNoteArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("GetNote_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareGetNoteArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err) }
+  if err := p.Req.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err) }
+  return err
+}
+
+func (p *ShareGetNoteArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareGetNoteArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type ShareGetNoteResult struct {
+  Success *GetNoteResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewShareGetNoteResult() *ShareGetNoteResult {
+  return &ShareGetNoteResult{}
+}
+
+var ShareGetNoteResult_Success_DEFAULT *GetNoteResponse
+func (p *ShareGetNoteResult) GetSuccess() *GetNoteResponse {
+  if !p.IsSetSuccess() {
+    return ShareGetNoteResult_Success_DEFAULT
+  }
+return p.Success
+}
+func (p *ShareGetNoteResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *ShareGetNoteResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField0(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareGetNoteResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &GetNoteResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *ShareGetNoteResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("GetNote_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareGetNoteResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *ShareGetNoteResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareGetNoteResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type ShareSyncNotesArgs struct {
+  Req *SyncNotesRequest `thrift:"req,1" db:"req" json:"req"`
+}
+
+func NewShareSyncNotesArgs() *ShareSyncNotesArgs {
+  return &ShareSyncNotesArgs{}
+}
+
+var ShareSyncNotesArgs_Req_DEFAULT *SyncNotesRequest
+func (p *ShareSyncNotesArgs) GetReq() *SyncNotesRequest {
+  if !p.IsSetReq() {
+    return ShareSyncNotesArgs_Req_DEFAULT
+  }
+return p.Req
+}
+func (p *ShareSyncNotesArgs) IsSetReq() bool {
+  return p.Req != nil
+}
+
+func (p *ShareSyncNotesArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareSyncNotesArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.Req = &SyncNotesRequest{}
+  if err := p.Req.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+  }
+  return nil
+}
+
+func (p *ShareSyncNotesArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("SyncNotes_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareSyncNotesArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err) }
+  if err := p.Req.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err) }
+  return err
+}
+
+func (p *ShareSyncNotesArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareSyncNotesArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type ShareSyncNotesResult struct {
+  Success *SyncNotesResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewShareSyncNotesResult() *ShareSyncNotesResult {
+  return &ShareSyncNotesResult{}
+}
+
+var ShareSyncNotesResult_Success_DEFAULT *SyncNotesResponse
+func (p *ShareSyncNotesResult) GetSuccess() *SyncNotesResponse {
+  if !p.IsSetSuccess() {
+    return ShareSyncNotesResult_Success_DEFAULT
+  }
+return p.Success
+}
+func (p *ShareSyncNotesResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *ShareSyncNotesResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField0(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareSyncNotesResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &SyncNotesResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *ShareSyncNotesResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("SyncNotes_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareSyncNotesResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *ShareSyncNotesResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareSyncNotesResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type ShareForkNoteArgs struct {
+  Req *ForkNoteRequest `thrift:"req,1" db:"req" json:"req"`
+}
+
+func NewShareForkNoteArgs() *ShareForkNoteArgs {
+  return &ShareForkNoteArgs{}
+}
+
+var ShareForkNoteArgs_Req_DEFAULT *ForkNoteRequest
+func (p *ShareForkNoteArgs) GetReq() *ForkNoteRequest {
+  if !p.IsSetReq() {
+    return ShareForkNoteArgs_Req_DEFAULT
+  }
+return p.Req
+}
+func (p *ShareForkNoteArgs) IsSetReq() bool {
+  return p.Req != nil
+}
+
+func (p *ShareForkNoteArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareForkNoteArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.Req = &ForkNoteRequest{}
+  if err := p.Req.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+  }
+  return nil
+}
+
+func (p *ShareForkNoteArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ForkNote_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareForkNoteArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err) }
+  if err := p.Req.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err) }
+  return err
+}
+
+func (p *ShareForkNoteArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareForkNoteArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type ShareForkNoteResult struct {
+  Success *ForkNoteResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewShareForkNoteResult() *ShareForkNoteResult {
+  return &ShareForkNoteResult{}
+}
+
+var ShareForkNoteResult_Success_DEFAULT *ForkNoteResponse
+func (p *ShareForkNoteResult) GetSuccess() *ForkNoteResponse {
+  if !p.IsSetSuccess() {
+    return ShareForkNoteResult_Success_DEFAULT
+  }
+return p.Success
+}
+func (p *ShareForkNoteResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *ShareForkNoteResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField0(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareForkNoteResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &ForkNoteResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *ShareForkNoteResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ForkNote_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareForkNoteResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *ShareForkNoteResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareForkNoteResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type ShareListForksArgs struct {
+  Req *ListForksRequest `thrift:"req,1" db:"req" json:"req"`
+}
+
+func NewShareListForksArgs() *ShareListForksArgs {
+  return &ShareListForksArgs{}
+}
+
+var ShareListForksArgs_Req_DEFAULT *ListForksRequest
+func (p *ShareListForksArgs) GetReq() *ListForksRequest {
+  if !p.IsSetReq() {
+    return ShareListForksArgs_Req_DEFAULT
+  }
+return p.Req
+}
+func (p *ShareListForksArgs) IsSetReq() bool {
+  return p.Req != nil
+}
+
+func (p *ShareListForksArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareListForksArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.Req = &ListForksRequest{}
+  if err := p.Req.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+  }
+  return nil
+}
+
+func (p *ShareListForksArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ListForks_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareListForksArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err) }
+  if err := p.Req.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err) }
+  return err
+}
+
+func (p *ShareListForksArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareListForksArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type ShareListForksResult struct {
+  Success *ListForksResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewShareListForksResult() *ShareListForksResult {
+  return &ShareListForksResult{}
+}
+
+var ShareListForksResult_Success_DEFAULT *ListForksResponse
+func (p *ShareListForksResult) GetSuccess() *ListForksResponse {
+  if !p.IsSetSuccess() {
+    return ShareListForksResult_Success_DEFAULT
+  }
+return p.Success
+}
+func (p *ShareListForksResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *ShareListForksResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField0(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareListForksResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &ListForksResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *ShareListForksResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ListForks_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareListForksResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *ShareListForksResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareListForksResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type ShareMarkTemplateArgs struct {
+  Req *MarkTemplateRequest `thrift:"req,1" db:"req" json:"req"`
+}
+
+func NewShareMarkTemplateArgs() *ShareMarkTemplateArgs {
+  return &ShareMarkTemplateArgs{}
+}
+
+var ShareMarkTemplateArgs_Req_DEFAULT *MarkTemplateRequest
+func (p *ShareMarkTemplateArgs) GetReq() *MarkTemplateRequest {
+  if !p.IsSetReq() {
+    return ShareMarkTemplateArgs_Req_DEFAULT
+  }
+return p.Req
+}
+func (p *ShareMarkTemplateArgs) IsSetReq() bool {
+  return p.Req != nil
+}
+
+func (p *ShareMarkTemplateArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareMarkTemplateArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.Req = &MarkTemplateRequest{}
+  if err := p.Req.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+  }
+  return nil
+}
+
+func (p *ShareMarkTemplateArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("MarkTemplate_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareMarkTemplateArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err) }
+  if err := p.Req.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err) }
+  return err
+}
+
+func (p *ShareMarkTemplateArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareMarkTemplateArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type ShareMarkTemplateResult struct {
+  Success *MarkTemplateResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewShareMarkTemplateResult() *ShareMarkTemplateResult {
+  return &ShareMarkTemplateResult{}
+}
+
+var ShareMarkTemplateResult_Success_DEFAULT *MarkTemplateResponse
+func (p *ShareMarkTemplateResult) GetSuccess() *MarkTemplateResponse {
+  if !p.IsSetSuccess() {
+    return ShareMarkTemplateResult_Success_DEFAULT
+  }
+return p.Success
+}
+func (p *ShareMarkTemplateResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *ShareMarkTemplateResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField0(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareMarkTemplateResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &MarkTemplateResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *ShareMarkTemplateResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("MarkTemplate_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareMarkTemplateResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *ShareMarkTemplateResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareMarkTemplateResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type ShareInstantiateTemplateArgs struct {
+  Req *InstantiateTemplateRequest `thrift:"req,1" db:"req" json:"req"`
+}
+
+func NewShareInstantiateTemplateArgs() *ShareInstantiateTemplateArgs {
+  return &ShareInstantiateTemplateArgs{}
+}
+
+var ShareInstantiateTemplateArgs_Req_DEFAULT *InstantiateTemplateRequest
+func (p *ShareInstantiateTemplateArgs) GetReq() *InstantiateTemplateRequest {
+  if !p.IsSetReq() {
+    return ShareInstantiateTemplateArgs_Req_DEFAULT
+  }
+return p.Req
+}
+func (p *ShareInstantiateTemplateArgs) IsSetReq() bool {
+  return p.Req != nil
+}
+
+func (p *ShareInstantiateTemplateArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareInstantiateTemplateArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.Req = &InstantiateTemplateRequest{}
+  if err := p.Req.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+  }
+  return nil
+}
+
+func (p *ShareInstantiateTemplateArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("InstantiateTemplate_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareInstantiateTemplateArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err) }
+  if err := p.Req.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err) }
+  return err
+}
+
+func (p *ShareInstantiateTemplateArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareInstantiateTemplateArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type ShareInstantiateTemplateResult struct {
+  Success *InstantiateTemplateResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewShareInstantiateTemplateResult() *ShareInstantiateTemplateResult {
+  return &ShareInstantiateTemplateResult{}
+}
+
+var ShareInstantiateTemplateResult_Success_DEFAULT *InstantiateTemplateResponse
+func (p *ShareInstantiateTemplateResult) GetSuccess() *InstantiateTemplateResponse {
+  if !p.IsSetSuccess() {
+    return ShareInstantiateTemplateResult_Success_DEFAULT
+  }
+return p.Success
+}
+func (p *ShareInstantiateTemplateResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *ShareInstantiateTemplateResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField0(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareInstantiateTemplateResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &InstantiateTemplateResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *ShareInstantiateTemplateResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("InstantiateTemplate_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareInstantiateTemplateResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *ShareInstantiateTemplateResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareInstantiateTemplateResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type ShareCreateCollectionArgs struct {
+  Req *CreateCollectionRequest `thrift:"req,1" db:"req" json:"req"`
+}
+
+func NewShareCreateCollectionArgs() *ShareCreateCollectionArgs {
+  return &ShareCreateCollectionArgs{}
+}
+
+var ShareCreateCollectionArgs_Req_DEFAULT *CreateCollectionRequest
+func (p *ShareCreateCollectionArgs) GetReq() *CreateCollectionRequest {
+  if !p.IsSetReq() {
+    return ShareCreateCollectionArgs_Req_DEFAULT
+  }
+return p.Req
+}
+func (p *ShareCreateCollectionArgs) IsSetReq() bool {
+  return p.Req != nil
+}
+
+func (p *ShareCreateCollectionArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareCreateCollectionArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.Req = &CreateCollectionRequest{}
+  if err := p.Req.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+  }
+  return nil
+}
+
+func (p *ShareCreateCollectionArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("CreateCollection_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareCreateCollectionArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err) }
+  if err := p.Req.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err) }
+  return err
+}
+
+func (p *ShareCreateCollectionArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareCreateCollectionArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type ShareCreateCollectionResult struct {
+  Success *CreateCollectionResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewShareCreateCollectionResult() *ShareCreateCollectionResult {
+  return &ShareCreateCollectionResult{}
+}
+
+var ShareCreateCollectionResult_Success_DEFAULT *CreateCollectionResponse
+func (p *ShareCreateCollectionResult) GetSuccess() *CreateCollectionResponse {
+  if !p.IsSetSuccess() {
+    return ShareCreateCollectionResult_Success_DEFAULT
+  }
+return p.Success
+}
+func (p *ShareCreateCollectionResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *ShareCreateCollectionResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField0(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareCreateCollectionResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &CreateCollectionResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *ShareCreateCollectionResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("CreateCollection_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareCreateCollectionResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *ShareCreateCollectionResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareCreateCollectionResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type ShareAddCollectionNotesArgs struct {
+  Req *AddCollectionNotesRequest `thrift:"req,1" db:"req" json:"req"`
+}
+
+func NewShareAddCollectionNotesArgs() *ShareAddCollectionNotesArgs {
+  return &ShareAddCollectionNotesArgs{}
+}
+
+var ShareAddCollectionNotesArgs_Req_DEFAULT *AddCollectionNotesRequest
+func (p *ShareAddCollectionNotesArgs) GetReq() *AddCollectionNotesRequest {
+  if !p.IsSetReq() {
+    return ShareAddCollectionNotesArgs_Req_DEFAULT
+  }
+return p.Req
+}
+func (p *ShareAddCollectionNotesArgs) IsSetReq() bool {
+  return p.Req != nil
+}
+
+func (p *ShareAddCollectionNotesArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareAddCollectionNotesArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.Req = &AddCollectionNotesRequest{}
+  if err := p.Req.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+  }
+  return nil
+}
+
+func (p *ShareAddCollectionNotesArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("AddCollectionNotes_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareAddCollectionNotesArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err) }
+  if err := p.Req.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err) }
+  return err
+}
+
+func (p *ShareAddCollectionNotesArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareAddCollectionNotesArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type ShareAddCollectionNotesResult struct {
+  Success *AddCollectionNotesResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewShareAddCollectionNotesResult() *ShareAddCollectionNotesResult {
+  return &ShareAddCollectionNotesResult{}
+}
+
+var ShareAddCollectionNotesResult_Success_DEFAULT *AddCollectionNotesResponse
+func (p *ShareAddCollectionNotesResult) GetSuccess() *AddCollectionNotesResponse {
+  if !p.IsSetSuccess() {
+    return ShareAddCollectionNotesResult_Success_DEFAULT
+  }
+return p.Success
+}
+func (p *ShareAddCollectionNotesResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *ShareAddCollectionNotesResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField0(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareAddCollectionNotesResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &AddCollectionNotesResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *ShareAddCollectionNotesResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("AddCollectionNotes_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareAddCollectionNotesResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *ShareAddCollectionNotesResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareAddCollectionNotesResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type ShareRemoveCollectionNotesArgs struct {
+  Req *RemoveCollectionNotesRequest `thrift:"req,1" db:"req" json:"req"`
+}
+
+func NewShareRemoveCollectionNotesArgs() *ShareRemoveCollectionNotesArgs {
+  return &ShareRemoveCollectionNotesArgs{}
+}
+
+var ShareRemoveCollectionNotesArgs_Req_DEFAULT *RemoveCollectionNotesRequest
+func (p *ShareRemoveCollectionNotesArgs) GetReq() *RemoveCollectionNotesRequest {
+  if !p.IsSetReq() {
+    return ShareRemoveCollectionNotesArgs_Req_DEFAULT
+  }
+return p.Req
+}
+func (p *ShareRemoveCollectionNotesArgs) IsSetReq() bool {
+  return p.Req != nil
+}
+
+func (p *ShareRemoveCollectionNotesArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareRemoveCollectionNotesArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.Req = &RemoveCollectionNotesRequest{}
+  if err := p.Req.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+  }
+  return nil
+}
+
+func (p *ShareRemoveCollectionNotesArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RemoveCollectionNotes_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareRemoveCollectionNotesArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err) }
+  if err := p.Req.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err) }
+  return err
+}
+
+func (p *ShareRemoveCollectionNotesArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareRemoveCollectionNotesArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type ShareRemoveCollectionNotesResult struct {
+  Success *RemoveCollectionNotesResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewShareRemoveCollectionNotesResult() *ShareRemoveCollectionNotesResult {
+  return &ShareRemoveCollectionNotesResult{}
+}
+
+var ShareRemoveCollectionNotesResult_Success_DEFAULT *RemoveCollectionNotesResponse
+func (p *ShareRemoveCollectionNotesResult) GetSuccess() *RemoveCollectionNotesResponse {
+  if !p.IsSetSuccess() {
+    return ShareRemoveCollectionNotesResult_Success_DEFAULT
+  }
+return p.Success
+}
+func (p *ShareRemoveCollectionNotesResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *ShareRemoveCollectionNotesResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField0(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareRemoveCollectionNotesResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &RemoveCollectionNotesResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *ShareRemoveCollectionNotesResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RemoveCollectionNotes_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareRemoveCollectionNotesResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *ShareRemoveCollectionNotesResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareRemoveCollectionNotesResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type ShareReorderCollectionArgs struct {
+  Req *ReorderCollectionRequest `thrift:"req,1" db:"req" json:"req"`
+}
+
+func NewShareReorderCollectionArgs() *ShareReorderCollectionArgs {
+  return &ShareReorderCollectionArgs{}
+}
+
+var ShareReorderCollectionArgs_Req_DEFAULT *ReorderCollectionRequest
+func (p *ShareReorderCollectionArgs) GetReq() *ReorderCollectionRequest {
+  if !p.IsSetReq() {
+    return ShareReorderCollectionArgs_Req_DEFAULT
+  }
+return p.Req
+}
+func (p *ShareReorderCollectionArgs) IsSetReq() bool {
+  return p.Req != nil
+}
+
+func (p *ShareReorderCollectionArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareReorderCollectionArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.Req = &ReorderCollectionRequest{}
+  if err := p.Req.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+  }
+  return nil
+}
+
+func (p *ShareReorderCollectionArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ReorderCollection_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareReorderCollectionArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err) }
+  if err := p.Req.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err) }
+  return err
+}
+
+func (p *ShareReorderCollectionArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareReorderCollectionArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type ShareReorderCollectionResult struct {
+  Success *ReorderCollectionResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewShareReorderCollectionResult() *ShareReorderCollectionResult {
+  return &ShareReorderCollectionResult{}
+}
+
+var ShareReorderCollectionResult_Success_DEFAULT *ReorderCollectionResponse
+func (p *ShareReorderCollectionResult) GetSuccess() *ReorderCollectionResponse {
+  if !p.IsSetSuccess() {
+    return ShareReorderCollectionResult_Success_DEFAULT
+  }
+return p.Success
+}
+func (p *ShareReorderCollectionResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *ShareReorderCollectionResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField0(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareReorderCollectionResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &ReorderCollectionResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *ShareReorderCollectionResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ReorderCollection_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareReorderCollectionResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *ShareReorderCollectionResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareReorderCollectionResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type ShareGetCollectionArgs struct {
+  Req *GetCollectionRequest `thrift:"req,1" db:"req" json:"req"`
+}
+
+func NewShareGetCollectionArgs() *ShareGetCollectionArgs {
+  return &ShareGetCollectionArgs{}
+}
+
+var ShareGetCollectionArgs_Req_DEFAULT *GetCollectionRequest
+func (p *ShareGetCollectionArgs) GetReq() *GetCollectionRequest {
+  if !p.IsSetReq() {
+    return ShareGetCollectionArgs_Req_DEFAULT
+  }
+return p.Req
+}
+func (p *ShareGetCollectionArgs) IsSetReq() bool {
+  return p.Req != nil
+}
+
+func (p *ShareGetCollectionArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareGetCollectionArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.Req = &GetCollectionRequest{}
+  if err := p.Req.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+  }
+  return nil
+}
+
+func (p *ShareGetCollectionArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("GetCollection_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareGetCollectionArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err) }
+  if err := p.Req.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err) }
+  return err
+}
+
+func (p *ShareGetCollectionArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareGetCollectionArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type ShareGetCollectionResult struct {
+  Success *GetCollectionResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewShareGetCollectionResult() *ShareGetCollectionResult {
+  return &ShareGetCollectionResult{}
+}
+
+var ShareGetCollectionResult_Success_DEFAULT *GetCollectionResponse
+func (p *ShareGetCollectionResult) GetSuccess() *GetCollectionResponse {
+  if !p.IsSetSuccess() {
+    return ShareGetCollectionResult_Success_DEFAULT
+  }
+return p.Success
+}
+func (p *ShareGetCollectionResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *ShareGetCollectionResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField0(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareGetCollectionResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &GetCollectionResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *ShareGetCollectionResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("GetCollection_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareGetCollectionResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *ShareGetCollectionResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareGetCollectionResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type ShareUploadAttachmentArgs struct {
+  Req *UploadAttachmentRequest `thrift:"req,1" db:"req" json:"req"`
+}
+
+func NewShareUploadAttachmentArgs() *ShareUploadAttachmentArgs {
+  return &ShareUploadAttachmentArgs{}
+}
+
+var ShareUploadAttachmentArgs_Req_DEFAULT *UploadAttachmentRequest
+func (p *ShareUploadAttachmentArgs) GetReq() *UploadAttachmentRequest {
+  if !p.IsSetReq() {
+    return ShareUploadAttachmentArgs_Req_DEFAULT
+  }
+return p.Req
+}
+func (p *ShareUploadAttachmentArgs) IsSetReq() bool {
+  return p.Req != nil
+}
+
+func (p *ShareUploadAttachmentArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareUploadAttachmentArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.Req = &UploadAttachmentRequest{}
+  if err := p.Req.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+  }
+  return nil
+}
+
+func (p *ShareUploadAttachmentArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("UploadAttachment_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareUploadAttachmentArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err) }
+  if err := p.Req.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err) }
+  return err
+}
+
+func (p *ShareUploadAttachmentArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareUploadAttachmentArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type ShareUploadAttachmentResult struct {
+  Success *UploadAttachmentResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewShareUploadAttachmentResult() *ShareUploadAttachmentResult {
+  return &ShareUploadAttachmentResult{}
+}
+
+var ShareUploadAttachmentResult_Success_DEFAULT *UploadAttachmentResponse
+func (p *ShareUploadAttachmentResult) GetSuccess() *UploadAttachmentResponse {
+  if !p.IsSetSuccess() {
+    return ShareUploadAttachmentResult_Success_DEFAULT
+  }
+return p.Success
+}
+func (p *ShareUploadAttachmentResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *ShareUploadAttachmentResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField0(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareUploadAttachmentResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &UploadAttachmentResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *ShareUploadAttachmentResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("UploadAttachment_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareUploadAttachmentResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *ShareUploadAttachmentResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareUploadAttachmentResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type ShareDownloadAttachmentArgs struct {
+  Req *DownloadAttachmentRequest `thrift:"req,1" db:"req" json:"req"`
+}
+
+func NewShareDownloadAttachmentArgs() *ShareDownloadAttachmentArgs {
+  return &ShareDownloadAttachmentArgs{}
+}
+
+var ShareDownloadAttachmentArgs_Req_DEFAULT *DownloadAttachmentRequest
+func (p *ShareDownloadAttachmentArgs) GetReq() *DownloadAttachmentRequest {
+  if !p.IsSetReq() {
+    return ShareDownloadAttachmentArgs_Req_DEFAULT
+  }
+return p.Req
+}
+func (p *ShareDownloadAttachmentArgs) IsSetReq() bool {
+  return p.Req != nil
+}
+
+func (p *ShareDownloadAttachmentArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareDownloadAttachmentArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.Req = &DownloadAttachmentRequest{}
+  if err := p.Req.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+  }
+  return nil
+}
+
+func (p *ShareDownloadAttachmentArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("DownloadAttachment_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareDownloadAttachmentArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err) }
+  if err := p.Req.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err) }
+  return err
+}
+
+func (p *ShareDownloadAttachmentArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareDownloadAttachmentArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type ShareDownloadAttachmentResult struct {
+  Success *DownloadAttachmentResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewShareDownloadAttachmentResult() *ShareDownloadAttachmentResult {
+  return &ShareDownloadAttachmentResult{}
+}
+
+var ShareDownloadAttachmentResult_Success_DEFAULT *DownloadAttachmentResponse
+func (p *ShareDownloadAttachmentResult) GetSuccess() *DownloadAttachmentResponse {
+  if !p.IsSetSuccess() {
+    return ShareDownloadAttachmentResult_Success_DEFAULT
+  }
+return p.Success
+}
+func (p *ShareDownloadAttachmentResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *ShareDownloadAttachmentResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField0(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareDownloadAttachmentResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &DownloadAttachmentResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *ShareDownloadAttachmentResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("DownloadAttachment_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareDownloadAttachmentResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *ShareDownloadAttachmentResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareDownloadAttachmentResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type ShareAddCommentArgs struct {
+  Req *AddCommentRequest `thrift:"req,1" db:"req" json:"req"`
+}
+
+func NewShareAddCommentArgs() *ShareAddCommentArgs {
+  return &ShareAddCommentArgs{}
+}
+
+var ShareAddCommentArgs_Req_DEFAULT *AddCommentRequest
+func (p *ShareAddCommentArgs) GetReq() *AddCommentRequest {
+  if !p.IsSetReq() {
+    return ShareAddCommentArgs_Req_DEFAULT
+  }
+return p.Req
+}
+func (p *ShareAddCommentArgs) IsSetReq() bool {
+  return p.Req != nil
+}
+
+func (p *ShareAddCommentArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareAddCommentArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.Req = &AddCommentRequest{}
+  if err := p.Req.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+  }
+  return nil
+}
+
+func (p *ShareAddCommentArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("AddComment_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareAddCommentArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err) }
+  if err := p.Req.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err) }
+  return err
+}
+
+func (p *ShareAddCommentArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareAddCommentArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type ShareAddCommentResult struct {
+  Success *AddCommentResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewShareAddCommentResult() *ShareAddCommentResult {
+  return &ShareAddCommentResult{}
+}
+
+var ShareAddCommentResult_Success_DEFAULT *AddCommentResponse
+func (p *ShareAddCommentResult) GetSuccess() *AddCommentResponse {
+  if !p.IsSetSuccess() {
+    return ShareAddCommentResult_Success_DEFAULT
+  }
+return p.Success
+}
+func (p *ShareAddCommentResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *ShareAddCommentResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField0(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareAddCommentResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &AddCommentResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *ShareAddCommentResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("AddComment_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareAddCommentResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *ShareAddCommentResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareAddCommentResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type ShareListCommentsArgs struct {
+  Req *ListCommentsRequest `thrift:"req,1" db:"req" json:"req"`
+}
+
+func NewShareListCommentsArgs() *ShareListCommentsArgs {
+  return &ShareListCommentsArgs{}
+}
+
+var ShareListCommentsArgs_Req_DEFAULT *ListCommentsRequest
+func (p *ShareListCommentsArgs) GetReq() *ListCommentsRequest {
+  if !p.IsSetReq() {
+    return ShareListCommentsArgs_Req_DEFAULT
+  }
+return p.Req
+}
+func (p *ShareListCommentsArgs) IsSetReq() bool {
+  return p.Req != nil
+}
+
+func (p *ShareListCommentsArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareListCommentsArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.Req = &ListCommentsRequest{}
+  if err := p.Req.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+  }
+  return nil
+}
+
+func (p *ShareListCommentsArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ListComments_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareListCommentsArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err) }
+  if err := p.Req.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err) }
+  return err
+}
+
+func (p *ShareListCommentsArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareListCommentsArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type ShareListCommentsResult struct {
+  Success *ListCommentsResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewShareListCommentsResult() *ShareListCommentsResult {
+  return &ShareListCommentsResult{}
+}
+
+var ShareListCommentsResult_Success_DEFAULT *ListCommentsResponse
+func (p *ShareListCommentsResult) GetSuccess() *ListCommentsResponse {
+  if !p.IsSetSuccess() {
+    return ShareListCommentsResult_Success_DEFAULT
+  }
+return p.Success
+}
+func (p *ShareListCommentsResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *ShareListCommentsResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField0(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareListCommentsResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &ListCommentsResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *ShareListCommentsResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ListComments_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareListCommentsResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *ShareListCommentsResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareListCommentsResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type ShareResolveCommentArgs struct {
+  Req *ResolveCommentRequest `thrift:"req,1" db:"req" json:"req"`
+}
+
+func NewShareResolveCommentArgs() *ShareResolveCommentArgs {
+  return &ShareResolveCommentArgs{}
+}
+
+var ShareResolveCommentArgs_Req_DEFAULT *ResolveCommentRequest
+func (p *ShareResolveCommentArgs) GetReq() *ResolveCommentRequest {
+  if !p.IsSetReq() {
+    return ShareResolveCommentArgs_Req_DEFAULT
+  }
+return p.Req
+}
+func (p *ShareResolveCommentArgs) IsSetReq() bool {
+  return p.Req != nil
+}
+
+func (p *ShareResolveCommentArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareResolveCommentArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.Req = &ResolveCommentRequest{}
+  if err := p.Req.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+  }
+  return nil
+}
+
+func (p *ShareResolveCommentArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ResolveComment_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareResolveCommentArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err) }
+  if err := p.Req.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err) }
+  return err
+}
+
+func (p *ShareResolveCommentArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareResolveCommentArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type ShareResolveCommentResult struct {
+  Success *ResolveCommentResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewShareResolveCommentResult() *ShareResolveCommentResult {
+  return &ShareResolveCommentResult{}
+}
+
+var ShareResolveCommentResult_Success_DEFAULT *ResolveCommentResponse
+func (p *ShareResolveCommentResult) GetSuccess() *ResolveCommentResponse {
+  if !p.IsSetSuccess() {
+    return ShareResolveCommentResult_Success_DEFAULT
+  }
+return p.Success
+}
+func (p *ShareResolveCommentResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *ShareResolveCommentResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField0(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareResolveCommentResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &ResolveCommentResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *ShareResolveCommentResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ResolveComment_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareResolveCommentResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *ShareResolveCommentResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareResolveCommentResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Req
+type ShareGetNoteStatsArgs struct {
+  Req *GetNoteStatsRequest `thrift:"req,1" db:"req" json:"req"`
+}
+
+func NewShareGetNoteStatsArgs() *ShareGetNoteStatsArgs {
+  return &ShareGetNoteStatsArgs{}
+}
+
+var ShareGetNoteStatsArgs_Req_DEFAULT *GetNoteStatsRequest
+func (p *ShareGetNoteStatsArgs) GetReq() *GetNoteStatsRequest {
+  if !p.IsSetReq() {
+    return ShareGetNoteStatsArgs_Req_DEFAULT
+  }
+return p.Req
+}
+func (p *ShareGetNoteStatsArgs) IsSetReq() bool {
+  return p.Req != nil
+}
+
+func (p *ShareGetNoteStatsArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField1(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareGetNoteStatsArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.Req = &GetNoteStatsRequest{}
+  if err := p.Req.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+  }
+  return nil
+}
+
+func (p *ShareGetNoteStatsArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("GetNoteStats_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareGetNoteStatsArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err) }
+  if err := p.Req.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err) }
+  return err
+}
+
+func (p *ShareGetNoteStatsArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareGetNoteStatsArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type ShareGetNoteStatsResult struct {
+  Success *GetNoteStatsResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewShareGetNoteStatsResult() *ShareGetNoteStatsResult {
+  return &ShareGetNoteStatsResult{}
+}
+
+var ShareGetNoteStatsResult_Success_DEFAULT *GetNoteStatsResponse
+func (p *ShareGetNoteStatsResult) GetSuccess() *GetNoteStatsResponse {
+  if !p.IsSetSuccess() {
+    return ShareGetNoteStatsResult_Success_DEFAULT
+  }
+return p.Success
+}
+func (p *ShareGetNoteStatsResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *ShareGetNoteStatsResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if fieldTypeId == thrift.STRUCT {
+        if err := p.ReadField0(iprot); err != nil {
+          return err
+        }
+      } else {
+        if err := iprot.Skip(fieldTypeId); err != nil {
+          return err
+        }
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ShareGetNoteStatsResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &GetNoteStatsResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *ShareGetNoteStatsResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("GetNoteStats_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ShareGetNoteStatsResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *ShareGetNoteStatsResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ShareGetNoteStatsResult(%+v)", *p)
+}
+
+
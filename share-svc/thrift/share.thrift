@@ -0,0 +1,331 @@
+namespace go share
+
+struct PrivateNoteRequest {
+    1: string note_id;
+}
+
+struct PrivateNoteResponse {
+    1: string error;
+}
+
+struct ShareNoteRequest {
+    2: string name;
+    3: string content;
+}
+
+// ShareNoteResponse carries the owner token of the new note, which is the
+// only way to read its stats.
+struct ShareNoteResponse {
+    1: string url;
+    2: string note_id;
+    3: string error;
+    4: string owner_token;
+}
+
+// GetNoteRequest makes the read conditional with if_none_match, an ETag
+// list, or if_modified_since, in Unix seconds.
+struct GetNoteRequest {
+    1: string id;
+    2: string if_none_match;
+    3: i64 if_modified_since;
+}
+
+// GetNoteResponse carries the version of the note, without its name and
+// content when not_modified is set.
+struct GetNoteResponse {
+    1: string name;
+    2: string content;
+    3: string error;
+    4: string etag;
+    5: i64 last_modified;
+    6: bool not_modified;
+}
+
+// NoteChange is the state of a note exchanged by SyncNotes, versions maps
+// every replica to the number of changes it made to the note.
+struct NoteChange {
+    1: string note_id;
+    2: string name;
+    3: string content;
+    // Thrift has no unsigned integers, versions never exceed the int64 range.
+    4: map<string, i64> versions;
+    5: i64 updated_at;
+    6: string updated_by;
+    7: bool deactivated;
+}
+
+// SyncConflict reports a local change concurrent with the server version,
+// resolution is one of "local" or "server".
+struct SyncConflict {
+    1: string note_id;
+    2: NoteChange local;
+    3: NoteChange server;
+    4: string resolution;
+}
+
+struct SyncNotesRequest {
+    1: string client_id;
+    2: i64 since;
+    3: list<string> note_ids;
+    4: list<NoteChange> changes;
+}
+
+struct SyncNotesResponse {
+    1: i64 version;
+    2: list<NoteChange> changes;
+    3: list<SyncConflict> conflicts;
+    4: string error;
+}
+
+struct ForkNoteRequest {
+    1: string id;
+    2: string owner;
+}
+
+struct ForkNoteResponse {
+    1: string url;
+    2: string note_id;
+    3: string error;
+    4: string owner_token;
+}
+
+struct ListForksRequest {
+    1: string id;
+}
+
+// NoteFork describes a visible note forked from the listed note.
+struct NoteFork {
+    1: string note_id;
+    2: string name;
+    3: string owner;
+    4: string url;
+    5: i64 created_at;
+}
+
+struct ListForksResponse {
+    1: list<NoteFork> forks;
+    2: string error;
+}
+
+struct MarkTemplateRequest {
+    1: string id;
+    2: bool template;
+}
+
+// TemplateVariable is a placeholder of a template, written {{name}} when it is
+// required, or {{name|default}} when it falls back to default_value.
+struct TemplateVariable {
+    1: string name;
+    2: bool required;
+    3: string default_value;
+}
+
+struct MarkTemplateResponse {
+    1: list<TemplateVariable> variables;
+    2: string error;
+}
+
+struct InstantiateTemplateRequest {
+    1: string id;
+    2: map<string, string> vars;
+}
+
+// InstantiateTemplateResponse carries the owner token of the new note, which
+// is the only way to read its stats.
+struct InstantiateTemplateResponse {
+    1: string url;
+    2: string note_id;
+    3: string error;
+    4: string owner_token;
+}
+
+struct CreateCollectionRequest {
+    1: string name;
+    2: list<string> note_ids;
+}
+
+struct CreateCollectionResponse {
+    1: string url;
+    2: string collection_id;
+    3: string error;
+}
+
+struct AddCollectionNotesRequest {
+    1: string id;
+    2: list<string> note_ids;
+}
+
+struct AddCollectionNotesResponse {
+    1: string error;
+}
+
+struct RemoveCollectionNotesRequest {
+    1: string id;
+    2: list<string> note_ids;
+}
+
+struct RemoveCollectionNotesResponse {
+    1: string error;
+}
+
+// ReorderCollectionRequest lists every note of the collection in the new order.
+struct ReorderCollectionRequest {
+    1: string id;
+    2: list<string> note_ids;
+}
+
+struct ReorderCollectionResponse {
+    1: string error;
+}
+
+struct GetCollectionRequest {
+    1: string id;
+}
+
+// CollectionNote summarizes a visible note of a collection.
+struct CollectionNote {
+    1: string note_id;
+    2: string name;
+    3: string url;
+}
+
+struct GetCollectionResponse {
+    1: string name;
+    2: list<CollectionNote> notes;
+    3: string error;
+}
+
+// Attachment describes a file attached to a note.
+struct Attachment {
+    1: string id;
+    2: string note_id;
+    3: string name;
+    4: string content_type;
+    5: i64 size;
+    6: string url;
+    7: i64 created_at;
+}
+
+// UploadAttachmentRequest carries the whole attachment content, which is still
+// bounded by the maximum attachment size.
+struct UploadAttachmentRequest {
+    1: string note_id;
+    2: string name;
+    3: binary content;
+}
+
+struct UploadAttachmentResponse {
+    1: Attachment attachment;
+    2: string error;
+}
+
+struct DownloadAttachmentRequest {
+    1: string note_id;
+    2: string id;
+}
+
+struct DownloadAttachmentResponse {
+    1: Attachment attachment;
+    2: binary content;
+    3: string error;
+}
+
+// Comment is a comment left on a note, anchored to the lines start_line to
+// end_line of the note content, both 1-based and inclusive, unless start_line is 0.
+struct Comment {
+    1: string id;
+    2: string note_id;
+    3: string author;
+    4: string body;
+    5: i64 start_line;
+    6: i64 end_line;
+    7: bool resolved;
+    8: string resolved_by;
+    9: i64 created_at;
+    10: i64 resolved_at;
+}
+
+struct AddCommentRequest {
+    1: string note_id;
+    2: string author;
+    3: string body;
+    4: i64 start_line;
+    5: i64 end_line;
+}
+
+struct AddCommentResponse {
+    1: Comment comment;
+    2: string error;
+}
+
+struct ListCommentsRequest {
+    1: string note_id;
+    2: bool include_resolved;
+}
+
+struct ListCommentsResponse {
+    1: list<Comment> comments;
+    2: string error;
+}
+
+struct ResolveCommentRequest {
+    1: string note_id;
+    2: string id;
+    3: string user;
+}
+
+struct ResolveCommentResponse {
+    1: Comment comment;
+    2: string error;
+}
+
+struct GetNoteStatsRequest {
+    1: string id;
+    2: string owner_token;
+    3: i32 days;
+}
+
+// DailyViews counts the views of a note during a UTC day, formatted as
+// 2006-01-02, and the distinct clients they came from.
+struct DailyViews {
+    1: string day;
+    2: i64 views;
+    3: i64 visitors;
+}
+
+struct NoteStats {
+    1: string note_id;
+    2: i64 views;
+    3: map<string, i64> transports;
+    4: map<string, i64> referrers;
+    5: list<DailyViews> daily;
+    6: i64 first_viewed_at;
+    7: i64 last_viewed_at;
+}
+
+struct GetNoteStatsResponse {
+    1: NoteStats stats;
+    2: string error;
+}
+
+service Share {
+    ShareNoteResponse ShareNote(1: ShareNoteRequest req);
+    PrivateNoteResponse PrivateNote(1: PrivateNoteRequest req);
+    GetNoteResponse GetNote(1: GetNoteRequest req);
+    SyncNotesResponse SyncNotes(1: SyncNotesRequest req);
+    ForkNoteResponse ForkNote(1: ForkNoteRequest req);
+    ListForksResponse ListForks(1: ListForksRequest req);
+    MarkTemplateResponse MarkTemplate(1: MarkTemplateRequest req);
+    InstantiateTemplateResponse InstantiateTemplate(1: InstantiateTemplateRequest req);
+    CreateCollectionResponse CreateCollection(1: CreateCollectionRequest req);
+    AddCollectionNotesResponse AddCollectionNotes(1: AddCollectionNotesRequest req);
+    RemoveCollectionNotesResponse RemoveCollectionNotes(1: RemoveCollectionNotesRequest req);
+    ReorderCollectionResponse ReorderCollection(1: ReorderCollectionRequest req);
+    GetCollectionResponse GetCollection(1: GetCollectionRequest req);
+    UploadAttachmentResponse UploadAttachment(1: UploadAttachmentRequest req);
+    DownloadAttachmentResponse DownloadAttachment(1: DownloadAttachmentRequest req);
+    AddCommentResponse AddComment(1: AddCommentRequest req);
+    ListCommentsResponse ListComments(1: ListCommentsRequest req);
+    ResolveCommentResponse ResolveComment(1: ResolveCommentRequest req);
+    GetNoteStatsResponse GetNoteStats(1: GetNoteStatsRequest req);
+}
@@ -0,0 +1,11 @@
+// Package thrift holds the Thrift IDL of the Share service, mirroring
+// pb/share.proto for the services only speaking Thrift. The code generated
+// from it is in gen-go/share, the service is served and called over Thrift by
+// pkg/transport.
+//
+// Thrift has no streams: the streaming RPCs of the gRPC service are served by
+// their unary counterparts, the attachments are sent whole and WatchNote and
+// EditNote are left out.
+package thrift
+
+//go:generate thrift -r --gen go:package_prefix=github.com/al8n/shareable-notes/share-svc/thrift/gen-go/ share.thrift